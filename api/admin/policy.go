@@ -0,0 +1,339 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/util"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// swagger:operation GET /api/v1/admin/policies admin AdminAllPolicies
+//
+// Get all of the policies in the database
+//
+// ---
+// produces:
+// - application/json
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved all policies from the database
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/Policy"
+//   '500':
+//     description: Unable to retrieve all policies from the database
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// AllPolicies represents the API handler to
+// captures all policies stored in the database.
+func AllPolicies(c *gin.Context) {
+	logrus.Info("Admin: reading all policies")
+
+	// send API call to capture all policies
+	p, err := database.FromContext(c).GetPolicyList()
+	if err != nil {
+		retErr := fmt.Errorf("unable to capture all policies: %w", err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// swagger:operation POST /api/v1/admin/policies admin AdminCreatePolicy
+//
+// Create a policy in the database
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: body
+//   name: body
+//   description: Payload containing the policy to create
+//   required: true
+//   schema:
+//     "$ref": "#/definitions/Policy"
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '201':
+//     description: Successfully created the policy in the database
+//     schema:
+//       "$ref": "#/definitions/Policy"
+//   '400':
+//     description: Unable to create the policy in the database
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to create the policy in the database
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// CreatePolicy represents the API handler to
+// create a policy in the database.
+func CreatePolicy(c *gin.Context) {
+	logrus.Info("Admin: creating policy in database")
+
+	// capture body from API request
+	input := new(model.Policy)
+
+	err := c.Bind(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to decode JSON for new policy: %w", err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// validate the policy before storing it
+	err = input.Validate()
+	if err != nil {
+		retErr := fmt.Errorf("unable to validate policy %s: %w", input.Name, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to create the policy
+	err = database.FromContext(c).CreatePolicy(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to create policy %s: %w", input.Name, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, input)
+}
+
+// swagger:operation GET /api/v1/admin/policies/{policy} admin AdminGetPolicy
+//
+// Get a policy in the database
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: policy
+//   description: ID of the policy
+//   required: true
+//   type: integer
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved the policy from the database
+//     schema:
+//       "$ref": "#/definitions/Policy"
+//   '400':
+//     description: Unable to retrieve the policy from the database
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '404':
+//     description: Unable to retrieve the policy from the database
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetPolicy represents the API handler to
+// capture a policy stored in the database.
+func GetPolicy(c *gin.Context) {
+	logrus.Infof("Admin: reading policy %s", c.Param("policy"))
+
+	id, err := strconv.ParseInt(c.Param("policy"), 10, 64)
+	if err != nil {
+		retErr := fmt.Errorf("invalid policy parameter provided: %s", c.Param("policy"))
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to capture the policy
+	p, err := database.FromContext(c).GetPolicy(id)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get policy %d: %w", id, err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// swagger:operation PUT /api/v1/admin/policies/{policy} admin AdminUpdatePolicy
+//
+// Update a policy in the database
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: policy
+//   description: ID of the policy
+//   required: true
+//   type: integer
+// - in: body
+//   name: body
+//   description: Payload containing the policy to update
+//   required: true
+//   schema:
+//     "$ref": "#/definitions/Policy"
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully updated the policy in the database
+//     schema:
+//       "$ref": "#/definitions/Policy"
+//   '400':
+//     description: Unable to update the policy in the database
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '404':
+//     description: Unable to update the policy in the database
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to update the policy in the database
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// UpdatePolicy represents the API handler to
+// update a policy stored in the database.
+func UpdatePolicy(c *gin.Context) {
+	logrus.Infof("Admin: updating policy %s in database", c.Param("policy"))
+
+	id, err := strconv.ParseInt(c.Param("policy"), 10, 64)
+	if err != nil {
+		retErr := fmt.Errorf("invalid policy parameter provided: %s", c.Param("policy"))
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to capture the policy
+	_, err = database.FromContext(c).GetPolicy(id)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get policy %d: %w", id, err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	// capture body from API request
+	input := new(model.Policy)
+
+	err = c.Bind(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to decode JSON for policy %d: %w", id, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	input.ID = id
+
+	// validate the policy before storing it
+	err = input.Validate()
+	if err != nil {
+		retErr := fmt.Errorf("unable to validate policy %d: %w", id, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to update the policy
+	err = database.FromContext(c).UpdatePolicy(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to update policy %d: %w", id, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, input)
+}
+
+// swagger:operation DELETE /api/v1/admin/policies/{policy} admin AdminDeletePolicy
+//
+// Delete a policy in the database
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: policy
+//   description: ID of the policy
+//   required: true
+//   type: integer
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully deleted the policy from the database
+//     schema:
+//       type: string
+//   '400':
+//     description: Unable to delete the policy from the database
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to delete the policy from the database
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// DeletePolicy represents the API handler to
+// remove a policy stored in the database.
+func DeletePolicy(c *gin.Context) {
+	logrus.Infof("Admin: deleting policy %s from database", c.Param("policy"))
+
+	id, err := strconv.ParseInt(c.Param("policy"), 10, 64)
+	if err != nil {
+		retErr := fmt.Errorf("invalid policy parameter provided: %s", c.Param("policy"))
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to remove the policy
+	err = database.FromContext(c).DeletePolicy(id)
+	if err != nil {
+		retErr := fmt.Errorf("unable to delete policy %d: %w", id, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fmt.Sprintf("policy %d deleted", id))
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/queue"
+	"github.com/go-vela/server/router/middleware/build"
+	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/repo"
+	"github.com/go-vela/server/router/middleware/user"
+	"github.com/go-vela/server/util"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// swagger:operation POST /api/v1/repos/{org}/{repo}/builds/{build}/approve builds ApproveBuild
+//
+// Approve a build waiting for approval
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: build
+//   description: Build number to approve
+//   required: true
+//   type: integer
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully approved the build
+//     schema:
+//       "$ref": "#/definitions/Build"
+//   '400':
+//     description: Unable to approve build
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to approve build
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// ApproveBuild represents the API handler to approve
+// a build waiting for approval and publish it to the queue.
+func ApproveBuild(c *gin.Context) {
+	// capture middleware values
+	b := build.Retrieve(c)
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	entry := fmt.Sprintf("%s/%d", r.GetFullName(), b.GetNumber())
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+		"org":   o,
+		"repo":  r.GetName(),
+		"user":  u.GetName(),
+	}).Infof("approving build %s", entry)
+
+	// check to see if build is waiting for approval
+	if !strings.EqualFold(b.GetStatus(), model.StatusPendingApproval) {
+		retErr := fmt.Errorf("found build %s but its status was %s", entry, b.GetStatus())
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	err := approveBuild(c, b, r, u.GetName())
+	if errors.Is(err, model.ErrBuildApprovalApproved) {
+		retErr := fmt.Errorf("unable to approve build %s: %w", entry, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	if err != nil {
+		retErr := fmt.Errorf("unable to approve build %s: %w", entry, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, b)
+}
+
+// planBuildApproval is a helper function to record the reason
+// the build requires approval with the compiled pipeline
+// for the build in the configured backend.
+func planBuildApproval(database database.Service, reason string, p *pipeline.Build, b *library.Build) error {
+	// convert the pipeline to JSON for publishing it once approved
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("unable to convert pipeline for build to JSON: %w", err)
+	}
+
+	// send API call to create the build approval
+	err = database.CreateBuildApproval(&model.BuildApproval{
+		BuildID:  b.GetID(),
+		Reason:   reason,
+		Pipeline: string(data),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create approval for build: %w", err)
+	}
+
+	return nil
+}
+
+// approveBuild is a helper function to record the approval
+// of a build waiting for approval and to publish the build
+// with the pipeline compiled for it to the queue.
+func approveBuild(c *gin.Context, b *library.Build, r *library.Repo, approver string) error {
+	// send API call to capture the build approval
+	a, err := database.FromContext(c).GetBuildApproval(b)
+	if err != nil {
+		return fmt.Errorf("unable to get approval for build: %w", err)
+	}
+
+	p := new(pipeline.Build)
+
+	err = json.Unmarshal([]byte(a.Pipeline), p)
+	if err != nil {
+		return fmt.Errorf("unable to parse pipeline for build: %w", err)
+	}
+
+	// send API call to capture the repo owner
+	u, err := database.FromContext(c).GetUser(r.GetUserID())
+	if err != nil {
+		return fmt.Errorf("unable to get owner for %s: %w", r.GetFullName(), err)
+	}
+
+	// update fields in build approval object
+	a.ApprovedBy = approver
+	a.ApprovedAt = time.Now().UTC().Unix()
+
+	// send API call to approve the build approval
+	//
+	// the approval is only recorded when the build wasn't approved
+	// yet, so concurrent approvals only publish the build once
+	err = database.FromContext(c).ApproveBuildApproval(a)
+	if err != nil {
+		return fmt.Errorf("unable to update approval for build: %w", err)
+	}
+
+	// update fields in build object
+	b.SetStatus(constants.StatusPending)
+
+	// send API call to update the build
+	err = database.FromContext(c).UpdateBuild(b)
+	if err != nil {
+		return fmt.Errorf("unable to update build: %w", err)
+	}
+
+	// send API call to set the status on the commit
+	err = pipelineStatus(c, u, b, r, buildPipeline(database.FromContext(c), b))
+	if err != nil {
+		logrus.Errorf("unable to set commit status for %s/%d: %v", r.GetFullName(), b.GetNumber(), err)
+	}
+
+	// publish the build to the queue
+	go publishToQueue(
+		queue.FromGinContext(c),
+		database.FromContext(c),
+		p,
+		b,
+		r,
+		u,
+	)
+
+	return nil
+}
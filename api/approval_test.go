@@ -0,0 +1,71 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/database/sqlite"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+func Test_planBuildApproval(t *testing.T) {
+	// setup types
+	b := new(library.Build)
+	b.SetID(1)
+
+	p := &pipeline.Build{
+		ID:      "__0",
+		Version: "1",
+		Steps: pipeline.ContainerSlice{
+			{ID: "step___0_test", Name: "test", Image: "alpine", Commands: []string{"echo test"}},
+		},
+	}
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from build_approvals;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	// run test
+	err = planBuildApproval(db, "pull request is from a fork", p, b)
+	if err != nil {
+		t.Errorf("planBuildApproval returned err: %v", err)
+	}
+
+	got, err := db.GetBuildApproval(b)
+	if err != nil {
+		t.Errorf("GetBuildApproval returned err: %v", err)
+	}
+
+	if got.Reason != "pull request is from a fork" {
+		t.Errorf("planBuildApproval reason is %s, want %s", got.Reason, "pull request is from a fork")
+	}
+
+	if got.Approved() {
+		t.Errorf("planBuildApproval approved is %v, want false", got.Approved())
+	}
+
+	gotPipeline := new(pipeline.Build)
+
+	err = json.Unmarshal([]byte(got.Pipeline), gotPipeline)
+	if err != nil {
+		t.Errorf("unable to parse pipeline for build approval: %v", err)
+	}
+
+	if !reflect.DeepEqual(gotPipeline, p) {
+		t.Errorf("planBuildApproval pipeline is %v, want %v", gotPipeline, p)
+	}
+}
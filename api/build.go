@@ -17,6 +17,7 @@ import (
 
 	"github.com/go-vela/server/compiler"
 	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
 	"github.com/go-vela/server/queue"
 	"github.com/go-vela/server/router/middleware/build"
 	"github.com/go-vela/server/router/middleware/executors"
@@ -58,6 +59,10 @@ import (
 //   required: true
 //   schema:
 //     "$ref": "#/definitions/Build"
+// - in: query
+//   name: pipeline
+//   description: Name of the repo pipeline to create the build for
+//   type: string
 // security:
 //   - ApiKeyAuth: []
 // responses:
@@ -130,6 +135,16 @@ func CreateBuild(c *gin.Context) {
 		return
 	}
 
+	// verify the pipeline settings of the repo allow the event type
+	if !allowEvent(database.FromContext(c), r, input.GetEvent()) {
+		// nolint: lll // ignore long line length due to error message
+		retErr := fmt.Errorf("unable to create new build: %s does not have %s events enabled", r.GetFullName(), input.GetEvent())
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
 	// send API call to capture the repo owner
 	u, err = database.FromContext(c).GetUser(r.GetUserID())
 	if err != nil {
@@ -238,8 +253,18 @@ func CreateBuild(c *gin.Context) {
 		}
 	}
 
+	// send API call to capture the named pipeline of the repo to build
+	settings, named, err := repoPipeline(database.FromContext(c), r, c.Query("pipeline"))
+	if err != nil {
+		retErr := fmt.Errorf("unable to create new build: %w", err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
 	// send API call to capture the pipeline configuration file
-	config, err := scm.FromContext(c).ConfigBackoff(u, r, input.GetCommit())
+	config, err := pipelineConfig(c, u, r, input.GetCommit(), settings, named)
 	if err != nil {
 		// nolint: lll // ignore long line length due to error message
 		retErr := fmt.Errorf("unable to get pipeline configuration for %s/%d: %w", r.GetFullName(), input.GetNumber(), err)
@@ -249,14 +274,44 @@ func CreateBuild(c *gin.Context) {
 		return
 	}
 
+	// send API call to capture the policies for the repo
+	policies, err := database.FromContext(c).GetRepoPolicyList(r)
+	if err != nil {
+		retErr := fmt.Errorf("unable to create new build: failed to get policies for %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	// send API call to capture the required pipelines for the org
+	required, err := database.FromContext(c).GetRequiredPipelineList(r.GetOrg())
+	if err != nil {
+		retErr := fmt.Errorf("unable to create new build: failed to get required pipelines for %s: %w", r.GetOrg(), err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
 	// parse and compile the pipeline configuration file
-	p, err := compiler.FromContext(c).
+	comp := compiler.FromContext(c).
+		Duplicate().
 		WithBuild(input).
 		WithFiles(files).
 		WithMetadata(m).
+		WithPolicies(policies).
 		WithRepo(r).
-		WithUser(u).
-		Compile(config)
+		WithRequiredPipelines(required).
+		WithSCM(scm.FromContext(c)).
+		WithUser(u)
+
+	p, err := comp.Compile(config)
+	if err == nil {
+		// verify the secrets referenced in the pipeline
+		err = validateSecrets(c, r, input.GetEvent(), p)
+	}
+
 	if err != nil {
 		// nolint: lll // ignore long line length due to error message
 		retErr := fmt.Errorf("unable to compile pipeline configuration for %s/%d: %w", r.GetFullName(), input.GetNumber(), err)
@@ -273,7 +328,7 @@ func CreateBuild(c *gin.Context) {
 		input.SetStatus(constants.StatusSuccess)
 
 		// send API call to set the status on the commit
-		err = scm.FromContext(c).Status(u, input, r.GetOrg(), r.GetName())
+		err = pipelineStatus(c, u, input, r, named)
 		if err != nil {
 			// nolint: lll // ignore long line length due to error message
 			logger.Errorf("unable to set commit status for %s/%d: %v", r.GetFullName(), input.GetNumber(), err)
@@ -284,7 +339,8 @@ func CreateBuild(c *gin.Context) {
 	}
 
 	// create the objects from the pipeline in the database
-	err = planBuild(database.FromContext(c), p, input, r)
+	// nolint: lll // ignore long line length due to parameters
+	err = planBuild(database.FromContext(c), p, input, r, comp.Templates(), comp.PolicyResults(), comp.InjectedSteps(), named, nil)
 	if err != nil {
 		util.HandleError(c, http.StatusInternalServerError, err)
 
@@ -297,7 +353,7 @@ func CreateBuild(c *gin.Context) {
 	c.JSON(http.StatusCreated, input)
 
 	// send API call to set the status on the commit
-	err = scm.FromContext(c).Status(u, input, r.GetOrg(), r.GetName())
+	err = pipelineStatus(c, u, input, r, named)
 	if err != nil {
 		// nolint: lll // ignore long line length due to error message
 		logger.Errorf("unable to set commit status for build %s/%d: %v", r.GetFullName(), input.GetNumber(), err)
@@ -477,7 +533,8 @@ func GetBuilds(c *gin.Context) {
 		// verify the event provided is a valid event type
 		if event != constants.EventComment && event != constants.EventDeploy &&
 			event != constants.EventPush && event != constants.EventPull &&
-			event != constants.EventTag {
+			event != constants.EventTag && event != model.EventRelease &&
+			event != model.EventDelete {
 			retErr := fmt.Errorf("unable to process event %s: invalid event type provided", event)
 
 			util.HandleError(c, http.StatusBadRequest, retErr)
@@ -650,7 +707,8 @@ func GetOrgBuilds(c *gin.Context) {
 		// verify the event provided is a valid event type
 		if event != constants.EventComment && event != constants.EventDeploy &&
 			event != constants.EventPush && event != constants.EventPull &&
-			event != constants.EventTag {
+			event != constants.EventTag && event != model.EventRelease &&
+			event != model.EventDelete {
 			retErr := fmt.Errorf("unable to process event %s: invalid event type provided", event)
 
 			util.HandleError(c, http.StatusBadRequest, retErr)
@@ -846,7 +904,6 @@ func GetBuild(c *gin.Context) {
 // nolint: funlen // ignore function length due to comments
 func RestartBuild(c *gin.Context) {
 	// capture middleware values
-	m := c.MustGet("metadata").(*types.Metadata)
 	b := build.Retrieve(c)
 	o := org.Retrieve(c)
 	r := repo.Retrieve(c)
@@ -857,25 +914,48 @@ func RestartBuild(c *gin.Context) {
 	// update engine logger with API metadata
 	//
 	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
-	logger := logrus.WithFields(logrus.Fields{
+	logrus.WithFields(logrus.Fields{
 		"build": b.GetNumber(),
 		"org":   o,
 		"repo":  r.GetName(),
 		"user":  u.GetName(),
-	})
-
-	logger.Infof("restarting build %s", entry)
+	}).Infof("restarting build %s", entry)
 
-	// send API call to capture the repo owner
-	u, err := database.FromContext(c).GetUser(r.GetUserID())
+	b, skip, code, err := restartBuild(c, r, b)
 	if err != nil {
-		retErr := fmt.Errorf("unable to get owner for %s: %w", r.GetFullName(), err)
+		util.HandleError(c, code, err)
 
-		util.HandleError(c, http.StatusBadRequest, retErr)
+		return
+	}
+
+	// check if the restarted build was skipped
+	if skip != "" {
+		c.JSON(http.StatusOK, skip)
 
 		return
 	}
 
+	c.JSON(http.StatusCreated, b)
+}
+
+// restartBuild is a helper function to restart the build for the
+// repo pipeline and pull request the build was created for. The
+// reason is returned when the restarted build was skipped and
+// the HTTP status code is returned for the error.
+//
+// nolint: funlen // ignore function length
+func restartBuild(c *gin.Context, r *library.Repo, b *library.Build) (*library.Build, string, int, error) {
+	// capture middleware values
+	m := c.MustGet("metadata").(*types.Metadata)
+
+	entry := fmt.Sprintf("%s/%d", r.GetFullName(), b.GetNumber())
+
+	// send API call to capture the repo owner
+	u, err := database.FromContext(c).GetUser(r.GetUserID())
+	if err != nil {
+		return nil, "", http.StatusBadRequest, fmt.Errorf("unable to get owner for %s: %w", r.GetFullName(), err)
+	}
+
 	// create SQL filters for querying pending and running builds for repo
 	filters := map[string]interface{}{
 		"status": []string{constants.StatusPending, constants.StatusRunning},
@@ -885,33 +965,36 @@ func RestartBuild(c *gin.Context) {
 	builds, err := database.FromContext(c).GetRepoBuildCount(r, filters)
 	if err != nil {
 		// nolint: lll // ignore long line length due to error message
-		retErr := fmt.Errorf("unable to restart build: unable to get count of builds for repo %s", r.GetFullName())
-
-		util.HandleError(c, http.StatusBadRequest, retErr)
-
-		return
+		return nil, "", http.StatusBadRequest, fmt.Errorf("unable to restart build: unable to get count of builds for repo %s", r.GetFullName())
 	}
 
 	// check if the number of pending and running builds exceeds the limit for the repo
 	if builds >= r.GetBuildLimit() {
 		// nolint: lll // ignore long line length due to error message
-		retErr := fmt.Errorf("unable to restart build: repo %s has exceeded the concurrent build limit of %d", r.GetFullName(), r.GetBuildLimit())
-
-		util.HandleError(c, http.StatusBadRequest, retErr)
-
-		return
+		return nil, "", http.StatusBadRequest, fmt.Errorf("unable to restart build: repo %s has exceeded the concurrent build limit of %d", r.GetFullName(), r.GetBuildLimit())
 	}
 
 	// send API call to capture the last build for the repo
 	lastBuild, err := database.FromContext(c).GetLastBuild(r)
 	if err != nil {
-		retErr := fmt.Errorf("unable to get last build for %s: %w", entry, err)
+		return nil, "", http.StatusInternalServerError, fmt.Errorf("unable to get last build for %s: %w", entry, err)
+	}
 
-		util.HandleError(c, http.StatusInternalServerError, retErr)
+	// send API call to capture the repo pipeline the build was created for
+	named := buildPipeline(database.FromContext(c), b)
 
-		return
+	// send API call to capture the pull request the build was created for
+	pull := buildPullRequest(database.FromContext(c), b)
+
+	// capture the action of the event the build was created for
+	action := ""
+	if pull != nil {
+		action = pull.Action
 	}
 
+	// send API call to capture the pipeline settings for the repo
+	settings, _, _ := repoPipeline(database.FromContext(c), r, "")
+
 	// update the build numbers based off repo counter
 	inc := r.GetCounter() + 1
 
@@ -945,11 +1028,7 @@ func RestartBuild(c *gin.Context) {
 		files, err = scm.FromContext(c).Changeset(u, r, b.GetCommit())
 		if err != nil {
 			// nolint: lll // ignore long line length due to error message
-			retErr := fmt.Errorf("unable to process webhook: failed to get changeset for %s: %w", r.GetFullName(), err)
-
-			util.HandleError(c, http.StatusInternalServerError, retErr)
-
-			return
+			return nil, "", http.StatusInternalServerError, fmt.Errorf("unable to process webhook: failed to get changeset for %s: %w", r.GetFullName(), err)
 		}
 	}
 
@@ -959,49 +1038,54 @@ func RestartBuild(c *gin.Context) {
 		number, err := getPRNumberFromBuild(b)
 		if err != nil {
 			// nolint: lll // ignore long line length due to error message
-			retErr := fmt.Errorf("unable to restart build: failed to get pull_request number for %s: %w", r.GetFullName(), err)
-
-			util.HandleError(c, http.StatusInternalServerError, retErr)
-
-			return
+			return nil, "", http.StatusInternalServerError, fmt.Errorf("unable to restart build: failed to get pull_request number for %s: %w", r.GetFullName(), err)
 		}
 
 		// send API call to capture list of files changed for the pull request
 		files, err = scm.FromContext(c).ChangesetPR(u, r, number)
 		if err != nil {
 			// nolint: lll // ignore long line length due to error message
-			retErr := fmt.Errorf("unable to restart build: failed to get changeset for %s: %w", r.GetFullName(), err)
-
-			util.HandleError(c, http.StatusInternalServerError, retErr)
-
-			return
+			return nil, "", http.StatusInternalServerError, fmt.Errorf("unable to restart build: failed to get changeset for %s: %w", r.GetFullName(), err)
 		}
 	}
 
 	// send API call to capture the pipeline configuration file
-	config, err := scm.FromContext(c).ConfigBackoff(u, r, b.GetCommit())
+	config, err := pipelineConfig(c, u, r, b.GetCommit(), settings, named)
 	if err != nil {
-		retErr := fmt.Errorf("unable to get pipeline configuration for %s: %w", entry, err)
+		return nil, "", http.StatusNotFound, fmt.Errorf("unable to get pipeline configuration for %s: %w", entry, err)
+	}
 
-		util.HandleError(c, http.StatusNotFound, retErr)
+	// send API call to capture the policies for the repo
+	policies, err := database.FromContext(c).GetRepoPolicyList(r)
+	if err != nil {
+		// nolint: lll // ignore long line length due to error message
+		return nil, "", http.StatusInternalServerError, fmt.Errorf("unable to restart build: failed to get policies for %s: %w", r.GetFullName(), err)
+	}
 
-		return
+	// send API call to capture the required pipelines for the org
+	required, err := database.FromContext(c).GetRequiredPipelineList(r.GetOrg())
+	if err != nil {
+		// nolint: lll // ignore long line length due to error message
+		return nil, "", http.StatusInternalServerError, fmt.Errorf("unable to restart build: failed to get required pipelines for %s: %w", r.GetOrg(), err)
 	}
 
 	// parse and compile the pipeline configuration file
-	p, err := compiler.FromContext(c).
+	comp := compiler.FromContext(c).
+		Duplicate().
 		WithBuild(b).
+		WithAction(action).
 		WithFiles(files).
 		WithMetadata(m).
+		WithPolicies(policies).
+		WithPullRequest(pull).
 		WithRepo(r).
-		WithUser(u).
-		Compile(config)
-	if err != nil {
-		retErr := fmt.Errorf("unable to compile pipeline configuration for %s: %w", entry, err)
+		WithRequiredPipelines(required).
+		WithSCM(scm.FromContext(c)).
+		WithUser(u)
 
-		util.HandleError(c, http.StatusInternalServerError, retErr)
-
-		return
+	p, err := comp.Compile(config)
+	if err != nil {
+		return nil, "", http.StatusInternalServerError, fmt.Errorf("unable to compile pipeline configuration for %s: %w", entry, err)
 	}
 
 	// skip the build if only the init or clone steps are found
@@ -1011,42 +1095,35 @@ func RestartBuild(c *gin.Context) {
 		b.SetStatus(constants.StatusSkipped)
 
 		// send API call to set the status on the commit
-		err = scm.FromContext(c).Status(u, b, r.GetOrg(), r.GetName())
+		err = pipelineStatus(c, u, b, r, named)
 		if err != nil {
-			logger.Errorf("unable to set commit status for %s: %v", entry, err)
+			logrus.Errorf("unable to set commit status for %s: %v", entry, err)
 		}
 
-		c.JSON(http.StatusOK, skip)
-		return
+		return b, skip, http.StatusOK, nil
 	}
 
 	// create the objects from the pipeline in the database
-	err = planBuild(database.FromContext(c), p, b, r)
+	// nolint: lll // ignore long line length due to parameters
+	err = planBuild(database.FromContext(c), p, b, r, comp.Templates(), comp.PolicyResults(), comp.InjectedSteps(), named, pull)
 	if err != nil {
-		util.HandleError(c, http.StatusInternalServerError, err)
-
-		return
+		return nil, "", http.StatusInternalServerError, err
 	}
 
 	// send API call to update repo for ensuring counter is incremented
 	err = database.FromContext(c).UpdateRepo(r)
 	if err != nil {
 		// nolint: lll // ignore long line length due to error message
-		retErr := fmt.Errorf("unable to restart build: failed to update repo %s: %v", r.GetFullName(), err)
-		util.HandleError(c, http.StatusBadRequest, retErr)
-
-		return
+		return nil, "", http.StatusBadRequest, fmt.Errorf("unable to restart build: failed to update repo %s: %v", r.GetFullName(), err)
 	}
 
 	// send API call to capture the restarted build
 	b, _ = database.FromContext(c).GetBuild(b.GetNumber(), r)
 
-	c.JSON(http.StatusCreated, b)
-
 	// send API call to set the status on the commit
-	err = scm.FromContext(c).Status(u, b, r.GetOrg(), r.GetName())
+	err = pipelineStatus(c, u, b, r, named)
 	if err != nil {
-		logger.Errorf("unable to set commit status for build %s: %v", entry, err)
+		logrus.Errorf("unable to set commit status for build %s: %v", entry, err)
 	}
 
 	// publish the build to the queue
@@ -1058,6 +1135,8 @@ func RestartBuild(c *gin.Context) {
 		r,
 		u,
 	)
+
+	return b, "", http.StatusCreated, nil
 }
 
 // swagger:operation PUT /api/v1/repos/{org}/{repo}/builds/{build} builds UpdateBuild
@@ -1139,6 +1218,9 @@ func UpdateBuild(c *gin.Context) {
 		return
 	}
 
+	// capture the status of the build before the update
+	status := b.GetStatus()
+
 	// update build fields if provided
 	if len(input.GetStatus()) > 0 {
 		// update status if set
@@ -1205,22 +1287,39 @@ func UpdateBuild(c *gin.Context) {
 
 	c.JSON(http.StatusOK, b)
 
+	// send API call to capture the repo owner
+	u, err = database.FromContext(c).GetUser(r.GetUserID())
+	if err != nil {
+		logrus.Errorf("unable to get owner for build %s: %v", entry, err)
+
+		return
+	}
+
+	// check if the status of the build changed
+	if b.GetStatus() != status {
+		// send API call to update the comment on the pull request
+		err = pullComment(c, u, r, b)
+		if err != nil {
+			logrus.Errorf("unable to set comment on pull request for build %s: %v", entry, err)
+		}
+	}
+
 	// check if the build is in a "final" state
 	if b.GetStatus() == constants.StatusSuccess ||
 		b.GetStatus() == constants.StatusFailure ||
 		b.GetStatus() == constants.StatusCanceled ||
 		b.GetStatus() == constants.StatusKilled ||
 		b.GetStatus() == constants.StatusError {
-		// send API call to capture the repo owner
-		u, err := database.FromContext(c).GetUser(r.GetUserID())
+		// send API call to set the status on the commit
+		err = pipelineStatus(c, u, b, r, buildPipeline(database.FromContext(c), b))
 		if err != nil {
-			logrus.Errorf("unable to get owner for build %s: %v", entry, err)
+			logrus.Errorf("unable to set commit status for build %s: %v", entry, err)
 		}
 
-		// send API call to set the status on the commit
-		err = scm.FromContext(c).Status(u, b, r.GetOrg(), r.GetName())
+		// send API call to set the status on the commit for the stages
+		err = buildStageStatus(c, u, b, r)
 		if err != nil {
-			logrus.Errorf("unable to set commit status for build %s: %v", entry, err)
+			logrus.Errorf("unable to set commit status for stages of build %s: %v", entry, err)
 		}
 	}
 }
@@ -1303,9 +1402,10 @@ func DeleteBuild(c *gin.Context) {
 func getPRNumberFromBuild(b *library.Build) (int, error) {
 	// parse out pull request number from base ref
 	//
-	// pattern: refs/pull/1/head
+	// pattern: refs/pull/1/head or refs/merge-requests/1/head
 	var parts []string
-	if strings.HasPrefix(b.GetRef(), "refs/pull/") {
+	if strings.HasPrefix(b.GetRef(), "refs/pull/") ||
+		strings.HasPrefix(b.GetRef(), "refs/merge-requests/") {
 		parts = strings.Split(b.GetRef(), "/")
 	}
 
@@ -1321,11 +1421,13 @@ func getPRNumberFromBuild(b *library.Build) (int, error) {
 }
 
 // planBuild is a helper function to plan the build for
-// execution. This creates all resources, like steps
-// and services, for the build in the configured backend.
+// execution. This creates all resources, like steps,
+// services, templates, policy results, injected steps,
+// the repo pipeline and the pull request, for the build
+// in the configured backend.
 //
 // nolint: lll // ignore long line length due to variable names
-func planBuild(database database.Service, p *pipeline.Build, b *library.Build, r *library.Repo) error {
+func planBuild(database database.Service, p *pipeline.Build, b *library.Build, r *library.Repo, templates []*model.BuildTemplate, results []*model.PolicyResult, injected []*model.InjectedStep, named *model.RepoPipeline, pull *model.BuildPullRequest) error {
 	// update fields in build object
 	b.SetCreated(time.Now().UTC().Unix())
 
@@ -1359,6 +1461,51 @@ func planBuild(database database.Service, p *pipeline.Build, b *library.Build, r
 		return err
 	}
 
+	// plan all templates for the build
+	err = planTemplates(database, templates, b)
+	if err != nil {
+		// clean up the objects from the pipeline in the database
+		cleanBuild(database, b, services, steps)
+
+		return err
+	}
+
+	// plan all policy results for the build
+	err = planPolicyResults(database, results, b)
+	if err != nil {
+		// clean up the objects from the pipeline in the database
+		cleanBuild(database, b, services, steps)
+
+		return err
+	}
+
+	// plan all injected steps for the build
+	err = planInjectedSteps(database, injected, b)
+	if err != nil {
+		// clean up the objects from the pipeline in the database
+		cleanBuild(database, b, services, steps)
+
+		return err
+	}
+
+	// plan the repo pipeline for the build
+	err = planBuildPipeline(database, named, b)
+	if err != nil {
+		// clean up the objects from the pipeline in the database
+		cleanBuild(database, b, services, steps)
+
+		return err
+	}
+
+	// plan the pull request for the build
+	err = planBuildPullRequest(database, pull, b)
+	if err != nil {
+		// clean up the objects from the pipeline in the database
+		cleanBuild(database, b, services, steps)
+
+		return err
+	}
+
 	return nil
 }
 
@@ -1483,14 +1630,26 @@ func CancelBuild(c *gin.Context) {
 		return
 	}
 
-	// retrieve the worker info
-	w, err := database.FromContext(c).GetWorker(b.GetHost())
+	b, code, err := cancelBuild(c, e, r, b)
 	if err != nil {
-		retErr := fmt.Errorf("unable to get worker for build %s: %w", entry, err)
-		util.HandleError(c, http.StatusNotFound, retErr)
+		util.HandleError(c, code, err)
+
 		return
 	}
 
+	c.JSON(code, b)
+}
+
+// cancelBuild is a helper function to cancel the running build
+// with the executor of the worker running the build. The build
+// with its steps and services is canceled in the database when
+// the build was abandoned. The HTTP status code is returned for
+// the response.
+//
+// nolint: funlen // ignore function length
+func cancelBuild(c *gin.Context, e []library.Executor, r *library.Repo, b *library.Build) (*library.Build, int, error) {
+	entry := fmt.Sprintf("%s/%d", r.GetFullName(), b.GetNumber())
+
 	for _, executor := range e {
 		// check each executor on the worker running the build
 		// to see if it's running the build we want to cancel
@@ -1499,6 +1658,12 @@ func CancelBuild(c *gin.Context) {
 		if strings.EqualFold(executor.Repo.GetFullName(), r.GetFullName()) &&
 			*executor.GetBuild().Number == b.GetNumber() {
 
+			// retrieve the worker info
+			w, err := database.FromContext(c).GetWorker(b.GetHost())
+			if err != nil {
+				return nil, http.StatusNotFound, fmt.Errorf("unable to get worker for build %s: %w", entry, err)
+			}
+
 			// prepare the request to the worker
 			client := http.DefaultClient
 			client.Timeout = 30 * time.Second
@@ -1507,9 +1672,7 @@ func CancelBuild(c *gin.Context) {
 			u := fmt.Sprintf("%s/api/v1/executors/%d/build/cancel", w.GetAddress(), executor.GetID())
 			req, err := http.NewRequest("DELETE", u, nil)
 			if err != nil {
-				retErr := fmt.Errorf("unable to form a request to %s: %w", u, err)
-				util.HandleError(c, http.StatusBadRequest, retErr)
-				return
+				return nil, http.StatusBadRequest, fmt.Errorf("unable to form a request to %s: %w", u, err)
 			}
 
 			// add the token to authenticate to the worker
@@ -1518,40 +1681,32 @@ func CancelBuild(c *gin.Context) {
 			// perform the request to the worker
 			resp, err := client.Do(req)
 			if err != nil {
-				retErr := fmt.Errorf("unable to connect to %s: %w", u, err)
-				util.HandleError(c, http.StatusBadRequest, retErr)
-				return
+				return nil, http.StatusBadRequest, fmt.Errorf("unable to connect to %s: %w", u, err)
 			}
 			defer resp.Body.Close()
 
 			// Read Response Body
 			respBody, err := ioutil.ReadAll(resp.Body)
 			if err != nil {
-				retErr := fmt.Errorf("unable to read response from %s: %w", u, err)
-				util.HandleError(c, http.StatusBadRequest, retErr)
-				return
+				return nil, http.StatusBadRequest, fmt.Errorf("unable to read response from %s: %w", u, err)
 			}
 
 			err = json.Unmarshal(respBody, b)
 			if err != nil {
-				retErr := fmt.Errorf("unable to parse response from %s: %w", u, err)
-				util.HandleError(c, http.StatusBadRequest, retErr)
-				return
+				return nil, http.StatusBadRequest, fmt.Errorf("unable to parse response from %s: %w", u, err)
 			}
 
-			c.JSON(resp.StatusCode, b)
-			return
+			return b, resp.StatusCode, nil
 		}
 	}
 
 	// build has been abandoned
 	// update the status in the build table
 	b.SetStatus(constants.StatusCanceled)
-	err = database.FromContext(c).UpdateBuild(b)
+
+	err := database.FromContext(c).UpdateBuild(b)
 	if err != nil {
-		retErr := fmt.Errorf("unable to update status for build %s: %w", entry, err)
-		util.HandleError(c, http.StatusInternalServerError, retErr)
-		return
+		return nil, http.StatusInternalServerError, fmt.Errorf("unable to update status for build %s: %w", entry, err)
 	}
 
 	// retrieve the steps for the build from the step table
@@ -1562,9 +1717,7 @@ func CancelBuild(c *gin.Context) {
 		// retrieve build steps (per page) from the database
 		stepsPart, err := database.FromContext(c).GetBuildStepList(b, page, perPage)
 		if err != nil {
-			retErr := fmt.Errorf("unable to retrieve steps for build %s: %w", entry, err)
-			util.HandleError(c, http.StatusNotFound, retErr)
-			return
+			return nil, http.StatusNotFound, fmt.Errorf("unable to retrieve steps for build %s: %w", entry, err)
 		}
 
 		// add page of steps to list steps
@@ -1588,9 +1741,7 @@ func CancelBuild(c *gin.Context) {
 			step.SetStatus(constants.StatusCanceled)
 			err = database.FromContext(c).UpdateStep(step)
 			if err != nil {
-				retErr := fmt.Errorf("unable to update step %s for build %s: %w", step.GetName(), entry, err)
-				util.HandleError(c, http.StatusNotFound, retErr)
-				return
+				return nil, http.StatusNotFound, fmt.Errorf("unable to update step %s for build %s: %w", step.GetName(), entry, err)
 			}
 		}
 	}
@@ -1602,9 +1753,7 @@ func CancelBuild(c *gin.Context) {
 		// retrieve build services (per page) from the database
 		servicesPart, err := database.FromContext(c).GetBuildServiceList(b, page, perPage)
 		if err != nil {
-			retErr := fmt.Errorf("unable to retrieve services for build %s: %w", entry, err)
-			util.HandleError(c, http.StatusNotFound, retErr)
-			return
+			return nil, http.StatusNotFound, fmt.Errorf("unable to retrieve services for build %s: %w", entry, err)
 		}
 
 		// add page of services to the list of services
@@ -1628,16 +1777,14 @@ func CancelBuild(c *gin.Context) {
 			service.SetStatus(constants.StatusCanceled)
 			err = database.FromContext(c).UpdateService(service)
 			if err != nil {
-				retErr := fmt.Errorf("unable to update service %s for build %s: %w",
+				return nil, http.StatusNotFound, fmt.Errorf("unable to update service %s for build %s: %w",
 					service.GetName(),
 					entry,
 					err,
 				)
-				util.HandleError(c, http.StatusNotFound, retErr)
-				return
 			}
 		}
 	}
 
-	c.JSON(http.StatusOK, b)
+	return b, http.StatusOK, nil
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"testing"
 
+	"github.com/go-vela/types/library"
 	"github.com/go-vela/types/pipeline"
 )
 
@@ -72,3 +73,40 @@ func Test_skipEmptyBuild(t *testing.T) {
 		})
 	}
 }
+
+func Test_getPRNumberFromBuild(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		ref     string
+		want    int
+		failure bool
+	}{
+		{ref: "refs/pull/1/head", want: 1},
+		{ref: "refs/merge-requests/2/head", want: 2},
+		{ref: "refs/heads/main", failure: true},
+	}
+
+	// run tests
+	for _, test := range tests {
+		b := new(library.Build)
+		b.SetRef(test.ref)
+
+		got, err := getPRNumberFromBuild(b)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("getPRNumberFromBuild for %s should have returned err", test.ref)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("getPRNumberFromBuild for %s returned err: %v", test.ref, err)
+		}
+
+		if got != test.want {
+			t.Errorf("getPRNumberFromBuild for %s is %v, want %v", test.ref, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"sort"
+
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+
+	"github.com/sirupsen/logrus"
+)
+
+// checkSteps is a helper function to capture the results
+// of the steps for a build reported in the check run.
+// The output of every failed step is parsed for
+// annotations when the annotations are requested.
+//
+// nolint: lll // ignore long line length due to parameters
+func checkSteps(database database.Service, b *library.Build, annotate bool) ([]*model.CheckStep, error) {
+	// send API call to capture the steps for the build
+	steps, err := buildSteps(database, b)
+	if err != nil {
+		return nil, err
+	}
+
+	// report the steps in the order they were run
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].GetNumber() < steps[j].GetNumber()
+	})
+
+	checks := []*model.CheckStep{}
+
+	for _, s := range steps {
+		check := &model.CheckStep{Step: s}
+
+		if annotate && stepFailed(s) {
+			// send API call to capture the logs for the step
+			l, err := database.GetStepLog(s.GetID())
+			if err != nil {
+				logrus.Debugf("unable to get logs for step %s: %v", s.GetName(), err)
+			} else {
+				check.Annotations = model.ParseCheckAnnotations(l.GetData())
+			}
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// stepFailed is a helper function to check
+// if a step completed with a failure.
+func stepFailed(s *library.Step) bool {
+	return s.GetStatus() == constants.StatusFailure ||
+		s.GetStatus() == constants.StatusError ||
+		s.GetExitCode() != 0
+}
+
+// buildCompleted is a helper function to check
+// if a build is in a "final" state.
+func buildCompleted(b *library.Build) bool {
+	return b.GetStatus() == constants.StatusSuccess ||
+		b.GetStatus() == constants.StatusFailure ||
+		b.GetStatus() == constants.StatusCanceled ||
+		b.GetStatus() == constants.StatusKilled ||
+		b.GetStatus() == constants.StatusSkipped ||
+		b.GetStatus() == constants.StatusError
+}
+
+// stepCompleted is a helper function to check
+// if a step is in a "final" state.
+func stepCompleted(s *library.Step) bool {
+	return s.GetStatus() == constants.StatusSuccess ||
+		s.GetStatus() == constants.StatusFailure ||
+		s.GetStatus() == constants.StatusCanceled ||
+		s.GetStatus() == constants.StatusKilled ||
+		s.GetStatus() == constants.StatusSkipped ||
+		s.GetStatus() == constants.StatusError
+}
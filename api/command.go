@@ -0,0 +1,306 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/router/middleware/executors"
+	"github.com/go-vela/server/scm"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// commandPrefix defines the prefix for the
+// commands in the comments on a pull request.
+const commandPrefix = "/vela"
+
+const (
+	// commandApprove defines the command to approve
+	// the builds waiting for approval for a pull request.
+	commandApprove = "approve"
+
+	// commandCancel defines the command to cancel the
+	// pending and running builds for a pull request.
+	commandCancel = "cancel"
+
+	// commandDeploy defines the command to create a deployment
+	// to a target from the head commit of a pull request.
+	commandDeploy = "deploy"
+
+	// commandRestart defines the command to restart
+	// the last build for a pull request.
+	commandRestart = "restart"
+)
+
+// parseCommand is a helper function to capture the name and the
+// arguments of the command from the first line of a comment on a
+// pull request, i.e. `/vela deploy production`. False is returned
+// when the comment doesn't start with the command prefix.
+func parseCommand(comment string) (string, []string, bool) {
+	line := strings.SplitN(strings.TrimSpace(comment), "\n", 2)[0]
+
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != commandPrefix {
+		return "", nil, false
+	}
+
+	return strings.ToLower(fields[1]), fields[2:], true
+}
+
+// runCommand is a helper function to run the command from a comment
+// on a pull request when the sender of the comment has write access
+// to the repo. The result of the command is replied on the pull
+// request and returned.
+func runCommand(c *gin.Context, r *library.Repo, b *library.Build, number int, name string, args []string) string {
+	logrus.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": b.GetSender(),
+	}).Infof("running command %s for pull request %s#%d", name, r.GetFullName(), number)
+
+	// send API call to capture the repo owner
+	u, err := database.FromContext(c).GetUser(r.GetUserID())
+	if err != nil {
+		return fmt.Sprintf("unable to get owner for %s: %v", r.GetFullName(), err)
+	}
+
+	reply, err := command(c, u, r, b, number, name, args)
+	if err != nil {
+		reply = fmt.Sprintf("Unable to %s: %v", name, err)
+	}
+
+	// send API call to reply with the result on the pull request
+	_, err = scm.FromContext(c).CreateComment(u, r, number, reply)
+	if err != nil {
+		logrus.Errorf("unable to reply on pull request %s#%d: %v", r.GetFullName(), number, err)
+	}
+
+	return reply
+}
+
+// command is a helper function to verify the access of the
+// sender of the comment and to run the command by name.
+//
+// nolint: lll // ignore long line length due to parameters
+func command(c *gin.Context, u *library.User, r *library.Repo, b *library.Build, number int, name string, args []string) (string, error) {
+	sender := &library.User{Name: b.Sender}
+
+	// send API call to capture the access level of the sender for the repo
+	perm, err := scm.FromContext(c).RepoAccess(sender, u.GetToken(), r.GetOrg(), r.GetName())
+	if err != nil {
+		return "", fmt.Errorf("unable to get user %s access level for repo %s: %w", sender.GetName(), r.GetFullName(), err)
+	}
+
+	if perm != "admin" && perm != "write" {
+		return "", fmt.Errorf("user %s does not have 'write' permissions for the repo %s", sender.GetName(), r.GetFullName())
+	}
+
+	switch name {
+	case commandApprove:
+		return approveCommand(c, r, b)
+	case commandCancel:
+		return cancelCommand(c, r, b)
+	case commandDeploy:
+		return deployCommand(c, u, r, b, number, args)
+	case commandRestart:
+		return restartCommand(c, r, b)
+	default:
+		// nolint: lll // ignore long line length due to error message
+		return "", fmt.Errorf("unknown command %s, the supported commands are %s, %s, %s <target> and %s", name, commandApprove, commandCancel, commandDeploy, commandRestart)
+	}
+}
+
+// approveCommand is a helper function to approve the
+// builds waiting for approval for the pull request.
+func approveCommand(c *gin.Context, r *library.Repo, b *library.Build) (string, error) {
+	// create SQL filters for querying the builds waiting for approval for the pull request
+	filters := map[string]interface{}{
+		"status": model.StatusPendingApproval,
+		"ref":    b.GetRef(),
+	}
+
+	// send API call to capture the builds waiting for approval
+	//
+	// nolint: gomnd // ignore magic number
+	builds, _, err := database.FromContext(c).GetRepoBuildList(r, filters, 1, 100)
+	if err != nil {
+		return "", fmt.Errorf("unable to get builds waiting for approval for %s: %w", r.GetFullName(), err)
+	}
+
+	if len(builds) == 0 {
+		return "No builds are waiting for approval.", nil
+	}
+
+	approved := []string{}
+
+	for _, build := range builds {
+		err = approveBuild(c, build, r, b.GetSender())
+		// skip builds approved by another approval
+		if errors.Is(err, model.ErrBuildApprovalApproved) {
+			continue
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("unable to approve build %s: %w", commandEntry(build), err)
+		}
+
+		approved = append(approved, commandEntry(build))
+	}
+
+	if len(approved) == 0 {
+		return "No builds are waiting for approval.", nil
+	}
+
+	return fmt.Sprintf("Approved build %s.", strings.Join(approved, ", ")), nil
+}
+
+// cancelCommand is a helper function to cancel the pending,
+// waiting for approval and running builds for the pull request.
+func cancelCommand(c *gin.Context, r *library.Repo, b *library.Build) (string, error) {
+	// create SQL filters for querying the builds to cancel for the pull request
+	filters := map[string]interface{}{
+		"status": []string{constants.StatusPending, constants.StatusRunning, model.StatusPendingApproval},
+		"ref":    b.GetRef(),
+	}
+
+	// send API call to capture the builds to cancel
+	//
+	// nolint: gomnd // ignore magic number
+	builds, _, err := database.FromContext(c).GetRepoBuildList(r, filters, 1, 100)
+	if err != nil {
+		return "", fmt.Errorf("unable to get builds for %s: %w", r.GetFullName(), err)
+	}
+
+	if len(builds) == 0 {
+		return "No builds are pending or running.", nil
+	}
+
+	canceled := []string{}
+
+	for _, build := range builds {
+		var e []library.Executor
+
+		// capture the executors of the worker running the build
+		if strings.EqualFold(build.GetStatus(), constants.StatusRunning) {
+			e = buildExecutors(c, build)
+		}
+
+		_, _, err = cancelBuild(c, e, r, build)
+		if err != nil {
+			return "", fmt.Errorf("unable to cancel build %s: %w", commandEntry(build), err)
+		}
+
+		canceled = append(canceled, commandEntry(build))
+	}
+
+	return fmt.Sprintf("Canceled build %s.", strings.Join(canceled, ", ")), nil
+}
+
+// deployCommand is a helper function to create a deployment to
+// the target from the head commit of the pull request.
+//
+// nolint: lll // ignore long line length due to parameters
+func deployCommand(c *gin.Context, u *library.User, r *library.Repo, b *library.Build, number int, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("the %s command requires a target, i.e. %s %s production", commandDeploy, commandPrefix, commandDeploy)
+	}
+
+	// send API call to capture the head commit of the pull request
+	commit, _, _, _, _, err := scm.FromContext(c).GetPullRequest(u, r, number)
+	if err != nil {
+		return "", fmt.Errorf("unable to get pull request info for %s: %w", r.GetFullName(), err)
+	}
+
+	d := new(library.Deployment)
+	d.SetRepoID(r.GetID())
+	d.SetRef(commit)
+	d.SetTarget(args[0])
+	d.SetTask("deploy:vela")
+	d.SetUser(b.GetSender())
+	d.SetDescription(fmt.Sprintf("Deployment request from Vela by %s", b.GetSender()))
+
+	// send API call to create the deployment
+	err = scm.FromContext(c).CreateDeployment(u, r, d)
+	if err != nil {
+		return "", fmt.Errorf("unable to create deployment for %s: %w", r.GetFullName(), err)
+	}
+
+	return fmt.Sprintf("Created deployment to %s for %s.", d.GetTarget(), commit), nil
+}
+
+// restartCommand is a helper function to restart
+// the last build for the pull request.
+func restartCommand(c *gin.Context, r *library.Repo, b *library.Build) (string, error) {
+	// create SQL filters for querying the last build for the pull request
+	filters := map[string]interface{}{
+		"event": constants.EventPull,
+		"ref":   b.GetRef(),
+	}
+
+	// send API call to capture the last build for the pull request
+	builds, _, err := database.FromContext(c).GetRepoBuildList(r, filters, 1, 1)
+	if err != nil {
+		return "", fmt.Errorf("unable to get builds for %s: %w", r.GetFullName(), err)
+	}
+
+	if len(builds) == 0 {
+		return "No build was found to restart.", nil
+	}
+
+	last := commandEntry(builds[0])
+
+	build, skip, _, err := restartBuild(c, r, builds[0])
+	if err != nil {
+		return "", fmt.Errorf("unable to restart build %s: %w", last, err)
+	}
+
+	if skip != "" {
+		return fmt.Sprintf("Restarted build %s but the build was skipped: %s", last, skip), nil
+	}
+
+	return fmt.Sprintf("Restarted build %s as build %s.", last, commandEntry(build)), nil
+}
+
+// commandEntry is a helper function to capture the
+// number of the build with the link to the build
+// for the reply to a command.
+func commandEntry(b *library.Build) string {
+	if len(b.GetLink()) == 0 {
+		return fmt.Sprintf("#%d", b.GetNumber())
+	}
+
+	return fmt.Sprintf("[#%d](%s)", b.GetNumber(), b.GetLink())
+}
+
+// buildExecutors is a helper function to capture the executors
+// of the worker running the build. No executors are returned
+// when the worker is unavailable so the build is treated as
+// abandoned by the worker.
+func buildExecutors(c *gin.Context, b *library.Build) []library.Executor {
+	// send API call to capture the worker running the build
+	w, err := database.FromContext(c).GetWorker(b.GetHost())
+	if err != nil {
+		return []library.Executor{}
+	}
+
+	// send API call to capture the executors of the worker
+	e, err := executors.Capture(c, w)
+	if err != nil {
+		logrus.Errorf("unable to get executors for build %s: %v", commandEntry(b), err)
+
+		return []library.Executor{}
+	}
+
+	return e
+}
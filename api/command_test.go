@@ -0,0 +1,71 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/types/library"
+)
+
+func Test_parseCommand(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		comment  string
+		wantName string
+		wantArgs []string
+		wantOk   bool
+	}{
+		{comment: "/vela restart", wantName: "restart", wantArgs: []string{}, wantOk: true},
+		{comment: "  /vela Approve\n", wantName: "approve", wantArgs: []string{}, wantOk: true},
+		{comment: "/vela deploy production", wantName: "deploy", wantArgs: []string{"production"}, wantOk: true},
+		{comment: "/vela cancel\nthe build is stuck", wantName: "cancel", wantArgs: []string{}, wantOk: true},
+		{comment: "/vela", wantOk: false},
+		{comment: "/velarestart", wantOk: false},
+		{comment: "please /vela restart", wantOk: false},
+		{comment: "looks good to me", wantOk: false},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.comment, func(t *testing.T) {
+			name, args, ok := parseCommand(test.comment)
+
+			if ok != test.wantOk {
+				t.Errorf("parseCommand ok is %v, want %v", ok, test.wantOk)
+			}
+
+			if name != test.wantName {
+				t.Errorf("parseCommand name is %v, want %v", name, test.wantName)
+			}
+
+			if test.wantOk && !reflect.DeepEqual(args, test.wantArgs) {
+				t.Errorf("parseCommand args is %v, want %v", args, test.wantArgs)
+			}
+		})
+	}
+}
+
+func Test_commandEntry(t *testing.T) {
+	// setup types
+	b := new(library.Build)
+	b.SetNumber(2)
+
+	link := new(library.Build)
+	link.SetNumber(3)
+	link.SetLink("https://vela.example.com/foo/bar/3")
+
+	// run tests
+	if got := commandEntry(b); got != "#2" {
+		t.Errorf("commandEntry is %v, want %v", got, "#2")
+	}
+
+	want := "[#3](https://vela.example.com/foo/bar/3)"
+
+	if got := commandEntry(link); got != want {
+		t.Errorf("commandEntry is %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/scm"
+
+	"github.com/go-vela/types/library"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// pullComment is a helper function to create or update the comment
+// with the summary of the build on the pull request the build was
+// created for. The comment is only posted when it's enabled in the
+// pipeline settings of the repo and isn't updated for a build older
+// than the build the comment was last updated for.
+func pullComment(c *gin.Context, u *library.User, r *library.Repo, b *library.Build) error {
+	// capture the pull request number from the build
+	//
+	// builds not created for a pull request have no comment
+	number, err := getPRNumberFromBuild(b)
+	if err != nil {
+		return nil
+	}
+
+	// send API call to capture the pipeline settings for the repo
+	settings, _, _ := repoPipeline(database.FromContext(c), r, "")
+	if settings == nil || !settings.PullComment {
+		return nil
+	}
+
+	pipeline := ""
+
+	// send API call to capture the repo pipeline the build was created for
+	if named := buildPipeline(database.FromContext(c), b); named != nil {
+		pipeline = named.Name
+	}
+
+	// send API call to capture the steps for the build
+	steps, err := buildSteps(database.FromContext(c), b)
+	if err != nil {
+		return fmt.Errorf("unable to get steps for build: %w", err)
+	}
+
+	body := pullCommentBody(b, pipeline, steps)
+
+	// send API call to capture the comment for the pull request
+	comment, err := database.FromContext(c).GetPullComment(r, number, b.GetEvent(), pipeline)
+	if err != nil {
+		return claimPullComment(c, u, r, &model.PullComment{
+			RepoID:   r.GetID(),
+			Number:   number,
+			Event:    b.GetEvent(),
+			Pipeline: pipeline,
+			Build:    b.GetNumber(),
+		}, body)
+	}
+
+	// skip while the comment is created by another update
+	if comment.CommentID == 0 {
+		return nil
+	}
+
+	comment.Build = b.GetNumber()
+
+	// send API call to claim the comment for the build
+	err = database.FromContext(c).ClaimPullComment(comment)
+	if err != nil {
+		// skip the update for an older build finishing late
+		if errors.Is(err, model.ErrPullCommentStale) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to claim comment for pull request: %w", err)
+	}
+
+	// send API call to update the comment on the pull request
+	err = scm.FromContext(c).UpdateComment(u, r, number, comment.CommentID, body)
+	if err == nil {
+		return nil
+	}
+
+	// the comment may have been deleted from the pull request
+	// so a new comment is created to replace the comment
+	logrus.Debugf("unable to update comment %d on pull request %s#%d: %v", comment.CommentID, r.GetFullName(), number, err)
+
+	// send API call to create the comment on the pull request
+	id, err := scm.FromContext(c).CreateComment(u, r, number, body)
+	if err != nil {
+		return fmt.Errorf("unable to create comment on pull request: %w", err)
+	}
+
+	// update the record of the replaced comment
+	comment.CommentID = id
+
+	return database.FromContext(c).UpdatePullComment(comment)
+}
+
+// claimPullComment is a helper function to record the comment
+// for the pull request before the comment is created, so only
+// one of the concurrent updates of the builds creates it. The
+// record is removed when the comment can't be created so the
+// comment is created by a later update.
+func claimPullComment(c *gin.Context, u *library.User, r *library.Repo, comment *model.PullComment, body string) error {
+	// send API call to claim the record for the comment
+	err := database.FromContext(c).CreatePullComment(comment)
+	if err != nil {
+		// send API call to check if another update claimed the record
+		_, getErr := database.FromContext(c).GetPullComment(r, comment.Number, comment.Event, comment.Pipeline)
+		if getErr == nil {
+			return nil
+		}
+
+		return fmt.Errorf("unable to create comment for pull request: %w", err)
+	}
+
+	// send API call to create the comment on the pull request
+	id, err := scm.FromContext(c).CreateComment(u, r, comment.Number, body)
+	if err != nil {
+		retErr := fmt.Errorf("unable to create comment on pull request: %w", err)
+
+		// send API call to release the record for the comment
+		err = database.FromContext(c).DeletePullComment(comment.ID)
+		if err != nil {
+			logrus.Errorf("unable to delete comment %d for pull request %s#%d: %v", comment.ID, r.GetFullName(), comment.Number, err)
+		}
+
+		return retErr
+	}
+
+	comment.CommentID = id
+
+	// send API call to record the comment for the pull request
+	return database.FromContext(c).UpdatePullComment(comment)
+}
+
+// pullCommentBody is a helper function to render the
+// summary of the build for the comment on the pull
+// request with the status, duration and failed steps.
+func pullCommentBody(b *library.Build, pipeline string, steps []*library.Step) string {
+	var body strings.Builder
+
+	title := fmt.Sprintf("Vela build #%d", b.GetNumber())
+	if len(b.GetLink()) > 0 {
+		title = fmt.Sprintf("Vela build [#%d](%s)", b.GetNumber(), b.GetLink())
+	}
+
+	if len(pipeline) > 0 {
+		title = fmt.Sprintf("%s for pipeline %s", title, pipeline)
+	}
+
+	fmt.Fprintf(&body, "### %s\n\n", title)
+	fmt.Fprintf(&body, "| Status | Duration | Commit |\n")
+	fmt.Fprintf(&body, "| --- | --- | --- |\n")
+	fmt.Fprintf(&body, "| %s | %s | %s |\n", b.GetStatus(), buildDuration(b), b.GetCommit())
+
+	// report the steps in the order they were run
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].GetNumber() < steps[j].GetNumber()
+	})
+
+	failed := []string{}
+
+	for _, s := range steps {
+		if stepFailed(s) {
+			failed = append(failed, fmt.Sprintf("- `%s` (exit code %d)", s.GetName(), s.GetExitCode()))
+		}
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintf(&body, "\n**Failed steps**\n\n%s\n", strings.Join(failed, "\n"))
+	}
+
+	if len(b.GetError()) > 0 {
+		fmt.Fprintf(&body, "\n**Error**: %s\n", b.GetError())
+	}
+
+	return body.String()
+}
+
+// buildDuration is a helper function to capture the
+// duration of the build. The duration until now is
+// used for a build that hasn't finished yet.
+func buildDuration(b *library.Build) string {
+	if b.GetStarted() == 0 {
+		return "-"
+	}
+
+	finished := b.GetFinished()
+	if finished == 0 {
+		finished = time.Now().UTC().Unix()
+	}
+
+	return (time.Duration(finished-b.GetStarted()) * time.Second).String()
+}
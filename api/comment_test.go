@@ -0,0 +1,292 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/database/sqlite"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/scm"
+	"github.com/go-vela/server/scm/github"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+)
+
+func Test_pullCommentBody(t *testing.T) {
+	// setup types
+	b := new(library.Build)
+	b.SetNumber(2)
+	b.SetLink("https://vela.example.com/foo/bar/2")
+	b.SetStatus(constants.StatusFailure)
+	b.SetCommit("abc123")
+	b.SetStarted(1563474077)
+	b.SetFinished(1563474167)
+
+	test := new(library.Step)
+	test.SetNumber(3)
+	test.SetName("test")
+	test.SetStatus(constants.StatusFailure)
+	test.SetExitCode(1)
+
+	clone := new(library.Step)
+	clone.SetNumber(2)
+	clone.SetName("clone")
+	clone.SetStatus(constants.StatusSuccess)
+
+	lint := new(library.Step)
+	lint.SetNumber(4)
+	lint.SetName("lint")
+	lint.SetStatus(constants.StatusError)
+	lint.SetExitCode(2)
+
+	want := "### Vela build [#2](https://vela.example.com/foo/bar/2) for pipeline api\n\n" +
+		"| Status | Duration | Commit |\n" +
+		"| --- | --- | --- |\n" +
+		"| failure | 1m30s | abc123 |\n" +
+		"\n**Failed steps**\n\n" +
+		"- `test` (exit code 1)\n" +
+		"- `lint` (exit code 2)\n"
+
+	// run test
+	got := pullCommentBody(b, "api", []*library.Step{lint, test, clone})
+
+	if got != want {
+		t.Errorf("pullCommentBody is %v, want %v", got, want)
+	}
+}
+
+func Test_buildDuration(t *testing.T) {
+	// setup types
+	pending := new(library.Build)
+
+	finished := new(library.Build)
+	finished.SetStarted(1563474077)
+	finished.SetFinished(1563474084)
+
+	// run tests
+	if got := buildDuration(pending); got != "-" {
+		t.Errorf("buildDuration is %v, want %v", got, "-")
+	}
+
+	if got := buildDuration(finished); got != "7s" {
+		t.Errorf("buildDuration is %v, want %v", got, "7s")
+	}
+}
+
+func Test_claimPullComment(t *testing.T) {
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetID(1)
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+
+	// setup mock server
+	created := 0
+	failure := false
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if failure {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		created++
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer s.Close()
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from pull_comments;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	client, _ := github.NewTest(s.URL)
+
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	database.ToContext(c, db)
+	scm.ToContext(c, client)
+
+	comment := func(number int) *model.PullComment {
+		return &model.PullComment{RepoID: 1, Number: number, Event: "pull_request", Pipeline: "api"}
+	}
+
+	// run test for the update claiming the comment
+	err = claimPullComment(c, u, r, comment(1), "body")
+	if err != nil {
+		t.Errorf("claimPullComment returned err: %v", err)
+	}
+
+	got, _ := db.GetPullComment(r, 1, "pull_request", "api")
+	if got == nil || got.CommentID != 1 {
+		t.Errorf("GetPullComment is %v, want comment_id 1", got)
+	}
+
+	// run test for an update with the comment already claimed
+	err = claimPullComment(c, u, r, comment(1), "body")
+	if err != nil {
+		t.Errorf("claimPullComment returned err: %v", err)
+	}
+
+	if created != 1 {
+		t.Errorf("claimPullComment created %d comments, want 1", created)
+	}
+
+	// run test for an update failing to create the comment
+	failure = true
+
+	err = claimPullComment(c, u, r, comment(2), "body")
+	if err == nil {
+		t.Errorf("claimPullComment should have returned err")
+	}
+
+	if got, err := db.GetPullComment(r, 2, "pull_request", "api"); err == nil {
+		t.Errorf("GetPullComment is %v, want the claim to be released", got)
+	}
+}
+
+func Test_pullComment(t *testing.T) {
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetID(1)
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+
+	build := func(number int) *library.Build {
+		b := new(library.Build)
+		b.SetID(int64(number))
+		b.SetRepoID(1)
+		b.SetNumber(number)
+		b.SetEvent(constants.EventPull)
+		b.SetRef("refs/pull/1/head")
+		b.SetStatus(constants.StatusSuccess)
+
+		return b
+	}
+
+	// setup mock server
+	created := 0
+	updated := []string{}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if req.Method == http.MethodPatch {
+			body := struct {
+				Body string `json:"body"`
+			}{}
+
+			_ = json.NewDecoder(req.Body).Decode(&body)
+
+			updated = append(updated, body.Body)
+
+			_, _ = w.Write([]byte(`{"id": 1}`))
+
+			return
+		}
+
+		created++
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer s.Close()
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from pull_comments;")
+		db.Sqlite.Exec("delete from pipeline_settings;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreatePipelineSettings(&model.PipelineSettings{RepoID: 1, PullComment: true})
+
+	client, _ := github.NewTest(s.URL)
+
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	database.ToContext(c, db)
+	scm.ToContext(c, client)
+
+	// setup tests
+	tests := []struct {
+		name        string
+		build       int
+		wantUpdated int
+		wantBuild   int
+	}{
+		{"create for build", 2, 0, 2},
+		{"update for newer build", 3, 1, 3},
+		{"skip older build", 2, 1, 3},
+		{"update for same build", 3, 2, 3},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := pullComment(c, u, r, build(test.build))
+		if err != nil {
+			t.Errorf("pullComment %s returned err: %v", test.name, err)
+		}
+
+		if created != 1 {
+			t.Errorf("pullComment %s created %d comments, want 1", test.name, created)
+		}
+
+		if len(updated) != test.wantUpdated {
+			t.Errorf("pullComment %s updated %d comments, want %d", test.name, len(updated), test.wantUpdated)
+		}
+
+		got, _ := db.GetPullComment(r, 1, constants.EventPull, "")
+		if got == nil || got.Build != test.wantBuild {
+			t.Errorf("pullComment %s comment is %v, want build %d", test.name, got, test.wantBuild)
+		}
+	}
+
+	for _, body := range updated {
+		if !strings.Contains(body, "Vela build #3") {
+			t.Errorf("pullComment updated the comment with %s, want build #3", body)
+		}
+	}
+}
@@ -0,0 +1,599 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/repo"
+	"github.com/go-vela/server/router/middleware/user"
+	"github.com/go-vela/server/util"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+
+	"gorm.io/gorm"
+)
+
+// swagger:operation GET /api/v1/default-pipelines/{org} default-pipelines GetDefaultPipeline
+//
+// Get the default pipeline for an org from the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved the default pipeline for the org
+//     schema:
+//       "$ref": "#/definitions/DefaultPipeline"
+//   '404':
+//     description: Unable to retrieve the default pipeline for the org
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetDefaultPipeline represents the API handler to capture
+// the default pipeline for an org from the configured backend.
+func GetDefaultPipeline(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"user": u.GetName(),
+	}).Infof("reading default pipeline for org %s", o)
+
+	// send API call to capture the default pipeline for the org
+	p, err := database.FromContext(c).GetDefaultPipeline(o)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get default pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// swagger:operation POST /api/v1/default-pipelines/{org} default-pipelines CreateDefaultPipeline
+//
+// Create the default pipeline for an org in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: body
+//   name: body
+//   description: Payload containing the default pipeline to create
+//   required: true
+//   schema:
+//     "$ref": "#/definitions/DefaultPipeline"
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '201':
+//     description: Successfully created the default pipeline
+//     schema:
+//       "$ref": "#/definitions/DefaultPipeline"
+//   '400':
+//     description: Unable to create the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '409':
+//     description: Unable to create the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to create the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// CreateDefaultPipeline represents the API handler to create
+// the default pipeline for an org in the configured backend.
+func CreateDefaultPipeline(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"user": u.GetName(),
+	}).Infof("creating default pipeline for org %s", o)
+
+	// capture body from API request
+	input := new(model.DefaultPipeline)
+
+	err := c.Bind(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to decode JSON for new default pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	input.Org = o
+
+	// validate the default pipeline before storing it
+	err = input.Validate()
+	if err != nil {
+		retErr := fmt.Errorf("unable to validate default pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to capture the default pipeline
+	_, err = database.FromContext(c).GetDefaultPipeline(o)
+	if err == nil {
+		retErr := fmt.Errorf("default pipeline already exists for org %s", o)
+
+		util.HandleError(c, http.StatusConflict, retErr)
+
+		return
+	}
+
+	// send API call to create the default pipeline
+	err = database.FromContext(c).CreateDefaultPipeline(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to create default pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	// send API call to capture the created default pipeline
+	p, _ := database.FromContext(c).GetDefaultPipeline(o)
+
+	c.JSON(http.StatusCreated, p)
+}
+
+// swagger:operation PUT /api/v1/default-pipelines/{org} default-pipelines UpdateDefaultPipeline
+//
+// Update the default pipeline for an org in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: body
+//   name: body
+//   description: Payload containing the default pipeline to update
+//   required: true
+//   schema:
+//     "$ref": "#/definitions/DefaultPipeline"
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully updated the default pipeline
+//     schema:
+//       "$ref": "#/definitions/DefaultPipeline"
+//   '400':
+//     description: Unable to update the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '404':
+//     description: Unable to update the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to update the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// UpdateDefaultPipeline represents the API handler to update
+// the default pipeline for an org in the configured backend.
+func UpdateDefaultPipeline(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"user": u.GetName(),
+	}).Infof("updating default pipeline for org %s", o)
+
+	// send API call to capture the default pipeline
+	p, err := database.FromContext(c).GetDefaultPipeline(o)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get default pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	// capture body from API request
+	input := new(model.DefaultPipeline)
+
+	err = c.Bind(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to decode JSON for default pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	input.ID = p.ID
+	input.Org = o
+
+	// validate the default pipeline before storing it
+	err = input.Validate()
+	if err != nil {
+		retErr := fmt.Errorf("unable to validate default pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to update the default pipeline
+	err = database.FromContext(c).UpdateDefaultPipeline(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to update default pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, input)
+}
+
+// swagger:operation DELETE /api/v1/default-pipelines/{org} default-pipelines DeleteDefaultPipeline
+//
+// Delete the default pipeline for an org from the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully deleted the default pipeline
+//     schema:
+//       type: string
+//   '404':
+//     description: Unable to delete the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to delete the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// DeleteDefaultPipeline represents the API handler to remove
+// the default pipeline for an org from the configured backend.
+func DeleteDefaultPipeline(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"user": u.GetName(),
+	}).Infof("deleting default pipeline for org %s", o)
+
+	// send API call to capture the default pipeline
+	p, err := database.FromContext(c).GetDefaultPipeline(o)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get default pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	// send API call to remove the default pipeline
+	err = database.FromContext(c).DeleteDefaultPipeline(p.ID)
+	if err != nil {
+		retErr := fmt.Errorf("unable to delete default pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fmt.Sprintf("default pipeline for org %s deleted", o))
+}
+
+// swagger:operation GET /api/v1/repos/{org}/{repo}/default-pipeline/opt-out repos GetDefaultPipelineOptOut
+//
+// Get the default pipeline opt out for a repo from the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved the default pipeline opt out for the repo
+//     schema:
+//       "$ref": "#/definitions/DefaultPipelineOptOut"
+//   '404':
+//     description: Unable to retrieve the default pipeline opt out for the repo
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetDefaultPipelineOptOut represents the API handler to capture
+// the default pipeline opt out for a repo from the configured backend.
+func GetDefaultPipelineOptOut(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Infof("reading default pipeline opt out for repo %s", r.GetFullName())
+
+	// send API call to capture the default pipeline opt out for the repo
+	optOut, err := database.FromContext(c).GetDefaultPipelineOptOut(r)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get default pipeline opt out for repo %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, optOut)
+}
+
+// swagger:operation POST /api/v1/repos/{org}/{repo}/default-pipeline/opt-out repos CreateDefaultPipelineOptOut
+//
+// Opt a repo out of the default pipeline for the org in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '201':
+//     description: Successfully opted the repo out of the default pipeline
+//     schema:
+//       "$ref": "#/definitions/DefaultPipelineOptOut"
+//   '409':
+//     description: Unable to opt the repo out of the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to opt the repo out of the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// CreateDefaultPipelineOptOut represents the API handler to opt a repo
+// out of the default pipeline for the org in the configured backend.
+func CreateDefaultPipelineOptOut(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Infof("creating default pipeline opt out for repo %s", r.GetFullName())
+
+	// send API call to capture the default pipeline opt out for the repo
+	_, err := database.FromContext(c).GetDefaultPipelineOptOut(r)
+	if err == nil {
+		retErr := fmt.Errorf("repo %s already opted out of the default pipeline", r.GetFullName())
+
+		util.HandleError(c, http.StatusConflict, retErr)
+
+		return
+	}
+
+	// send API call to create the default pipeline opt out
+	err = database.FromContext(c).CreateDefaultPipelineOptOut(&model.DefaultPipelineOptOut{RepoID: r.GetID()})
+	if err != nil {
+		retErr := fmt.Errorf("unable to create default pipeline opt out for repo %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	// send API call to capture the created default pipeline opt out
+	optOut, _ := database.FromContext(c).GetDefaultPipelineOptOut(r)
+
+	c.JSON(http.StatusCreated, optOut)
+}
+
+// swagger:operation DELETE /api/v1/repos/{org}/{repo}/default-pipeline/opt-out repos DeleteDefaultPipelineOptOut
+//
+// Opt a repo back in to the default pipeline for the org in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully opted the repo in to the default pipeline
+//     schema:
+//       type: string
+//   '404':
+//     description: Unable to opt the repo in to the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to opt the repo in to the default pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// DeleteDefaultPipelineOptOut represents the API handler to opt a repo
+// back in to the default pipeline for the org in the configured backend.
+func DeleteDefaultPipelineOptOut(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Infof("deleting default pipeline opt out for repo %s", r.GetFullName())
+
+	// send API call to capture the default pipeline opt out for the repo
+	optOut, err := database.FromContext(c).GetDefaultPipelineOptOut(r)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get default pipeline opt out for repo %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	// send API call to remove the default pipeline opt out
+	err = database.FromContext(c).DeleteDefaultPipelineOptOut(optOut.ID)
+	if err != nil {
+		retErr := fmt.Errorf("unable to delete default pipeline opt out for repo %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fmt.Sprintf("default pipeline opt out for repo %s deleted", r.GetFullName()))
+}
+
+// defaultConfig is a helper function to capture the default
+// pipeline for the org of the repo when the repo has no
+// pipeline configuration file. The provided error is
+// returned when the default pipeline can not be used.
+func defaultConfig(database database.Service, r *library.Repo, err error) ([]byte, error) {
+	// only use the default pipeline when the repo has no pipeline configuration file
+	if !errors.Is(err, model.ErrNoPipelineConfig) {
+		return nil, err
+	}
+
+	// the default pipeline is yaml which can not be parsed as starlark
+	if strings.EqualFold(r.GetPipelineType(), constants.PipelineTypeStarlark) {
+		return nil, err
+	}
+
+	// send API call to capture the default pipeline for the org
+	p, dErr := database.GetDefaultPipeline(r.GetOrg())
+	if dErr != nil || !p.Active {
+		return nil, err
+	}
+
+	// send API call to capture the default pipeline opt out for the repo
+	_, dErr = database.GetDefaultPipelineOptOut(r)
+
+	switch {
+	case dErr == nil:
+		// the repo opted out of the default pipeline
+		return nil, err
+	case !errors.Is(dErr, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("unable to get default pipeline opt out for repo %s: %w", r.GetFullName(), dErr)
+	}
+
+	logrus.Debugf("using default pipeline for org %s for repo %s", r.GetOrg(), r.GetFullName())
+
+	return p.Config(), nil
+}
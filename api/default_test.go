@@ -0,0 +1,145 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/database/sqlite"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+)
+
+func Test_defaultConfig(t *testing.T) {
+	// setup types
+	r := new(library.Repo)
+	r.SetID(1)
+	r.SetOrg("github")
+	r.SetName("octocat")
+	r.SetFullName("github/octocat")
+	r.SetPipelineType(constants.PipelineTypeYAML)
+
+	star := new(library.Repo)
+	star.SetID(2)
+	star.SetOrg("github")
+	star.SetName("starlark")
+	star.SetFullName("github/starlark")
+	star.SetPipelineType(constants.PipelineTypeStarlark)
+
+	optOut := new(library.Repo)
+	optOut.SetID(3)
+	optOut.SetOrg("github")
+	optOut.SetName("opt-out")
+	optOut.SetFullName("github/opt-out")
+
+	other := new(library.Repo)
+	other.SetID(4)
+	other.SetOrg("other")
+	other.SetName("octocat")
+	other.SetFullName("other/octocat")
+
+	pipeline := "version: \"1\"\nsteps:\n  - name: test\n    image: alpine:latest\n"
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from default_pipelines;")
+		db.Sqlite.Exec("delete from default_pipeline_opt_outs;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreateDefaultPipeline(&model.DefaultPipeline{Org: "github", Pipeline: pipeline, Active: true})
+	_ = db.CreateDefaultPipelineOptOut(&model.DefaultPipelineOptOut{RepoID: optOut.GetID()})
+
+	// setup tests
+	tests := []struct {
+		name    string
+		repo    *library.Repo
+		err     error
+		failure bool
+		want    string
+	}{
+		{"no pipeline configuration file", r, model.ErrNoPipelineConfig, false, pipeline},
+		{"unable to capture pipeline configuration file", r, errors.New("bad request"), true, ""},
+		{"starlark pipeline", star, model.ErrNoPipelineConfig, true, ""},
+		{"opted out of the default pipeline", optOut, model.ErrNoPipelineConfig, true, ""},
+		{"no default pipeline for the org", other, model.ErrNoPipelineConfig, true, ""},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := defaultConfig(db, test.repo, test.err)
+
+			if test.failure {
+				if !errors.Is(err, test.err) {
+					t.Errorf("defaultConfig returned err %v, want %v", err, test.err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("defaultConfig returned err: %v", err)
+			}
+
+			if string(got) != test.want {
+				t.Errorf("defaultConfig is %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_defaultConfig_OptOutError(t *testing.T) {
+	// setup types
+	r := new(library.Repo)
+	r.SetID(1)
+	r.SetOrg("github")
+	r.SetName("octocat")
+	r.SetFullName("github/octocat")
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from default_pipelines;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreateDefaultPipeline(&model.DefaultPipeline{Org: "github", Pipeline: "version: \"1\"", Active: true})
+
+	// run test
+	got, err := defaultConfig(&optOutErrorDatabase{db}, r, model.ErrNoPipelineConfig)
+	if err == nil || errors.Is(err, model.ErrNoPipelineConfig) {
+		t.Errorf("defaultConfig returned err %v, want opt out error", err)
+	}
+
+	if got != nil {
+		t.Errorf("defaultConfig is %s, want nil", got)
+	}
+}
+
+// optOutErrorDatabase is a test helper type for a database
+// failing to capture the default pipeline opt out of a repo.
+type optOutErrorDatabase struct {
+	database.Service
+}
+
+// GetDefaultPipelineOptOut returns an error other than not found.
+func (d *optOutErrorDatabase) GetDefaultPipelineOptOut(*library.Repo) (*model.DefaultPipelineOptOut, error) {
+	return nil, errors.New("database is unavailable")
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/router/middleware/build"
+	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/repo"
+	"github.com/go-vela/server/router/middleware/user"
+	"github.com/go-vela/server/util"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+)
+
+// swagger:operation GET /api/v1/pipelines/{org}/{repo}/graph pipelines GetPipelineGraph
+//
+// Get the execution graph of a pipeline configuration from the source provider
+//
+// ---
+// produces:
+// - application/x-yaml
+// - application/json
+// - text/vnd.graphviz
+// - text/plain
+// parameters:
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: query
+//   name: ref
+//   description: Ref for retrieving pipeline configuration file
+//   type: string
+// - in: query
+//   name: pipeline
+//   description: Name of the repo pipeline for retrieving pipeline configuration file
+//   type: string
+// - in: query
+//   name: output
+//   description: Output string for specifying output format (yaml, json, dot or mermaid)
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved the execution graph of the pipeline
+//     schema:
+//       "$ref": "#/definitions/Graph"
+//   '400':
+//     description: Unable to expand the pipeline configuration
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetPipelineGraph represents the API handler to capture the
+// execution graph of the stages and steps in a pipeline
+// configuration for a repo from the source provider.
+func GetPipelineGraph(ctx *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(ctx)
+	r := repo.Retrieve(ctx)
+	u := user.Retrieve(ctx)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Infof("reading pipeline graph for repo %s", r.GetFullName())
+
+	pipeline, comp, err := getUnprocessedPipeline(ctx)
+	if err != nil {
+		util.HandleError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := expandPipeline(ctx, pipeline, comp, false); err != nil {
+		util.HandleError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	writeGraph(ctx, model.NewGraph(pipeline))
+}
+
+// swagger:operation GET /api/v1/repos/{org}/{repo}/builds/{build}/graph builds GetBuildGraph
+//
+// Get the execution graph of the pipeline for a build annotated with the step status
+//
+// ---
+// produces:
+// - application/x-yaml
+// - application/json
+// - text/vnd.graphviz
+// - text/plain
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// - in: path
+//   name: build
+//   description: Build number
+//   required: true
+//   type: integer
+// - in: query
+//   name: output
+//   description: Output string for specifying output format (yaml, json, dot or mermaid)
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved the execution graph of the build
+//     schema:
+//       "$ref": "#/definitions/Graph"
+//   '400':
+//     description: Unable to compile the pipeline configuration for the build
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to retrieve the templates or steps for the build
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetBuildGraph represents the API handler to capture the
+// execution graph of the pipeline for a build, with the
+// status and duration of each stage and step in the build.
+func GetBuildGraph(ctx *gin.Context) {
+	// capture middleware values
+	b := build.Retrieve(ctx)
+	o := org.Retrieve(ctx)
+	r := repo.Retrieve(ctx)
+	u := user.Retrieve(ctx)
+
+	entry := fmt.Sprintf("%s/%d", r.GetFullName(), b.GetNumber())
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+		"org":   o,
+		"repo":  r.GetName(),
+		"user":  u.GetName(),
+	}).Infof("reading graph for build %s", entry)
+
+	// capture the repo pipeline the build was created for
+	name := ""
+	if p := buildPipeline(database.FromContext(ctx), b); p != nil {
+		name = p.Name
+	}
+
+	config, comp, err := getRefConfig(ctx, b.GetCommit(), name)
+	if err != nil {
+		util.HandleError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// send API call to capture the templates recorded for the build
+	templates, err := database.FromContext(ctx).GetBuildTemplateList(b)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get templates for build %s: %w", entry, err)
+
+		util.HandleError(ctx, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	// send API call to capture the pull request the build was created for
+	pull := buildPullRequest(database.FromContext(ctx), b)
+
+	// capture the action of the event the build was created for
+	action := ""
+	if pull != nil {
+		action = pull.Action
+	}
+
+	// compile the pipeline like it was compiled for the build so
+	// the graph contains the injected and required steps without
+	// the steps purged for the build
+	pipeline, err := comp.
+		WithBuild(b).
+		WithAction(action).
+		WithPullRequest(pull).
+		WithTemplates(templates).
+		Compile(config)
+	if err != nil {
+		retErr := fmt.Errorf("unable to compile pipeline configuration for build %s: %w", entry, err)
+
+		util.HandleError(ctx, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to capture the steps for the build
+	steps, err := buildSteps(database.FromContext(ctx), b)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get steps for build %s: %w", entry, err)
+
+		util.HandleError(ctx, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	graph := model.NewBuildGraph(pipeline)
+	graph.Annotate(steps)
+
+	writeGraph(ctx, graph)
+}
+
+// buildSteps is a helper function to capture
+// all pages of the steps for a build.
+func buildSteps(database database.Service, b *library.Build) ([]*library.Step, error) {
+	steps := []*library.Step{}
+	page := 1
+	perPage := 100
+
+	for page > 0 {
+		// send API call to capture the page of steps for the build
+		stepsPart, err := database.GetBuildStepList(b, page, perPage)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, stepsPart...)
+
+		// assume no more pages exist if under 100 results are returned
+		if len(stepsPart) < perPage {
+			page = 0
+		} else {
+			page++
+		}
+	}
+
+	return steps, nil
+}
+
+// writeGraph writes the graph to the request based on the
+// preferred output as defined in the request's 'output' query,
+// supporting the DOT and Mermaid formats along with the formats
+// supported for a pipeline.
+func writeGraph(ctx *gin.Context, graph *model.Graph) {
+	switch strings.ToLower(ctx.Query("output")) {
+	case outputDOT:
+		ctx.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(graph.DOT()))
+	case outputMermaid:
+		ctx.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(graph.Mermaid()))
+	default:
+		writeOutput(ctx, graph)
+	}
+}
@@ -14,20 +14,25 @@ import (
 	"github.com/go-vela/server/compiler"
 	"github.com/go-vela/server/compiler/registry/github"
 	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
 	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/perm"
 	"github.com/go-vela/server/router/middleware/repo"
 	"github.com/go-vela/server/router/middleware/user"
 	"github.com/go-vela/server/scm"
 	"github.com/go-vela/server/util"
 	"github.com/go-vela/types"
 	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
 	"github.com/go-vela/types/yaml"
 	"github.com/sirupsen/logrus"
 )
 
 const (
-	outputJSON = "json"
-	outputYAML = "yaml"
+	outputDOT     = "dot"
+	outputJSON    = "json"
+	outputMermaid = "mermaid"
+	outputYAML    = "yaml"
 )
 
 // swagger:operation GET /api/v1/pipelines/{org}/{repo} pipelines GetPipeline
@@ -54,6 +59,10 @@ const (
 //   description: Ref for retrieving pipeline configuration file
 //   type: string
 // - in: query
+//   name: pipeline
+//   description: Name of the repo pipeline for retrieving pipeline configuration file
+//   type: string
+// - in: query
 //   name: output
 //   description: Output string for specifying output format
 //   type: string
@@ -123,6 +132,10 @@ func GetPipeline(ctx *gin.Context) {
 //   description: Ref for retrieving pipeline configuration file
 //   type: string
 // - in: query
+//   name: pipeline
+//   description: Name of the repo pipeline for retrieving pipeline configuration file
+//   type: string
+// - in: query
 //   name: output
 //   description: Output string for specifying output format
 //   type: string
@@ -200,6 +213,10 @@ func GetTemplates(ctx *gin.Context) {
 //   description: Ref for retrieving pipeline configuration file
 //   type: string
 // - in: query
+//   name: pipeline
+//   description: Name of the repo pipeline for retrieving pipeline configuration file
+//   type: string
+// - in: query
 //   name: output
 //   description: Output string for specifying output format
 //   type: string
@@ -274,9 +291,22 @@ func ExpandPipeline(ctx *gin.Context) {
 //   description: Ref for retrieving pipeline configuration file
 //   type: string
 // - in: query
+//   name: pipeline
+//   description: Name of the repo pipeline for retrieving pipeline configuration file
+//   type: string
+// - in: query
 //   name: output
 //   description: Output string for specifying output format
 //   type: string
+// - in: query
+//   name: policies
+//   description: Include the results of the evaluated policies in the response
+//   default: false
+//   type: boolean
+// - in: query
+//   name: event
+//   description: Build event for verifying the events and images allowed for the secrets, the secrets are only verified for users with write access to the repo
+//   type: string
 // security:
 //   - ApiKeyAuth: []
 // responses:
@@ -331,9 +361,69 @@ func ValidatePipeline(ctx *gin.Context) {
 		return
 	}
 
+	// verify the secrets referenced in the yaml configuration
+	//
+	// the secrets are only captured from the secret provider for
+	// users with write access to the repo, since the results show
+	// which secrets exist for the repo and what they're allowed for
+	if perm.AllowWrite(ctx) {
+		if err = validateSecrets(ctx, r, ctx.Query("event"), yamlSecrets(pipeline)); err != nil {
+			retErr := fmt.Errorf("unable to validate secrets for %s: %w", repoName(ctx), err)
+			util.HandleError(ctx, http.StatusBadRequest, retErr)
+			return
+		}
+	}
+
+	// evaluate the policies against the yaml configuration
+	if err = policyPipeline(comp, pipeline); err != nil {
+		retErr := fmt.Errorf("unable to validate pipeline configuration for %s: %w", repoName(ctx), err)
+		util.HandleError(ctx, http.StatusBadRequest, retErr)
+		return
+	}
+
+	// check optional policies query parameter
+	if ok, _ := strconv.ParseBool(ctx.DefaultQuery("policies", "false")); ok {
+		writeOutput(ctx, &validateOutput{Pipeline: pipeline, Policies: comp.PolicyResults()})
+		return
+	}
+
 	writeOutput(ctx, pipeline)
 }
 
+// policyPipeline is a helper function to evaluate the policies
+// against the steps, services and secret origins of a pipeline.
+// No rule data is provided since no build is being created.
+func policyPipeline(comp compiler.Engine, p *yaml.Build) error {
+	var err error
+
+	p.Services, err = comp.PolicyServices(p.Services)
+	if err != nil {
+		return err
+	}
+
+	p.Secrets, err = comp.PolicySecrets(p.Secrets)
+	if err != nil {
+		return err
+	}
+
+	if len(p.Stages) > 0 {
+		p.Stages, err = comp.PolicyStages(nil, p.Stages)
+
+		return err
+	}
+
+	p.Steps, err = comp.PolicySteps(nil, p.Steps)
+
+	return err
+}
+
+// validateOutput is the response for validating a pipeline
+// configuration with the results of the evaluated policies.
+type validateOutput struct {
+	Pipeline *yaml.Build           `json:"pipeline" yaml:"pipeline"`
+	Policies []*model.PolicyResult `json:"policies" yaml:"policies"`
+}
+
 // swagger:operation POST /api/v1/pipelines/{org}/{repo}/compile pipelines CompilePipeline
 //
 // Get, expand and compile a pipeline configuration from the source provider
@@ -358,6 +448,10 @@ func ValidatePipeline(ctx *gin.Context) {
 //   description: Ref for retrieving pipeline configuration file
 //   type: string
 // - in: query
+//   name: pipeline
+//   description: Name of the repo pipeline for retrieving pipeline configuration file
+//   type: string
+// - in: query
 //   name: output
 //   description: Output string for specifying output format
 //   type: string
@@ -416,40 +510,439 @@ func CompilePipeline(ctx *gin.Context) {
 	writeOutput(ctx, pipeline)
 }
 
+// swagger:operation POST /api/v1/pipelines/{org}/{repo}/preview pipelines PreviewPipeline
+//
+// Get, expand and evaluate the rulesets of a pipeline configuration from the source provider
+//
+// ---
+// produces:
+// - application/x-yaml
+// - application/json
+// parameters:
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: query
+//   name: ref
+//   description: Ref for retrieving pipeline configuration file
+//   type: string
+// - in: query
+//   name: pipeline
+//   description: Name of the repo pipeline for retrieving pipeline configuration file
+//   type: string
+// - in: query
+//   name: output
+//   description: Output string for specifying output format
+//   type: string
+// - in: body
+//   name: body
+//   description: Rule data and pull request to evaluate the rulesets of the pipeline against
+//   required: true
+//   schema:
+//     "$ref": "#/definitions/PreviewData"
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully evaluated the rulesets of the pipeline
+//     schema:
+//       "$ref": "#/definitions/RulesetPreview"
+//   '400':
+//     description: Unable to evaluate the rulesets of the pipeline configuration
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// PreviewPipeline represents the API handler to capture,
+// expand and evaluate the rulesets of a pipeline configuration
+// to preview the stages and steps that would run for the
+// provided rule data.
+func PreviewPipeline(ctx *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(ctx)
+	r := repo.Retrieve(ctx)
+	u := user.Retrieve(ctx)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Infof("previewing pipeline for repo %s", r.GetFullName())
+
+	// capture body from API request
+	input := new(model.PreviewData)
+
+	err := ctx.Bind(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to decode JSON for rule data for %s: %w", repoName(ctx), err)
+		util.HandleError(ctx, http.StatusBadRequest, retErr)
+		return
+	}
+
+	// default the repo to the repo of the pipeline
+	if len(input.Repo) == 0 {
+		input.Repo = r.GetFullName()
+	}
+
+	// capture query parameters
+	ref := ctx.DefaultQuery("ref", r.GetBranch())
+
+	config, comp, err := getRefConfig(ctx, ref, ctx.Query("pipeline"))
+	if err != nil {
+		util.HandleError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// evaluate the pull request rules the same way they are for a build
+	comp = comp.WithAction(input.Action).WithPullRequest(input.PullRequest())
+
+	// the pipeline is parsed twice since expanding and purging the
+	// pipeline modifies it, one to capture every stage and step
+	// of the pipeline and one to purge with the rule data
+	p, err := parseRefPipeline(ctx, comp, ref, config)
+	if err != nil {
+		util.HandleError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := expandPipeline(ctx, p, comp, true); err != nil {
+		util.HandleError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// validate the yaml configuration
+	if err = comp.Validate(p); err != nil {
+		retErr := fmt.Errorf("unable to validate pipeline configuration for %s: %w", repoName(ctx), err)
+		util.HandleError(ctx, http.StatusBadRequest, retErr)
+		return
+	}
+
+	// capture every stage and step of the pipeline before it's purged
+	full := &pipeline.Build{
+		Stages: *p.Stages.ToPipeline(),
+		Steps:  *p.Steps.ToPipeline(),
+	}
+
+	p, err = parseRefPipeline(ctx, comp, ref, config)
+	if err != nil {
+		util.HandleError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	stages := len(p.Stages) > 0
+
+	// purge the stages and steps not matching the pull request
+	// before expanding the pipeline the same way it's compiled
+	if stages {
+		p.Stages, err = comp.PurgeStages(p.Stages)
+	} else {
+		p.Steps, err = comp.PurgeSteps(p.Steps)
+	}
+
+	if err != nil {
+		retErr := fmt.Errorf("unable to purge pipeline configuration for %s: %w", repoName(ctx), err)
+		util.HandleError(ctx, http.StatusBadRequest, retErr)
+		return
+	}
+
+	if err := expandPipeline(ctx, p, comp, true); err != nil {
+		util.HandleError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// qualify the event with the action the same way it's compiled
+	data := input.RuleData
+	data.Event = model.EventAction(data.Event, input.Action)
+
+	// transform the pipeline with the rule data to purge the
+	// steps the same way the pipeline for a build is purged
+	transform := comp.TransformSteps
+	if stages {
+		transform = comp.TransformStages
+	}
+
+	purged, err := transform(&data, p)
+	if err != nil {
+		retErr := fmt.Errorf("unable to transform pipeline configuration for %s: %w", repoName(ctx), err)
+		util.HandleError(ctx, http.StatusBadRequest, retErr)
+		return
+	}
+
+	writeOutput(ctx, model.NewRulesetPreview(full, purged, &data))
+}
+
+// swagger:operation GET /api/v1/pipelines/{org}/{repo}/diff pipelines DiffPipeline
+//
+// Get the semantic difference between the pipelines for two refs or builds
+//
+// ---
+// produces:
+// - application/x-yaml
+// - application/json
+// parameters:
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: query
+//   name: from
+//   description: Ref for retrieving the pipeline configuration file to compare from
+//   type: string
+// - in: query
+//   name: to
+//   description: Ref for retrieving the pipeline configuration file to compare to
+//   type: string
+// - in: query
+//   name: from_build
+//   description: Build number for the pipeline to compare from
+//   type: integer
+// - in: query
+//   name: to_build
+//   description: Build number for the pipeline to compare to
+//   type: integer
+// - in: query
+//   name: pipeline
+//   description: Name of the repo pipeline for retrieving pipeline configuration file
+//   type: string
+// - in: query
+//   name: output
+//   description: Output string for specifying output format
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully compared the pipelines
+//     schema:
+//       "$ref": "#/definitions/PipelineDiff"
+//   '400':
+//     description: Unable to compare the pipelines
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// DiffPipeline represents the API handler to capture the
+// semantic difference between the expanded pipelines for
+// two refs or two builds of a repo.
+func DiffPipeline(ctx *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(ctx)
+	r := repo.Retrieve(ctx)
+	u := user.Retrieve(ctx)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Infof("comparing pipelines for repo %s", r.GetFullName())
+
+	if len(ctx.Query("from")) == 0 && len(ctx.Query("from_build")) == 0 {
+		retErr := fmt.Errorf("no from or from_build provided for %s", r.GetFullName())
+		util.HandleError(ctx, http.StatusBadRequest, retErr)
+		return
+	}
+
+	// nolint: lll // ignore long line length due to variable names
+	from, fromTemplates, fromName, err := getDiffPipeline(ctx, ctx.Query("from"), ctx.Query("from_build"))
+	if err != nil {
+		util.HandleError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// nolint: lll // ignore long line length due to variable names
+	to, toTemplates, toName, err := getDiffPipeline(ctx, ctx.DefaultQuery("to", r.GetBranch()), ctx.Query("to_build"))
+	if err != nil {
+		util.HandleError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	diff := model.NewPipelineDiff(from, to, fromTemplates, toTemplates)
+	diff.From = fromName
+	diff.To = toName
+
+	writeOutput(ctx, diff)
+}
+
+// getDiffPipeline retrieves and expands the pipeline for a side of a
+// pipeline comparison, along with the templates used to compile it.
+// When a build number is provided, the pipeline is captured at the
+// commit of the build with the templates recorded for the build.
+//
+// nolint: lll // ignore long line length due to return values
+func getDiffPipeline(ctx *gin.Context, ref, number string) (*yaml.Build, []*model.BuildTemplate, string, error) {
+	// capture middleware values
+	r := repo.Retrieve(ctx)
+
+	name := ctx.Query("pipeline")
+
+	var b *library.Build
+
+	if len(number) > 0 {
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("invalid build number %s provided: %w", number, err)
+		}
+
+		// send API call to capture the build
+		b, err = database.FromContext(ctx).GetBuild(n, r)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("unable to get build %s/%d: %w", r.GetFullName(), n, err)
+		}
+
+		// capture the repo pipeline the build was created for
+		name = ""
+		if p := buildPipeline(database.FromContext(ctx), b); p != nil {
+			name = p.Name
+		}
+
+		ref = b.GetCommit()
+	}
+
+	p, comp, err := getRefPipeline(ctx, ref, name)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	if b == nil {
+		if err := expandPipeline(ctx, p, comp, true); err != nil {
+			return nil, nil, "", err
+		}
+
+		return p, comp.Templates(), ref, nil
+	}
+
+	// send API call to capture the templates recorded for the build
+	templates, err := database.FromContext(ctx).GetBuildTemplateList(b)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("unable to get templates for build %s/%d: %w", r.GetFullName(), b.GetNumber(), err)
+	}
+
+	// expand the pipeline with the templates recorded for the build
+	// so a template changed since the build isn't part of the diff
+	if err := expandPipeline(ctx, p, comp.WithTemplates(templates), true); err != nil {
+		return nil, nil, "", err
+	}
+
+	if len(templates) == 0 {
+		templates = comp.Templates()
+	}
+
+	return p, templates, fmt.Sprintf("%s/%d", r.GetFullName(), b.GetNumber()), nil
+}
+
 // getUnprocessedPipeline retrieves the unprocessed pipeline from a given context.
 func getUnprocessedPipeline(ctx *gin.Context) (*yaml.Build, compiler.Engine, error) {
 	// capture middleware values
-	meta := ctx.MustGet("metadata").(*types.Metadata)
 	repo := repo.Retrieve(ctx)
 
 	// capture query parameters
 	ref := ctx.DefaultQuery("ref", repo.GetBranch())
 
+	return getRefPipeline(ctx, ref, ctx.Query("pipeline"))
+}
+
+// getRefPipeline retrieves the unprocessed pipeline
+// of a repo pipeline at the provided reference.
+//
+// nolint: lll // ignore long line length due to return values
+func getRefPipeline(ctx *gin.Context, ref, name string) (*yaml.Build, compiler.Engine, error) {
+	config, comp, err := getRefConfig(ctx, ref, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline, err := parseRefPipeline(ctx, comp, ref, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pipeline, comp, nil
+}
+
+// getRefConfig retrieves the pipeline configuration of a repo
+// pipeline at the provided reference along with the compiler
+// to parse the pipeline configuration.
+//
+// nolint: lll // ignore long line length due to return values
+func getRefConfig(ctx *gin.Context, ref, name string) ([]byte, compiler.Engine, error) {
+	// capture middleware values
+	meta := ctx.MustGet("metadata").(*types.Metadata)
+	repo := repo.Retrieve(ctx)
+
 	// send API call to capture the repo owner
 	user, err := database.FromContext(ctx).GetUser(repo.GetUserID())
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to get owner for %s: %w", repo.GetFullName(), err)
 	}
 
+	// send API call to capture the named pipeline of the repo
+	settings, named, err := repoPipeline(database.FromContext(ctx), repo, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// send API call to capture the pipeline configuration file
-	config, err := scm.FromContext(ctx).ConfigBackoff(user, repo, ref)
+	config, err := pipelineConfig(ctx, user, repo, ref, settings, named)
+	if err != nil {
+		// nolint: lll // ignore long line length due to error message
+		return nil, nil, fmt.Errorf("unable to get pipeline configuration for %s@%s: %w", repo.GetFullName(), ref, err)
+	}
+
+	// send API call to capture the policies for the repo
+	policies, err := database.FromContext(ctx).GetRepoPolicyList(repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get policies for %s: %w", repo.GetFullName(), err)
+	}
+
+	// send API call to capture the required pipelines for the org
+	required, err := database.FromContext(ctx).GetRequiredPipelineList(repo.GetOrg())
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to get pipeline configuration for %s: %w", repoName(ctx), err)
+		return nil, nil, fmt.Errorf("unable to get required pipelines for %s: %w", repo.GetOrg(), err)
 	}
 
 	// create the compiler with extra information embedded into it
 	comp := compiler.FromContext(ctx).
+		Duplicate().
+		WithCommit(ref).
 		WithMetadata(meta).
+		WithPolicies(policies).
 		WithRepo(repo).
+		WithRequiredPipelines(required).
+		WithSCM(scm.FromContext(ctx)).
 		WithUser(user)
 
+	return config, comp, nil
+}
+
+// parseRefPipeline parses the pipeline configuration of
+// a repo pipeline at the provided reference.
+//
+// nolint: lll // ignore long line length due to parameters
+func parseRefPipeline(ctx *gin.Context, comp compiler.Engine, ref string, config []byte) (*yaml.Build, error) {
 	pipeline, err := comp.Parse(config)
 	if err != nil {
 		// nolint: lll // ignore long line length due to error message
-		return nil, nil, fmt.Errorf("unable to parse pipeline configuration for %s: %w", repoName(ctx), err)
+		return nil, fmt.Errorf("unable to parse pipeline configuration for %s@%s: %w", repo.Retrieve(ctx).GetFullName(), ref, err)
 	}
 
-	return pipeline, comp, nil
+	return pipeline, nil
 }
 
 // getTemplateLinks helper function that retrieves source provider links
@@ -468,6 +961,18 @@ func getTemplateLinks(ctx *gin.Context, templates yaml.TemplateSlice) (map[strin
 		// convert to library type
 		tmpl := t.ToLibrary()
 
+		// only github templates are hosted by the source provider
+		if !strings.EqualFold(tmpl.GetType(), "github") {
+			// link directly to templates hosted by a generic url
+			if strings.EqualFold(tmpl.GetType(), "http") {
+				tmpl.SetLink(tmpl.GetSource())
+			}
+
+			m[tmpl.GetName()] = tmpl
+
+			continue
+		}
+
 		// create a new compiler github client for parsing,
 		// no address or token needed for Parse
 		cl, err := github.New("", "")
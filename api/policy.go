@@ -0,0 +1,110 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/router/middleware/build"
+	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/repo"
+	"github.com/go-vela/server/router/middleware/user"
+	"github.com/go-vela/server/util"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+)
+
+// swagger:operation GET /api/v1/repos/{org}/{repo}/builds/{build}/policies builds GetBuildPolicyResults
+//
+// Get the results of the policies evaluated while compiling the pipeline for a build in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// - in: path
+//   name: build
+//   description: Build number
+//   required: true
+//   type: integer
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved policy results for the build
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/PolicyResult"
+//   '500':
+//     description: Unable to retrieve policy results for the build
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetBuildPolicyResults represents the API handler to capture a
+// list of results for the policies evaluated while compiling the
+// pipeline for a build from the configured backend.
+func GetBuildPolicyResults(c *gin.Context) {
+	// capture middleware values
+	b := build.Retrieve(c)
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	entry := fmt.Sprintf("%s/%d", r.GetFullName(), b.GetNumber())
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+		"org":   o,
+		"repo":  r.GetName(),
+		"user":  u.GetName(),
+	}).Infof("reading policy results for build %s", entry)
+
+	// send API call to capture the list of policy results for the build
+	p, err := database.FromContext(c).GetBuildPolicyResultList(b)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get policy results for build %s: %w", entry, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// planPolicyResults is a helper function to record the
+// results of the policies evaluated while compiling the
+// pipeline for the build in the configured backend.
+func planPolicyResults(database database.Service, results []*model.PolicyResult, b *library.Build) error {
+	for _, r := range results {
+		r.BuildID = b.GetID()
+
+		// send API call to create the policy result
+		err := database.CreateBuildPolicyResult(r)
+		if err != nil {
+			return fmt.Errorf("unable to create policy result %s: %w", r.Policy, err)
+		}
+	}
+
+	return nil
+}
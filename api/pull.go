@@ -0,0 +1,49 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+)
+
+// planBuildPullRequest is a helper function to record the
+// pull request matched by the rulesets of the pipeline
+// for the build in the configured backend.
+func planBuildPullRequest(database database.Service, p *model.BuildPullRequest, b *library.Build) error {
+	if p == nil {
+		return nil
+	}
+
+	// send API call to create the build pull request
+	err := database.CreateBuildPullRequest(&model.BuildPullRequest{
+		BuildID:           b.GetID(),
+		Labels:            p.Labels,
+		Draft:             p.Draft,
+		AuthorAssociation: p.AuthorAssociation,
+		Fork:              p.Fork,
+		Action:            p.Action,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create pull request for build: %w", err)
+	}
+
+	return nil
+}
+
+// buildPullRequest is a helper function to capture
+// the pull request a build was created for.
+func buildPullRequest(database database.Service, b *library.Build) *model.BuildPullRequest {
+	// send API call to capture the build pull request
+	p, err := database.GetBuildPullRequest(b)
+	if err != nil {
+		return nil
+	}
+
+	return p
+}
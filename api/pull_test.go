@@ -0,0 +1,70 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/database/sqlite"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+)
+
+func Test_planBuildPullRequest(t *testing.T) {
+	// setup types
+	b := new(library.Build)
+	b.SetID(1)
+
+	none := new(library.Build)
+	none.SetID(2)
+
+	want := &model.BuildPullRequest{
+		ID:                1,
+		BuildID:           b.GetID(),
+		Labels:            model.Labels{"bug"},
+		Draft:             true,
+		AuthorAssociation: "MEMBER",
+		Action:            "labeled",
+	}
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from build_pull_requests;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	// run tests
+	err = planBuildPullRequest(db, &model.BuildPullRequest{
+		Labels:            model.Labels{"bug"},
+		Draft:             true,
+		AuthorAssociation: "MEMBER",
+		Action:            "labeled",
+	}, b)
+	if err != nil {
+		t.Errorf("planBuildPullRequest returned err: %v", err)
+	}
+
+	err = planBuildPullRequest(db, nil, none)
+	if err != nil {
+		t.Errorf("planBuildPullRequest returned err: %v", err)
+	}
+
+	got := buildPullRequest(db, b)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildPullRequest is %v, want %v", got, want)
+	}
+
+	if got := buildPullRequest(db, none); got != nil {
+		t.Errorf("buildPullRequest is %v, want nil", got)
+	}
+}
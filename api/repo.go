@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-vela/server/router/middleware/org"
 
+	"github.com/go-vela/server/cache"
 	"github.com/go-vela/server/database"
 	"github.com/go-vela/server/router/middleware/repo"
 	"github.com/go-vela/server/router/middleware/user"
@@ -265,6 +266,8 @@ func CreateRepo(c *gin.Context) {
 			return
 		}
 
+		invalidateRepoAccess(c, dbRepo.GetOrg(), dbRepo.GetName())
+
 		// send API call to capture the updated repo
 		r, _ = database.FromContext(c).GetRepo(dbRepo.GetOrg(), dbRepo.GetName())
 	} else {
@@ -804,6 +807,8 @@ func UpdateRepo(c *gin.Context) {
 		return
 	}
 
+	invalidateRepoAccess(c, r.GetOrg(), r.GetName())
+
 	// send API call to capture the updated repo
 	r, _ = database.FromContext(c).GetRepo(r.GetOrg(), r.GetName())
 
@@ -889,6 +894,8 @@ func DeleteRepo(c *gin.Context) {
 		return
 	}
 
+	invalidateRepoAccess(c, r.GetOrg(), r.GetName())
+
 	// Comment out actual delete until delete mechanism is fleshed out
 	// err = database.FromContext(c).DeleteRepo(r.ID)
 	// if err != nil {
@@ -980,6 +987,8 @@ func RepairRepo(c *gin.Context) {
 
 			return
 		}
+
+		invalidateRepoAccess(c, r.GetOrg(), r.GetName())
 	}
 
 	c.JSON(http.StatusOK, fmt.Sprintf("repo %s repaired", r.GetFullName()))
@@ -1045,6 +1054,8 @@ func ChownRepo(c *gin.Context) {
 		return
 	}
 
+	invalidateRepoAccess(c, r.GetOrg(), r.GetName())
+
 	c.JSON(http.StatusOK, fmt.Sprintf("repo %s changed owner", r.GetFullName()))
 }
 
@@ -1073,3 +1084,18 @@ func checkAllowlist(r *library.Repo, allowlist []string) bool {
 
 	return false
 }
+
+// invalidateRepoAccess is a helper function to remove the cached
+// permissions of all users for the repo after the repo changed.
+func invalidateRepoAccess(c *gin.Context, org, name string) {
+	// the cache is optional for the permissions
+	s := cache.FromGinContext(c)
+	if s == nil {
+		return
+	}
+
+	err := s.DeletePrefix(c, cache.RepoAccessPrefix(org, name))
+	if err != nil {
+		logrus.Errorf("unable to invalidate cached permissions for repo %s/%s: %v", org, name, err)
+	}
+}
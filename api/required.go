@@ -0,0 +1,534 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/compiler"
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/router/middleware/build"
+	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/repo"
+	"github.com/go-vela/server/router/middleware/user"
+	"github.com/go-vela/server/util"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+)
+
+// swagger:operation GET /api/v1/required-pipelines/{org} required-pipelines GetRequiredPipelines
+//
+// Get the required pipelines for an org from the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved the required pipelines for the org
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/RequiredPipeline"
+//   '500':
+//     description: Unable to retrieve the required pipelines for the org
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetRequiredPipelines represents the API handler to capture
+// a list of required pipelines for an org from the configured backend.
+func GetRequiredPipelines(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"user": u.GetName(),
+	}).Infof("reading required pipelines for org %s", o)
+
+	// send API call to capture the list of required pipelines for the org
+	p, err := database.FromContext(c).GetRequiredPipelineList(o)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get required pipelines for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// swagger:operation POST /api/v1/required-pipelines/{org} required-pipelines CreateRequiredPipeline
+//
+// Create a required pipeline for an org in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: body
+//   name: body
+//   description: Payload containing the required pipeline to create
+//   required: true
+//   schema:
+//     "$ref": "#/definitions/RequiredPipeline"
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '201':
+//     description: Successfully created the required pipeline
+//     schema:
+//       "$ref": "#/definitions/RequiredPipeline"
+//   '400':
+//     description: Unable to create the required pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '409':
+//     description: Unable to create the required pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to create the required pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// CreateRequiredPipeline represents the API handler to create
+// a required pipeline for an org in the configured backend.
+func CreateRequiredPipeline(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logger := logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"user": u.GetName(),
+	})
+
+	// capture body from API request
+	input := new(model.RequiredPipeline)
+
+	err := c.Bind(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to decode JSON for new required pipeline for org %s: %w", o, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	input.Org = o
+
+	logger.Infof("creating required pipeline %s for org %s", input.Name, o)
+
+	// validate the required pipeline before storing it
+	err = validateRequiredPipeline(c, input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to validate required pipeline %s for org %s: %w", input.Name, o, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to capture the required pipeline
+	_, err = database.FromContext(c).GetRequiredPipeline(o, input.Name)
+	if err == nil {
+		retErr := fmt.Errorf("required pipeline %s already exists for org %s", input.Name, o)
+
+		util.HandleError(c, http.StatusConflict, retErr)
+
+		return
+	}
+
+	// send API call to create the required pipeline
+	err = database.FromContext(c).CreateRequiredPipeline(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to create required pipeline %s for org %s: %w", input.Name, o, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	// send API call to capture the created required pipeline
+	p, _ := database.FromContext(c).GetRequiredPipeline(o, input.Name)
+
+	c.JSON(http.StatusCreated, p)
+}
+
+// swagger:operation GET /api/v1/required-pipelines/{org}/{pipeline} required-pipelines GetRequiredPipeline
+//
+// Get a required pipeline for an org from the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: pipeline
+//   description: Name of the required pipeline
+//   required: true
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved the required pipeline
+//     schema:
+//       "$ref": "#/definitions/RequiredPipeline"
+//   '404':
+//     description: Unable to retrieve the required pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetRequiredPipeline represents the API handler to capture
+// a required pipeline for an org from the configured backend.
+func GetRequiredPipeline(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	u := user.Retrieve(c)
+	n := c.Param("pipeline")
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"user": u.GetName(),
+	}).Infof("reading required pipeline %s for org %s", n, o)
+
+	// send API call to capture the required pipeline
+	p, err := database.FromContext(c).GetRequiredPipeline(o, n)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get required pipeline %s for org %s: %w", n, o, err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// swagger:operation PUT /api/v1/required-pipelines/{org}/{pipeline} required-pipelines UpdateRequiredPipeline
+//
+// Update a required pipeline for an org in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: pipeline
+//   description: Name of the required pipeline
+//   required: true
+//   type: string
+// - in: body
+//   name: body
+//   description: Payload containing the required pipeline to update
+//   required: true
+//   schema:
+//     "$ref": "#/definitions/RequiredPipeline"
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully updated the required pipeline
+//     schema:
+//       "$ref": "#/definitions/RequiredPipeline"
+//   '400':
+//     description: Unable to update the required pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '404':
+//     description: Unable to update the required pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to update the required pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// UpdateRequiredPipeline represents the API handler to update
+// a required pipeline for an org in the configured backend.
+func UpdateRequiredPipeline(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	u := user.Retrieve(c)
+	n := c.Param("pipeline")
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"user": u.GetName(),
+	}).Infof("updating required pipeline %s for org %s", n, o)
+
+	// send API call to capture the required pipeline
+	p, err := database.FromContext(c).GetRequiredPipeline(o, n)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get required pipeline %s for org %s: %w", n, o, err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	// capture body from API request
+	input := new(model.RequiredPipeline)
+
+	err = c.Bind(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to decode JSON for required pipeline %s for org %s: %w", n, o, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	input.ID = p.ID
+	input.Org = o
+	input.Name = n
+
+	// validate the required pipeline before storing it
+	err = validateRequiredPipeline(c, input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to validate required pipeline %s for org %s: %w", n, o, err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to update the required pipeline
+	err = database.FromContext(c).UpdateRequiredPipeline(input)
+	if err != nil {
+		retErr := fmt.Errorf("unable to update required pipeline %s for org %s: %w", n, o, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, input)
+}
+
+// swagger:operation DELETE /api/v1/required-pipelines/{org}/{pipeline} required-pipelines DeleteRequiredPipeline
+//
+// Delete a required pipeline for an org from the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: pipeline
+//   description: Name of the required pipeline
+//   required: true
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully deleted the required pipeline
+//     schema:
+//       type: string
+//   '404':
+//     description: Unable to delete the required pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to delete the required pipeline
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// DeleteRequiredPipeline represents the API handler to remove
+// a required pipeline for an org from the configured backend.
+func DeleteRequiredPipeline(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	u := user.Retrieve(c)
+	n := c.Param("pipeline")
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"user": u.GetName(),
+	}).Infof("deleting required pipeline %s for org %s", n, o)
+
+	// send API call to capture the required pipeline
+	p, err := database.FromContext(c).GetRequiredPipeline(o, n)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get required pipeline %s for org %s: %w", n, o, err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	// send API call to remove the required pipeline
+	err = database.FromContext(c).DeleteRequiredPipeline(p.ID)
+	if err != nil {
+		retErr := fmt.Errorf("unable to delete required pipeline %s for org %s: %w", n, o, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fmt.Sprintf("required pipeline %s for org %s deleted", n, o))
+}
+
+// swagger:operation GET /api/v1/repos/{org}/{repo}/builds/{build}/injected builds GetBuildInjectedSteps
+//
+// Get the steps injected from required pipelines into the pipeline for a build in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// - in: path
+//   name: build
+//   description: Build number
+//   required: true
+//   type: integer
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved injected steps for the build
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/InjectedStep"
+//   '500':
+//     description: Unable to retrieve injected steps for the build
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetBuildInjectedSteps represents the API handler to capture a
+// list of steps injected from required pipelines into the
+// pipeline for a build from the configured backend.
+func GetBuildInjectedSteps(c *gin.Context) {
+	// capture middleware values
+	b := build.Retrieve(c)
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	entry := fmt.Sprintf("%s/%d", r.GetFullName(), b.GetNumber())
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+		"org":   o,
+		"repo":  r.GetName(),
+		"user":  u.GetName(),
+	}).Infof("reading injected steps for build %s", entry)
+
+	// send API call to capture the list of injected steps for the build
+	s, err := database.FromContext(c).GetBuildInjectedStepList(b)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get injected steps for build %s: %w", entry, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// validateRequiredPipeline is a helper function to verify
+// the fields and the pipeline of a required pipeline.
+func validateRequiredPipeline(c *gin.Context, p *model.RequiredPipeline) error {
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// parse the pipeline to verify the yaml configuration
+	config, err := compiler.FromContext(c).Duplicate().Parse(p.Pipeline)
+	if err != nil {
+		return err
+	}
+
+	if len(config.Stages) == 0 && len(config.Steps) == 0 {
+		return fmt.Errorf("no stages or steps provided for required pipeline %s", p.Name)
+	}
+
+	return nil
+}
+
+// planInjectedSteps is a helper function to record the
+// steps injected from required pipelines into the
+// pipeline for the build in the configured backend.
+func planInjectedSteps(database database.Service, steps []*model.InjectedStep, b *library.Build) error {
+	for _, s := range steps {
+		s.BuildID = b.GetID()
+
+		// send API call to create the injected step
+		err := database.CreateBuildInjectedStep(s)
+		if err != nil {
+			return fmt.Errorf("unable to create injected step %s: %w", s.Step, err)
+		}
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-vela/server/model"
 	"github.com/go-vela/server/router/middleware/user"
 	"github.com/go-vela/server/scm"
 	"github.com/go-vela/server/secret"
@@ -18,6 +19,8 @@ import (
 
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+	"github.com/go-vela/types/yaml"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -760,3 +763,165 @@ func unique(stringSlice []string) []string {
 
 	return list
 }
+
+// validateSecrets is a helper function to verify the secrets
+// declared in a pipeline exist in the secret provider and the
+// secrets referenced by the steps are declared in the pipeline.
+// When an event is provided, the events, images and commands
+// allowed for each secret are verified against the steps.
+func validateSecrets(c *gin.Context, r *library.Repo, event string, p *pipeline.Build) error {
+	declared := make(map[string]*library.Secret)
+
+	// secrets captured by a secret plugin can't be verified
+	origin := false
+
+	for _, s := range p.Secrets {
+		if !s.Origin.Empty() {
+			origin = true
+
+			continue
+		}
+
+		// send API call to capture the secret from the secret provider
+		secret, err := pipelineSecret(c, r, s)
+		if err != nil {
+			return err
+		}
+
+		declared[s.Name] = secret
+	}
+
+	steps := pipeline.ContainerSlice{}
+	steps = append(steps, p.Steps...)
+
+	for _, stage := range p.Stages {
+		steps = append(steps, stage.Steps...)
+	}
+
+	for _, step := range steps {
+		for _, ref := range step.Secrets {
+			secret, ok := declared[ref.Source]
+			if !ok {
+				if origin {
+					continue
+				}
+
+				return fmt.Errorf("step %s references undeclared secret %s", step.Name, ref.Source)
+			}
+
+			if len(event) == 0 {
+				continue
+			}
+
+			// check the secret against the step for the event
+			if !matchSecret(secret, step, event) {
+				// nolint: lll // ignore long line length due to error message
+				return fmt.Errorf("secret %s is not allowed for step %s with image %s on %s event", ref.Source, step.Name, step.Image, event)
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchSecret is a helper function to check if the secret is
+// allowed for the step on the event. The release and delete
+// events are checked here since the library only matches the
+// push, pull_request, tag, deployment and comment events.
+func matchSecret(s *library.Secret, step *pipeline.Container, event string) bool {
+	switch event {
+	case model.EventRelease, model.EventDelete:
+	default:
+		ctn := *step
+		ctn.Environment = map[string]string{"BUILD_EVENT": event}
+
+		return s.Match(&ctn)
+	}
+
+	// check if commands are utilized when not allowed
+	if !s.GetAllowCommand() && len(step.Commands) > 0 {
+		return false
+	}
+
+	eACL, iACL := false, false
+
+	// check the events allowed for the secret
+	for _, e := range s.GetEvents() {
+		if e == event {
+			eACL = true
+
+			break
+		}
+	}
+
+	// check the images allowed for the secret
+	for _, i := range s.GetImages() {
+		if len(i) > 0 && strings.HasPrefix(step.Image, i) {
+			iACL = true
+
+			break
+		}
+	}
+
+	switch {
+	case iACL && len(s.GetEvents()) == 0:
+		return true
+	case eACL && len(s.GetImages()) == 0:
+		return true
+	default:
+		return eACL && iACL
+	}
+}
+
+// pipelineSecret is a helper function to capture a
+// secret declared in a pipeline from the secret provider.
+func pipelineSecret(c *gin.Context, r *library.Repo, s *pipeline.Secret) (*library.Secret, error) {
+	service := secret.FromContext(c, s.Engine)
+	if service == nil {
+		return nil, fmt.Errorf("unable to get secret %s: no %s secret engine", s.Name, s.Engine)
+	}
+
+	var (
+		org, name, key string
+		err            error
+	)
+
+	// capture the parts of the secret path from the key
+	switch s.Type {
+	case constants.SecretOrg:
+		name = "*"
+		org, key, err = s.ParseOrg(r.GetOrg())
+	case constants.SecretShared:
+		org, name, key, err = s.ParseShared()
+	default:
+		org, name, key, err = s.ParseRepo(r.GetOrg(), r.GetName())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret %s: %w", s.Name, err)
+	}
+
+	t := s.Type
+	if len(t) == 0 {
+		t = constants.SecretRepo
+	}
+
+	// send API call to capture the secret
+	secret, err := service.Get(t, org, name, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get secret %s from %s service: %w", s.Name, s.Engine, err)
+	}
+
+	return secret, nil
+}
+
+// yamlSecrets is a helper function to convert the
+// secrets and steps of a yaml configuration to
+// validate the secrets referenced in the pipeline.
+func yamlSecrets(p *yaml.Build) *pipeline.Build {
+	return &pipeline.Build{
+		Secrets: *p.Secrets.ToPipeline(),
+		Stages:  *p.Stages.ToPipeline(),
+		Steps:   *p.Steps.ToPipeline(),
+	}
+}
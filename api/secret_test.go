@@ -0,0 +1,228 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/database/sqlite"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/secret"
+	"github.com/go-vela/server/secret/native"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+)
+
+func Test_validateSecrets(t *testing.T) {
+	// setup types
+	r := new(library.Repo)
+	r.SetOrg("github")
+	r.SetName("octocat")
+	r.SetFullName("github/octocat")
+
+	s := new(library.Secret)
+	s.SetOrg("github")
+	s.SetRepo("octocat")
+	s.SetName("docker_password")
+	s.SetValue("foo")
+	s.SetType(constants.SecretRepo)
+	s.SetImages([]string{"target/vela-docker"})
+	s.SetEvents([]string{constants.EventPush, model.EventRelease})
+	s.SetAllowCommand(false)
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from secrets;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	service, _ := native.New(native.WithDatabase(db))
+	_ = service.Create(constants.SecretRepo, "github", "octocat", s)
+
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	secret.ToContext(c, constants.DriverNative, service)
+
+	declared := pipeline.SecretSlice{
+		{Name: "docker_password", Key: "docker_password", Engine: constants.DriverNative, Type: constants.SecretRepo},
+	}
+
+	step := func(image string, commands []string, secrets ...string) *pipeline.Container {
+		ctn := &pipeline.Container{Name: "publish", Image: image, Commands: commands}
+
+		for _, s := range secrets {
+			ctn.Secrets = append(ctn.Secrets, &pipeline.StepSecret{Source: s, Target: s})
+		}
+
+		return ctn
+	}
+
+	// setup tests
+	tests := []struct {
+		name     string
+		failure  bool
+		event    string
+		pipeline *pipeline.Build
+	}{
+		{
+			name:    "declared and allowed secret",
+			failure: false,
+			event:   constants.EventPush,
+			pipeline: &pipeline.Build{
+				Secrets: declared,
+				Steps:   pipeline.ContainerSlice{step("target/vela-docker:latest", nil, "docker_password")},
+			},
+		},
+		{
+			name:    "no event to verify",
+			failure: false,
+			pipeline: &pipeline.Build{
+				Secrets: declared,
+				Steps:   pipeline.ContainerSlice{step("alpine", nil, "docker_password")},
+			},
+		},
+		{
+			name:    "undeclared secret from a secret plugin",
+			failure: false,
+			event:   constants.EventPush,
+			pipeline: &pipeline.Build{
+				Secrets: pipeline.SecretSlice{
+					{Origin: &pipeline.Container{Name: "vault", Image: "target/secret-vault"}},
+				},
+				Steps: pipeline.ContainerSlice{step("alpine", nil, "vault_token")},
+			},
+		},
+		{
+			name:    "undeclared secret in a stage",
+			failure: true,
+			event:   constants.EventPush,
+			pipeline: &pipeline.Build{
+				Stages: pipeline.StageSlice{
+					{Name: "publish", Steps: pipeline.ContainerSlice{step("alpine", nil, "docker_username")}},
+				},
+			},
+		},
+		{
+			name:    "undeclared secret",
+			failure: true,
+			event:   constants.EventPush,
+			pipeline: &pipeline.Build{
+				Secrets: declared,
+				Steps:   pipeline.ContainerSlice{step("target/vela-docker:latest", nil, "docker_username")},
+			},
+		},
+		{
+			name:    "declared secret that doesn't exist",
+			failure: true,
+			pipeline: &pipeline.Build{
+				Secrets: pipeline.SecretSlice{
+					{Name: "npm_token", Key: "npm_token", Engine: constants.DriverNative, Type: constants.SecretRepo},
+				},
+			},
+		},
+		{
+			name:    "declared secret for an engine that isn't configured",
+			failure: true,
+			pipeline: &pipeline.Build{
+				Secrets: pipeline.SecretSlice{
+					{Name: "npm_token", Key: "github/octocat/npm_token", Engine: constants.DriverVault, Type: constants.SecretRepo},
+				},
+			},
+		},
+		{
+			name:    "secret not allowed for event",
+			failure: true,
+			event:   constants.EventPull,
+			pipeline: &pipeline.Build{
+				Secrets: declared,
+				Steps:   pipeline.ContainerSlice{step("target/vela-docker:latest", nil, "docker_password")},
+			},
+		},
+		{
+			name:    "secret allowed for release event",
+			failure: false,
+			event:   model.EventRelease,
+			pipeline: &pipeline.Build{
+				Secrets: declared,
+				Steps:   pipeline.ContainerSlice{step("target/vela-docker:latest", nil, "docker_password")},
+			},
+		},
+		{
+			name:    "secret not allowed for delete event",
+			failure: true,
+			event:   model.EventDelete,
+			pipeline: &pipeline.Build{
+				Secrets: declared,
+				Steps:   pipeline.ContainerSlice{step("target/vela-docker:latest", nil, "docker_password")},
+			},
+		},
+		{
+			name:    "secret not allowed for image on release event",
+			failure: true,
+			event:   model.EventRelease,
+			pipeline: &pipeline.Build{
+				Secrets: declared,
+				Steps:   pipeline.ContainerSlice{step("alpine", nil, "docker_password")},
+			},
+		},
+		{
+			name:    "secret not allowed for commands on release event",
+			failure: true,
+			event:   model.EventRelease,
+			pipeline: &pipeline.Build{
+				Secrets: declared,
+				Steps:   pipeline.ContainerSlice{step("target/vela-docker:latest", []string{"echo $DOCKER_PASSWORD"}, "docker_password")},
+			},
+		},
+		{
+			name:    "secret not allowed for image",
+			failure: true,
+			event:   constants.EventPush,
+			pipeline: &pipeline.Build{
+				Secrets: declared,
+				Steps:   pipeline.ContainerSlice{step("alpine", nil, "docker_password")},
+			},
+		},
+		{
+			name:    "secret not allowed for commands",
+			failure: true,
+			event:   constants.EventPush,
+			pipeline: &pipeline.Build{
+				Secrets: declared,
+				Steps:   pipeline.ContainerSlice{step("target/vela-docker:latest", []string{"echo $DOCKER_PASSWORD"}, "docker_password")},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateSecrets(c, r, test.event, test.pipeline)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("validateSecrets should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("validateSecrets returned err: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,476 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/repo"
+	"github.com/go-vela/server/router/middleware/user"
+	"github.com/go-vela/server/scm"
+	"github.com/go-vela/server/util"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// swagger:operation GET /api/v1/repos/{org}/{repo}/pipeline-settings repos GetPipelineSettings
+//
+// Get the pipeline settings for a repo from the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved the pipeline settings for the repo
+//     schema:
+//       "$ref": "#/definitions/PipelineSettings"
+//   '404':
+//     description: Unable to retrieve the pipeline settings for the repo
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetPipelineSettings represents the API handler to capture
+// the pipeline settings for a repo from the configured backend.
+func GetPipelineSettings(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Infof("reading pipeline settings for repo %s", r.GetFullName())
+
+	// send API call to capture the pipeline settings for the repo
+	s, err := database.FromContext(c).GetPipelineSettings(r)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get pipeline settings for repo %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// swagger:operation PUT /api/v1/repos/{org}/{repo}/pipeline-settings repos UpdatePipelineSettings
+//
+// Create or update the pipeline settings for a repo in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// - in: body
+//   name: body
+//   description: Payload containing the pipeline settings for the repo
+//   required: true
+//   schema:
+//     "$ref": "#/definitions/PipelineSettings"
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully updated the pipeline settings for the repo
+//     schema:
+//       "$ref": "#/definitions/PipelineSettings"
+//   '400':
+//     description: Unable to update the pipeline settings for the repo
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to update the pipeline settings for the repo
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// UpdatePipelineSettings represents the API handler to create or
+// update the pipeline settings for a repo in the configured backend.
+func UpdatePipelineSettings(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Infof("updating pipeline settings for repo %s", r.GetFullName())
+
+	// capture body from API request
+	input := new(model.PipelineSettings)
+
+	err := c.Bind(input)
+	if err != nil {
+		// nolint: lll // ignore long line length due to error message
+		retErr := fmt.Errorf("unable to decode JSON for pipeline settings for repo %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	input.ID = 0
+	input.RepoID = r.GetID()
+
+	// validate the pipeline settings before storing them
+	err = input.Validate()
+	if err != nil {
+		retErr := fmt.Errorf("unable to validate pipeline settings for repo %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		return
+	}
+
+	// send API call to capture the existing pipeline settings for the repo
+	s, err := database.FromContext(c).GetPipelineSettings(r)
+	if err != nil {
+		// send API call to create the pipeline settings
+		err = database.FromContext(c).CreatePipelineSettings(input)
+	} else {
+		input.ID = s.ID
+
+		// send API call to update the pipeline settings
+		err = database.FromContext(c).UpdatePipelineSettings(input)
+	}
+
+	if err != nil {
+		retErr := fmt.Errorf("unable to update pipeline settings for repo %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	// send API call to capture the updated pipeline settings
+	s, _ = database.FromContext(c).GetPipelineSettings(r)
+
+	c.JSON(http.StatusOK, s)
+}
+
+// swagger:operation DELETE /api/v1/repos/{org}/{repo}/pipeline-settings repos DeletePipelineSettings
+//
+// Delete the pipeline settings for a repo in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully deleted the pipeline settings for the repo
+//     schema:
+//       type: string
+//   '404':
+//     description: Unable to delete the pipeline settings for the repo
+//     schema:
+//       "$ref": "#/definitions/Error"
+//   '500':
+//     description: Unable to delete the pipeline settings for the repo
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// DeletePipelineSettings represents the API handler to remove
+// the pipeline settings for a repo from the configured backend.
+func DeletePipelineSettings(c *gin.Context) {
+	// capture middleware values
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Infof("deleting pipeline settings for repo %s", r.GetFullName())
+
+	// send API call to capture the pipeline settings for the repo
+	s, err := database.FromContext(c).GetPipelineSettings(r)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get pipeline settings for repo %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusNotFound, retErr)
+
+		return
+	}
+
+	// send API call to remove the pipeline settings
+	err = database.FromContext(c).DeletePipelineSettings(s.ID)
+	if err != nil {
+		retErr := fmt.Errorf("unable to delete pipeline settings for repo %s: %w", r.GetFullName(), err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fmt.Sprintf("pipeline settings for repo %s deleted", r.GetFullName()))
+}
+
+// repoPipelines is a helper function to capture the pipelines of a
+// repo matching the changed files. A single nil pipeline is returned
+// for a repo without named pipelines to create one build for the event.
+// An error is returned when the pipeline settings of the repo can't be
+// captured so the settings, like requiring approval, aren't bypassed.
+//
+// nolint: lll // ignore long line length due to parameters
+func repoPipelines(database database.Service, r *library.Repo, files []string) (*model.PipelineSettings, model.RepoPipelines, error) {
+	// send API call to capture the pipeline settings for the repo
+	s, err := database.GetPipelineSettings(r)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, model.RepoPipelines{nil}, nil
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get pipeline settings for repo %s: %w", r.GetFullName(), err)
+	}
+
+	if len(s.Pipelines) == 0 {
+		return s, model.RepoPipelines{nil}, nil
+	}
+
+	return s, s.Match(files), nil
+}
+
+// allowEvent is a helper function to check if the pipeline
+// settings of a repo enable an event. Every event with an
+// allow field in the settings is disabled for a repo
+// without pipeline settings.
+func allowEvent(database database.Service, r *library.Repo, event string) bool {
+	// send API call to capture the pipeline settings for the repo
+	s, err := database.GetPipelineSettings(r)
+	if err != nil {
+		s = new(model.PipelineSettings)
+	}
+
+	return s.AllowEvent(event)
+}
+
+// repoPipeline is a helper function to capture a
+// pipeline of a repo by name. No pipeline is returned
+// when no name is provided.
+//
+// nolint: lll // ignore long line length due to parameters
+func repoPipeline(database database.Service, r *library.Repo, name string) (*model.PipelineSettings, *model.RepoPipeline, error) {
+	// send API call to capture the pipeline settings for the repo
+	s, err := database.GetPipelineSettings(r)
+	if err != nil {
+		s = nil
+	}
+
+	if len(name) == 0 {
+		return s, nil, nil
+	}
+
+	if s != nil {
+		for _, p := range s.Pipelines {
+			if p.Name == name {
+				return s, p, nil
+			}
+		}
+	}
+
+	return nil, nil, fmt.Errorf("pipeline %s not found for repo %s", name, r.GetFullName())
+}
+
+// pipelineConfig is a helper function to capture the pipeline
+// configuration file for a repo pipeline. The default pipeline
+// configuration files are used when no path is set for the repo.
+//
+// nolint: lll // ignore long line length due to parameters
+func pipelineConfig(c *gin.Context, u *library.User, r *library.Repo, ref string, s *model.PipelineSettings, p *model.RepoPipeline) ([]byte, error) {
+	// capture the pipeline configuration file for a named pipeline
+	if p != nil {
+		return scm.FromContext(c).ConfigFile(u, r, ref, p.Path)
+	}
+
+	var (
+		config []byte
+		err    error
+	)
+
+	if s != nil && len(s.Path) > 0 {
+		// send API call to capture the pipeline configuration file from the custom path
+		config, err = scm.FromContext(c).ConfigFile(u, r, ref, s.Path)
+	} else {
+		// send API call to capture the pipeline configuration file
+		config, err = scm.FromContext(c).ConfigBackoff(u, r, ref)
+	}
+
+	if err != nil {
+		// use the default pipeline for the org when the repo has no pipeline configuration file
+		config, err = defaultConfig(database.FromContext(c), r, err)
+	}
+
+	return config, err
+}
+
+// planBuildPipeline is a helper function to
+// record the repo pipeline created for a build.
+func planBuildPipeline(database database.Service, p *model.RepoPipeline, b *library.Build) error {
+	if p == nil {
+		return nil
+	}
+
+	// send API call to create the build pipeline
+	err := database.CreateBuildPipeline(&model.BuildPipeline{
+		BuildID: b.GetID(),
+		Name:    p.Name,
+		Path:    p.Path,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create pipeline %s for build: %w", p.Name, err)
+	}
+
+	return nil
+}
+
+// buildPipeline is a helper function to capture
+// the repo pipeline a build was created for.
+func buildPipeline(database database.Service, b *library.Build) *model.RepoPipeline {
+	// send API call to capture the build pipeline
+	p, err := database.GetBuildPipeline(b)
+	if err != nil {
+		return nil
+	}
+
+	return &model.RepoPipeline{Name: p.Name, Path: p.Path}
+}
+
+// pipelineChecks is a helper function to report a build
+// created for a repo pipeline with a check run. The check
+// run created for the build is stored so it's updated with
+// every report and the annotations are only sent once when
+// the build is completed.
+//
+// nolint: lll // ignore long line length due to parameters
+func pipelineChecks(c *gin.Context, u *library.User, b *library.Build, r *library.Repo, pipeline string) error {
+	// send API call to capture the check for the build
+	check, err := database.FromContext(c).GetBuildCheck(b)
+	if err != nil {
+		check = &model.BuildCheck{BuildID: b.GetID()}
+	}
+
+	created := check.CheckRunID > 0
+	annotate := buildCompleted(b) && !check.Annotated
+
+	// capture the results of the steps for the build
+	steps, err := checkSteps(database.FromContext(c), b, annotate)
+	if err != nil {
+		return fmt.Errorf("unable to get steps for build: %w", err)
+	}
+
+	// send API call to set the check run on the commit
+	err = scm.FromContext(c).PipelineChecks(u, b, check, steps, r.GetOrg(), r.GetName(), pipeline)
+	if err != nil {
+		return err
+	}
+
+	// check if no check run was created for the build
+	if check.CheckRunID == 0 {
+		return nil
+	}
+
+	// check if the check run was created for the build
+	if !created {
+		check.Annotated = annotate
+
+		// send API call to create the check for the build
+		err = database.FromContext(c).CreateBuildCheck(check)
+		if err != nil {
+			return fmt.Errorf("unable to create check for build: %w", err)
+		}
+
+		return nil
+	}
+
+	// check if the annotations were sent for the build
+	if annotate {
+		check.Annotated = true
+
+		// send API call to update the check for the build
+		err = database.FromContext(c).UpdateBuildCheck(check)
+		if err != nil {
+			return fmt.Errorf("unable to update check for build: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// pipelineStatus is a helper function to send the commit
+// status for a build created for a repo pipeline.
+//
+// nolint: lll // ignore long line length due to parameters
+func pipelineStatus(c *gin.Context, u *library.User, b *library.Build, r *library.Repo, p *model.RepoPipeline) error {
+	name := ""
+	if p != nil {
+		name = p.Name
+	}
+
+	// check if builds are reported with a check run
+	if scm.FromContext(c).Reporting() == model.ReportingChecks {
+		return pipelineChecks(c, u, b, r, name)
+	}
+
+	// send API call to set the status on the commit
+	return scm.FromContext(c).PipelineStatus(u, b, r.GetOrg(), r.GetName(), name)
+}
@@ -0,0 +1,420 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/database/sqlite"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/scm"
+	"github.com/go-vela/server/scm/github"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+)
+
+func Test_repoPipelines(t *testing.T) {
+	// setup types
+	r := new(library.Repo)
+	r.SetID(1)
+	r.SetOrg("github")
+	r.SetName("octocat")
+	r.SetFullName("github/octocat")
+
+	path := new(library.Repo)
+	path.SetID(2)
+	path.SetOrg("github")
+	path.SetName("path")
+	path.SetFullName("github/path")
+
+	none := new(library.Repo)
+	none.SetID(3)
+	none.SetOrg("github")
+	none.SetName("none")
+	none.SetFullName("github/none")
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from pipeline_settings;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreatePipelineSettings(&model.PipelineSettings{
+		RepoID: r.GetID(),
+		Pipelines: model.RepoPipelines{
+			{Name: "api", Path: "api/.vela.yml", Rules: []string{"api/*"}},
+			{Name: "web", Path: "web/.vela.yml", Rules: []string{"web/*"}},
+		},
+	})
+	_ = db.CreatePipelineSettings(&model.PipelineSettings{RepoID: path.GetID(), Path: "ci/vela.yml"})
+
+	// setup tests
+	tests := []struct {
+		name  string
+		repo  *library.Repo
+		files []string
+		want  []string
+	}{
+		{"changed files match one pipeline", r, []string{"api/main.go"}, []string{"api"}},
+		{"changed files match every pipeline", r, []string{"api/main.go", "web/index.html"}, []string{"api", "web"}},
+		{"changed files match no pipelines", r, []string{"README.md"}, []string{}},
+		{"no changed files", r, []string{}, []string{"api", "web"}},
+		{"custom path without pipelines", path, []string{"README.md"}, []string{""}},
+		{"no pipeline settings", none, []string{"README.md"}, []string{""}},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, pipelines, err := repoPipelines(db, test.repo, test.files)
+			if err != nil {
+				t.Errorf("repoPipelines returned err: %v", err)
+			}
+
+			got := []string{}
+
+			for _, p := range pipelines {
+				if p == nil {
+					got = append(got, "")
+
+					continue
+				}
+
+				got = append(got, p.Name)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("repoPipelines is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_repoPipeline(t *testing.T) {
+	// setup types
+	r := new(library.Repo)
+	r.SetID(1)
+	r.SetOrg("github")
+	r.SetName("octocat")
+	r.SetFullName("github/octocat")
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from pipeline_settings;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreatePipelineSettings(&model.PipelineSettings{
+		RepoID: r.GetID(),
+		Path:   "ci/vela.yml",
+		Pipelines: model.RepoPipelines{
+			{Name: "api", Path: "api/.vela.yml", Rules: []string{"api/*"}},
+		},
+	})
+
+	// setup tests
+	tests := []struct {
+		name     string
+		pipeline string
+		failure  bool
+		want     *model.RepoPipeline
+	}{
+		{"no pipeline name", "", false, nil},
+		{"pipeline name", "api", false, &model.RepoPipeline{Name: "api", Path: "api/.vela.yml", Rules: []string{"api/*"}}},
+		{"unknown pipeline name", "web", true, nil},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, got, err := repoPipeline(db, r, test.pipeline)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("repoPipeline should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("repoPipeline returned err: %v", err)
+			}
+
+			if s == nil || s.Path != "ci/vela.yml" {
+				t.Errorf("repoPipeline settings are %v, want path ci/vela.yml", s)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("repoPipeline is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_allowEvent(t *testing.T) {
+	// setup types
+	r := new(library.Repo)
+	r.SetID(1)
+	r.SetOrg("github")
+	r.SetName("octocat")
+	r.SetFullName("github/octocat")
+
+	none := new(library.Repo)
+	none.SetID(2)
+	none.SetOrg("github")
+	none.SetName("none")
+	none.SetFullName("github/none")
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from pipeline_settings;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreatePipelineSettings(&model.PipelineSettings{
+		RepoID:       r.GetID(),
+		AllowRelease: true,
+	})
+
+	// setup tests
+	tests := []struct {
+		name  string
+		repo  *library.Repo
+		event string
+		want  bool
+	}{
+		{
+			name:  "allowed by settings",
+			repo:  r,
+			event: model.EventRelease,
+			want:  true,
+		},
+		{
+			name:  "not allowed by settings",
+			repo:  r,
+			event: model.EventPullClosed,
+			want:  false,
+		},
+		{
+			name:  "no settings",
+			repo:  none,
+			event: model.EventDelete,
+			want:  false,
+		},
+		{
+			name:  "allowed by repo",
+			repo:  none,
+			event: "push",
+			want:  true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := allowEvent(db, test.repo, test.event)
+
+			if got != test.want {
+				t.Errorf("allowEvent is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_pipelineChecks(t *testing.T) {
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetID(1)
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+
+	b := new(library.Build)
+	b.SetID(1)
+	b.SetRepoID(1)
+	b.SetNumber(1)
+	b.SetEvent(constants.EventPush)
+	b.SetCommit("6dcb09b5b57875f334f61aebed695e2e4193db5e")
+
+	// setup mock server
+	created := 0
+	updated := 0
+	annotations := 0
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body := struct {
+			Output struct {
+				Annotations []interface{} `json:"annotations"`
+			} `json:"output"`
+		}{}
+
+		_ = json.NewDecoder(req.Body).Decode(&body)
+
+		annotations = len(body.Output.Annotations)
+
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/api/v3/repos/foo/bar/check-runs":
+			created++
+		case req.Method == http.MethodPatch && req.URL.Path == "/api/v3/repos/foo/bar/check-runs/4":
+			updated++
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 4}`))
+	}))
+	defer s.Close()
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from build_checks;")
+		db.Sqlite.Exec("delete from steps;")
+		db.Sqlite.Exec("delete from logs;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	step := new(library.Step)
+	step.SetID(1)
+	step.SetBuildID(1)
+	step.SetRepoID(1)
+	step.SetNumber(1)
+	step.SetName("test")
+	step.SetImage("golang:latest")
+	step.SetStatus(constants.StatusFailure)
+	step.SetExitCode(1)
+
+	err = db.CreateStep(step)
+	if err != nil {
+		t.Errorf("unable to create test step: %v", err)
+	}
+
+	l := new(library.Log)
+	l.SetBuildID(1)
+	l.SetRepoID(1)
+	l.SetStepID(1)
+	l.SetData([]byte("::error file=main.go,line=1::undefined: foo\n"))
+
+	err = db.CreateLog(l)
+	if err != nil {
+		t.Errorf("unable to create test log: %v", err)
+	}
+
+	client, _ := github.NewTest(s.URL)
+
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	database.ToContext(c, db)
+	scm.ToContext(c, client)
+
+	// setup tests
+	tests := []struct {
+		name          string
+		status        string
+		wantCreated   int
+		wantUpdated   int
+		wantNotes     int
+		wantAnnotated bool
+	}{
+		{
+			name:        "create running",
+			status:      constants.StatusRunning,
+			wantCreated: 1,
+		},
+		{
+			name:        "update running",
+			status:      constants.StatusRunning,
+			wantCreated: 1,
+			wantUpdated: 1,
+		},
+		{
+			name:          "update completed",
+			status:        constants.StatusFailure,
+			wantCreated:   1,
+			wantUpdated:   2,
+			wantNotes:     1,
+			wantAnnotated: true,
+		},
+		{
+			name:          "update completed again",
+			status:        constants.StatusFailure,
+			wantCreated:   1,
+			wantUpdated:   3,
+			wantAnnotated: true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		b.SetStatus(test.status)
+
+		err := pipelineChecks(c, u, b, r, "")
+		if err != nil {
+			t.Errorf("pipelineChecks %s returned err: %v", test.name, err)
+		}
+
+		if created != test.wantCreated {
+			t.Errorf("pipelineChecks %s created is %d, want %d", test.name, created, test.wantCreated)
+		}
+
+		if updated != test.wantUpdated {
+			t.Errorf("pipelineChecks %s updated is %d, want %d", test.name, updated, test.wantUpdated)
+		}
+
+		if annotations != test.wantNotes {
+			t.Errorf("pipelineChecks %s annotations is %d, want %d", test.name, annotations, test.wantNotes)
+		}
+
+		check, err := db.GetBuildCheck(b)
+		if err != nil {
+			t.Errorf("unable to get check for %s: %v", test.name, err)
+
+			continue
+		}
+
+		if check.CheckRunID != 4 || check.Annotated != test.wantAnnotated {
+			t.Errorf("pipelineChecks %s check is %+v", test.name, check)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/scm"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+)
+
+// stageStatus is a helper function to derive the
+// status of a stage from the status of its steps.
+func stageStatus(statuses []string) string {
+	counts := make(map[string]int)
+
+	for _, status := range statuses {
+		counts[status]++
+	}
+
+	switch {
+	case len(statuses) == 0:
+		return constants.StatusPending
+	case counts[constants.StatusRunning] > 0:
+		return constants.StatusRunning
+	case counts[constants.StatusPending] == len(statuses):
+		return constants.StatusPending
+	case counts[constants.StatusPending] > 0:
+		// some steps of the stage completed while others are waiting
+		return constants.StatusRunning
+	case counts[constants.StatusError] > 0:
+		return constants.StatusError
+	case counts[constants.StatusFailure] > 0:
+		return constants.StatusFailure
+	case counts[constants.StatusKilled] > 0:
+		return constants.StatusKilled
+	case counts[constants.StatusCanceled] > 0:
+		return constants.StatusCanceled
+	case counts[constants.StatusSkipped] == len(statuses):
+		return constants.StatusSkipped
+	default:
+		return constants.StatusSuccess
+	}
+}
+
+// stageStatuses is a helper function to capture
+// the status of the steps for every stage.
+func stageStatuses(steps []*library.Step) map[string][]string {
+	stages := make(map[string][]string)
+
+	for _, s := range steps {
+		if len(s.GetStage()) == 0 {
+			continue
+		}
+
+		stages[s.GetStage()] = append(stages[s.GetStage()], s.GetStatus())
+	}
+
+	return stages
+}
+
+// stageStatusEnabled is a helper function to check if a
+// commit status is sent for the stages of the build.
+func stageStatusEnabled(database database.Service, b *library.Build, r *library.Repo) bool {
+	// deployments are reported with a deployment status
+	if strings.EqualFold(b.GetEvent(), constants.EventDeploy) {
+		return false
+	}
+
+	// send API call to capture the pipeline settings for the repo
+	s, err := database.GetPipelineSettings(r)
+	if err != nil {
+		return false
+	}
+
+	return s.StageStatus
+}
+
+// stepStageStatus is a helper function to send the commit status
+// for the stage of a step when the update of the step changed
+// the status of the stage.
+//
+// nolint: lll // ignore long line length due to parameters
+func stepStageStatus(c *gin.Context, b *library.Build, r *library.Repo, s *library.Step, previous string) error {
+	// check if the step is in a stage and the status of the step changed
+	if len(s.GetStage()) == 0 || s.GetStatus() == previous {
+		return nil
+	}
+
+	if !stageStatusEnabled(database.FromContext(c), b, r) {
+		return nil
+	}
+
+	// send API call to capture the steps for the build
+	steps, err := buildSteps(database.FromContext(c), b)
+	if err != nil {
+		return err
+	}
+
+	after := []string{}
+	before := []string{}
+
+	for _, step := range steps {
+		if step.GetStage() != s.GetStage() {
+			continue
+		}
+
+		after = append(after, step.GetStatus())
+
+		// use the status of the step before it was updated
+		if step.GetNumber() == s.GetNumber() {
+			before = append(before, previous)
+		} else {
+			before = append(before, step.GetStatus())
+		}
+	}
+
+	status := stageStatus(after)
+
+	// check if the update of the step changed the status of the stage
+	if status == stageStatus(before) {
+		return nil
+	}
+
+	// send API call to capture the repo owner
+	u, err := database.FromContext(c).GetUser(r.GetUserID())
+	if err != nil {
+		return err
+	}
+
+	// send API call to set the status on the commit
+	return sendStageStatus(c, u, b, r, s.GetStage(), status)
+}
+
+// buildStageStatus is a helper function to send the commit status
+// for every stage of a build in a "final" state. A stage that never
+// completed is reported with the status of the build.
+func buildStageStatus(c *gin.Context, u *library.User, b *library.Build, r *library.Repo) error {
+	if !stageStatusEnabled(database.FromContext(c), b, r) {
+		return nil
+	}
+
+	// send API call to capture the steps for the build
+	steps, err := buildSteps(database.FromContext(c), b)
+	if err != nil {
+		return err
+	}
+
+	for stage, statuses := range stageStatuses(steps) {
+		status := stageStatus(statuses)
+
+		// report a stage that never completed with the status of the build
+		if status == constants.StatusPending || status == constants.StatusRunning {
+			status = b.GetStatus()
+		}
+
+		// send API call to set the status on the commit
+		err = sendStageStatus(c, u, b, r, stage, status)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sendStageStatus is a helper function to send the
+// commit status for a stage of a build.
+//
+// nolint: lll // ignore long line length due to parameters
+func sendStageStatus(c *gin.Context, u *library.User, b *library.Build, r *library.Repo, stage, status string) error {
+	name := ""
+	if p := buildPipeline(database.FromContext(c), b); p != nil {
+		name = p.Name
+	}
+
+	// send API call to set the status on the commit
+	return scm.FromContext(c).StageStatus(u, b, r.GetOrg(), r.GetName(), name, stage, status)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/database/sqlite"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+)
+
+func Test_stageStatus(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name     string
+		statuses []string
+		want     string
+	}{
+		{name: "no steps", statuses: []string{}, want: "pending"},
+		{name: "all pending", statuses: []string{"pending", "pending"}, want: "pending"},
+		{name: "running", statuses: []string{"success", "running", "pending"}, want: "running"},
+		{name: "partially completed", statuses: []string{"success", "pending"}, want: "running"},
+		{name: "success", statuses: []string{"success", "skipped"}, want: "success"},
+		{name: "failure", statuses: []string{"success", "failure", "killed"}, want: "failure"},
+		{name: "error", statuses: []string{"failure", "error"}, want: "error"},
+		{name: "killed", statuses: []string{"success", "killed"}, want: "killed"},
+		{name: "canceled", statuses: []string{"success", "canceled"}, want: "canceled"},
+		{name: "skipped", statuses: []string{"skipped", "skipped"}, want: "skipped"},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := stageStatus(test.statuses)
+
+			if got != test.want {
+				t.Errorf("stageStatus is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_stageStatuses(t *testing.T) {
+	// setup types
+	clone := new(library.Step)
+	clone.SetName("clone")
+	clone.SetStatus("success")
+
+	test := new(library.Step)
+	test.SetStage("test")
+	test.SetStatus("success")
+
+	lint := new(library.Step)
+	lint.SetStage("test")
+	lint.SetStatus("running")
+
+	perf := new(library.Step)
+	perf.SetStage("perf")
+	perf.SetStatus("pending")
+
+	want := map[string][]string{
+		"test": {"success", "running"},
+		"perf": {"pending"},
+	}
+
+	// run test
+	got := stageStatuses([]*library.Step{clone, test, lint, perf})
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stageStatuses is %v, want %v", got, want)
+	}
+}
+
+func Test_stageStatusEnabled(t *testing.T) {
+	// setup types
+	enabled := new(library.Repo)
+	enabled.SetID(1)
+
+	disabled := new(library.Repo)
+	disabled.SetID(2)
+
+	none := new(library.Repo)
+	none.SetID(3)
+
+	push := new(library.Build)
+	push.SetEvent("push")
+
+	deploy := new(library.Build)
+	deploy.SetEvent("deployment")
+
+	// setup database
+	db, err := sqlite.NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+
+	defer func() {
+		db.Sqlite.Exec("delete from pipeline_settings;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreatePipelineSettings(&model.PipelineSettings{RepoID: enabled.GetID(), StageStatus: true})
+	_ = db.CreatePipelineSettings(&model.PipelineSettings{RepoID: disabled.GetID()})
+
+	// setup tests
+	tests := []struct {
+		name  string
+		build *library.Build
+		repo  *library.Repo
+		want  bool
+	}{
+		{name: "enabled", build: push, repo: enabled, want: true},
+		{name: "disabled", build: push, repo: disabled, want: false},
+		{name: "no settings", build: push, repo: none, want: false},
+		{name: "deployment", build: deploy, repo: enabled, want: false},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := stageStatusEnabled(db, test.build, test.repo)
+
+			if got != test.want {
+				t.Errorf("stageStatusEnabled is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
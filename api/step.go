@@ -12,11 +12,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
 	"github.com/go-vela/server/router/middleware/build"
 	"github.com/go-vela/server/router/middleware/org"
 	"github.com/go-vela/server/router/middleware/repo"
 	"github.com/go-vela/server/router/middleware/step"
 	"github.com/go-vela/server/router/middleware/user"
+	"github.com/go-vela/server/scm"
 	"github.com/go-vela/server/util"
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
@@ -402,6 +404,9 @@ func UpdateStep(c *gin.Context) {
 		"user":  u.GetName(),
 	}).Infof("updating step %s", entry)
 
+	// capture the status of the step before the update
+	previous := s.GetStatus()
+
 	// capture body from API request
 	input := new(library.Step)
 
@@ -469,6 +474,29 @@ func UpdateStep(c *gin.Context) {
 	s, _ = database.FromContext(c).GetStep(s.GetNumber(), b)
 
 	c.JSON(http.StatusOK, s)
+
+	// check if the build is reported with a check run and the step is in a "final" state
+	if scm.FromContext(c).Reporting() == model.ReportingChecks && stepCompleted(s) {
+		// send API call to capture the repo owner
+		u, err := database.FromContext(c).GetUser(r.GetUserID())
+		if err != nil {
+			logrus.Errorf("unable to get owner for build %s: %v", entry, err)
+
+			return
+		}
+
+		// send API call to set the check run on the commit
+		err = pipelineStatus(c, u, b, r, buildPipeline(database.FromContext(c), b))
+		if err != nil {
+			logrus.Errorf("unable to set check run for build %s: %v", entry, err)
+		}
+	}
+
+	// send API call to set the status on the commit for the stage of the step
+	err = stepStageStatus(c, b, r, s, previous)
+	if err != nil {
+		logrus.Errorf("unable to set commit status for stage %s of %s: %v", s.GetStage(), entry, err)
+	}
 }
 
 // swagger:operation DELETE /api/v1/repos/{org}/{repo}/builds/{build}/steps/{step} steps DeleteStep
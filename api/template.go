@@ -0,0 +1,110 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/router/middleware/build"
+	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/repo"
+	"github.com/go-vela/server/router/middleware/user"
+	"github.com/go-vela/server/util"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+)
+
+// swagger:operation GET /api/v1/repos/{org}/{repo}/builds/{build}/templates builds GetBuildTemplates
+//
+// Get the templates used to compile the pipeline for a build in the configured backend
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - in: path
+//   name: org
+//   description: Name of the org
+//   required: true
+//   type: string
+// - in: path
+//   name: repo
+//   description: Name of the repo
+//   required: true
+//   type: string
+// - in: path
+//   name: build
+//   description: Build number
+//   required: true
+//   type: integer
+// security:
+//   - ApiKeyAuth: []
+// responses:
+//   '200':
+//     description: Successfully retrieved templates for the build
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/BuildTemplate"
+//   '500':
+//     description: Unable to retrieve templates for the build
+//     schema:
+//       "$ref": "#/definitions/Error"
+
+// GetBuildTemplates represents the API handler to capture a list of
+// templates, with the commit and digest they were captured from,
+// used to compile the pipeline for a build from the configured backend.
+func GetBuildTemplates(c *gin.Context) {
+	// capture middleware values
+	b := build.Retrieve(c)
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	entry := fmt.Sprintf("%s/%d", r.GetFullName(), b.GetNumber())
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logrus.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+		"org":   o,
+		"repo":  r.GetName(),
+		"user":  u.GetName(),
+	}).Infof("reading templates for build %s", entry)
+
+	// send API call to capture the list of templates for the build
+	t, err := database.FromContext(c).GetBuildTemplateList(b)
+	if err != nil {
+		retErr := fmt.Errorf("unable to get templates for build %s: %w", entry, err)
+
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// planTemplates is a helper function to record the
+// templates used to compile the pipeline for the
+// build in the configured backend.
+func planTemplates(database database.Service, templates []*model.BuildTemplate, b *library.Build) error {
+	for _, t := range templates {
+		t.BuildID = b.GetID()
+
+		// send API call to create the template
+		err := database.CreateBuildTemplate(t)
+		if err != nil {
+			return fmt.Errorf("unable to create template %s: %w", t.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/go-vela/server/compiler"
 	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/model"
 	"github.com/go-vela/server/queue"
 	"github.com/go-vela/server/scm"
 	"github.com/go-vela/server/util"
@@ -245,6 +246,15 @@ func PostWebhook(c *gin.Context) {
 		return
 	}
 
+	// check if the created comment on the pull request is a command
+	if strings.EqualFold(b.GetEvent(), constants.EventComment) && webhook.PRNumber > 0 && createdComment(webhook.PullRequest) {
+		if name, args, ok := parseCommand(webhook.Comment); ok {
+			c.JSON(http.StatusOK, runCommand(c, r, b, webhook.PRNumber, name, args))
+
+			return
+		}
+	}
+
 	// verify the build has a valid event and the repo allows that event type
 	if (b.GetEvent() == constants.EventPush && !r.GetAllowPush()) ||
 		(b.GetEvent() == constants.EventPull && !r.GetAllowPull()) ||
@@ -261,6 +271,18 @@ func PostWebhook(c *gin.Context) {
 		return
 	}
 
+	// verify the pipeline settings of the repo allow the event type
+	if !allowEvent(database.FromContext(c), r, h.GetEvent()) {
+		// nolint: lll // ignore long line length due to error message
+		retErr := fmt.Errorf("%s: %s does not have %s events enabled", baseErr, r.GetFullName(), h.GetEvent())
+		util.HandleError(c, http.StatusBadRequest, retErr)
+
+		h.SetStatus(constants.StatusFailure)
+		h.SetError(retErr.Error())
+
+		return
+	}
+
 	// check if the repo has a valid owner
 	if r.GetUserID() == 0 {
 		retErr := fmt.Errorf("%s: %s has no valid owner", baseErr, r.GetFullName())
@@ -320,7 +342,7 @@ func PostWebhook(c *gin.Context) {
 
 	// if this is a comment on a pull_request event
 	if strings.EqualFold(b.GetEvent(), constants.EventComment) && webhook.PRNumber > 0 {
-		commit, branch, baseref, headref, err := scm.FromContext(c).GetPullRequest(u, r, webhook.PRNumber)
+		commit, branch, baseref, headref, fork, err := scm.FromContext(c).GetPullRequest(u, r, webhook.PRNumber)
 		if err != nil {
 			// nolint: lll // ignore long line length due to error message
 			retErr := fmt.Errorf("%s: failed to get pull request info for %s: %v", baseErr, r.GetFullName(), err)
@@ -336,12 +358,45 @@ func PostWebhook(c *gin.Context) {
 		b.SetBranch(strings.Replace(branch, "refs/heads/", "", -1))
 		b.SetBaseRef(baseref)
 		b.SetHeadRef(headref)
+
+		// the comment event doesn't capture the head repo of the pull
+		// request so the fork is set from the pull request for approval
+		if webhook.PullRequest != nil {
+			webhook.PullRequest.Fork = fork
+		}
+	}
+
+	// if this is a release or delete event without a commit
+	if len(b.GetCommit()) == 0 &&
+		(strings.EqualFold(b.GetEvent(), model.EventRelease) || strings.EqualFold(b.GetEvent(), model.EventDelete)) {
+		// capture the commit for the tag of the release
+		ref := strings.TrimPrefix(b.GetRef(), "refs/tags/")
+
+		// the deleted branch or tag no longer exists so
+		// the build runs on the default branch of the repo
+		if strings.EqualFold(b.GetEvent(), model.EventDelete) {
+			ref = r.GetBranch()
+		}
+
+		commit, err := scm.FromContext(c).GetRefCommit(u, r, ref)
+		if err != nil {
+			retErr := fmt.Errorf("%s: failed to get commit for %s: %v", baseErr, r.GetFullName(), err)
+			util.HandleError(c, http.StatusInternalServerError, retErr)
+
+			h.SetStatus(constants.StatusFailure)
+			h.SetError(retErr.Error())
+
+			return
+		}
+
+		b.SetCommit(commit)
 	}
 
 	// variable to store changeset files
 	var files []string
-	// check if the build event is not issue_comment
-	if !strings.EqualFold(b.GetEvent(), constants.EventComment) {
+	// check if the build event is not issue_comment or delete
+	if !strings.EqualFold(b.GetEvent(), constants.EventComment) &&
+		!strings.EqualFold(b.GetEvent(), model.EventDelete) {
 		// check if the build event is not pull_request
 		if !strings.EqualFold(b.GetEvent(), constants.EventPull) {
 			// send API call to capture list of files changed for the commit
@@ -373,12 +428,11 @@ func PostWebhook(c *gin.Context) {
 		}
 	}
 
-	// send API call to capture the pipeline configuration file
-	config, err := scm.FromContext(c).ConfigBackoff(u, r, b.GetCommit())
+	// send API call to capture the pipelines of the repo matching the changed files
+	settings, pipelines, err := repoPipelines(database.FromContext(c), r, files)
 	if err != nil {
-		// nolint: lll // ignore long line length due to error message
-		retErr := fmt.Errorf("%s: failed to get pipeline configuration for %s: %v", baseErr, r.GetFullName(), err)
-		util.HandleError(c, http.StatusNotFound, retErr)
+		retErr := fmt.Errorf("%s: %v", baseErr, err)
+		util.HandleError(c, http.StatusInternalServerError, retErr)
 
 		h.SetStatus(constants.StatusFailure)
 		h.SetError(retErr.Error())
@@ -386,24 +440,76 @@ func PostWebhook(c *gin.Context) {
 		return
 	}
 
-	// variable to store pipeline
-	var p *pipeline.Build
-	// number of times to retry
-	retryLimit := 3
+	// check if no pipelines of the repo match the changed files
+	if len(pipelines) == 0 {
+		// nolint: lll // ignore long line length due to message
+		c.JSON(http.StatusOK, fmt.Sprintf("skipping build since no pipelines match the changed files for %s", r.GetFullName()))
 
-	// iterate through with a retryLimit
-	for i := 0; i < retryLimit; i++ {
-		// check if we're on the first iteration of the loop
-		if i > 0 {
-			// incrementally sleep in between retries
-			time.Sleep(time.Duration(i) * time.Second)
-		}
+		return
+	}
 
-		// send API call to capture repo for the counter
-		r, err = database.FromContext(c).GetRepo(r.GetOrg(), r.GetName())
+	// send API call to capture the policies for the repo
+	policies, err := database.FromContext(c).GetRepoPolicyList(r)
+	if err != nil {
+		retErr := fmt.Errorf("%s: failed to get policies for %s: %v", baseErr, r.GetFullName(), err)
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		h.SetStatus(constants.StatusFailure)
+		h.SetError(retErr.Error())
+
+		return
+	}
+
+	// send API call to capture the required pipelines for the org
+	required, err := database.FromContext(c).GetRequiredPipelineList(r.GetOrg())
+	if err != nil {
+		retErr := fmt.Errorf("%s: failed to get required pipelines for %s: %v", baseErr, r.GetOrg(), err)
+		util.HandleError(c, http.StatusInternalServerError, retErr)
+
+		h.SetStatus(constants.StatusFailure)
+		h.SetError(retErr.Error())
+
+		return
+	}
+
+	// variables to store the builds created for the pipelines of the repo
+	var (
+		created  []*library.Build
+		compiled []*pipeline.Build
+		planned  []*model.RepoPipeline
+		skipped  string
+	)
+
+	// capture the build from the webhook
+	hookBuild := b
+
+	// capture the reason the builds require approval before they run
+	reason := settings.ApprovalReason(webhook.PullRequest)
+	if len(reason) > 0 {
+		hookBuild.SetStatus(model.StatusPendingApproval)
+	}
+
+	// capture the action of the event from the webhook for the rulesets
+	_, action := model.ParseEventAction(h.GetEvent())
+
+	// record the action with the pull request so
+	// a restarted build is compiled with the action
+	if webhook.PullRequest != nil {
+		webhook.PullRequest.Action = action
+	}
+
+	// create a build for every pipeline of the repo
+	for _, named := range pipelines {
+		// copy the build from the webhook for the pipeline
+		build := *hookBuild
+		b := &build
+
+		// send API call to capture the pipeline configuration file
+		config, err := pipelineConfig(c, u, r, b.GetCommit(), settings, named)
 		if err != nil {
-			retErr := fmt.Errorf("%s: failed to get repo %s: %v", baseErr, r.GetFullName(), err)
-			util.HandleError(c, http.StatusBadRequest, retErr)
+			// nolint: lll // ignore long line length due to error message
+			retErr := fmt.Errorf("%s: failed to get pipeline configuration for %s: %v", baseErr, r.GetFullName(), err)
+			util.HandleError(c, http.StatusNotFound, retErr)
 
 			h.SetStatus(constants.StatusFailure)
 			h.SetError(retErr.Error())
@@ -411,143 +517,252 @@ func PostWebhook(c *gin.Context) {
 			return
 		}
 
-		// set the parent equal to the current repo counter
-		b.SetParent(r.GetCounter())
+		// variable to store pipeline
+		var p *pipeline.Build
+		// variable to store the reason the build was skipped
+		var skip string
+		// number of times to retry
+		retryLimit := 3
+
+		// iterate through with a retryLimit
+		for i := 0; i < retryLimit; i++ {
+			// check if we're on the first iteration of the loop
+			if i > 0 {
+				// incrementally sleep in between retries
+				time.Sleep(time.Duration(i) * time.Second)
+			}
 
-		// check if the parent is set to 0
-		if b.GetParent() == 0 {
-			// parent should be "1" if it's the first build ran
-			b.SetParent(1)
-		}
+			// send API call to capture repo for the counter
+			r, err = database.FromContext(c).GetRepo(r.GetOrg(), r.GetName())
+			if err != nil {
+				retErr := fmt.Errorf("%s: failed to get repo %s: %v", baseErr, r.GetFullName(), err)
+				util.HandleError(c, http.StatusBadRequest, retErr)
 
-		// update the build numbers based off repo counter
-		inc := r.GetCounter() + 1
+				h.SetStatus(constants.StatusFailure)
+				h.SetError(retErr.Error())
 
-		r.SetCounter(inc)
-		b.SetNumber(inc)
+				return
+			}
 
-		// populate the build link if a web address is provided
-		if len(m.Vela.WebAddress) > 0 {
-			b.SetLink(
-				fmt.Sprintf("%s/%s/%d", m.Vela.WebAddress, r.GetFullName(), b.GetNumber()),
-			)
-		}
+			// set the parent equal to the current repo counter
+			b.SetParent(r.GetCounter())
 
-		// parse and compile the pipeline configuration file
-		p, err = compiler.FromContext(c).
-			Duplicate().
-			WithBuild(b).
-			WithComment(webhook.Comment).
-			WithFiles(files).
-			WithMetadata(m).
-			WithRepo(r).
-			WithUser(u).
-			Compile(config)
-		if err != nil {
-			// format the error message with extra information
-			err = fmt.Errorf("unable to compile pipeline configuration for %s: %v", r.GetFullName(), err)
+			// check if the parent is set to 0
+			if b.GetParent() == 0 {
+				// parent should be "1" if it's the first build ran
+				b.SetParent(1)
+			}
 
-			// log the error for traceability
-			logrus.Error(err.Error())
+			// update the build numbers based off repo counter
+			inc := r.GetCounter() + 1
 
-			retErr := fmt.Errorf("%s: %v", baseErr, err)
-			util.HandleError(c, http.StatusInternalServerError, retErr)
+			r.SetCounter(inc)
+			b.SetNumber(inc)
 
-			h.SetStatus(constants.StatusFailure)
-			h.SetError(retErr.Error())
+			// populate the build link if a web address is provided
+			if len(m.Vela.WebAddress) > 0 {
+				b.SetLink(
+					fmt.Sprintf("%s/%s/%d", m.Vela.WebAddress, r.GetFullName(), b.GetNumber()),
+				)
+			}
 
-			return
+			// parse and compile the pipeline configuration file
+			comp := compiler.FromContext(c).
+				Duplicate().
+				WithBuild(b).
+				WithAction(action).
+				WithComment(webhook.Comment).
+				WithFiles(files).
+				WithMetadata(m).
+				WithPolicies(policies).
+				WithPullRequest(webhook.PullRequest).
+				WithRepo(r).
+				WithRequiredPipelines(required).
+				WithSCM(scm.FromContext(c)).
+				WithUser(u)
+
+			p, err = comp.Compile(config)
+			if err == nil {
+				// verify the secrets referenced in the pipeline
+				err = validateSecrets(c, r, b.GetEvent(), p)
+			}
+
+			if err != nil {
+				// format the error message with extra information
+				err = fmt.Errorf("unable to compile pipeline configuration for %s: %v", r.GetFullName(), err)
+
+				// log the error for traceability
+				logrus.Error(err.Error())
+
+				retErr := fmt.Errorf("%s: %v", baseErr, err)
+				util.HandleError(c, http.StatusInternalServerError, retErr)
+
+				h.SetStatus(constants.StatusFailure)
+				h.SetError(retErr.Error())
+
+				return
+			}
+
+			// skip the build if only the init or clone steps are found
+			skip = skipEmptyBuild(p)
+			if skip != "" {
+				break
+			}
+
+			// create the objects from the pipeline in the database
+			// nolint: lll // ignore long line length due to parameters
+			err = planBuild(database.FromContext(c), p, b, r, comp.Templates(), comp.PolicyResults(), comp.InjectedSteps(), named, webhook.PullRequest)
+			if err != nil {
+				// log the error for traceability
+				logrus.Error(err.Error())
+
+				// check if the retry limit has been exceeded
+				if i < retryLimit {
+					// reset fields set by cleanBuild for retry
+					b.SetError("")
+					b.SetStatus(hookBuild.GetStatus())
+					b.SetFinished(0)
+
+					// continue to the next iteration of the loop
+					continue
+				}
+
+				retErr := fmt.Errorf("%s: %v", baseErr, err)
+				util.HandleError(c, http.StatusInternalServerError, retErr)
+
+				h.SetStatus(constants.StatusFailure)
+				h.SetError(retErr.Error())
+
+				return
+			}
+
+			// break the loop because everything was successful
+			break
 		}
 
-		// skip the build if only the init or clone steps are found
-		skip := skipEmptyBuild(p)
+		// check if the build for the pipeline was skipped
 		if skip != "" {
 			// set build to successful status
 			b.SetStatus(constants.StatusSkipped)
 
 			// send API call to set the status on the commit
-			err = scm.FromContext(c).Status(u, b, r.GetOrg(), r.GetName())
+			err = pipelineStatus(c, u, b, r, named)
 			if err != nil {
 				logrus.Errorf("unable to set commit status for %s/%d: %v", r.GetFullName(), b.GetNumber(), err)
 			}
 
-			c.JSON(http.StatusOK, skip)
-			return
+			skipped = skip
+
+			continue
 		}
 
-		// create the objects from the pipeline in the database
-		err = planBuild(database.FromContext(c), p, b, r)
+		// send API call to update repo for ensuring counter is incremented
+		err = database.FromContext(c).UpdateRepo(r)
 		if err != nil {
-			// log the error for traceability
-			logrus.Error(err.Error())
-
-			// check if the retry limit has been exceeded
-			if i < retryLimit {
-				// reset fields set by cleanBuild for retry
-				b.SetError("")
-				b.SetStatus(constants.StatusPending)
-				b.SetFinished(0)
-
-				// continue to the next iteration of the loop
-				continue
-			}
+			retErr := fmt.Errorf("%s: failed to update repo %s: %v", baseErr, r.GetFullName(), err)
+			util.HandleError(c, http.StatusBadRequest, retErr)
 
-			retErr := fmt.Errorf("%s: %v", baseErr, err)
+			h.SetStatus(constants.StatusFailure)
+			h.SetError(retErr.Error())
+
+			return
+		}
+
+		// send API call to capture the triggered build
+		b, err = database.FromContext(c).GetBuild(b.GetNumber(), r)
+		if err != nil {
+			// nolint: lll // ignore long line length due to error message
+			retErr := fmt.Errorf("%s: failed to get new build %s/%d: %v", baseErr, r.GetFullName(), b.GetNumber(), err)
 			util.HandleError(c, http.StatusInternalServerError, retErr)
 
 			h.SetStatus(constants.StatusFailure)
 			h.SetError(retErr.Error())
+		}
 
-			return
+		// check if the build for the pipeline requires approval
+		if strings.EqualFold(b.GetStatus(), model.StatusPendingApproval) {
+			// send API call to record the approval for the build
+			err = planBuildApproval(database.FromContext(c), reason, p, b)
+			if err != nil {
+				retErr := fmt.Errorf("%s: %v", baseErr, err)
+
+				// log the error for traceability
+				logrus.Error(retErr.Error())
+
+				h.SetStatus(constants.StatusFailure)
+				h.SetError(retErr.Error())
+
+				// error out the build since it can't be approved without
+				// the approval, the builds created for the other pipelines
+				// are still published and reported on the commit
+				b.SetError(err.Error())
+				b.SetStatus(constants.StatusError)
+				b.SetFinished(time.Now().UTC().Unix())
+
+				// send API call to update the build
+				err = database.FromContext(c).UpdateBuild(b)
+				if err != nil {
+					logrus.Errorf("unable to update build %s/%d: %v", r.GetFullName(), b.GetNumber(), err)
+				}
+			}
 		}
 
-		// break the loop because everything was successful
-		break
+		created = append(created, b)
+		compiled = append(compiled, p)
+		planned = append(planned, named)
 	}
 
-	// send API call to update repo for ensuring counter is incremented
-	err = database.FromContext(c).UpdateRepo(r)
-	if err != nil {
-		retErr := fmt.Errorf("%s: failed to update repo %s: %v", baseErr, r.GetFullName(), err)
-		util.HandleError(c, http.StatusBadRequest, retErr)
-
-		h.SetStatus(constants.StatusFailure)
-		h.SetError(retErr.Error())
+	// check if the builds for every pipeline were skipped
+	if len(created) == 0 {
+		c.JSON(http.StatusOK, skipped)
 
 		return
 	}
 
-	// send API call to capture the triggered build
-	b, err = database.FromContext(c).GetBuild(b.GetNumber(), r)
-	if err != nil {
-		// nolint: lll // ignore long line length due to error message
-		retErr := fmt.Errorf("%s: failed to get new build %s/%d: %v", baseErr, r.GetFullName(), b.GetNumber(), err)
-		util.HandleError(c, http.StatusInternalServerError, retErr)
+	// set the BuildID field
+	h.SetBuildID(created[0].GetID())
 
-		h.SetStatus(constants.StatusFailure)
-		h.SetError(retErr.Error())
+	// respond with a list of builds when more than one pipeline of the repo was built
+	if len(created) == 1 {
+		c.JSON(http.StatusOK, created[0])
+	} else {
+		c.JSON(http.StatusOK, created)
 	}
 
-	// set the BuildID field
-	h.SetBuildID(b.GetID())
+	for i, b := range created {
+		// send API call to set the status on the commit
+		err = pipelineStatus(c, u, b, r, planned[i])
+		if err != nil {
+			logrus.Errorf("unable to set commit status for %s/%d: %v", r.GetFullName(), b.GetNumber(), err)
+		}
 
-	c.JSON(http.StatusOK, b)
+		// skip publishing the build until it's approved
+		if strings.EqualFold(b.GetStatus(), model.StatusPendingApproval) {
+			continue
+		}
 
-	// send API call to set the status on the commit
-	err = scm.FromContext(c).Status(u, b, r.GetOrg(), r.GetName())
-	if err != nil {
-		logrus.Errorf("unable to set commit status for %s/%d: %v", r.GetFullName(), b.GetNumber(), err)
+		// skip publishing the build when its approval failed
+		if strings.EqualFold(b.GetStatus(), constants.StatusError) {
+			continue
+		}
+
+		// publish the build to the queue
+		go publishToQueue(
+			queue.FromGinContext(c),
+			database.FromContext(c),
+			compiled[i],
+			b,
+			r,
+			u,
+		)
 	}
+}
 
-	// publish the build to the queue
-	go publishToQueue(
-		queue.FromGinContext(c),
-		database.FromContext(c),
-		p,
-		b,
-		r,
-		u,
-	)
+// createdComment is a helper function to check if the comment
+// on the pull request was created, so edited comments aren't
+// handled as commands.
+func createdComment(p *model.BuildPullRequest) bool {
+	return p != nil && p.Action == model.ActionCreated
 }
 
 // publishToQueue is a helper function that creates
@@ -644,6 +859,9 @@ func renameRepository(h *library.Hook, r *library.Repo, c *gin.Context) error {
 		return retErr
 	}
 
+	invalidateRepoAccess(c, r.GetOrg(), previousName)
+	invalidateRepoAccess(c, r.GetOrg(), r.GetName())
+
 	// get total number of secrets associated with repository
 	// nolint: lll // ignore long line due to extensive function arguments
 	t, err := database.FromContext(c).GetTypeSecretCount(constants.SecretRepo, r.GetOrg(), previousName, []string{})
@@ -0,0 +1,52 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/go-vela/types/constants"
+	"github.com/sirupsen/logrus"
+)
+
+// DriverMemory defines the driver type
+// when integrating with an in-memory cache.
+const DriverMemory = "memory"
+
+// nolint: godot // ignore period at end for comment ending in a list
+//
+// New creates and returns a Vela service capable of
+// integrating with the configured cache environment.
+// Currently, the following caches are supported:
+//
+// * memory
+// * redis
+func New(s *Setup) (Service, error) {
+	// validate the setup being provided
+	//
+	// https://pkg.go.dev/github.com/go-vela/server/cache?tab=doc#Setup.Validate
+	err := s.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Debug("creating cache client from setup")
+	// process the cache driver being provided
+	switch s.Driver {
+	case DriverMemory:
+		// handle the in-memory cache driver being provided
+		//
+		// https://pkg.go.dev/github.com/go-vela/server/cache?tab=doc#Setup.Memory
+		return s.Memory()
+	case constants.DriverRedis:
+		// handle the Redis cache driver being provided
+		//
+		// https://pkg.go.dev/github.com/go-vela/server/cache?tab=doc#Setup.Redis
+		return s.Redis()
+	default:
+		// handle an invalid cache driver being provided
+		return nil, fmt.Errorf("invalid cache driver provided: %s", s.Driver)
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package cache provides the ability for Vela to integrate
+// with different supported Cache backends.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/server/cache"
+package cache
@@ -0,0 +1,40 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package cache
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Flags represents all supported command line
+// interface (CLI) flags for the cache.
+//
+// https://pkg.go.dev/github.com/urfave/cli?tab=doc#Flag
+var Flags = []cli.Flag{
+	// Cache Flags
+
+	&cli.StringFlag{
+		EnvVars:  []string{"VELA_CACHE_DRIVER", "CACHE_DRIVER"},
+		FilePath: "/vela/cache/driver",
+		Name:     "cache.driver",
+		Usage:    "driver to be used for the cache (memory or redis)",
+		Value:    DriverMemory,
+	},
+	&cli.StringFlag{
+		EnvVars:  []string{"VELA_CACHE_ADDR", "CACHE_ADDR"},
+		FilePath: "/vela/cache/addr",
+		Name:     "cache.addr",
+		Usage:    "fully qualified url (<scheme>://<host>) for the cache",
+	},
+	&cli.DurationFlag{
+		EnvVars:  []string{"VELA_CACHE_TTL", "CACHE_TTL"},
+		FilePath: "/vela/cache/ttl",
+		Name:     "cache.ttl",
+		Usage:    "time to live for the permissions stored in the cache",
+		Value:    time.Minute,
+	},
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package cache
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keyPrefix defines the prefix for the keys
+// of the permissions stored in the cache.
+const keyPrefix = "vela:perm"
+
+// OrgAccessKey returns the key for the
+// permissions of the user for the org.
+func OrgAccessKey(user, org string) string {
+	return strings.ToLower(fmt.Sprintf("%s:org:%s:%s", keyPrefix, org, user))
+}
+
+// RepoAccessKey returns the key for the
+// permissions of the user for the repo.
+func RepoAccessKey(user, org, repo string) string {
+	return RepoAccessPrefix(org, repo) + strings.ToLower(user)
+}
+
+// RepoAccessPrefix returns the prefix for the keys
+// of the permissions of all users for the repo.
+func RepoAccessPrefix(org, repo string) string {
+	return strings.ToLower(fmt.Sprintf("%s:repo:%s/%s:", keyPrefix, org, repo))
+}
+
+// TeamAccessKey returns the key for the
+// permissions of the user for the team.
+func TeamAccessKey(user, org, team string) string {
+	return strings.ToLower(fmt.Sprintf("%s:team:%s/%s:%s", keyPrefix, org, team, user))
+}
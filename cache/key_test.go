@@ -0,0 +1,25 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package cache
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCache_RepoAccessKey(t *testing.T) {
+	// run test
+	got := RepoAccessKey("Octocat", "Foo", "Bar")
+
+	want := "vela:perm:repo:foo/bar:octocat"
+
+	if got != want {
+		t.Errorf("RepoAccessKey is %v, want %v", got, want)
+	}
+
+	if !strings.HasPrefix(got, RepoAccessPrefix("foo", "bar")) {
+		t.Errorf("RepoAccessKey %v does not have prefix %v", got, RepoAccessPrefix("foo", "bar"))
+	}
+}
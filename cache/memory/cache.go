@@ -0,0 +1,90 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Get captures the value for a key from the cache.
+func (c *client) Get(ctx context.Context, key string) (string, bool, error) {
+	c.Logger.Tracef("getting key %s from the cache", key)
+
+	c.mutex.RLock()
+	e, ok := c.values[key]
+	c.mutex.RUnlock()
+
+	if !ok {
+		return "", false, nil
+	}
+
+	// remove the key from the cache once it has expired
+	if time.Now().After(e.expires) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		// the key may have been set again after the read lock
+		// was released so the expiry is checked again
+		e, ok = c.values[key]
+		if !ok {
+			return "", false, nil
+		}
+
+		if time.Now().After(e.expires) {
+			delete(c.values, key)
+
+			return "", false, nil
+		}
+	}
+
+	return e.value, true, nil
+}
+
+// Set stores the value for a key in the cache.
+func (c *client) Set(ctx context.Context, key, value string) error {
+	c.Logger.Tracef("setting key %s in the cache", key)
+
+	now := time.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// remove the expired keys from the cache once per TTL
+	// to prevent the cache from growing unbounded
+	if now.Sub(c.swept) > c.config.TTL {
+		for k, e := range c.values {
+			if now.After(e.expires) {
+				delete(c.values, k)
+			}
+		}
+
+		c.swept = now
+	}
+
+	c.values[key] = entry{
+		value:   value,
+		expires: now.Add(c.config.TTL),
+	}
+
+	return nil
+}
+
+// DeletePrefix removes all keys with the prefix from the cache.
+func (c *client) DeletePrefix(ctx context.Context, prefix string) error {
+	c.Logger.Tracef("deleting keys with prefix %s from the cache", prefix)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for k := range c.values {
+		if strings.HasPrefix(k, prefix) {
+			delete(c.values, k)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemory_Cache(t *testing.T) {
+	// setup types
+	_cache, err := New(WithTTL(time.Minute))
+	if err != nil {
+		t.Errorf("unable to create cache service: %v", err)
+	}
+
+	ctx := context.Background()
+
+	_ = _cache.Set(ctx, "vela:perm:repo:foo/bar:octocat", "admin")
+	_ = _cache.Set(ctx, "vela:perm:repo:foo/baz:octocat", "read")
+
+	// run tests
+	got, ok, err := _cache.Get(ctx, "vela:perm:repo:foo/bar:octocat")
+	if err != nil {
+		t.Errorf("Get returned err: %v", err)
+	}
+
+	if !ok || got != "admin" {
+		t.Errorf("Get is %v, %v, want admin, true", got, ok)
+	}
+
+	err = _cache.DeletePrefix(ctx, "vela:perm:repo:foo/bar:")
+	if err != nil {
+		t.Errorf("DeletePrefix returned err: %v", err)
+	}
+
+	_, ok, _ = _cache.Get(ctx, "vela:perm:repo:foo/bar:octocat")
+	if ok {
+		t.Errorf("Get should not have found deleted key")
+	}
+
+	_, ok, _ = _cache.Get(ctx, "vela:perm:repo:foo/baz:octocat")
+	if !ok {
+		t.Errorf("Get should have found key without prefix")
+	}
+}
+
+func TestMemory_Cache_Expired(t *testing.T) {
+	// setup types
+	_cache, _ := New(WithTTL(time.Millisecond))
+
+	ctx := context.Background()
+
+	_ = _cache.Set(ctx, "foo", "bar")
+
+	time.Sleep(5 * time.Millisecond)
+
+	// run test
+	_, ok, err := _cache.Get(ctx, "foo")
+	if err != nil {
+		t.Errorf("Get returned err: %v", err)
+	}
+
+	if ok {
+		t.Errorf("Get should not have found expired key")
+	}
+}
+
+func TestMemory_New_Failure(t *testing.T) {
+	// run test
+	_, err := New(WithTTL(0))
+	if err == nil {
+		t.Errorf("New should have returned err")
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package memory
+
+// Driver outputs the configured cache driver.
+func (c *client) Driver() string {
+	return "memory"
+}
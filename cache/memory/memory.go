@@ -0,0 +1,66 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type config struct {
+	// specifies the time to live for the keys stored by the memory client
+	TTL time.Duration
+}
+
+// entry represents a value stored in
+// the memory client with its expiration.
+type entry struct {
+	value   string
+	expires time.Time
+}
+
+type client struct {
+	config *config
+	mutex  sync.RWMutex
+	values map[string]entry
+	swept  time.Time
+	// https://pkg.go.dev/github.com/sirupsen/logrus#Entry
+	Logger *logrus.Entry
+}
+
+// New returns a Cache implementation that
+// integrates with an in-memory cache.
+//
+// nolint: revive // ignore returning unexported client
+func New(opts ...ClientOpt) (*client, error) {
+	// create new memory client
+	c := new(client)
+
+	// create new fields
+	c.config = new(config)
+	c.values = make(map[string]entry)
+
+	// create new logger for the client
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#StandardLogger
+	logger := logrus.StandardLogger()
+
+	// create new logger for the client
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#NewEntry
+	c.Logger = logrus.NewEntry(logger).WithField("cache", c.Driver())
+
+	// apply all provided configuration options
+	for _, opt := range opts {
+		err := opt(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
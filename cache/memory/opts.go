@@ -0,0 +1,30 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package memory
+
+import (
+	"fmt"
+	"time"
+)
+
+// ClientOpt represents a configuration option to initialize the cache client for memory.
+type ClientOpt func(*client) error
+
+// WithTTL sets the time to live in the cache client for memory.
+func WithTTL(ttl time.Duration) ClientOpt {
+	return func(c *client) error {
+		c.Logger.Trace("configuring ttl in memory cache client")
+
+		// check if the ttl provided is empty
+		if ttl <= 0 {
+			return fmt.Errorf("no memory cache ttl provided")
+		}
+
+		// set the cache ttl in the memory client
+		c.config.TTL = ttl
+
+		return nil
+	}
+}
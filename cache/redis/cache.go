@@ -0,0 +1,59 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Get captures the value for a key from the cache.
+func (c *client) Get(ctx context.Context, key string) (string, bool, error) {
+	c.Logger.Tracef("getting key %s from the cache", key)
+
+	// send API call to capture the value for the key
+	//
+	// https://pkg.go.dev/github.com/go-redis/redis/v8?tab=doc#Client.Get
+	value, err := c.Redis.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return value, true, nil
+}
+
+// Set stores the value for a key in the cache.
+func (c *client) Set(ctx context.Context, key, value string) error {
+	c.Logger.Tracef("setting key %s in the cache", key)
+
+	// send API call to store the value for the key with the ttl
+	//
+	// https://pkg.go.dev/github.com/go-redis/redis/v8?tab=doc#Client.Set
+	return c.Redis.Set(ctx, key, value, c.config.TTL).Err()
+}
+
+// DeletePrefix removes all keys with the prefix from the cache.
+func (c *client) DeletePrefix(ctx context.Context, prefix string) error {
+	c.Logger.Tracef("deleting keys with prefix %s from the cache", prefix)
+
+	// iterate through the keys matching the prefix
+	//
+	// https://pkg.go.dev/github.com/go-redis/redis/v8?tab=doc#Client.Scan
+	iter := c.Redis.Scan(ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		err := c.Redis.Del(ctx, iter.Val()).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	return iter.Err()
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package redis provides the ability for Vela to
+// integrate with a Redis server as a cache backend.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/server/cache/redis"
+package redis
@@ -0,0 +1,47 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package redis
+
+import (
+	"fmt"
+	"time"
+)
+
+// ClientOpt represents a configuration option to initialize the cache client for Redis.
+type ClientOpt func(*client) error
+
+// WithAddress sets the address in the cache client for Redis.
+func WithAddress(address string) ClientOpt {
+	return func(c *client) error {
+		c.Logger.Trace("configuring address in redis cache client")
+
+		// check if the address provided is empty
+		if len(address) == 0 {
+			return fmt.Errorf("no Redis cache address provided")
+		}
+
+		// set the cache address in the redis client
+		c.config.Address = address
+
+		return nil
+	}
+}
+
+// WithTTL sets the time to live in the cache client for Redis.
+func WithTTL(ttl time.Duration) ClientOpt {
+	return func(c *client) error {
+		c.Logger.Trace("configuring ttl in redis cache client")
+
+		// check if the ttl provided is empty
+		if ttl <= 0 {
+			return fmt.Errorf("no Redis cache ttl provided")
+		}
+
+		// set the cache ttl in the redis client
+		c.config.TTL = ttl
+
+		return nil
+	}
+}
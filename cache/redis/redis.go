@@ -0,0 +1,127 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+type config struct {
+	// specifies the address to use for the Redis client
+	Address string
+	// specifies the time to live for the keys stored by the Redis client
+	TTL time.Duration
+}
+
+type client struct {
+	config  *config
+	Redis   *redis.Client
+	Options *redis.Options
+	// https://pkg.go.dev/github.com/sirupsen/logrus#Entry
+	Logger *logrus.Entry
+}
+
+// New returns a Cache implementation that
+// integrates with a Redis cache instance.
+//
+// nolint: revive // ignore returning unexported client
+func New(opts ...ClientOpt) (*client, error) {
+	// create new Redis client
+	c := new(client)
+
+	// create new fields
+	c.config = new(config)
+	c.Redis = new(redis.Client)
+	c.Options = new(redis.Options)
+
+	// create new logger for the client
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#StandardLogger
+	logger := logrus.StandardLogger()
+
+	// create new logger for the client
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#NewEntry
+	c.Logger = logrus.NewEntry(logger).WithField("cache", c.Driver())
+
+	// apply all provided configuration options
+	for _, opt := range opts {
+		err := opt(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// parse the url provided
+	options, err := redis.ParseURL(c.config.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// create the Redis options from the parsed url
+	c.Options = options
+
+	// create the Redis client from the parsed url
+	c.Redis = redis.NewClient(c.Options)
+
+	// ping the cache
+	err = pingCache(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// pingCache is a helper function to send a "ping"
+// request with backoff to the cache.
+//
+// This will ensure we have properly established a
+// connection to the Redis cache instance before
+// we try to set it up.
+func pingCache(c *client) error {
+	// attempt 10 times
+	for i := 0; i < 10; i++ {
+		// send ping request to client
+		err := c.Redis.Ping(context.Background()).Err()
+		if err != nil {
+			c.Logger.Debugf("unable to ping Redis cache. Retrying in %v", time.Duration(i)*time.Second)
+			time.Sleep(1 * time.Second)
+
+			continue
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unable to establish connection to Redis cache")
+}
+
+// NewTest returns a Cache implementation that
+// integrates with a local Redis instance.
+//
+// This function is intended for running tests only.
+//
+// nolint: revive // ignore returning unexported client
+func NewTest() (*client, error) {
+	// create a local fake redis instance
+	//
+	// https://pkg.go.dev/github.com/alicebob/miniredis/v2#Run
+	_redis, err := miniredis.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(
+		WithAddress(fmt.Sprintf("redis://%s", _redis.Addr())),
+		WithTTL(time.Minute),
+	)
+}
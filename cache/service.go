@@ -0,0 +1,30 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package cache
+
+import "context"
+
+// Service represents the interface for Vela integrating
+// with the different supported Cache backends.
+type Service interface {
+	// Service Interface Functions
+
+	// Driver defines a function that outputs
+	// the configured cache driver.
+	Driver() string
+
+	// Get defines a function that captures the value
+	// for a key from the cache. False is returned
+	// when the key is missing or has expired.
+	Get(context.Context, string) (string, bool, error)
+
+	// Set defines a function that stores the value
+	// for a key in the cache for the configured TTL.
+	Set(context.Context, string, string) error
+
+	// DeletePrefix defines a function that removes
+	// all keys with the prefix from the cache.
+	DeletePrefix(context.Context, string) error
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-vela/server/cache/memory"
+	"github.com/go-vela/server/cache/redis"
+	"github.com/go-vela/types/constants"
+	"github.com/sirupsen/logrus"
+)
+
+// Setup represents the configuration necessary for
+// creating a Vela service capable of integrating
+// with a configured cache environment.
+type Setup struct {
+	// Cache Configuration
+
+	// specifies the driver to use for the cache client
+	Driver string
+	// specifies the address to use for the cache client
+	Address string
+	// specifies the time to live for the keys stored by the cache client
+	TTL time.Duration
+}
+
+// Memory creates and returns a Vela service capable
+// of integrating with an in-memory cache.
+func (s *Setup) Memory() (Service, error) {
+	logrus.Trace("creating memory cache client from setup")
+
+	// create new in-memory cache service
+	//
+	// https://pkg.go.dev/github.com/go-vela/server/cache/memory?tab=doc#New
+	return memory.New(
+		memory.WithTTL(s.TTL),
+	)
+}
+
+// Redis creates and returns a Vela service capable
+// of integrating with a Redis cache.
+func (s *Setup) Redis() (Service, error) {
+	logrus.Trace("creating redis cache client from setup")
+
+	// create new Redis cache service
+	//
+	// https://pkg.go.dev/github.com/go-vela/server/cache/redis?tab=doc#New
+	return redis.New(
+		redis.WithAddress(s.Address),
+		redis.WithTTL(s.TTL),
+	)
+}
+
+// Validate verifies the necessary fields for the
+// provided configuration are populated correctly.
+func (s *Setup) Validate() error {
+	logrus.Trace("validating cache setup for client")
+
+	// verify a cache driver was provided
+	if len(s.Driver) == 0 {
+		return fmt.Errorf("no cache driver provided")
+	}
+
+	// verify a cache TTL was provided
+	if s.TTL <= 0 {
+		return fmt.Errorf("no cache ttl provided")
+	}
+
+	// the in-memory cache has no address
+	if !strings.EqualFold(s.Driver, constants.DriverRedis) {
+		return nil
+	}
+
+	// verify a cache address was provided
+	if len(s.Address) == 0 {
+		return fmt.Errorf("no cache address provided")
+	}
+
+	// check if the cache address has a scheme
+	if !strings.Contains(s.Address, "://") {
+		return fmt.Errorf("cache address must be fully qualified (<scheme>://<host>)")
+	}
+
+	// check if the cache address has a trailing slash
+	if strings.HasSuffix(s.Address, "/") {
+		return fmt.Errorf("cache address must not have trailing slash")
+	}
+
+	// setup is valid
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestCache_Setup_Memory(t *testing.T) {
+	// setup types
+	_setup := &Setup{
+		Driver: "memory",
+		TTL:    time.Minute,
+	}
+
+	_, err := _setup.Memory()
+	if err != nil {
+		t.Errorf("Memory returned err: %v", err)
+	}
+}
+
+func TestCache_Setup_Redis(t *testing.T) {
+	// setup types
+
+	// create a local fake redis instance
+	//
+	// https://pkg.go.dev/github.com/alicebob/miniredis/v2#Run
+	_redis, err := miniredis.Run()
+	if err != nil {
+		t.Errorf("unable to create miniredis instance: %v", err)
+	}
+	defer _redis.Close()
+
+	_setup := &Setup{
+		Driver:  "redis",
+		Address: fmt.Sprintf("redis://%s", _redis.Addr()),
+		TTL:     time.Minute,
+	}
+
+	_, err = _setup.Redis()
+	if err != nil {
+		t.Errorf("Redis returned err: %v", err)
+	}
+}
+
+func TestCache_Setup_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		setup   *Setup
+	}{
+		{
+			failure: false,
+			setup: &Setup{
+				Driver: "memory",
+				TTL:    time.Minute,
+			},
+		},
+		{
+			failure: false,
+			setup: &Setup{
+				Driver:  "redis",
+				Address: "redis://redis.example.com",
+				TTL:     time.Minute,
+			},
+		},
+		{
+			failure: true,
+			setup: &Setup{
+				TTL: time.Minute,
+			},
+		},
+		{
+			failure: true,
+			setup: &Setup{
+				Driver: "memory",
+			},
+		},
+		{
+			failure: true,
+			setup: &Setup{
+				Driver: "redis",
+				TTL:    time.Minute,
+			},
+		},
+		{
+			failure: true,
+			setup: &Setup{
+				Driver:  "redis",
+				Address: "redis.example.com",
+				TTL:     time.Minute,
+			},
+		},
+		{
+			failure: true,
+			setup: &Setup{
+				Driver:  "redis",
+				Address: "redis://redis.example.com/",
+				TTL:     time.Minute,
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.setup.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
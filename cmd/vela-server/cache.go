@@ -0,0 +1,30 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package main
+
+import (
+	"github.com/go-vela/server/cache"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/urfave/cli/v2"
+)
+
+// helper function to setup the cache from the CLI arguments.
+func setupCache(c *cli.Context) (cache.Service, error) {
+	logrus.Debug("Creating cache client from CLI configuration")
+
+	// cache configuration
+	_setup := &cache.Setup{
+		Driver:  c.String("cache.driver"),
+		Address: c.String("cache.addr"),
+		TTL:     c.Duration("cache.ttl"),
+	}
+
+	// setup the cache
+	//
+	// https://pkg.go.dev/github.com/go-vela/server/cache?tab=doc#New
+	return cache.New(_setup)
+}
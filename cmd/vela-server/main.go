@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-vela/types/constants"
 
+	"github.com/go-vela/server/cache"
 	"github.com/go-vela/server/database"
 	"github.com/go-vela/server/queue"
 	"github.com/go-vela/server/scm"
@@ -146,6 +147,61 @@ func main() {
 			Name:    "github-token",
 			Usage:   "github token, used by compiler, for pulling registry templates",
 		},
+		&cli.DurationFlag{
+			EnvVars: []string{"VELA_COMPILER_TEMPLATE_CACHE_TTL", "COMPILER_TEMPLATE_CACHE_TTL"},
+			Name:    "template-cache-ttl",
+			// nolint: lll // ignore long line length due to description
+			Usage: "template cache ttl, used by compiler, duration before cached github templates for branches are revalidated",
+			Value: 5 * time.Minute,
+		},
+		&cli.IntFlag{
+			EnvVars: []string{"VELA_COMPILER_TEMPLATE_CACHE_SIZE", "COMPILER_TEMPLATE_CACHE_SIZE"},
+			Name:    "template-cache-size",
+			// nolint: lll // ignore long line length due to description
+			Usage: "template cache size, used by compiler, max number of github templates to cache (0 disables the cache)",
+			Value: 1000,
+		},
+		&cli.StringFlag{
+			EnvVars: []string{"VELA_COMPILER_CLONE_IMAGE", "COMPILER_CLONE_IMAGE"},
+			Name:    "clone-image",
+			Usage:   "default image, used by compiler, for the injected clone step",
+			Value:   "target/vela-git:v0.4.0",
+		},
+		&cli.StringSliceFlag{
+			EnvVars: []string{"VELA_COMPILER_CLONE_IMAGE_ALLOWLIST", "COMPILER_CLONE_IMAGE_ALLOWLIST"},
+			Name:    "clone-image-allowlist",
+			// nolint: lll // ignore long line length due to description
+			Usage: "list of image patterns, used by compiler, that pipelines may override the clone image with (no list allows any image)",
+		},
+		&cli.IntFlag{
+			EnvVars: []string{"VELA_COMPILER_MATRIX_MAX_COMBINATIONS", "COMPILER_MATRIX_MAX_COMBINATIONS"},
+			Name:    "matrix-max-combinations",
+			// nolint: lll // ignore long line length due to description
+			Usage: "max combinations, used by compiler, for a matrix in a step or stage (0 disables the limit)",
+			Value: 50,
+		},
+		&cli.StringSliceFlag{
+			EnvVars: []string{"VELA_COMPILER_TEMPLATE_PINNED_ORGS", "COMPILER_TEMPLATE_PINNED_ORGS"},
+			Name:    "template-pinned-orgs",
+			// nolint: lll // ignore long line length due to description
+			Usage: "list of orgs, used by compiler, where repos must pin github templates to a commit SHA and http templates with a digest",
+		},
+		&cli.StringFlag{
+			EnvVars: []string{"VELA_COMPILER_TEMPLATE_FILE_ROOT", "COMPILER_TEMPLATE_FILE_ROOT"},
+			Name:    "template-file-root",
+			Usage:   "root directory, used by compiler, for pulling file registry templates",
+		},
+		&cli.BoolFlag{
+			EnvVars: []string{"VELA_COMPILER_TEMPLATE_HTTP", "COMPILER_TEMPLATE_HTTP"},
+			Name:    "template-http-driver",
+			Usage:   "http compiler driver, used by compiler, for pulling registry templates from https urls",
+		},
+		&cli.StringSliceFlag{
+			EnvVars: []string{"VELA_COMPILER_TEMPLATE_HTTP_HEADERS", "COMPILER_TEMPLATE_HTTP_HEADERS"},
+			Name:    "template-http-headers",
+			// nolint: lll // ignore long line length due to description
+			Usage: "headers, used by compiler, sent when pulling http registry templates from a host in <host>=<name>:<value> format",
+		},
 
 		&cli.StringFlag{
 			EnvVars: []string{"VELA_MODIFICATION_ADDR", "MODIFICATION_ADDR"},
@@ -181,6 +237,10 @@ func main() {
 		},
 	}
 
+	// Cache Flags
+
+	app.Flags = append(app.Flags, cache.Flags...)
+
 	// Database Flags
 
 	app.Flags = append(app.Flags, database.Flags...)
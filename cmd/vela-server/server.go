@@ -54,6 +54,11 @@ func server(c *cli.Context) error {
 		logrus.SetLevel(logrus.PanicLevel)
 	}
 
+	cache, err := setupCache(c)
+	if err != nil {
+		return err
+	}
+
 	compiler, err := setupCompiler(c)
 	if err != nil {
 		return err
@@ -85,6 +90,7 @@ func server(c *cli.Context) error {
 	}
 
 	router := router.Load(
+		middleware.Cache(cache),
 		middleware.Compiler(compiler),
 		middleware.Database(database),
 		middleware.Logger(logrus.StandardLogger(), time.RFC3339, true),
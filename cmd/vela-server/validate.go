@@ -99,5 +99,9 @@ func validateCompiler(c *cli.Context) error {
 		}
 	}
 
+	if len(c.StringSlice("template-http-headers")) > 0 && !c.Bool("template-http-driver") {
+		return fmt.Errorf("template-http-headers (VELA_COMPILER_TEMPLATE_HTTP_HEADERS or COMPILER_TEMPLATE_HTTP_HEADERS) flag provided without template-http-driver (VELA_COMPILER_TEMPLATE_HTTP or COMPILER_TEMPLATE_HTTP)")
+	}
+
 	return nil
 }
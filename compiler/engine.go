@@ -5,6 +5,8 @@
 package compiler
 
 import (
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/scm"
 	"github.com/go-vela/types"
 	"github.com/go-vela/types/library"
 	"github.com/go-vela/types/pipeline"
@@ -38,6 +40,10 @@ type Engine interface {
 	// the yaml configuration is accurate.
 	Validate(*yaml.Build) error
 
+	// Templates defines a function that returns the
+	// templates captured while compiling the pipeline.
+	Templates() []*model.BuildTemplate
+
 	// Clone Compiler Interface Functions
 
 	// CloneStage defines a function that injects the
@@ -81,6 +87,47 @@ type Engine interface {
 	// InitStep step process into a yaml configuration.
 	InitStep(*yaml.Build) (*yaml.Build, error)
 
+	// Policy Compiler Interface Functions
+
+	// PolicyStages defines a function that evaluates the policies
+	// against each step in every stage in a yaml configuration.
+	PolicyStages(*pipeline.RuleData, yaml.StageSlice) (yaml.StageSlice, error)
+	// PolicySteps defines a function that evaluates the policies
+	// against each step in a yaml configuration.
+	PolicySteps(*pipeline.RuleData, yaml.StepSlice) (yaml.StepSlice, error)
+	// PolicyServices defines a function that evaluates the policies
+	// against each service in a yaml configuration.
+	PolicyServices(yaml.ServiceSlice) (yaml.ServiceSlice, error)
+	// PolicySecrets defines a function that evaluates the policies
+	// against the origin of each secret in a yaml configuration.
+	PolicySecrets(yaml.SecretSlice) (yaml.SecretSlice, error)
+	// PolicyResults defines a function that returns the results
+	// of the policies evaluated while compiling the pipeline.
+	PolicyResults() []*model.PolicyResult
+
+	// Purge Compiler Interface Functions
+
+	// PurgeStages defines a function that removes every stage
+	// and step where the pull request of the build doesn't
+	// match the rules provided in the extensions.
+	PurgeStages(yaml.StageSlice) (yaml.StageSlice, error)
+	// PurgeSteps defines a function that removes every step
+	// where the pull request of the build doesn't match the
+	// rules provided in the extensions.
+	PurgeSteps(yaml.StepSlice) (yaml.StepSlice, error)
+
+	// Required Compiler Interface Functions
+
+	// RequiredStages defines a function that injects the
+	// required pipelines into the stages in a yaml configuration.
+	RequiredStages(yaml.StageSlice) (yaml.StageSlice, error)
+	// RequiredSteps defines a function that injects the
+	// required pipelines into the steps in a yaml configuration.
+	RequiredSteps(yaml.StepSlice) (yaml.StepSlice, error)
+	// InjectedSteps defines a function that returns the steps
+	// injected from required pipelines while compiling the pipeline.
+	InjectedSteps() []*model.InjectedStep
+
 	// Script Compiler Interface Functions
 
 	// ScriptStages defines a function that injects the script
@@ -112,12 +159,18 @@ type Engine interface {
 
 	// With Compiler Interface Functions
 
+	// WithAction defines a function that sets
+	// the action of the event in the Engine.
+	WithAction(string) Engine
 	// WithBuild defines a function that sets
 	// the library build type in the Engine.
 	WithBuild(*library.Build) Engine
 	// WithComment defines a function that sets
 	// the comment in the Engine.
 	WithComment(string) Engine
+	// WithCommit defines a function that sets
+	// the commit in the Engine.
+	WithCommit(string) Engine
 	// WithFiles defines a function that sets
 	// the changeset files in the Engine.
 	WithFiles([]string) Engine
@@ -127,9 +180,24 @@ type Engine interface {
 	// WithMetadata defines a function that sets
 	// the compiler Metadata type in the Engine.
 	WithMetadata(*types.Metadata) Engine
+	// WithPolicies defines a function that sets
+	// the policies evaluated while compiling in the Engine.
+	WithPolicies([]*model.Policy) Engine
+	// WithPullRequest defines a function that sets
+	// the pull request of the build in the Engine.
+	WithPullRequest(*model.BuildPullRequest) Engine
 	// WithRepo defines a function that sets
 	// the library repo type in the Engine.
 	WithRepo(*library.Repo) Engine
+	// WithRequiredPipelines defines a function that sets
+	// the required pipelines injected while compiling in the Engine.
+	WithRequiredPipelines([]*model.RequiredPipeline) Engine
+	// WithSCM defines a function that sets the scm
+	// the included files are captured from in the Engine.
+	WithSCM(scm.Service) Engine
+	// WithTemplates defines a function that sets
+	// the templates recorded for a build in the Engine.
+	WithTemplates([]*model.BuildTemplate) Engine
 	// WithUser defines a function that sets
 	// the library user type in the Engine.
 	WithUser(*library.User) Engine
@@ -5,6 +5,9 @@
 package native
 
 import (
+	"fmt"
+	"path"
+
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/yaml"
 )
@@ -28,18 +31,16 @@ func (c *client) CloneStage(p *yaml.Build) (*yaml.Build, error) {
 
 	stages := yaml.StageSlice{}
 
+	// create new clone step
+	step, err := c.cloneStep()
+	if err != nil {
+		return nil, err
+	}
+
 	// create new clone stage
 	clone := &yaml.Stage{
-		Name: cloneStageName,
-		Steps: yaml.StepSlice{
-			&yaml.Step{
-				Detach:     false,
-				Image:      cloneImage,
-				Name:       cloneStepName,
-				Privileged: false,
-				Pull:       constants.PullNotPresent,
-			},
-		},
+		Name:  cloneStageName,
+		Steps: yaml.StepSlice{step},
 	}
 
 	// add clone stage as first stage
@@ -65,12 +66,9 @@ func (c *client) CloneStep(p *yaml.Build) (*yaml.Build, error) {
 	steps := yaml.StepSlice{}
 
 	// create new clone step
-	clone := &yaml.Step{
-		Detach:     false,
-		Image:      cloneImage,
-		Name:       cloneStepName,
-		Privileged: false,
-		Pull:       constants.PullNotPresent,
+	clone, err := c.cloneStep()
+	if err != nil {
+		return nil, err
 	}
 
 	// add clone step as first step
@@ -84,3 +82,78 @@ func (c *client) CloneStep(p *yaml.Build) (*yaml.Build, error) {
 
 	return p, nil
 }
+
+// cloneStep is a helper function that creates the clone step
+// with the image and parameters from the configuration.
+func (c *client) cloneStep() (*yaml.Step, error) {
+	// use the configured image for the clone step
+	image := c.CloneImage
+	if len(image) == 0 {
+		image = cloneImage
+	}
+
+	step := &yaml.Step{
+		Detach:     false,
+		Image:      image,
+		Name:       cloneStepName,
+		Privileged: false,
+		Pull:       constants.PullNotPresent,
+	}
+
+	clone := c.extensions.clone()
+	if clone == nil {
+		return step, nil
+	}
+
+	// override the image for the clone step
+	if len(clone.Image) > 0 {
+		if !c.allowCloneImage(clone.Image) {
+			return nil, fmt.Errorf("clone image %s is not allowed", clone.Image)
+		}
+
+		step.Image = clone.Image
+	}
+
+	// parameters passed to the image for the clone step
+	params := make(map[string]interface{})
+
+	if clone.Depth > 0 {
+		params["depth"] = clone.Depth
+	}
+
+	if clone.Submodules {
+		params["submodules"] = true
+	}
+
+	if clone.LFS {
+		params["lfs"] = true
+	}
+
+	if len(clone.Sparse) > 0 {
+		params["sparse"] = clone.Sparse
+	}
+
+	if len(params) > 0 {
+		step.Parameters = params
+	}
+
+	return step, nil
+}
+
+// allowCloneImage is a helper function that returns true if
+// the image matches a pattern in the list of clone images
+// allowed as an override or if no list is configured.
+func (c *client) allowCloneImage(image string) bool {
+	if len(c.CloneImageAllowlist) == 0 {
+		return true
+	}
+
+	for _, pattern := range c.CloneImageAllowlist {
+		ok, err := path.Match(pattern, image)
+		if err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -191,3 +191,107 @@ func TestNative_CloneStep(t *testing.T) {
 		}
 	}
 }
+
+func TestNative_CloneStep_Configured(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	set.String("clone-image", "mirror.example.com/vela-git:v0.4.0", "doc")
+	set.Var(cli.NewStringSlice("mirror.example.com/*"), "clone-image-allowlist", "doc")
+	c := cli.NewContext(nil, set, nil)
+
+	// setup tests
+	tests := []struct {
+		name    string
+		config  string
+		want    *yaml.Step
+		wantErr bool
+	}{
+		{
+			name: "default",
+			config: `
+version: "1"
+steps:
+  - name: test
+    image: alpine
+`,
+			want: &yaml.Step{
+				Image: "mirror.example.com/vela-git:v0.4.0",
+				Name:  "clone",
+				Pull:  "not_present",
+			},
+		},
+		{
+			name: "object",
+			config: `
+version: "1"
+metadata:
+  clone:
+    image: mirror.example.com/vela-git:v0.5.0
+    depth: 1
+    submodules: true
+    lfs: true
+    sparse: [ api, compiler ]
+steps:
+  - name: test
+    image: alpine
+`,
+			want: &yaml.Step{
+				Image: "mirror.example.com/vela-git:v0.5.0",
+				Name:  "clone",
+				Pull:  "not_present",
+				Parameters: map[string]interface{}{
+					"depth":      1,
+					"submodules": true,
+					"lfs":        true,
+					"sparse":     []string{"api", "compiler"},
+				},
+			},
+		},
+		{
+			name: "image not allowed",
+			config: `
+version: "1"
+metadata:
+  clone:
+    image: target/vela-git:latest
+steps:
+  - name: test
+    image: alpine
+`,
+			wantErr: true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compiler, err := New(c)
+			if err != nil {
+				t.Errorf("Creating compiler returned err: %v", err)
+			}
+
+			p, err := compiler.Parse(test.config)
+			if err != nil {
+				t.Errorf("Parse returned err: %v", err)
+			}
+
+			got, err := compiler.CloneStep(p)
+
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("CloneStep should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("CloneStep returned err: %v", err)
+			}
+
+			if !reflect.DeepEqual(got.Steps[0], test.want) {
+				t.Errorf("CloneStep is %v, want %v", got.Steps[0], test.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,8 @@ import (
 
 	yml "github.com/buildkite/yaml"
 
+	"github.com/go-vela/server/model"
+
 	"github.com/go-vela/types/library"
 	"github.com/go-vela/types/pipeline"
 	"github.com/go-vela/types/raw"
@@ -60,7 +62,7 @@ func (c *client) Compile(v interface{}) (*pipeline.Build, error) {
 	r := &pipeline.RuleData{
 		Branch:  c.build.GetBranch(),
 		Comment: c.comment,
-		Event:   c.build.GetEvent(),
+		Event:   model.EventAction(c.build.GetEvent(), c.action),
 		Path:    c.files,
 		Repo:    c.repo.GetFullName(),
 		Tag:     strings.TrimPrefix(c.build.GetRef(), "refs/tags/"),
@@ -69,7 +71,7 @@ func (c *client) Compile(v interface{}) (*pipeline.Build, error) {
 
 	if len(p.Stages) > 0 {
 		// check if the pipeline disabled the clone
-		if p.Metadata.Clone == nil || *p.Metadata.Clone {
+		if c.clone(p) {
 			// inject the clone stage
 			p, err = c.CloneStage(p)
 			if err != nil {
@@ -83,6 +85,12 @@ func (c *client) Compile(v interface{}) (*pipeline.Build, error) {
 			return nil, err
 		}
 
+		// purge the stages and steps not matching the pull request
+		p.Stages, err = c.PurgeStages(p.Stages)
+		if err != nil {
+			return nil, err
+		}
+
 		// inject the templates into the stages
 		p.Stages, p.Secrets, p.Services, p.Environment, err = c.ExpandStages(p, tmpls)
 		if err != nil {
@@ -97,6 +105,12 @@ func (c *client) Compile(v interface{}) (*pipeline.Build, error) {
 			}
 		}
 
+		// inject the required pipelines into the stages
+		p.Stages, err = c.RequiredStages(p.Stages)
+		if err != nil {
+			return nil, err
+		}
+
 		// validate the yaml configuration
 		err = c.Validate(p)
 		if err != nil {
@@ -150,12 +164,30 @@ func (c *client) Compile(v interface{}) (*pipeline.Build, error) {
 			return nil, err
 		}
 
+		// evaluate the policies against the services
+		p.Services, err = c.PolicyServices(p.Services)
+		if err != nil {
+			return nil, err
+		}
+
+		// evaluate the policies against the secret origins
+		p.Secrets, err = c.PolicySecrets(p.Secrets)
+		if err != nil {
+			return nil, err
+		}
+
+		// evaluate the policies against the stages
+		p.Stages, err = c.PolicyStages(r, p.Stages)
+		if err != nil {
+			return nil, err
+		}
+
 		// return executable representation
 		return c.TransformStages(r, p)
 	}
 
 	// check if the pipeline disabled the clone
-	if p.Metadata.Clone == nil || *p.Metadata.Clone {
+	if c.clone(p) {
 		// inject the clone step
 		p, err = c.CloneStep(p)
 		if err != nil {
@@ -169,6 +201,12 @@ func (c *client) Compile(v interface{}) (*pipeline.Build, error) {
 		return nil, err
 	}
 
+	// purge the steps not matching the pull request
+	p.Steps, err = c.PurgeSteps(p.Steps)
+	if err != nil {
+		return nil, err
+	}
+
 	// inject the templates into the steps
 	p.Steps, p.Secrets, p.Services, p.Environment, err = c.ExpandSteps(p, tmpls)
 	if err != nil {
@@ -183,6 +221,12 @@ func (c *client) Compile(v interface{}) (*pipeline.Build, error) {
 		}
 	}
 
+	// inject the required pipelines into the steps
+	p.Steps, err = c.RequiredSteps(p.Steps)
+	if err != nil {
+		return nil, err
+	}
+
 	// validate the yaml configuration
 	err = c.Validate(p)
 	if err != nil {
@@ -236,10 +280,39 @@ func (c *client) Compile(v interface{}) (*pipeline.Build, error) {
 		return nil, err
 	}
 
+	// evaluate the policies against the services
+	p.Services, err = c.PolicyServices(p.Services)
+	if err != nil {
+		return nil, err
+	}
+
+	// evaluate the policies against the secret origins
+	p.Secrets, err = c.PolicySecrets(p.Secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	// evaluate the policies against the steps
+	p.Steps, err = c.PolicySteps(r, p.Steps)
+	if err != nil {
+		return nil, err
+	}
+
 	// return executable representation
 	return c.TransformSteps(r, p)
 }
 
+// clone is a helper function to check if the clone is
+// injected into the pipeline. The ref of a delete event
+// no longer exists in the repo so it is never cloned.
+func (c *client) clone(p *yaml.Build) bool {
+	if c.build.GetEvent() == model.EventDelete {
+		return false
+	}
+
+	return p.Metadata.Clone == nil || *p.Metadata.Clone
+}
+
 // errorHandler ensures the error contains the number of request attempts.
 func errorHandler(resp *http.Response, err error, attempts int) (*http.Response, error) {
 	if err != nil {
@@ -10,7 +10,6 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
-	"reflect"
 	"testing"
 	"time"
 
@@ -592,6 +591,10 @@ func TestNative_Compile_StagesPipelineTemplate(t *testing.T) {
 	_, engine := gin.CreateTestContext(resp)
 
 	// setup mock server
+	engine.GET("/api/v3/repos/:org/:name/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
 	engine.GET("/api/v3/repos/:org/:name/contents/:path", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 		c.Status(http.StatusOK)
@@ -855,6 +858,10 @@ func TestNative_Compile_StepsPipelineTemplate(t *testing.T) {
 	_, engine := gin.CreateTestContext(resp)
 
 	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
 	engine.GET("/api/v3/repos/foo/bar/contents/:path", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 		c.Status(http.StatusOK)
@@ -1080,6 +1087,10 @@ func TestNative_Compile_InvalidType(t *testing.T) {
 	_, engine := gin.CreateTestContext(resp)
 
 	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
 	engine.GET("/api/v3/repos/foo/bar/contents/:path", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 		c.Status(http.StatusOK)
@@ -1116,80 +1127,6 @@ func TestNative_Compile_InvalidType(t *testing.T) {
 		},
 	}
 
-	gradleEnv := environment(nil, m, nil, nil)
-	gradleEnv["GRADLE_OPTS"] = "-Dorg.gradle.daemon=false -Dorg.gradle.workers.max=1 -Dorg.gradle.parallel=false"
-	gradleEnv["GRADLE_USER_HOME"] = ".gradle"
-
-	dockerEnv := environment(nil, m, nil, nil)
-	dockerEnv["PARAMETER_REGISTRY"] = "index.docker.io"
-	dockerEnv["PARAMETER_REPO"] = "github/octocat"
-	dockerEnv["PARAMETER_TAGS"] = "latest,dev"
-
-	want := &pipeline.Build{
-		Version: "1",
-		ID:      "__0",
-		Metadata: pipeline.Metadata{
-			Clone:       true,
-			Template:    false,
-			Environment: []string{"steps", "services", "secrets"},
-		},
-		Steps: pipeline.ContainerSlice{
-			&pipeline.Container{
-				ID:          "step___0_init",
-				Directory:   "/vela/src/foo//",
-				Environment: environment(nil, m, nil, nil),
-				Image:       "#init",
-				Name:        "init",
-				Number:      1,
-				Pull:        "not_present",
-			},
-			&pipeline.Container{
-				ID:          "step___0_clone",
-				Directory:   "/vela/src/foo//",
-				Environment: environment(nil, m, nil, nil),
-				Image:       "target/vela-git:v0.4.0",
-				Name:        "clone",
-				Number:      2,
-				Pull:        "not_present",
-			},
-			&pipeline.Container{
-				ID:          "step___0_docker",
-				Directory:   "/vela/src/foo//",
-				Image:       "plugins/docker:18.09",
-				Environment: dockerEnv,
-				Name:        "docker",
-				Number:      3,
-				Pull:        "always",
-				Secrets: pipeline.StepSecretSlice{
-					&pipeline.StepSecret{
-						Source: "docker_username",
-						Target: "registry_username",
-					},
-					&pipeline.StepSecret{
-						Source: "docker_password",
-						Target: "registry_password",
-					},
-				},
-			},
-		},
-		Secrets: pipeline.SecretSlice{
-			&pipeline.Secret{
-				Name:   "docker_username",
-				Key:    "org/repo/docker/username",
-				Engine: "native",
-				Type:   "repo",
-				Origin: &pipeline.Container{},
-			},
-			&pipeline.Secret{
-				Name:   "docker_password",
-				Key:    "org/repo/docker/password",
-				Engine: "vault",
-				Type:   "repo",
-				Origin: &pipeline.Container{},
-			},
-		},
-	}
-
 	// run test
 	yaml, err := ioutil.ReadFile("testdata/invalid_type.yml")
 	if err != nil {
@@ -1204,12 +1141,12 @@ func TestNative_Compile_InvalidType(t *testing.T) {
 	compiler.WithMetadata(m)
 
 	got, err := compiler.Compile(yaml)
-	if err != nil {
-		t.Errorf("Compile returned err: %v", err)
+	if err == nil {
+		t.Errorf("Compile should have returned err")
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("Compile is %v, want %v", got, want)
+	if got != nil {
+		t.Errorf("Compile is %v, want %v", got, nil)
 	}
 }
 
@@ -1400,6 +1337,92 @@ func TestNative_Compile_Clone(t *testing.T) {
 	}
 }
 
+func TestNative_Compile_Action(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	set.Bool("github-driver", true, "doc")
+	set.String("github-token", "", "doc")
+	c := cli.NewContext(nil, set, nil)
+
+	m := &types.Metadata{
+		Database: &types.Database{
+			Driver: "foo",
+			Host:   "foo",
+		},
+		Queue: &types.Queue{
+			Channel: "foo",
+			Driver:  "foo",
+			Host:    "foo",
+		},
+		Source: &types.Source{
+			Driver: "foo",
+			Host:   "foo",
+		},
+		Vela: &types.Vela{
+			Address:    "foo",
+			WebAddress: "foo",
+		},
+	}
+
+	// setup tests
+	tests := []struct {
+		name   string
+		event  string
+		action string
+		want   []string
+	}{
+		{
+			name:  "pull_request",
+			event: "pull_request",
+			want:  []string{"init", "clone", "test"},
+		},
+		{
+			name:   "pull_request closed",
+			event:  "pull_request",
+			action: "closed",
+			want:   []string{"init", "clone", "test", "cleanup"},
+		},
+		{ // the deleted ref is never cloned
+			name:  "delete",
+			event: "delete",
+			want:  []string{"init", "test", "cleanup"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			yaml, err := ioutil.ReadFile("testdata/action.yml")
+			if err != nil {
+				t.Errorf("Reading yaml file return err: %v", err)
+			}
+
+			b := new(library.Build)
+			b.SetEvent(test.event)
+			b.SetRef("refs/pull/1/head")
+
+			compiler, err := New(c)
+			if err != nil {
+				t.Errorf("Creating compiler returned err: %v", err)
+			}
+
+			got, err := compiler.WithBuild(b).WithAction(test.action).WithMetadata(m).Compile(yaml)
+			if err != nil {
+				t.Errorf("Compile returned err: %v", err)
+			}
+
+			names := []string{}
+			for _, step := range got.Steps {
+				names = append(names, step.Name)
+			}
+
+			if diff := cmp.Diff(test.want, names); diff != "" {
+				t.Errorf("Compile() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestNative_Compile_Pipeline_Type(t *testing.T) {
 	// setup types
 	set := flag.NewFlagSet("test", 0)
@@ -1683,6 +1706,10 @@ func Test_client_modifyConfig(t *testing.T) {
 	_, engine := gin.CreateTestContext(resp)
 
 	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
 	engine.GET("/api/v3/repos/foo/bar/contents/:path", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 		c.Status(http.StatusOK)
@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/go-vela/server/compiler/registry"
 	"github.com/go-vela/server/compiler/template/native"
 	"github.com/go-vela/server/compiler/template/starlark"
 	"github.com/spf13/afero"
 
+	"github.com/go-vela/types/library"
 	"github.com/go-vela/types/raw"
 	"github.com/go-vela/types/yaml"
 	"github.com/sirupsen/logrus"
@@ -22,6 +24,14 @@ import (
 //
 // nolint: lll // ignore long line length due to variable names
 func (c *client) ExpandStages(s *yaml.Build, tmpls map[string]*yaml.Template) (yaml.StageSlice, yaml.SecretSlice, yaml.ServiceSlice, raw.StringSliceMap, error) {
+	// create the variants for the stages and steps with a matrix
+	stages, err := c.expandMatrixStages(s.Stages)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	s.Stages = stages
+
 	// iterate through all stages
 	for _, stage := range s.Stages {
 		// inject the templates into the steps for the stage
@@ -52,10 +62,18 @@ func (c *client) ExpandSteps(s *yaml.Build, tmpls map[string]*yaml.Template) (ya
 		environment = make(raw.StringSliceMap)
 	}
 
+	// create the variants for the steps with a matrix
+	matrixSteps, err := c.expandMatrixSteps(s.Steps, c.extensions.steps(""))
+	if err != nil {
+		return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, err
+	}
+
 	// iterate through each step
-	for _, step := range s.Steps {
+	for _, step := range matrixSteps {
 		// nolint: ineffassign,staticcheck // ignore ineffectual assignment
 		bytes := []byte{}
+		// ref and commit will hold the version the template was captured from
+		ref, commit := "", ""
 
 		// skip if no template is provided for the step
 		if len(step.Template.Name) == 0 {
@@ -103,35 +121,50 @@ func (c *client) ExpandSteps(s *yaml.Build, tmpls map[string]*yaml.Template) (ya
 				return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, fmt.Errorf("invalid template source provided for %s: %v", step.Template.Name, err)
 			}
 
-			// pull from github without auth when the host isn't provided or is set to github.com
-			if !c.UsePrivateGithub && (len(src.Host) == 0 || strings.Contains(src.Host, "github.com")) {
-				logrus.WithFields(logrus.Fields{
-					"org":  src.Org,
-					"repo": src.Repo,
-					"path": src.Name,
-					"host": src.Host,
-				}).Tracef("Using GitHub client to pull template")
-				bytes, err = c.Github.Template(nil, src)
-				if err != nil {
-					return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, err
-				}
-			} else {
-				logrus.WithFields(logrus.Fields{
-					"org":  src.Org,
-					"repo": src.Repo,
-					"path": src.Name,
-					"host": src.Host,
-				}).Tracef("Using authenticated GitHub client to pull template")
-				// use private (authenticated) github instance to pull from
-				bytes, err = c.PrivateGithub.Template(c.user, src)
-				if err != nil {
-					return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, err
-				}
+			ref = src.Ref
+
+			// capture the template from the commit recorded for it
+			if pinned := c.pinnedCommit(tmpl.Name); len(pinned) > 0 {
+				src.Ref = pinned
+			}
+
+			bytes, commit, err = c.getGithubTemplate(src)
+			if err != nil {
+				return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, err
+			}
+
+		case strings.EqualFold(tmpl.Type, "file"):
+			// ensure the file registry is enabled
+			if c.File == nil {
+				return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, fmt.Errorf("template type %s is not enabled for template %s", tmpl.Type, step.Template.Name)
+			}
+
+			bytes, err = getTemplate(c.File, tmpl, step)
+			if err != nil {
+				return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, err
+			}
+
+		case strings.EqualFold(tmpl.Type, "http"):
+			// ensure the http registry is enabled
+			if c.HTTP == nil {
+				return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, fmt.Errorf("template type %s is not enabled for template %s", tmpl.Type, step.Template.Name)
+			}
+
+			bytes, err = getTemplate(c.HTTP, tmpl, step)
+			if err != nil {
+				return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, err
 			}
 
 		default:
-			logrus.Errorf("Unsupported template type: %v", tmpl.Type)
-			continue
+			return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, fmt.Errorf("unsupported template type %s for template %s", tmpl.Type, step.Template.Name)
+		}
+
+		// verify and record the template captured from the registry
+		if !c.local {
+			err = c.verifyTemplate(tmpl, ref, commit, bytes)
+			if err != nil {
+				return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, err
+			}
 		}
 
 		var tmplSteps yaml.StepSlice
@@ -149,7 +182,7 @@ func (c *client) ExpandSteps(s *yaml.Build, tmpls map[string]*yaml.Template) (ya
 			}
 		case "starlark":
 			// render template for steps
-			tmplSteps, tmplSecrets, tmplServices, tmplEnvironment, err = starlark.RenderStep(string(bytes), step)
+			tmplSteps, tmplSecrets, tmplServices, tmplEnvironment, err = starlark.RenderStep(string(bytes), step, c.starlarkLoader())
 			if err != nil {
 				return yaml.StepSlice{}, yaml.SecretSlice{}, yaml.ServiceSlice{}, raw.StringSliceMap{}, err
 			}
@@ -211,6 +244,120 @@ func (c *client) ExpandSteps(s *yaml.Build, tmpls map[string]*yaml.Template) (ya
 	return steps, secrets, services, environment, nil
 }
 
+// getTemplate is a helper function that captures the
+// contents of a template from the provided registry.
+func getTemplate(r registry.Service, tmpl *yaml.Template, step *yaml.Step) ([]byte, error) {
+	// parse source from template
+	src, err := r.Parse(tmpl.Source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template source provided for %s: %v", step.Template.Name, err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"type": tmpl.Type,
+		"path": src.Name,
+		"host": src.Host,
+	}).Tracef("Using %s client to pull template", tmpl.Type)
+
+	return r.Template(nil, src)
+}
+
+// getGithubTemplate is a helper function that captures the contents
+// of a template or module from the GitHub registry. The contents are
+// captured from the commit the reference resolves to so they always
+// match the commit returned for the template.
+func (c *client) getGithubTemplate(src *registry.Source) ([]byte, string, error) {
+	// use the public github instance without auth by default
+	r, u := c.Github, (*library.User)(nil)
+
+	// pull from github without auth when the host isn't provided or is set to github.com
+	if !c.UsePrivateGithub && (len(src.Host) == 0 || strings.Contains(src.Host, "github.com")) {
+		logrus.WithFields(logrus.Fields{
+			"org":  src.Org,
+			"repo": src.Repo,
+			"path": src.Name,
+			"host": src.Host,
+		}).Tracef("Using GitHub client to pull template")
+	} else {
+		logrus.WithFields(logrus.Fields{
+			"org":  src.Org,
+			"repo": src.Repo,
+			"path": src.Name,
+			"host": src.Host,
+		}).Tracef("Using authenticated GitHub client to pull template")
+
+		// use private (authenticated) github instance to pull from
+		r, u = c.PrivateGithub, c.user
+	}
+
+	// resolve the commit for the reference of the template
+	commit, err := r.Commit(u, src)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// capture the template from the resolved commit
+	pinned := *src
+	pinned.Ref = commit
+
+	bytes, err := r.Template(u, &pinned)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return bytes, commit, nil
+}
+
+// starlarkLoader is a helper function that returns the loader used
+// to resolve load() statements in Starlark templates. The loader is
+// shared for the lifetime of the Engine so modules are only fetched
+// and executed once per compilation.
+func (c *client) starlarkLoader() *starlark.Loader {
+	if c.loader == nil {
+		c.loader = starlark.NewLoader(c.getModule)
+	}
+
+	return c.loader
+}
+
+// getModule is a helper function that captures the contents of a
+// Starlark module from the path provided to a load() statement.
+func (c *client) getModule(module string) ([]byte, error) {
+	// read the module from the filesystem for local compilations
+	if c.local {
+		a := &afero.Afero{
+			Fs: afero.NewOsFs(),
+		}
+
+		return a.ReadFile(module)
+	}
+
+	// parse source from module path
+	src, err := c.Github.Parse(module)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module source provided for %s: %v", module, err)
+	}
+
+	bytes, commit, err := c.getGithubTemplate(src)
+	if err != nil {
+		return nil, err
+	}
+
+	// verify and record the module the same as a template
+	tmpl := &yaml.Template{
+		Name:   module,
+		Source: module,
+		Type:   "github",
+	}
+
+	err = c.verifyTemplate(tmpl, src.Ref, commit, bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
+
 // helper function that creates a map of templates from a yaml configuration.
 func mapFromTemplates(templates []*yaml.Template) map[string]*yaml.Template {
 	m := make(map[string]*yaml.Template)
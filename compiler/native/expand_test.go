@@ -27,6 +27,10 @@ func TestNative_ExpandStages(t *testing.T) {
 	_, engine := gin.CreateTestContext(resp)
 
 	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
 	engine.GET("/api/v3/repos/foo/bar/contents/:path", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 		c.Status(http.StatusOK)
@@ -173,6 +177,10 @@ func TestNative_ExpandSteps(t *testing.T) {
 	_, engine := gin.CreateTestContext(resp)
 
 	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
 	engine.GET("/api/v3/repos/foo/bar/contents/:path", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 		c.Status(http.StatusOK)
@@ -316,11 +324,19 @@ func TestNative_ExpandStepsMulti(t *testing.T) {
 	_, engine := gin.CreateTestContext(resp)
 
 	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
 	engine.GET("/api/v3/repos/foo/bar/contents/:path", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 		c.Status(http.StatusOK)
 		c.File("testdata/template-gradle.json")
 	})
+	engine.GET("/api/v3/repos/bar/foo/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
 	engine.GET("/api/v3/repos/bar/foo/contents/:path", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 		c.Status(http.StatusOK)
@@ -550,6 +566,10 @@ func TestNative_ExpandStepsStarlark(t *testing.T) {
 	_, engine := gin.CreateTestContext(resp)
 
 	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
 	engine.GET("/api/v3/repos/foo/bar/contents/:path", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 		c.Status(http.StatusOK)
@@ -629,6 +649,191 @@ func TestNative_ExpandStepsStarlark(t *testing.T) {
 	}
 }
 
+func TestNative_ExpandStepsStarlarkLoad(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	// track the number of requests for the module
+	moduleRequests := 0
+
+	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
+	engine.GET("/api/v3/repos/foo/bar/contents/:path", func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+
+		if c.Param("path") == "lib.star" {
+			moduleRequests++
+			c.File("testdata/template-starlark-lib.json")
+
+			return
+		}
+
+		c.File("testdata/template-starlark-load.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	set.Bool("github-driver", true, "doc")
+	set.String("github-url", s.URL, "doc")
+	set.String("github-token", "", "doc")
+	c := cli.NewContext(nil, set, nil)
+
+	tmpls := map[string]*yaml.Template{
+		"go": {
+			Name:   "go",
+			Source: "github.example.com/foo/bar/template-load.star",
+			Format: "starlark",
+			Type:   "github",
+		},
+	}
+
+	steps := yaml.StepSlice{
+		&yaml.Step{
+			Name: "sample",
+			Template: yaml.StepTemplate{
+				Name:      "go",
+				Variables: map[string]interface{}{},
+			},
+		},
+		&yaml.Step{
+			Name: "other",
+			Template: yaml.StepTemplate{
+				Name:      "go",
+				Variables: map[string]interface{}{},
+			},
+		},
+	}
+
+	wantSteps := yaml.StepSlice{
+		&yaml.Step{
+			Commands: []string{"go build", "go test"},
+			Image:    "golang:latest",
+			Name:     "sample_build",
+			Pull:     "not_present",
+		},
+		&yaml.Step{
+			Commands: []string{"go build", "go test"},
+			Image:    "golang:latest",
+			Name:     "other_build",
+			Pull:     "not_present",
+		},
+	}
+
+	// run test
+	compiler, err := New(c)
+	if err != nil {
+		t.Errorf("Creating new compiler returned err: %v", err)
+	}
+
+	steps, _, _, _, err = compiler.ExpandSteps(&yaml.Build{Steps: steps, Secrets: yaml.SecretSlice{}, Services: yaml.ServiceSlice{}, Environment: raw.StringSliceMap{}}, tmpls)
+	if err != nil {
+		t.Errorf("ExpandSteps returned err: %v", err)
+	}
+
+	if diff := cmp.Diff(steps, wantSteps); diff != "" {
+		t.Errorf("ExpandSteps() mismatch (-want +got):\n%s", diff)
+	}
+
+	if moduleRequests != 1 {
+		t.Errorf("ExpandSteps requested module %d times, want 1", moduleRequests)
+	}
+}
+
+func TestNative_ExpandStepsFile(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	set.String("template-file-root", "testdata", "doc")
+	c := cli.NewContext(nil, set, nil)
+
+	steps := yaml.StepSlice{
+		&yaml.Step{
+			Name: "sample",
+			Template: yaml.StepTemplate{
+				Name: "gradle",
+				Variables: map[string]interface{}{
+					"image":       "openjdk:latest",
+					"environment": "{ GRADLE_USER_HOME: .gradle }",
+					"pull_policy": "pull: true",
+				},
+			},
+		},
+	}
+
+	// setup tests
+	tests := []struct {
+		name    string
+		tmpl    *yaml.Template
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "file",
+			tmpl: &yaml.Template{Name: "gradle", Source: "template.yml", Type: "file"},
+			want: []string{"sample_install", "sample_test", "sample_build"},
+		},
+		{
+			name:    "file outside root",
+			tmpl:    &yaml.Template{Name: "gradle", Source: "../expand.go", Type: "file"},
+			wantErr: true,
+		},
+		{
+			name:    "http not enabled",
+			tmpl:    &yaml.Template{Name: "gradle", Source: "https://templates.example.com/template.yml", Type: "http"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported type",
+			tmpl:    &yaml.Template{Name: "gradle", Source: "template.yml", Type: "bla"},
+			wantErr: true,
+		},
+	}
+
+	compiler, err := New(c)
+	if err != nil {
+		t.Errorf("Creating new compiler returned err: %v", err)
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpls := map[string]*yaml.Template{"gradle": test.tmpl}
+
+			got, _, _, _, err := compiler.ExpandSteps(&yaml.Build{Steps: steps, Services: yaml.ServiceSlice{}, Environment: raw.StringSliceMap{}}, tmpls)
+
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("ExpandSteps should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ExpandSteps returned err: %v", err)
+			}
+
+			names := []string{}
+			for _, step := range got {
+				names = append(names, step.Name)
+			}
+
+			if diff := cmp.Diff(test.want, names); diff != "" {
+				t.Errorf("ExpandSteps() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestNative_mapFromTemplates(t *testing.T) {
 	// setup types
 	str := "foo"
@@ -0,0 +1,152 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"fmt"
+
+	"github.com/buildkite/yaml"
+)
+
+type (
+	// extensions represents the fields in a yaml configuration
+	// supported by the compiler that aren't part of the yaml types.
+	extensions struct {
+		Metadata  metadataExtension          `yaml:"metadata,omitempty"`
+		Stages    map[string]*stageExtension `yaml:"stages,omitempty"`
+		Steps     []*stepExtension           `yaml:"steps,omitempty"`
+		Templates []*templateExtension       `yaml:"templates,omitempty"`
+	}
+
+	// metadataExtension represents the fields for
+	// the metadata that aren't part of the yaml types.
+	metadataExtension struct {
+		Clone *cloneExtension `yaml:"clone,omitempty"`
+	}
+
+	// cloneExtension represents the clone field for the
+	// metadata when it is provided as an object to
+	// configure the injected clone step.
+	cloneExtension struct {
+		Image      string   `yaml:"image,omitempty"`
+		Depth      int      `yaml:"depth,omitempty"`
+		Submodules bool     `yaml:"submodules,omitempty"`
+		LFS        bool     `yaml:"lfs,omitempty"`
+		Sparse     []string `yaml:"sparse,omitempty"`
+
+		// object is set when the clone field isn't a boolean
+		object bool
+	}
+
+	// stageExtension represents the fields for
+	// a stage that aren't part of the yaml types.
+	stageExtension struct {
+		Matrix  *matrix           `yaml:"matrix,omitempty"`
+		Ruleset *rulesetExtension `yaml:"ruleset,omitempty"`
+		Steps   []*stepExtension  `yaml:"steps,omitempty"`
+	}
+
+	// stepExtension represents the fields for
+	// a step that aren't part of the yaml types.
+	stepExtension struct {
+		Name    string            `yaml:"name,omitempty"`
+		Matrix  *matrix           `yaml:"matrix,omitempty"`
+		Ruleset *rulesetExtension `yaml:"ruleset,omitempty"`
+	}
+
+	// templateExtension represents the fields for
+	// a template that aren't part of the yaml types.
+	templateExtension struct {
+		Name   string `yaml:"name,omitempty"`
+		Digest string `yaml:"digest,omitempty"`
+	}
+)
+
+// parseExtensions is a helper function that captures the
+// extensions from a raw yaml configuration.
+func parseExtensions(raw string) (*extensions, error) {
+	e := new(extensions)
+
+	err := yaml.Unmarshal([]byte(raw), e)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal yaml: %v", err)
+	}
+
+	return e, nil
+}
+
+// UnmarshalYAML implements the Unmarshaler interface for the cloneExtension type.
+func (c *cloneExtension) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// the clone field provided as a boolean is part of the yaml types
+	enabled := false
+	if unmarshal(&enabled) == nil {
+		return nil
+	}
+
+	// alias the type to avoid recursively unmarshalling
+	type plain cloneExtension
+
+	err := unmarshal((*plain)(c))
+	if err != nil {
+		return fmt.Errorf("metadata clone must be a boolean or an object: %v", err)
+	}
+
+	c.object = true
+
+	return nil
+}
+
+// clone returns the configuration provided for the clone step.
+func (e *extensions) clone() *cloneExtension {
+	// only return the clone field provided as an object
+	if e == nil || e.Metadata.Clone == nil || !e.Metadata.Clone.object {
+		return nil
+	}
+
+	return e.Metadata.Clone
+}
+
+// digest returns the digest provided for the template.
+func (e *extensions) digest(name string) string {
+	if e == nil {
+		return ""
+	}
+
+	for _, tmpl := range e.Templates {
+		if tmpl.Name == name {
+			return tmpl.Digest
+		}
+	}
+
+	return ""
+}
+
+// stage returns the extensions provided for the stage.
+func (e *extensions) stage(name string) *stageExtension {
+	if e == nil {
+		return nil
+	}
+
+	return e.Stages[name]
+}
+
+// steps returns the extensions provided for the steps
+// in the stage or for the pipeline without stages.
+func (e *extensions) steps(stage string) []*stepExtension {
+	if len(stage) == 0 {
+		if e == nil {
+			return nil
+		}
+
+		return e.Steps
+	}
+
+	s := e.stage(stage)
+	if s == nil {
+		return nil
+	}
+
+	return s.Steps
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNative_parseExtensions(t *testing.T) {
+	// setup types
+	config := `
+version: "1"
+
+templates:
+  - name: go
+    source: github.com/octocat/hello-world/.vela/build.yml@7fd1a60b01f91b314f59955a4e4d4e80d8edf11d
+    digest: sha256:6e3f2c6e4b1f0c7ed0b0b26d5a0f1f2dfb3cbd2a0ef4b6d33c4d62ba1c1e0a59
+    type: github
+
+stages:
+  test:
+    matrix:
+      os: [ linux, darwin ]
+    steps:
+      - name: test
+        image: golang:latest
+        matrix:
+          go: [ 1.17, 1.18 ]
+          exclude:
+            - go: 1.17
+        commands:
+          - go test ./...
+`
+
+	want := &extensions{
+		Stages: map[string]*stageExtension{
+			"test": {
+				Matrix: &matrix{
+					Axes: map[string][]string{"os": {"linux", "darwin"}},
+				},
+				Steps: []*stepExtension{
+					{
+						Name: "test",
+						Matrix: &matrix{
+							Axes:    map[string][]string{"go": {"1.17", "1.18"}},
+							Exclude: []map[string]string{{"go": "1.17"}},
+						},
+					},
+				},
+			},
+		},
+		Templates: []*templateExtension{
+			{
+				Name:   "go",
+				Digest: "sha256:6e3f2c6e4b1f0c7ed0b0b26d5a0f1f2dfb3cbd2a0ef4b6d33c4d62ba1c1e0a59",
+			},
+		},
+	}
+
+	// run test
+	got, err := parseExtensions(config)
+	if err != nil {
+		t.Errorf("parseExtensions returned err: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(extensions{})); diff != "" {
+		t.Errorf("parseExtensions() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got.digest("go") != want.Templates[0].Digest {
+		t.Errorf("digest is %s, want %s", got.digest("go"), want.Templates[0].Digest)
+	}
+
+	if len(got.steps("test")) != 1 {
+		t.Errorf("steps returned %d steps, want 1", len(got.steps("test")))
+	}
+
+	if len(got.steps("")) != 0 {
+		t.Errorf("steps returned %d steps, want 0", len(got.steps("")))
+	}
+}
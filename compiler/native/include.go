@@ -0,0 +1,350 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/buildkite/yaml"
+	"github.com/spf13/afero"
+)
+
+// includeKey is the field in a yaml configuration
+// listing the files to include in the pipeline.
+const includeKey = "include"
+
+// includeFields are the fields in a yaml configuration
+// that can be provided by an included file.
+var includeFields = map[string]bool{
+	"environment": true,
+	"secrets":     true,
+	"services":    true,
+	"stages":      true,
+	"steps":       true,
+}
+
+// include merges the files listed in the include field
+// of a raw yaml configuration into the configuration.
+// The files are captured from the same repo and commit
+// as the configuration, or from the filesystem for
+// local compilations.
+func (c *client) include(raw string) (string, error) {
+	config := yaml.MapSlice{}
+
+	err := yaml.Unmarshal([]byte(raw), &config)
+	if err != nil {
+		return "", fmt.Errorf("unable to unmarshal yaml: %v", err)
+	}
+
+	// capture the files to include from the configuration
+	paths, config, err := includePaths(config)
+	if err != nil {
+		return "", err
+	}
+
+	// return the configuration when no files are included
+	if paths == nil {
+		return raw, nil
+	}
+
+	for _, p := range paths {
+		data, err := c.getInclude(p)
+		if err != nil {
+			return "", fmt.Errorf("unable to capture include %s: %v", p, err)
+		}
+
+		fragment := yaml.MapSlice{}
+
+		err = yaml.Unmarshal(data, &fragment)
+		if err != nil {
+			return "", fmt.Errorf("unable to unmarshal include %s: %v", p, err)
+		}
+
+		config, err = mergeInclude(config, fragment)
+		if err != nil {
+			return "", fmt.Errorf("unable to merge include %s: %v", p, err)
+		}
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal yaml: %v", err)
+	}
+
+	return string(out), nil
+}
+
+// includePaths is a helper function to capture the paths from the
+// include field and remove the field from the yaml configuration.
+func includePaths(config yaml.MapSlice) ([]string, yaml.MapSlice, error) {
+	for i, item := range config {
+		if item.Key != includeKey {
+			continue
+		}
+
+		values, ok := item.Value.([]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("include must be a list of paths")
+		}
+
+		paths := []string{}
+
+		for _, value := range values {
+			p, ok := value.(string)
+			if !ok || len(p) == 0 {
+				return nil, nil, fmt.Errorf("include must be a list of paths")
+			}
+
+			// only allow relative paths within the repo
+			clean := path.Clean(p)
+			if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+				return nil, nil, fmt.Errorf("include %s must be a relative path in the repo", p)
+			}
+
+			paths = append(paths, clean)
+		}
+
+		return paths, append(config[:i:i], config[i+1:]...), nil
+	}
+
+	return nil, config, nil
+}
+
+// mergeInclude is a helper function to merge the fields from
+// an included file into a yaml configuration. An error is
+// returned when a field from the included file conflicts with
+// the configuration.
+//
+// nolint: gocyclo // ignore cyclomatic complexity due to merge rules
+func mergeInclude(config, fragment yaml.MapSlice) (yaml.MapSlice, error) {
+	for _, item := range fragment {
+		key, _ := item.Key.(string)
+
+		if key == includeKey {
+			return nil, fmt.Errorf("nested include is not supported")
+		}
+
+		if !includeFields[key] {
+			return nil, fmt.Errorf("field %v is not supported in an include", item.Key)
+		}
+
+		// steps and stages can't be combined in a pipeline
+		if key == "steps" && hasField(config, "stages") {
+			return nil, fmt.Errorf("steps can not be included in a pipeline with stages")
+		}
+
+		if key == "stages" && hasField(config, "steps") {
+			return nil, fmt.Errorf("stages can not be included in a pipeline with steps")
+		}
+
+		i := fieldIndex(config, key)
+		if i < 0 {
+			config = append(config, item)
+
+			continue
+		}
+
+		var err error
+
+		switch key {
+		case "environment":
+			config[i].Value, err = mergeEnvironment(config[i].Value, item.Value)
+		case "stages":
+			config[i].Value, err = mergeStages(config[i].Value, item.Value)
+		default:
+			config[i].Value, err = mergeNamed(key, config[i].Value, item.Value)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+// mergeEnvironment is a helper function to merge the environment
+// from an included file into the environment of a configuration.
+// A variable provided with a different value is a conflict.
+func mergeEnvironment(base, include interface{}) (interface{}, error) {
+	env, err := environmentSlice(base)
+	if err != nil {
+		return nil, err
+	}
+
+	includeEnv, err := environmentSlice(include)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range includeEnv {
+		i := fieldIndex(env, item.Key)
+		if i < 0 {
+			env = append(env, item)
+
+			continue
+		}
+
+		if fmt.Sprint(env[i].Value) != fmt.Sprint(item.Value) {
+			return nil, fmt.Errorf("environment variable %v already exists with a different value", item.Key)
+		}
+	}
+
+	return env, nil
+}
+
+// environmentSlice is a helper function to convert the environment
+// provided as a map or a list of KEY=VALUE strings to a map.
+func environmentSlice(v interface{}) (yaml.MapSlice, error) {
+	switch env := v.(type) {
+	case nil:
+		return yaml.MapSlice{}, nil
+	case yaml.MapSlice:
+		return env, nil
+	case []interface{}:
+		out := yaml.MapSlice{}
+
+		for _, value := range env {
+			parts := strings.SplitN(fmt.Sprint(value), "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("environment variable %v must be provided as KEY=VALUE", value)
+			}
+
+			out = append(out, yaml.MapItem{Key: parts[0], Value: parts[1]})
+		}
+
+		return out, nil
+	default:
+		return nil, fmt.Errorf("environment must be a map or a list")
+	}
+}
+
+// mergeStages is a helper function to merge the stages from an
+// included file into the stages of a configuration. A stage
+// provided with the same name is a conflict.
+func mergeStages(base, include interface{}) (interface{}, error) {
+	stages, ok := base.(yaml.MapSlice)
+	if !ok {
+		return nil, fmt.Errorf("stages must be a map")
+	}
+
+	includeStages, ok := include.(yaml.MapSlice)
+	if !ok {
+		return nil, fmt.Errorf("stages must be a map")
+	}
+
+	for _, stage := range includeStages {
+		if fieldIndex(stages, stage.Key) >= 0 {
+			return nil, fmt.Errorf("stage %v already exists", stage.Key)
+		}
+
+		stages = append(stages, stage)
+	}
+
+	return stages, nil
+}
+
+// mergeNamed is a helper function to merge a list of named
+// entries, like steps, services and secrets, from an included
+// file into a configuration. An entry provided with the same
+// name is a conflict.
+func mergeNamed(field string, base, include interface{}) (interface{}, error) {
+	entries, ok := base.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list", field)
+	}
+
+	includeEntries, ok := include.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list", field)
+	}
+
+	names := make(map[string]bool)
+
+	for _, entry := range entries {
+		names[entryName(entry)] = true
+	}
+
+	for _, entry := range includeEntries {
+		name := entryName(entry)
+
+		if len(name) > 0 && names[name] {
+			return nil, fmt.Errorf("%s %s already exists", strings.TrimSuffix(field, "s"), name)
+		}
+
+		names[name] = true
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// entryName is a helper function to capture
+// the name of an entry in a list.
+func entryName(entry interface{}) string {
+	fields, ok := entry.(yaml.MapSlice)
+	if !ok {
+		return ""
+	}
+
+	i := fieldIndex(fields, "name")
+	if i < 0 {
+		return ""
+	}
+
+	return fmt.Sprint(fields[i].Value)
+}
+
+// hasField is a helper function to determine
+// if a field is provided in a yaml map.
+func hasField(m yaml.MapSlice, key string) bool {
+	return fieldIndex(m, key) >= 0
+}
+
+// fieldIndex is a helper function to capture the
+// index of a field in a yaml map or -1 when the
+// field is not provided.
+func fieldIndex(m yaml.MapSlice, key interface{}) int {
+	for i, item := range m {
+		if item.Key == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// getInclude is a helper function to capture the contents of a
+// file included in the pipeline from the same repo and commit.
+// The file is captured from the scm of the repo with the token
+// of the user the pipeline is compiled for.
+func (c *client) getInclude(p string) ([]byte, error) {
+	// read the file from the filesystem for local compilations
+	if c.local {
+		a := &afero.Afero{
+			Fs: afero.NewOsFs(),
+		}
+
+		return a.ReadFile(p)
+	}
+
+	if c.scm == nil {
+		return nil, fmt.Errorf("no scm provided to capture includes")
+	}
+
+	return c.scm.ConfigFile(c.user, c.repo, c.includeRef(), p)
+}
+
+// includeRef is a helper function to capture the reference
+// the files included in the pipeline are captured from.
+func (c *client) includeRef() string {
+	if len(c.commit) > 0 {
+		return c.commit
+	}
+
+	return c.build.GetCommit()
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/scm/github"
+
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/raw"
+	"github.com/go-vela/types/yaml"
+	"github.com/google/go-cmp/cmp"
+
+	yml "github.com/buildkite/yaml"
+	"github.com/gin-gonic/gin"
+	"github.com/urfave/cli/v2"
+)
+
+func TestNative_Parse_Include_Local(t *testing.T) {
+	// setup types
+	client, _ := New(cli.NewContext(nil, flag.NewFlagSet("test", 0), nil))
+	client.WithLocal(true)
+
+	want := &yaml.Build{
+		Version: "1",
+		Environment: raw.StringSliceMap{
+			"GOOS":   "linux",
+			"GOARCH": "amd64",
+		},
+		Steps: yaml.StepSlice{
+			&yaml.Step{
+				Name:     "install",
+				Commands: raw.StringSlice{"go get ./..."},
+				Image:    "golang:latest",
+				Pull:     "not_present",
+			},
+			&yaml.Step{
+				Name:     "test",
+				Commands: raw.StringSlice{"go test ./..."},
+				Image:    "golang:latest",
+				Pull:     "not_present",
+			},
+		},
+		Secrets: yaml.SecretSlice{
+			&yaml.Secret{
+				Name:   "docker_username",
+				Key:    "org/repo/docker/username",
+				Engine: "native",
+				Type:   "repo",
+			},
+		},
+	}
+
+	// run test
+	b, err := ioutil.ReadFile("testdata/include.yml")
+	if err != nil {
+		t.Errorf("Reading file returned err: %v", err)
+	}
+
+	got, err := client.Parse(b)
+	if err != nil {
+		t.Errorf("Parse returned err: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNative_Parse_Include_Github(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	ref := ""
+
+	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/contents/*path", func(c *gin.Context) {
+		ref = c.Query("ref")
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/include.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	c := cli.NewContext(nil, flag.NewFlagSet("test", 0), nil)
+
+	_scm, _ := github.NewTest(s.URL)
+
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	b := new(library.Build)
+	b.SetCommit("48afb5bdc41ad69bf22588491333f7cf71135163")
+
+	client, _ := New(c)
+	client.WithRepo(r).WithBuild(b).WithSCM(_scm).WithUser(u)
+
+	// run test
+	config, err := ioutil.ReadFile("testdata/include.yml")
+	if err != nil {
+		t.Errorf("Reading file returned err: %v", err)
+	}
+
+	got, err := client.Parse(config)
+	if err != nil {
+		t.Errorf("Parse returned err: %v", err)
+	}
+
+	if len(got.Steps) != 2 || got.Steps[1].Name != "test" {
+		t.Errorf("Parse steps is %v, want install and test", got.Steps)
+	}
+
+	if !reflect.DeepEqual(ref, b.GetCommit()) {
+		t.Errorf("Parse captured include at %s, want %s", ref, b.GetCommit())
+	}
+}
+
+func TestNative_Parse_Include_Conflict(t *testing.T) {
+	// setup types
+	client, _ := New(cli.NewContext(nil, flag.NewFlagSet("test", 0), nil))
+	client.WithLocal(true)
+
+	// run test
+	b, err := ioutil.ReadFile("testdata/include_conflict.yml")
+	if err != nil {
+		t.Errorf("Reading file returned err: %v", err)
+	}
+
+	_, err = client.Parse(b)
+	if err == nil {
+		t.Errorf("Parse should have returned err")
+	}
+}
+
+func TestNative_mergeInclude(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure  bool
+		config   string
+		fragment string
+	}{
+		{
+			failure:  false,
+			config:   "steps: [{name: install}]",
+			fragment: "steps: [{name: test}]\nservices: [{name: redis}]",
+		},
+		{
+			failure:  false,
+			config:   "stages: {install: {steps: [{name: install}]}}",
+			fragment: "stages: {test: {steps: [{name: test}]}}",
+		},
+		{
+			failure:  false,
+			config:   "environment: [GOOS=linux]",
+			fragment: "environment: {GOOS: linux, GOARCH: amd64}",
+		},
+		{
+			failure:  true,
+			config:   "steps: [{name: install}]",
+			fragment: "steps: [{name: install}]",
+		},
+		{
+			failure:  true,
+			config:   "stages: {install: {steps: [{name: install}]}}",
+			fragment: "stages: {install: {steps: [{name: test}]}}",
+		},
+		{
+			failure:  true,
+			config:   "steps: [{name: install}]",
+			fragment: "stages: {test: {steps: [{name: test}]}}",
+		},
+		{
+			failure:  true,
+			config:   "secrets: [{name: foo}]",
+			fragment: "secrets: [{name: foo}]",
+		},
+		{
+			failure:  true,
+			config:   "steps: [{name: install}]",
+			fragment: "version: \"1\"",
+		},
+		{
+			failure:  true,
+			config:   "steps: [{name: install}]",
+			fragment: "include: [foo.yml]",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		config := yml.MapSlice{}
+		fragment := yml.MapSlice{}
+
+		_ = yml.Unmarshal([]byte(test.config), &config)
+		_ = yml.Unmarshal([]byte(test.fragment), &fragment)
+
+		_, err := mergeInclude(config, fragment)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("mergeInclude should have returned err for %s", test.fragment)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("mergeInclude returned err for %s: %v", test.fragment, err)
+		}
+	}
+}
+
+func TestNative_includePaths(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		config  string
+		want    []string
+	}{
+		{
+			failure: false,
+			config:  "include: [ci/steps.yml, ./ci/services.yml]",
+			want:    []string{"ci/steps.yml", "ci/services.yml"},
+		},
+		{
+			failure: false,
+			config:  "steps: [{name: install}]",
+			want:    nil,
+		},
+		{
+			failure: true,
+			config:  "include: ci/steps.yml",
+		},
+		{
+			failure: true,
+			config:  "include: [/etc/passwd]",
+		},
+		{
+			failure: true,
+			config:  "include: [../other/steps.yml]",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		config := yml.MapSlice{}
+
+		_ = yml.Unmarshal([]byte(test.config), &config)
+
+		got, _, err := includePaths(config)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("includePaths should have returned err for %s", test.config)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("includePaths returned err for %s: %v", test.config, err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("includePaths is %v, want %v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/buildkite/yaml"
+
+	"github.com/go-vela/types/raw"
+	types "github.com/go-vela/types/yaml"
+)
+
+// matrixEnvPrefix represents the prefix for the environment
+// variables injected with the values for a matrix combination.
+const matrixEnvPrefix = "VELA_MATRIX_"
+
+type (
+	// matrix represents the axes and the combinations to include
+	// or exclude for a step or stage in a yaml configuration.
+	matrix struct {
+		Axes    map[string][]string
+		Include []map[string]string
+		Exclude []map[string]string
+	}
+
+	// matrixValue represents a value in the matrix block
+	// which is either an axis or a list of combinations.
+	matrixValue struct {
+		values       []string
+		combinations []map[string]string
+	}
+)
+
+// UnmarshalYAML implements the Unmarshaler interface for the matrixValue type.
+func (v *matrixValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// attempt to unmarshal as a list of values
+	err := unmarshal(&v.values)
+	if err == nil {
+		return nil
+	}
+
+	v.values = nil
+
+	// attempt to unmarshal as a list of combinations
+	return unmarshal(&v.combinations)
+}
+
+// UnmarshalYAML implements the Unmarshaler interface for the matrix type.
+func (m *matrix) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	values := make(map[string]*matrixValue)
+
+	err := unmarshal(&values)
+	if err != nil {
+		return err
+	}
+
+	m.Axes = make(map[string][]string)
+
+	for key, value := range values {
+		switch key {
+		case "include":
+			if value.values != nil {
+				return fmt.Errorf("matrix include must be a list of combinations")
+			}
+
+			m.Include = value.combinations
+		case "exclude":
+			if value.values != nil {
+				return fmt.Errorf("matrix exclude must be a list of combinations")
+			}
+
+			m.Exclude = value.combinations
+		default:
+			if value.combinations != nil {
+				return fmt.Errorf("matrix axis %s must be a list of values", key)
+			}
+
+			m.Axes[key] = value.values
+		}
+	}
+
+	return nil
+}
+
+// combinations returns every combination of values for the matrix
+// after applying the exclude and include lists. An error is returned
+// if the number of combinations exceeds the provided limit.
+func (m *matrix) combinations(limit int) ([]map[string]string, error) {
+	keys := []string{}
+	for key := range m.Axes {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	// check the limit before creating the combinations
+	total := 1
+	for _, key := range keys {
+		total *= len(m.Axes[key])
+
+		if limit > 0 && total > limit {
+			return nil, fmt.Errorf("matrix exceeds the limit of %d combinations", limit)
+		}
+	}
+
+	combinations := []map[string]string{}
+	if len(keys) > 0 {
+		combinations = append(combinations, map[string]string{})
+	}
+
+	// create the product of the values for every axis
+	for _, key := range keys {
+		product := []map[string]string{}
+
+		for _, combination := range combinations {
+			for _, value := range m.Axes[key] {
+				c := make(map[string]string)
+				for k, v := range combination {
+					c[k] = v
+				}
+
+				c[key] = value
+
+				product = append(product, c)
+			}
+		}
+
+		combinations = product
+	}
+
+	// remove the combinations matching an exclude entry
+	filtered := []map[string]string{}
+
+	for _, combination := range combinations {
+		excluded := false
+
+		for _, exclude := range m.Exclude {
+			if matchCombination(combination, exclude) {
+				excluded = true
+
+				break
+			}
+		}
+
+		if !excluded {
+			filtered = append(filtered, combination)
+		}
+	}
+
+	// add the include entries that aren't already a combination
+	for _, include := range m.Include {
+		found := false
+
+		for _, combination := range filtered {
+			if reflect.DeepEqual(combination, include) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found && len(include) > 0 {
+			filtered = append(filtered, include)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("matrix has no combinations")
+	}
+
+	if limit > 0 && len(filtered) > limit {
+		return nil, fmt.Errorf("matrix has %d combinations which exceeds the limit of %d", len(filtered), limit)
+	}
+
+	return filtered, nil
+}
+
+// matchCombination is a helper function that returns true if
+// every value in the pattern matches the value in the combination.
+func matchCombination(combination, pattern map[string]string) bool {
+	for key, value := range pattern {
+		if combination[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matrixName is a helper function that creates the
+// name for a variant of a step or stage by appending
+// the values for the combination sorted by key.
+func matrixName(name string, combination map[string]string) string {
+	keys := []string{}
+	for key := range combination {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	parts := []string{name}
+	for _, key := range keys {
+		parts = append(parts, combination[key])
+	}
+
+	return strings.Join(parts, "_")
+}
+
+// matrixEnvironment is a helper function that creates the
+// environment variables for the values of a combination.
+func matrixEnvironment(combination map[string]string) raw.StringSliceMap {
+	env := make(raw.StringSliceMap)
+
+	for key, value := range combination {
+		name := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(key))
+
+		env[matrixEnvPrefix+name] = value
+	}
+
+	return env
+}
+
+// injectMatrix is a helper function that injects the values for
+// a combination into a step as environment variables and as the
+// matrix variable for templates.
+func injectMatrix(step *types.Step, combination map[string]string, env bool) {
+	if env {
+		if step.Environment == nil {
+			step.Environment = make(raw.StringSliceMap)
+		}
+
+		for k, v := range matrixEnvironment(combination) {
+			step.Environment[k] = v
+		}
+	}
+
+	// skip the template variables if no template is provided for the step
+	if len(step.Template.Name) == 0 {
+		return
+	}
+
+	if step.Template.Variables == nil {
+		step.Template.Variables = make(map[string]interface{})
+	}
+
+	values := make(map[string]interface{})
+
+	// merge the values with a matrix from the stage for the step
+	if existing, ok := step.Template.Variables["matrix"].(map[string]interface{}); ok {
+		for k, v := range existing {
+			values[k] = v
+		}
+	}
+
+	for k, v := range combination {
+		values[k] = v
+	}
+
+	step.Template.Variables["matrix"] = values
+}
+
+// expandMatrixSteps is a helper function that replaces every step
+// with a matrix by a variant of the step for each combination.
+func (c *client) expandMatrixSteps(s types.StepSlice, exts []*stepExtension) (types.StepSlice, error) {
+	matrices := make(map[string]*matrix)
+
+	for _, ext := range exts {
+		if ext.Matrix != nil {
+			matrices[ext.Name] = ext.Matrix
+		}
+	}
+
+	// skip if no matrix is provided for the steps
+	if len(matrices) == 0 {
+		return s, nil
+	}
+
+	steps := types.StepSlice{}
+
+	for _, step := range s {
+		m, ok := matrices[step.Name]
+		if !ok {
+			steps = append(steps, step)
+
+			continue
+		}
+
+		combinations, err := m.combinations(c.MatrixMaxCombinations)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matrix for step %s: %v", step.Name, err)
+		}
+
+		for _, combination := range combinations {
+			variant := new(types.Step)
+
+			err = copyConfig(step, variant)
+			if err != nil {
+				return nil, err
+			}
+
+			variant.Name = matrixName(step.Name, combination)
+
+			injectMatrix(variant, combination, true)
+
+			steps = append(steps, variant)
+		}
+	}
+
+	return steps, nil
+}
+
+// expandMatrixStages is a helper function that replaces every stage
+// with a matrix by a variant of the stage for each combination. The
+// steps with a matrix in every stage are expanded as well.
+func (c *client) expandMatrixStages(s types.StageSlice) (types.StageSlice, error) {
+	stages := types.StageSlice{}
+	// variants will hold the names of the variants for each stage
+	variants := make(map[string][]string)
+
+	for _, stage := range s {
+		ext := c.extensions.stage(stage.Name)
+
+		// expand the steps with a matrix in the stage
+		steps, err := c.expandMatrixSteps(stage.Steps, c.extensions.steps(stage.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		stage.Steps = steps
+
+		if ext == nil || ext.Matrix == nil {
+			stages = append(stages, stage)
+
+			continue
+		}
+
+		combinations, err := ext.Matrix.combinations(c.MatrixMaxCombinations)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matrix for stage %s: %v", stage.Name, err)
+		}
+
+		for _, combination := range combinations {
+			variant := new(types.Stage)
+
+			err = copyConfig(stage, variant)
+			if err != nil {
+				return nil, err
+			}
+
+			variant.Name = matrixName(stage.Name, combination)
+
+			// the stage environment is injected into the steps
+			if variant.Environment == nil {
+				variant.Environment = make(raw.StringSliceMap)
+			}
+
+			for k, v := range matrixEnvironment(combination) {
+				variant.Environment[k] = v
+			}
+
+			for _, step := range variant.Steps {
+				injectMatrix(step, combination, false)
+			}
+
+			variants[stage.Name] = append(variants[stage.Name], variant.Name)
+
+			stages = append(stages, variant)
+		}
+	}
+
+	// skip if no matrix is provided for the stages
+	if len(variants) == 0 {
+		return stages, nil
+	}
+
+	// replace the needs for a stage with a matrix by every variant
+	for _, stage := range stages {
+		needs := []string{}
+
+		for _, need := range stage.Needs {
+			names, ok := variants[need]
+			if !ok {
+				needs = append(needs, need)
+
+				continue
+			}
+
+			needs = append(needs, names...)
+		}
+
+		stage.Needs = needs
+	}
+
+	return stages, nil
+}
+
+// copyConfig is a helper function that creates a
+// deep copy of a step or stage in a yaml configuration.
+func copyConfig(in, out interface{}) error {
+	body, err := yaml.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("unable to marshal configuration: %v", err)
+	}
+
+	err = yaml.Unmarshal(body, out)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal configuration: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/go-vela/types"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+	"github.com/go-vela/types/yaml"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestNative_matrix_combinations(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		matrix  *matrix
+		limit   int
+		want    []map[string]string
+		wantErr bool
+	}{
+		{
+			name: "product",
+			matrix: &matrix{
+				Axes: map[string][]string{"go": {"1.17", "1.18"}, "os": {"linux", "darwin"}},
+			},
+			want: []map[string]string{
+				{"go": "1.17", "os": "linux"},
+				{"go": "1.17", "os": "darwin"},
+				{"go": "1.18", "os": "linux"},
+				{"go": "1.18", "os": "darwin"},
+			},
+		},
+		{
+			name: "exclude and include",
+			matrix: &matrix{
+				Axes:    map[string][]string{"go": {"1.17", "1.18"}, "os": {"linux", "darwin"}},
+				Exclude: []map[string]string{{"os": "darwin"}},
+				Include: []map[string]string{{"go": "1.17", "os": "linux"}, {"go": "1.19", "os": "windows"}},
+			},
+			want: []map[string]string{
+				{"go": "1.17", "os": "linux"},
+				{"go": "1.18", "os": "linux"},
+				{"go": "1.19", "os": "windows"},
+			},
+		},
+		{
+			name: "only include",
+			matrix: &matrix{
+				Include: []map[string]string{{"go": "1.17"}},
+			},
+			want: []map[string]string{{"go": "1.17"}},
+		},
+		{
+			name: "product over limit",
+			matrix: &matrix{
+				Axes: map[string][]string{"go": {"1.17", "1.18"}, "os": {"linux", "darwin"}},
+			},
+			limit:   3,
+			wantErr: true,
+		},
+		{
+			name: "include over limit",
+			matrix: &matrix{
+				Axes:    map[string][]string{"go": {"1.17", "1.18"}},
+				Include: []map[string]string{{"go": "1.19"}},
+			},
+			limit:   2,
+			wantErr: true,
+		},
+		{
+			name: "everything excluded",
+			matrix: &matrix{
+				Axes:    map[string][]string{"go": {"1.17", "1.18"}},
+				Exclude: []map[string]string{{}},
+			},
+			wantErr: true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.matrix.combinations(test.limit)
+
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("combinations should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("combinations returned err: %v", err)
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("combinations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNative_Compile_Matrix(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	set.Int("matrix-max-combinations", 4, "doc")
+	c := cli.NewContext(nil, set, nil)
+
+	str := "foo"
+	r := &library.Repo{Org: &str, Name: &str, FullName: &str}
+
+	m := &types.Metadata{
+		Database: &types.Database{Driver: str, Host: str},
+		Queue:    &types.Queue{Channel: str, Driver: str, Host: str},
+		Source:   &types.Source{Driver: str, Host: str},
+		Vela:     &types.Vela{Address: str, WebAddress: str},
+	}
+
+	// setup tests
+	tests := []struct {
+		name      string
+		config    string
+		wantSteps map[string]string
+		wantNeeds map[string][]string
+		wantErr   bool
+	}{
+		{
+			name: "steps",
+			config: `
+version: "1"
+steps:
+  - name: test
+    image: golang:${VELA_MATRIX_GO}
+    matrix:
+      go: [ 1.17, 1.18 ]
+    commands:
+      - go test ./...
+`,
+			wantSteps: map[string]string{
+				"test_1.17": "golang:1.17",
+				"test_1.18": "golang:1.18",
+			},
+		},
+		{
+			name: "stages",
+			config: `
+version: "1"
+stages:
+  test:
+    matrix:
+      os: [ linux, darwin ]
+    steps:
+      - name: test
+        image: golang:${VELA_MATRIX_GO}-${VELA_MATRIX_OS}
+        matrix:
+          go: [ 1.17, 1.18 ]
+        commands:
+          - go test ./...
+  publish:
+    needs: [ test ]
+    steps:
+      - name: publish
+        image: alpine:latest
+        commands:
+          - echo publish
+`,
+			wantSteps: map[string]string{
+				"test_1.17": "golang:1.17-linux",
+				"test_1.18": "golang:1.18-linux",
+			},
+			wantNeeds: map[string][]string{
+				"test_linux":  {"clone"},
+				"test_darwin": {"clone"},
+				"publish":     {"test_linux", "test_darwin", "clone"},
+			},
+		},
+		{
+			name: "over limit",
+			config: `
+version: "1"
+steps:
+  - name: test
+    image: golang:latest
+    matrix:
+      go: [ 1.16, 1.17, 1.18 ]
+      os: [ linux, darwin ]
+    commands:
+      - go test ./...
+`,
+			wantErr: true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compiler, err := New(c)
+			if err != nil {
+				t.Errorf("Creating new compiler returned err: %v", err)
+			}
+
+			got, err := compiler.WithRepo(r).WithMetadata(m).Compile([]byte(test.config))
+
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("Compile should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Compile returned err: %v", err)
+			}
+
+			steps := pipeline.ContainerSlice{}
+			steps = append(steps, got.Steps...)
+
+			needs := map[string][]string{}
+
+			for _, stage := range got.Stages {
+				if stage.Name == "init" || stage.Name == "clone" {
+					continue
+				}
+
+				needs[stage.Name] = stage.Needs
+				steps = append(steps, stage.Steps...)
+			}
+
+			// capture the images for the variants of the step
+			images := map[string]string{}
+
+			for _, step := range steps {
+				if step.Name != "test_1.17" && step.Name != "test_1.18" {
+					continue
+				}
+
+				if step.Environment["VELA_MATRIX_GO"] != step.Name[len("test_"):] {
+					t.Errorf("Compile returned step %s with VELA_MATRIX_GO %s", step.Name, step.Environment["VELA_MATRIX_GO"])
+				}
+
+				// only capture the first stage for stages
+				if _, ok := images[step.Name]; !ok {
+					images[step.Name] = step.Image
+				}
+			}
+
+			if diff := cmp.Diff(test.wantSteps, images); diff != "" {
+				t.Errorf("Compile() steps mismatch (-want +got):\n%s", diff)
+			}
+
+			if test.wantNeeds != nil {
+				if diff := cmp.Diff(test.wantNeeds, needs); diff != "" {
+					t.Errorf("Compile() needs mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestNative_ExpandSteps_MatrixTemplate(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	set.String("template-file-root", "testdata", "doc")
+	c := cli.NewContext(nil, set, nil)
+
+	compiler, err := New(c)
+	if err != nil {
+		t.Errorf("Creating new compiler returned err: %v", err)
+	}
+
+	compiler.extensions = &extensions{
+		Steps: []*stepExtension{
+			{
+				Name:   "sample",
+				Matrix: &matrix{Axes: map[string][]string{"jdk": {"11", "17"}}},
+			},
+		},
+	}
+
+	steps := yaml.StepSlice{
+		&yaml.Step{
+			Name: "sample",
+			Template: yaml.StepTemplate{
+				Name: "gradle",
+				Variables: map[string]interface{}{
+					"image":       "openjdk:latest",
+					"environment": "{ GRADLE_USER_HOME: .gradle }",
+					"pull_policy": "pull: true",
+				},
+			},
+		},
+	}
+
+	tmpls := map[string]*yaml.Template{
+		"gradle": {Name: "gradle", Source: "template.yml", Type: "file"},
+	}
+
+	want := []string{
+		"sample_11_install", "sample_11_test", "sample_11_build",
+		"sample_17_install", "sample_17_test", "sample_17_build",
+	}
+
+	// run test
+	got, _, _, _, err := compiler.ExpandSteps(&yaml.Build{Steps: steps}, tmpls)
+	if err != nil {
+		t.Errorf("ExpandSteps returned err: %v", err)
+	}
+
+	names := []string{}
+	for _, step := range got {
+		names = append(names, step.Name)
+	}
+
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("ExpandSteps() mismatch (-want +got):\n%s", diff)
+	}
+
+	if steps[0].Template.Variables["matrix"] != nil {
+		t.Errorf("ExpandSteps modified the template variables for the original step")
+	}
+}
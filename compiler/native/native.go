@@ -10,7 +10,12 @@ import (
 	"github.com/go-vela/server/compiler"
 
 	"github.com/go-vela/server/compiler/registry"
+	"github.com/go-vela/server/compiler/registry/file"
 	"github.com/go-vela/server/compiler/registry/github"
+	"github.com/go-vela/server/compiler/registry/http"
+	"github.com/go-vela/server/compiler/template/starlark"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/scm"
 
 	"github.com/go-vela/types"
 	"github.com/go-vela/types/library"
@@ -27,18 +32,37 @@ type ModificationConfig struct {
 }
 
 type client struct {
-	Github              registry.Service
-	PrivateGithub       registry.Service
-	UsePrivateGithub    bool
-	ModificationService ModificationConfig
-
-	build    *library.Build
-	comment  string
-	files    []string
-	local    bool
-	metadata *types.Metadata
-	repo     *library.Repo
-	user     *library.User
+	Github                registry.Service
+	PrivateGithub         registry.Service
+	UsePrivateGithub      bool
+	File                  registry.Service
+	HTTP                  registry.Service
+	TemplateCache         *registry.Cache
+	TemplatePinnedOrgs    []string
+	MatrixMaxCombinations int
+	CloneImage            string
+	CloneImageAllowlist   []string
+	ModificationService   ModificationConfig
+
+	action     string
+	build      *library.Build
+	comment    string
+	commit     string
+	extensions *extensions
+	files      []string
+	injected   []*model.InjectedStep
+	loader     *starlark.Loader
+	local      bool
+	metadata   *types.Metadata
+	pinned     []*model.BuildTemplate
+	policies   []*model.Policy
+	pull       *model.BuildPullRequest
+	results    []*model.PolicyResult
+	repo       *library.Repo
+	required   []*model.RequiredPipeline
+	scm        scm.Service
+	templates  []*model.BuildTemplate
+	user       *library.User
 }
 
 // New returns a Pipeline implementation that integrates with the supported registries.
@@ -57,8 +81,24 @@ func New(ctx *cli.Context) (*client, error) {
 		}
 	}
 
+	if ctx.Int("template-cache-size") > 0 {
+		logrus.Tracef("setting up template cache with TTL of %s", ctx.Duration("template-cache-ttl"))
+		// setup cache for github templates
+		c.TemplateCache = registry.NewCache(ctx.Duration("template-cache-ttl"), ctx.Int("template-cache-size"))
+	}
+
+	// setup the image for the clone step
+	c.CloneImage = ctx.String("clone-image")
+	c.CloneImageAllowlist = ctx.StringSlice("clone-image-allowlist")
+
+	// setup the limit for combinations in a matrix
+	c.MatrixMaxCombinations = ctx.Int("matrix-max-combinations")
+
+	// setup orgs that must pin the templates for their repos
+	c.TemplatePinnedOrgs = ctx.StringSlice("template-pinned-orgs")
+
 	// setup github template service
-	github, err := setupGithub()
+	github, err := setupGithub(c.TemplateCache)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +108,7 @@ func New(ctx *cli.Context) (*client, error) {
 	if ctx.Bool("github-driver") {
 		logrus.Tracef("setting up Private GitHub Client for %s", ctx.String("github-url"))
 		// setup private github service
-		privGithub, err := setupPrivateGithub(ctx.String("github-url"), ctx.String("github-token"))
+		privGithub, err := setupPrivateGithub(ctx.String("github-url"), ctx.String("github-token"), c.TemplateCache)
 		if err != nil {
 			return nil, err
 		}
@@ -77,21 +117,73 @@ func New(ctx *cli.Context) (*client, error) {
 		c.UsePrivateGithub = true
 	}
 
+	if len(ctx.String("template-file-root")) > 0 {
+		logrus.Tracef("setting up File Client for %s", ctx.String("template-file-root"))
+		// setup file template service
+		file, err := setupFile(ctx.String("template-file-root"))
+		if err != nil {
+			return nil, err
+		}
+
+		c.File = file
+	}
+
+	if ctx.Bool("template-http-driver") {
+		logrus.Trace("setting up HTTP Client")
+		// setup http template service
+		http, err := setupHTTP(ctx.StringSlice("template-http-headers"))
+		if err != nil {
+			return nil, err
+		}
+
+		c.HTTP = http
+	}
+
 	return c, nil
 }
 
 // setupGithub is a helper function to setup the
 // Github registry service from the CLI arguments.
-func setupGithub() (registry.Service, error) {
+func setupGithub(cache *registry.Cache) (registry.Service, error) {
 	logrus.Tracef("Creating %s registry client from CLI configuration", "github")
-	return github.New("", "")
+
+	gh, err := github.New("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	gh.Cache = cache
+
+	return gh, nil
 }
 
 // setupPrivateGithub is a helper function to setup the
 // Github registry service from the CLI arguments.
-func setupPrivateGithub(addr, token string) (registry.Service, error) {
+func setupPrivateGithub(addr, token string, cache *registry.Cache) (registry.Service, error) {
 	logrus.Tracef("Creating private %s registry client from CLI configuration", "github")
-	return github.New(addr, token)
+
+	gh, err := github.New(addr, token)
+	if err != nil {
+		return nil, err
+	}
+
+	gh.Cache = cache
+
+	return gh, nil
+}
+
+// setupFile is a helper function to setup the
+// File registry service from the CLI arguments.
+func setupFile(root string) (registry.Service, error) {
+	logrus.Tracef("Creating %s registry client from CLI configuration", "file")
+	return file.New(root)
+}
+
+// setupHTTP is a helper function to setup the
+// HTTP registry service from the CLI arguments.
+func setupHTTP(headers []string) (registry.Service, error) {
+	logrus.Tracef("Creating %s registry client from CLI configuration", "http")
+	return http.New(headers)
 }
 
 // Duplicate creates a clone of the Engine.
@@ -102,11 +194,32 @@ func (c *client) Duplicate() compiler.Engine {
 	cc.Github = c.Github
 	cc.PrivateGithub = c.PrivateGithub
 	cc.UsePrivateGithub = c.UsePrivateGithub
+	cc.File = c.File
+	cc.HTTP = c.HTTP
+	cc.TemplateCache = c.TemplateCache
+	cc.TemplatePinnedOrgs = c.TemplatePinnedOrgs
+	cc.MatrixMaxCombinations = c.MatrixMaxCombinations
+	cc.CloneImage = c.CloneImage
+	cc.CloneImageAllowlist = c.CloneImageAllowlist
 	cc.ModificationService = c.ModificationService
 
 	return cc
 }
 
+// Templates returns the templates captured while compiling the pipeline.
+func (c *client) Templates() []*model.BuildTemplate {
+	return c.templates
+}
+
+// WithAction sets the action of the event in the Engine.
+func (c *client) WithAction(action string) compiler.Engine {
+	if action != "" {
+		c.action = action
+	}
+
+	return c
+}
+
 // WithBuild sets the library build type in the Engine.
 func (c *client) WithBuild(b *library.Build) compiler.Engine {
 	if b != nil {
@@ -125,6 +238,15 @@ func (c *client) WithComment(cmt string) compiler.Engine {
 	return c
 }
 
+// WithCommit sets the commit in the Engine.
+func (c *client) WithCommit(cmt string) compiler.Engine {
+	if cmt != "" {
+		c.commit = cmt
+	}
+
+	return c
+}
+
 // WithFiles sets the changeset files in the Engine.
 func (c *client) WithFiles(f []string) compiler.Engine {
 	if f != nil {
@@ -150,10 +272,19 @@ func (c *client) WithMetadata(m *types.Metadata) compiler.Engine {
 	return c
 }
 
+// WithPolicies sets the policies evaluated while compiling in the Engine.
+func (c *client) WithPolicies(p []*model.Policy) compiler.Engine {
+	if p != nil {
+		c.policies = p
+	}
+
+	return c
+}
+
 // WithPrivateGitHub sets the private github client in the Engine.
 func (c *client) WithPrivateGitHub(url, token string) compiler.Engine {
 	if len(url) != 0 && len(token) != 0 {
-		privGithub, _ := setupPrivateGithub(url, token)
+		privGithub, _ := setupPrivateGithub(url, token, c.TemplateCache)
 
 		c.PrivateGithub = privGithub
 	}
@@ -161,6 +292,15 @@ func (c *client) WithPrivateGitHub(url, token string) compiler.Engine {
 	return c
 }
 
+// WithPullRequest sets the pull request of the build in the Engine.
+func (c *client) WithPullRequest(p *model.BuildPullRequest) compiler.Engine {
+	if p != nil {
+		c.pull = p
+	}
+
+	return c
+}
+
 // WithRepo sets the library repo type in the Engine.
 func (c *client) WithRepo(r *library.Repo) compiler.Engine {
 	if r != nil {
@@ -170,6 +310,37 @@ func (c *client) WithRepo(r *library.Repo) compiler.Engine {
 	return c
 }
 
+// WithRequiredPipelines sets the required pipelines
+// injected while compiling in the Engine.
+func (c *client) WithRequiredPipelines(p []*model.RequiredPipeline) compiler.Engine {
+	if p != nil {
+		c.required = p
+	}
+
+	return c
+}
+
+// WithSCM sets the scm the included files are captured from in the Engine.
+func (c *client) WithSCM(s scm.Service) compiler.Engine {
+	if s != nil {
+		c.scm = s
+	}
+
+	return c
+}
+
+// WithTemplates sets the templates recorded for a build in the
+// Engine. The GitHub templates are captured from the commits
+// recorded for them instead of the commits their refs resolve to,
+// so the pipeline is expanded like it was for the build.
+func (c *client) WithTemplates(t []*model.BuildTemplate) compiler.Engine {
+	if t != nil {
+		c.pinned = t
+	}
+
+	return c
+}
+
 // WithUser sets the library user type in the Engine.
 func (c *client) WithUser(u *library.User) compiler.Engine {
 	if u != nil {
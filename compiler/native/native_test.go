@@ -8,8 +8,11 @@ import (
 	"flag"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/go-vela/server/compiler/registry/github"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/server/scm/gitlab"
 
 	"github.com/go-vela/types"
 	"github.com/go-vela/types/library"
@@ -67,6 +70,28 @@ func TestNative_New_PrivateGithub(t *testing.T) {
 	}
 }
 
+func TestNative_New_TemplateCache(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	set.Int("template-cache-size", 10, "doc")
+	set.Duration("template-cache-ttl", time.Minute, "doc")
+	c := cli.NewContext(nil, set, nil)
+
+	// run test
+	got, err := New(c)
+	if err != nil {
+		t.Errorf("New returned err: %v", err)
+	}
+
+	if got.TemplateCache == nil {
+		t.Errorf("New TemplateCache is nil")
+	}
+
+	if !reflect.DeepEqual(got.Duplicate().(*client).TemplateCache, got.TemplateCache) {
+		t.Errorf("Duplicate TemplateCache is not retained")
+	}
+}
+
 func TestNative_DuplicateRetainSettings(t *testing.T) {
 	// setup types
 	url := "http://foo.example.com"
@@ -162,6 +187,26 @@ func TestNative_WithFiles(t *testing.T) {
 	}
 }
 
+func TestNative_WithAction(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	action := "closed"
+	want, _ := New(c)
+	want.action = action
+
+	// run test
+	got, err := New(c)
+	if err != nil {
+		t.Errorf("Unable to create new compiler: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.WithAction(action), want) {
+		t.Errorf("WithAction is %v, want %v", got, want)
+	}
+}
+
 func TestNative_WithComment(t *testing.T) {
 	// setup types
 	set := flag.NewFlagSet("test", 0)
@@ -182,6 +227,26 @@ func TestNative_WithComment(t *testing.T) {
 	}
 }
 
+func TestNative_WithCommit(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	commit := "48afb5bdc41ad69bf22588491333f7cf71135163"
+	want, _ := New(c)
+	want.commit = commit
+
+	// run test
+	got, err := New(c)
+	if err != nil {
+		t.Errorf("Unable to create new compiler: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.WithCommit(commit), want) {
+		t.Errorf("WithCommit is %v, want %v", got, want)
+	}
+}
+
 func TestNative_WithLocal(t *testing.T) {
 	// setup types
 	set := flag.NewFlagSet("test", 0)
@@ -267,6 +332,47 @@ func TestNative_WithPrivateGitHub(t *testing.T) {
 	}
 }
 
+func TestNative_WithPolicies(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	p := []*model.Policy{{ID: 1, Name: "foo", Action: model.PolicyWarn}}
+
+	want, _ := New(c)
+	want.policies = p
+
+	// run test
+	got, err := New(c)
+	if err != nil {
+		t.Errorf("Unable to create new compiler: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.WithPolicies(p), want) {
+		t.Errorf("WithPolicies is %v, want %v", got, want)
+	}
+}
+
+func TestNative_WithPullRequest(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	p := &model.BuildPullRequest{Labels: model.Labels{"bug"}, Draft: true}
+	want, _ := New(c)
+	want.pull = p
+
+	// run test
+	got, err := New(c)
+	if err != nil {
+		t.Errorf("Unable to create new compiler: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.WithPullRequest(p), want) {
+		t.Errorf("WithPullRequest is %v, want %v", got, want)
+	}
+}
+
 func TestNative_WithRepo(t *testing.T) {
 	// setup types
 	set := flag.NewFlagSet("test", 0)
@@ -289,6 +395,27 @@ func TestNative_WithRepo(t *testing.T) {
 	}
 }
 
+func TestNative_WithRequiredPipelines(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	r := []*model.RequiredPipeline{{ID: 1, Org: "foo", Name: "bar", Position: model.RequiredPipelineAfter}}
+
+	want, _ := New(c)
+	want.required = r
+
+	// run test
+	got, err := New(c)
+	if err != nil {
+		t.Errorf("Unable to create new compiler: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.WithRequiredPipelines(r), want) {
+		t.Errorf("WithRequiredPipelines is %v, want %v", got, want)
+	}
+}
+
 func TestNative_WithUser(t *testing.T) {
 	// setup types
 	set := flag.NewFlagSet("test", 0)
@@ -310,3 +437,47 @@ func TestNative_WithUser(t *testing.T) {
 		t.Errorf("WithUser is %v, want %v", got, want)
 	}
 }
+
+func TestNative_WithSCM(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	s, _ := gitlab.NewTest("https://gitlab.example.com")
+
+	want, _ := New(c)
+	want.scm = s
+
+	// run test
+	got, err := New(c)
+	if err != nil {
+		t.Errorf("Unable to create new compiler: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.WithSCM(s), want) {
+		t.Errorf("WithSCM is %v, want %v", got, want)
+	}
+}
+
+func TestNative_WithTemplates(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	templates := []*model.BuildTemplate{
+		{Name: "gradle", Type: "github", Commit: "48afb5bdc41ad69bf22588491333f7cf71135163"},
+	}
+
+	want, _ := New(c)
+	want.pinned = templates
+
+	// run test
+	got, err := New(c)
+	if err != nil {
+		t.Errorf("Unable to create new compiler: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.WithTemplates(templates), want) {
+		t.Errorf("WithTemplates is %v, want %v", got, want)
+	}
+}
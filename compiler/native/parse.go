@@ -53,7 +53,15 @@ func (c *client) Parse(v interface{}) (*types.Build, error) {
 		if err != nil {
 			return nil, err
 		}
-		p, err = native.RenderBuild(parsedRaw, c.EnvironmentBuild())
+
+		// render the base configuration to the raw yaml
+		// configuration to parse the extensions from it
+		parsedRaw, err = native.RenderBuildRaw(parsedRaw, c.EnvironmentBuild())
+		if err != nil {
+			return nil, err
+		}
+
+		p, err = c.parseExtended(parsedRaw)
 		if err != nil {
 			return nil, err
 		}
@@ -63,30 +71,35 @@ func (c *client) Parse(v interface{}) (*types.Build, error) {
 		if err != nil {
 			return nil, err
 		}
-		p, err = starlark.RenderBuild(parsedRaw, c.EnvironmentBuild())
+
+		// render the base configuration to the raw yaml
+		// configuration to parse the extensions from it
+		parsedRaw, err = starlark.RenderBuildRaw(parsedRaw, c.EnvironmentBuild(), c.starlarkLoader())
+		if err != nil {
+			return nil, err
+		}
+
+		p, err = c.parseExtended(parsedRaw)
 		if err != nil {
 			return nil, err
 		}
 	case constants.PipelineTypeYAML, "":
-		switch v := v.(type) {
-		case []byte:
-			return ParseBytes(v)
-		case *os.File:
-			return ParseFile(v)
-		case io.Reader:
-			return ParseReader(v)
-		case string:
-			// check if string is path to file
-			_, err := os.Stat(v)
-			if err == nil {
-				// parse string as path to yaml configuration
-				return ParsePath(v)
-			}
+		// capture the raw configuration since it's also
+		// used to parse the extensions for the pipeline
+		parsedRaw, err := c.ParseRaw(v)
+		if err != nil {
+			return nil, err
+		}
+
+		// merge the files included in the pipeline
+		parsedRaw, err = c.include(parsedRaw)
+		if err != nil {
+			return nil, err
+		}
 
-			// parse string as yaml configuration
-			return ParseString(v)
-		default:
-			return nil, fmt.Errorf("unable to parse yaml: unrecognized type %T", v)
+		p, err = c.parseExtended(parsedRaw)
+		if err != nil {
+			return nil, err
 		}
 	default:
 		// nolint:lll // detailed error message
@@ -96,6 +109,64 @@ func (c *client) Parse(v interface{}) (*types.Build, error) {
 	return p, nil
 }
 
+// parseExtended is a helper function that captures the extensions
+// provided for the pipeline from a raw yaml configuration before
+// it converts the raw yaml configuration to a yaml configuration.
+func (c *client) parseExtended(raw string) (*types.Build, error) {
+	var err error
+
+	// capture the extensions provided for the pipeline
+	c.extensions, err = parseExtensions(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// replace the clone object in the metadata since
+	// it isn't supported by the yaml types
+	if c.extensions.clone() != nil {
+		raw, err = replaceCloneObject(raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ParseString(raw)
+}
+
+// replaceCloneObject is a helper function that replaces the
+// clone object in the metadata of a raw yaml configuration
+// with a boolean to enable the clone step.
+func replaceCloneObject(raw string) (string, error) {
+	config := yaml.MapSlice{}
+
+	err := yaml.Unmarshal([]byte(raw), &config)
+	if err != nil {
+		return "", fmt.Errorf("unable to unmarshal yaml: %v", err)
+	}
+
+	for i, item := range config {
+		metadata, ok := item.Value.(yaml.MapSlice)
+		if item.Key != "metadata" || !ok {
+			continue
+		}
+
+		for j, field := range metadata {
+			if field.Key == "clone" {
+				metadata[j].Value = true
+			}
+		}
+
+		config[i].Value = metadata
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal yaml: %v", err)
+	}
+
+	return string(out), nil
+}
+
 // ParseBytes converts a byte slice to a yaml configuration.
 func ParseBytes(b []byte) (*types.Build, error) {
 	config := new(types.Build)
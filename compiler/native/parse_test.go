@@ -904,6 +904,69 @@ func Test_client_Parse(t *testing.T) {
 	}
 }
 
+func Test_client_Parse_Extensions(t *testing.T) {
+	// setup types
+	want := &extensions{
+		Metadata: metadataExtension{
+			Clone: &cloneExtension{Depth: 1, object: true},
+		},
+		Steps: []*stepExtension{
+			{
+				Name: "test",
+				Matrix: &matrix{
+					Axes: map[string][]string{"go": {"1.17", "1.18"}},
+				},
+				Ruleset: &rulesetExtension{
+					If:       rulesExtension{Label: []string{"run-tests"}},
+					Matcher:  "filepath",
+					Operator: "and",
+				},
+			},
+		},
+		Templates: []*templateExtension{
+			{
+				Name:   "go",
+				Digest: "sha256:6e3f2c6e4b1f0c7ed0b0b26d5a0f1f2dfb3cbd2a0ef4b6d33c4d62ba1c1e0a59",
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		pipelineType string
+		file         string
+	}{
+		{"starlark", constants.PipelineTypeStarlark, "testdata/extensions.star"},
+		{"go", constants.PipelineTypeGo, "testdata/extensions_go.yml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := ioutil.ReadFile(tt.file)
+			if err != nil {
+				t.Errorf("Reading file returned err: %v", err)
+			}
+
+			c := &client{
+				repo: &library.Repo{PipelineType: &tt.pipelineType},
+			}
+
+			got, err := c.Parse(content)
+			if err != nil {
+				t.Errorf("Parse returned err: %v", err)
+			}
+
+			if got == nil || got.Metadata.Clone == nil || !*got.Metadata.Clone {
+				t.Errorf("Parse metadata clone is %v, want true", got)
+			}
+
+			if diff := cmp.Diff(want, c.extensions, cmp.AllowUnexported(extensions{}, cloneExtension{})); diff != "" {
+				t.Errorf("Parse() extensions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func Test_client_ParseRaw(t *testing.T) {
 	expected, err := ioutil.ReadFile("testdata/metadata.yml")
 	if err != nil {
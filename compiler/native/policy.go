@@ -0,0 +1,293 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/pipeline"
+	"github.com/go-vela/types/yaml"
+)
+
+// PolicyStages evaluates the policies against
+// each step in every stage in a yaml configuration.
+func (c *client) PolicyStages(r *pipeline.RuleData, s yaml.StageSlice) (yaml.StageSlice, error) {
+	steps := yaml.StepSlice{}
+
+	// collect the steps for all stages so required
+	// policies are evaluated against the whole pipeline
+	for _, stage := range s {
+		steps = append(steps, stage.Steps...)
+	}
+
+	_, err := c.PolicySteps(r, steps)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// PolicySteps evaluates the policies against
+// each step in a yaml configuration. A required policy
+// is only satisfied by the steps that run for the rule
+// data, every step is evaluated when none is provided.
+func (c *client) PolicySteps(r *pipeline.RuleData, s yaml.StepSlice) (yaml.StepSlice, error) {
+	steps := yaml.StepSlice{}
+
+	for _, step := range s {
+		// skip the init step injected by the compiler
+		if step.Image == initImage {
+			continue
+		}
+
+		steps = append(steps, step)
+	}
+
+	err := c.policy(r, "step", steps)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// PolicyServices evaluates the policies against
+// each service in a yaml configuration.
+func (c *client) PolicyServices(s yaml.ServiceSlice) (yaml.ServiceSlice, error) {
+	containers := yaml.StepSlice{}
+
+	for _, service := range s {
+		containers = append(containers, &yaml.Step{
+			Name:        service.Name,
+			Image:       service.Image,
+			Pull:        service.Pull,
+			Environment: service.Environment,
+		})
+	}
+
+	err := c.policy(nil, "service", containers)
+	if err != nil {
+		return nil, err
+	}
+
+	// apply the mutations of the policies to the services
+	for i, service := range s {
+		service.Pull = containers[i].Pull
+		service.Environment = containers[i].Environment
+	}
+
+	return s, nil
+}
+
+// PolicySecrets evaluates the policies against the
+// origin of each secret in a yaml configuration.
+func (c *client) PolicySecrets(s yaml.SecretSlice) (yaml.SecretSlice, error) {
+	secrets := yaml.SecretSlice{}
+	containers := yaml.StepSlice{}
+
+	for _, secret := range s {
+		// skip the secrets without a secret plugin
+		if secret.Origin.Empty() {
+			continue
+		}
+
+		secrets = append(secrets, secret)
+		containers = append(containers, &yaml.Step{
+			Name:        secret.Origin.Name,
+			Image:       secret.Origin.Image,
+			Pull:        secret.Origin.Pull,
+			Environment: secret.Origin.Environment,
+		})
+	}
+
+	err := c.policy(nil, "secret origin", containers)
+	if err != nil {
+		return nil, err
+	}
+
+	// apply the mutations of the policies to the origins
+	for i, secret := range secrets {
+		secret.Origin.Pull = containers[i].Pull
+		secret.Origin.Environment = containers[i].Environment
+	}
+
+	return s, nil
+}
+
+// policy is a helper function to evaluate the policies against
+// the containers of a yaml configuration. The required policies
+// are only evaluated against the steps since a service or secret
+// origin can't satisfy them.
+func (c *client) policy(r *pipeline.RuleData, kind string, s yaml.StepSlice) error {
+	denied := []string{}
+
+	for _, policy := range c.policies {
+		if policy.Rule.Required && kind != "step" {
+			continue
+		}
+
+		matched := yaml.StepSlice{}
+
+		for _, step := range s {
+			if matchPolicy(&policy.Rule, step) {
+				matched = append(matched, step)
+			}
+		}
+
+		// a required policy is reported once when no steps match
+		if policy.Rule.Required {
+			if matchRequired(r, matched) {
+				continue
+			}
+
+			msg := policy.Message
+			if len(msg) == 0 {
+				msg = fmt.Sprintf("no step matches required policy %s", policy.Name)
+			}
+
+			c.results = append(c.results, policyResult(policy, "", msg))
+
+			if policy.Action == model.PolicyDeny {
+				denied = append(denied, fmt.Sprintf("%s: %s", policy.Name, msg))
+			}
+
+			continue
+		}
+
+		for _, step := range matched {
+			msg := policy.Message
+			if len(msg) == 0 {
+				msg = fmt.Sprintf("%s %s matches policy %s", kind, step.Name, policy.Name)
+			}
+
+			c.results = append(c.results, policyResult(policy, step.Name, msg))
+
+			switch policy.Action {
+			case model.PolicyDeny:
+				denied = append(denied, fmt.Sprintf("%s: %s %s: %s", policy.Name, kind, step.Name, msg))
+			case model.PolicyMutate:
+				mutateStep(policy.Rule.Mutation, step)
+			}
+		}
+	}
+
+	if len(denied) > 0 {
+		return fmt.Errorf("pipeline denied by policy: %s", strings.Join(denied, "; "))
+	}
+
+	return nil
+}
+
+// PolicyResults returns the results of the policies
+// evaluated while compiling the pipeline.
+func (c *client) PolicyResults() []*model.PolicyResult {
+	if c.results == nil {
+		return []*model.PolicyResult{}
+	}
+
+	return c.results
+}
+
+// matchRequired is a helper function to determine if any of
+// the steps matching a required policy runs for the rule data.
+// The rulesets are matched with the status of a successful
+// build, the same way the worker matches them while the build
+// is running, so a step only running after a failure doesn't
+// satisfy the required policy.
+func matchRequired(r *pipeline.RuleData, s yaml.StepSlice) bool {
+	if r == nil {
+		return len(s) > 0
+	}
+
+	data := *r
+	data.Status = constants.StatusSuccess
+
+	for _, step := range s {
+		if step.Ruleset.ToPipeline().Match(&data) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// policyResult is a helper function to create
+// the result for a policy applied to a step.
+func policyResult(p *model.Policy, step, msg string) *model.PolicyResult {
+	return &model.PolicyResult{
+		PolicyID: p.ID,
+		Policy:   p.Name,
+		Action:   p.Action,
+		Step:     step,
+		Message:  msg,
+	}
+}
+
+// matchPolicy is a helper function to determine
+// if a step matches every condition of the rule.
+func matchPolicy(r *model.PolicyRule, s *yaml.Step) bool {
+	if len(r.Images) > 0 && !matchPolicyPatterns(r.Images, s.Image) {
+		return false
+	}
+
+	if len(r.ExceptImages) > 0 && matchPolicyPatterns(r.ExceptImages, s.Image) {
+		return false
+	}
+
+	if len(r.Names) > 0 && !matchPolicyPatterns(r.Names, s.Name) {
+		return false
+	}
+
+	if r.Privileged != nil && *r.Privileged != s.Privileged {
+		return false
+	}
+
+	return true
+}
+
+// matchPolicyPatterns is a helper function to determine if
+// a value matches any of the patterns. A '*' in a pattern
+// matches any sequence of characters, including '/'.
+func matchPolicyPatterns(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+
+		if ok, _ := regexp.MatchString(expr, value); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mutateStep is a helper function to apply
+// the mutation of a policy to a step.
+func mutateStep(m *model.PolicyMutation, s *yaml.Step) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Environment) > 0 && s.Environment == nil {
+		s.Environment = make(map[string]string)
+	}
+
+	for k, v := range m.Environment {
+		s.Environment[k] = v
+	}
+
+	if m.Privileged != nil {
+		s.Privileged = *m.Privileged
+	}
+
+	if len(m.Pull) > 0 {
+		s.Pull = m.Pull
+	}
+}
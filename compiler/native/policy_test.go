@@ -0,0 +1,430 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/pipeline"
+	"github.com/go-vela/types/yaml"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/urfave/cli/v2"
+)
+
+func TestNative_PolicySteps(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	privileged := true
+	unprivileged := false
+
+	denyPrivileged := &model.Policy{
+		ID:      1,
+		Name:    "no-privileged",
+		Action:  model.PolicyDeny,
+		Message: "privileged steps are not allowed",
+		Rule:    model.PolicyRule{Privileged: &privileged},
+	}
+
+	warnRegistry := &model.Policy{
+		ID:     2,
+		Name:   "approved-registry",
+		Action: model.PolicyWarn,
+		Rule:   model.PolicyRule{ExceptImages: []string{"registry.example.com/*"}},
+	}
+
+	requireScan := &model.Policy{
+		ID:      3,
+		Name:    "security-scan",
+		Action:  model.PolicyDeny,
+		Message: "a security scan step is required",
+		Rule:    model.PolicyRule{Images: []string{"*/scanner:*"}, Required: true},
+	}
+
+	mutatePull := &model.Policy{
+		ID:     4,
+		Name:   "always-pull",
+		Action: model.PolicyMutate,
+		Rule: model.PolicyRule{
+			Names: []string{"test*"},
+			Mutation: &model.PolicyMutation{
+				Environment: map[string]string{"FOO": "bar"},
+				Privileged:  &unprivileged,
+				Pull:        "always",
+			},
+		},
+	}
+
+	steps := func() yaml.StepSlice {
+		return yaml.StepSlice{
+			&yaml.Step{Image: initImage, Name: "init"},
+			&yaml.Step{Image: "registry.example.com/team/scanner:v1", Name: "scan"},
+			&yaml.Step{Image: "golang:latest", Name: "test", Pull: "not_present"},
+		}
+	}
+
+	// setup tests
+	tests := []struct {
+		name     string
+		failure  bool
+		policies []*model.Policy
+		data     *pipeline.RuleData
+		steps    yaml.StepSlice
+		want     yaml.StepSlice
+		results  []*model.PolicyResult
+	}{
+		{
+			name:     "no matching steps",
+			policies: []*model.Policy{denyPrivileged, requireScan},
+			steps:    steps(),
+			want:     steps(),
+			results:  []*model.PolicyResult{},
+		},
+		{
+			name:     "warn for each matching step",
+			policies: []*model.Policy{warnRegistry},
+			steps:    steps(),
+			want:     steps(),
+			results: []*model.PolicyResult{
+				{
+					PolicyID: 2,
+					Policy:   "approved-registry",
+					Action:   model.PolicyWarn,
+					Step:     "test",
+					Message:  "step test matches policy approved-registry",
+				},
+			},
+		},
+		{
+			name:     "mutate matching steps",
+			policies: []*model.Policy{mutatePull},
+			steps: yaml.StepSlice{
+				&yaml.Step{Image: "golang:latest", Name: "test", Privileged: true},
+				&yaml.Step{Image: "golang:latest", Name: "build"},
+			},
+			want: yaml.StepSlice{
+				&yaml.Step{Image: "golang:latest", Name: "test", Pull: "always", Environment: map[string]string{"FOO": "bar"}},
+				&yaml.Step{Image: "golang:latest", Name: "build"},
+			},
+			results: []*model.PolicyResult{
+				{
+					PolicyID: 4,
+					Policy:   "always-pull",
+					Action:   model.PolicyMutate,
+					Step:     "test",
+					Message:  "step test matches policy always-pull",
+				},
+			},
+		},
+		{
+			name:     "deny privileged step",
+			failure:  true,
+			policies: []*model.Policy{denyPrivileged},
+			steps: yaml.StepSlice{
+				&yaml.Step{Image: "docker:dind", Name: "docker", Privileged: true},
+			},
+			results: []*model.PolicyResult{
+				{
+					PolicyID: 1,
+					Policy:   "no-privileged",
+					Action:   model.PolicyDeny,
+					Step:     "docker",
+					Message:  "privileged steps are not allowed",
+				},
+			},
+		},
+		{
+			name:     "required step runs for the rule data",
+			policies: []*model.Policy{requireScan},
+			data:     &pipeline.RuleData{Branch: "main", Event: "push"},
+			steps: yaml.StepSlice{
+				&yaml.Step{
+					Image:   "registry.example.com/team/scanner:v1",
+					Name:    "scan",
+					Ruleset: yaml.Ruleset{If: yaml.Rules{Branch: []string{"main"}}, Operator: "and"},
+				},
+			},
+			want: yaml.StepSlice{
+				&yaml.Step{
+					Image:   "registry.example.com/team/scanner:v1",
+					Name:    "scan",
+					Ruleset: yaml.Ruleset{If: yaml.Rules{Branch: []string{"main"}}, Operator: "and"},
+				},
+			},
+			results: []*model.PolicyResult{},
+		},
+		{
+			name:     "deny required step skipped by the ruleset",
+			failure:  true,
+			policies: []*model.Policy{requireScan},
+			data:     &pipeline.RuleData{Branch: "feature", Event: "push"},
+			steps: yaml.StepSlice{
+				&yaml.Step{
+					Image:   "registry.example.com/team/scanner:v1",
+					Name:    "scan",
+					Ruleset: yaml.Ruleset{If: yaml.Rules{Branch: []string{"main"}}, Operator: "and"},
+				},
+			},
+			results: []*model.PolicyResult{
+				{
+					PolicyID: 3,
+					Policy:   "security-scan",
+					Action:   model.PolicyDeny,
+					Message:  "a security scan step is required",
+				},
+			},
+		},
+		{
+			name:     "deny required step only running after a failure",
+			failure:  true,
+			policies: []*model.Policy{requireScan},
+			data:     &pipeline.RuleData{Branch: "main", Event: "push"},
+			steps: yaml.StepSlice{
+				&yaml.Step{
+					Image:   "registry.example.com/team/scanner:v1",
+					Name:    "scan",
+					Ruleset: yaml.Ruleset{If: yaml.Rules{Status: []string{"failure"}}, Operator: "and"},
+				},
+			},
+			results: []*model.PolicyResult{
+				{
+					PolicyID: 3,
+					Policy:   "security-scan",
+					Action:   model.PolicyDeny,
+					Message:  "a security scan step is required",
+				},
+			},
+		},
+		{
+			name:     "deny missing required step",
+			failure:  true,
+			policies: []*model.Policy{requireScan},
+			steps: yaml.StepSlice{
+				&yaml.Step{Image: "golang:latest", Name: "test"},
+			},
+			results: []*model.PolicyResult{
+				{
+					PolicyID: 3,
+					Policy:   "security-scan",
+					Action:   model.PolicyDeny,
+					Message:  "a security scan step is required",
+				},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compiler, err := New(c)
+			if err != nil {
+				t.Errorf("Unable to create new compiler: %v", err)
+			}
+
+			got, err := compiler.WithPolicies(test.policies).PolicySteps(test.data, test.steps)
+
+			if diff := cmp.Diff(test.results, compiler.PolicyResults()); diff != "" {
+				t.Errorf("PolicyResults mismatch (-want +got):\n%s", diff)
+			}
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("PolicySteps should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("PolicySteps returned err: %v", err)
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("PolicySteps mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNative_PolicyStages(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	requireScan := &model.Policy{
+		ID:     1,
+		Name:   "security-scan",
+		Action: model.PolicyDeny,
+		Rule:   model.PolicyRule{Names: []string{"scan"}, Required: true},
+	}
+
+	stages := yaml.StageSlice{
+		&yaml.Stage{
+			Name:  "test",
+			Steps: yaml.StepSlice{&yaml.Step{Image: "golang:latest", Name: "test"}},
+		},
+		&yaml.Stage{
+			Name:  "scan",
+			Steps: yaml.StepSlice{&yaml.Step{Image: "scanner:latest", Name: "scan"}},
+		},
+	}
+
+	compiler, err := New(c)
+	if err != nil {
+		t.Errorf("Unable to create new compiler: %v", err)
+	}
+
+	// run test
+	got, err := compiler.WithPolicies([]*model.Policy{requireScan}).PolicyStages(nil, stages)
+	if err != nil {
+		t.Errorf("PolicyStages returned err: %v", err)
+	}
+
+	if diff := cmp.Diff(stages, got); diff != "" {
+		t.Errorf("PolicyStages mismatch (-want +got):\n%s", diff)
+	}
+
+	// run test without the required step
+	_, err = compiler.PolicyStages(nil, stages[:1])
+	if err == nil {
+		t.Errorf("PolicyStages should have returned err")
+	}
+}
+
+func TestNative_PolicyServices(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	denyRegistry := &model.Policy{
+		ID:     1,
+		Name:   "approved-registry",
+		Action: model.PolicyDeny,
+		Rule:   model.PolicyRule{ExceptImages: []string{"registry.example.com/*"}},
+	}
+
+	mutatePull := &model.Policy{
+		ID:     2,
+		Name:   "always-pull",
+		Action: model.PolicyMutate,
+		Rule: model.PolicyRule{
+			Names:    []string{"postgres"},
+			Mutation: &model.PolicyMutation{Environment: map[string]string{"FOO": "bar"}, Pull: "always"},
+		},
+	}
+
+	compiler, err := New(c)
+	if err != nil {
+		t.Errorf("Unable to create new compiler: %v", err)
+	}
+
+	// run test with a denied image
+	_, err = compiler.WithPolicies([]*model.Policy{denyRegistry}).PolicyServices(yaml.ServiceSlice{
+		&yaml.Service{Image: "docker.io/library/postgres:latest", Name: "postgres"},
+	})
+	if err == nil {
+		t.Errorf("PolicyServices should have returned err")
+	}
+
+	want := []*model.PolicyResult{
+		{
+			PolicyID: 1,
+			Policy:   "approved-registry",
+			Action:   model.PolicyDeny,
+			Step:     "postgres",
+			Message:  "service postgres matches policy approved-registry",
+		},
+	}
+
+	if diff := cmp.Diff(want, compiler.PolicyResults()); diff != "" {
+		t.Errorf("PolicyResults mismatch (-want +got):\n%s", diff)
+	}
+
+	// run test with a mutated service
+	compiler, _ = New(c)
+
+	got, err := compiler.WithPolicies([]*model.Policy{denyRegistry, mutatePull}).PolicyServices(yaml.ServiceSlice{
+		&yaml.Service{Image: "registry.example.com/postgres:latest", Name: "postgres"},
+	})
+	if err != nil {
+		t.Errorf("PolicyServices returned err: %v", err)
+	}
+
+	wantServices := yaml.ServiceSlice{
+		&yaml.Service{
+			Image:       "registry.example.com/postgres:latest",
+			Name:        "postgres",
+			Pull:        "always",
+			Environment: map[string]string{"FOO": "bar"},
+		},
+	}
+
+	if diff := cmp.Diff(wantServices, got); diff != "" {
+		t.Errorf("PolicyServices mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNative_PolicySecrets(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	denyRegistry := &model.Policy{
+		ID:     1,
+		Name:   "approved-registry",
+		Action: model.PolicyDeny,
+		Rule:   model.PolicyRule{ExceptImages: []string{"registry.example.com/*"}},
+	}
+
+	secrets := yaml.SecretSlice{
+		&yaml.Secret{Name: "docker_username", Key: "org/repo/docker/username", Engine: "native", Type: "repo"},
+		&yaml.Secret{
+			Origin: yaml.Origin{
+				Name:  "vault",
+				Image: "docker.io/target/secret-vault:latest",
+			},
+		},
+	}
+
+	compiler, err := New(c)
+	if err != nil {
+		t.Errorf("Unable to create new compiler: %v", err)
+	}
+
+	// run test with a denied origin image
+	_, err = compiler.WithPolicies([]*model.Policy{denyRegistry}).PolicySecrets(secrets)
+	if err == nil {
+		t.Errorf("PolicySecrets should have returned err")
+	}
+
+	want := []*model.PolicyResult{
+		{
+			PolicyID: 1,
+			Policy:   "approved-registry",
+			Action:   model.PolicyDeny,
+			Step:     "vault",
+			Message:  "secret origin vault matches policy approved-registry",
+		},
+	}
+
+	if diff := cmp.Diff(want, compiler.PolicyResults()); diff != "" {
+		t.Errorf("PolicyResults mismatch (-want +got):\n%s", diff)
+	}
+
+	// run test with an approved origin image
+	compiler, _ = New(c)
+
+	secrets[1].Origin.Image = "registry.example.com/target/secret-vault:latest"
+
+	_, err = compiler.WithPolicies([]*model.Policy{denyRegistry}).PolicySecrets(secrets)
+	if err != nil {
+		t.Errorf("PolicySecrets returned err: %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"fmt"
+
+	yml "github.com/buildkite/yaml"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/yaml"
+)
+
+// requiredEnv is the environment variable set on every
+// step injected from a required pipeline, with the name
+// of the required pipeline as the value.
+const requiredEnv = "VELA_REQUIRED_PIPELINE"
+
+// RequiredStages injects the required pipelines
+// for the org into the stages in a yaml configuration.
+func (c *client) RequiredStages(s yaml.StageSlice) (yaml.StageSlice, error) {
+	c.injected = []*model.InjectedStep{}
+
+	for _, required := range c.required {
+		if !required.Active {
+			continue
+		}
+
+		p, err := parseRequired(required)
+		if err != nil {
+			return nil, err
+		}
+
+		stages := p.Stages
+
+		// wrap the steps of the required pipeline in a stage
+		if len(stages) == 0 {
+			stages = yaml.StageSlice{
+				&yaml.Stage{
+					Name:  required.Name,
+					Needs: []string{cloneStageName},
+					Steps: p.Steps,
+				},
+			}
+		}
+
+		names := []string{}
+
+		for _, stage := range stages {
+			for _, existing := range s {
+				if stage.Name == existing.Name {
+					return nil, fmt.Errorf("required pipeline %s: stage %s already exists", required.Name, stage.Name)
+				}
+			}
+
+			for _, step := range stage.Steps {
+				c.markInjected(required, stage.Name, step)
+			}
+
+			names = append(names, stage.Name)
+		}
+
+		if required.Position == model.RequiredPipelineBefore {
+			// every stage in the pipeline waits for the required stages
+			for _, stage := range s {
+				if stage.Name == initStageName || stage.Name == cloneStageName {
+					continue
+				}
+
+				stage.Needs = append(stage.Needs, names...)
+			}
+
+			s = insertStages(s, stages, injectIndexStages(s))
+
+			continue
+		}
+
+		// every required stage waits for the stages in the pipeline
+		for _, stage := range stages {
+			for _, existing := range s {
+				if existing.Name == initStageName || existing.Name == cloneStageName {
+					continue
+				}
+
+				stage.Needs = append(stage.Needs, existing.Name)
+			}
+		}
+
+		s = append(s, stages...)
+	}
+
+	return s, nil
+}
+
+// RequiredSteps injects the required pipelines
+// for the org into the steps in a yaml configuration.
+func (c *client) RequiredSteps(s yaml.StepSlice) (yaml.StepSlice, error) {
+	c.injected = []*model.InjectedStep{}
+
+	for _, required := range c.required {
+		if !required.Active {
+			continue
+		}
+
+		p, err := parseRequired(required)
+		if err != nil {
+			return nil, err
+		}
+
+		steps := p.Steps
+
+		// flatten the stages of the required pipeline into steps
+		for _, stage := range p.Stages {
+			steps = append(steps, stage.Steps...)
+		}
+
+		for _, step := range steps {
+			for _, existing := range s {
+				if step.Name == existing.Name {
+					return nil, fmt.Errorf("required pipeline %s: step %s already exists", required.Name, step.Name)
+				}
+			}
+
+			c.markInjected(required, "", step)
+		}
+
+		if required.Position == model.RequiredPipelineBefore {
+			s = insertSteps(s, steps, injectIndexSteps(s))
+
+			continue
+		}
+
+		s = append(s, steps...)
+	}
+
+	return s, nil
+}
+
+// InjectedSteps returns the steps injected from
+// required pipelines while compiling the pipeline.
+func (c *client) InjectedSteps() []*model.InjectedStep {
+	return c.injected
+}
+
+// markInjected is a helper function to mark a step as
+// injected from a required pipeline and record it.
+func (c *client) markInjected(r *model.RequiredPipeline, stage string, s *yaml.Step) {
+	if s.Environment == nil {
+		s.Environment = make(map[string]string)
+	}
+
+	s.Environment[requiredEnv] = r.Name
+
+	c.injected = append(c.injected, &model.InjectedStep{
+		Pipeline: r.Name,
+		Stage:    stage,
+		Step:     s.Name,
+	})
+}
+
+// parseRequired is a helper function to parse
+// the steps or stages from a required pipeline.
+func parseRequired(r *model.RequiredPipeline) (*yaml.Build, error) {
+	p := new(yaml.Build)
+
+	err := yml.Unmarshal([]byte(r.Pipeline), p)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse required pipeline %s: %w", r.Name, err)
+	}
+
+	if len(p.Stages) == 0 && len(p.Steps) == 0 {
+		return nil, fmt.Errorf("no stages or steps provided for required pipeline %s", r.Name)
+	}
+
+	return p, nil
+}
+
+// injectIndexStages is a helper function to find the index
+// after the init and clone stages in the stages.
+func injectIndexStages(s yaml.StageSlice) int {
+	i := 0
+
+	for i < len(s) && (s[i].Name == initStageName || s[i].Name == cloneStageName) {
+		i++
+	}
+
+	return i
+}
+
+// injectIndexSteps is a helper function to find the index
+// after the init and clone steps in the steps.
+func injectIndexSteps(s yaml.StepSlice) int {
+	i := 0
+
+	for i < len(s) && (s[i].Name == initStepName || s[i].Name == cloneStepName) {
+		i++
+	}
+
+	return i
+}
+
+// insertStages is a helper function to insert
+// stages at an index in the stages.
+func insertStages(s, stages yaml.StageSlice, i int) yaml.StageSlice {
+	out := append(yaml.StageSlice{}, s[:i]...)
+	out = append(out, stages...)
+
+	return append(out, s[i:]...)
+}
+
+// insertSteps is a helper function to insert
+// steps at an index in the steps.
+func insertSteps(s, steps yaml.StepSlice, i int) yaml.StepSlice {
+	out := append(yaml.StepSlice{}, s[:i]...)
+	out = append(out, steps...)
+
+	return append(out, s[i:]...)
+}
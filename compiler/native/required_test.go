@@ -0,0 +1,271 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/yaml"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	requiredSteps = `
+version: "1"
+steps:
+  - name: scan
+    image: alpine:latest
+    commands:
+      - echo scan
+`
+
+	requiredStages = `
+version: "1"
+stages:
+  audit:
+    steps:
+      - name: audit
+        image: alpine:latest
+        commands:
+          - echo audit
+`
+)
+
+func TestNative_RequiredSteps(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	steps := func() yaml.StepSlice {
+		return yaml.StepSlice{
+			&yaml.Step{Image: initImage, Name: initStepName},
+			&yaml.Step{Image: "target/vela-git:latest", Name: cloneStepName},
+			&yaml.Step{Image: "golang:latest", Name: "test"},
+		}
+	}
+
+	// setup tests
+	tests := []struct {
+		name     string
+		failure  bool
+		required []*model.RequiredPipeline
+		steps    yaml.StepSlice
+		want     []string
+		injected []*model.InjectedStep
+	}{
+		{
+			name: "before",
+			required: []*model.RequiredPipeline{
+				{Name: "security", Position: model.RequiredPipelineBefore, Pipeline: requiredSteps, Active: true},
+			},
+			steps:    steps(),
+			want:     []string{initStepName, cloneStepName, "scan", "test"},
+			injected: []*model.InjectedStep{{Pipeline: "security", Step: "scan"}},
+		},
+		{
+			name: "after",
+			required: []*model.RequiredPipeline{
+				{Name: "security", Position: model.RequiredPipelineAfter, Pipeline: requiredSteps, Active: true},
+			},
+			steps:    steps(),
+			want:     []string{initStepName, cloneStepName, "test", "scan"},
+			injected: []*model.InjectedStep{{Pipeline: "security", Step: "scan"}},
+		},
+		{
+			name: "stages flattened into steps",
+			required: []*model.RequiredPipeline{
+				{Name: "audit", Position: model.RequiredPipelineAfter, Pipeline: requiredStages, Active: true},
+			},
+			steps:    steps(),
+			want:     []string{initStepName, cloneStepName, "test", "audit"},
+			injected: []*model.InjectedStep{{Pipeline: "audit", Step: "audit"}},
+		},
+		{
+			name: "inactive",
+			required: []*model.RequiredPipeline{
+				{Name: "security", Position: model.RequiredPipelineBefore, Pipeline: requiredSteps},
+			},
+			steps:    steps(),
+			want:     []string{initStepName, cloneStepName, "test"},
+			injected: []*model.InjectedStep{},
+		},
+		{
+			name:    "conflicting step name",
+			failure: true,
+			required: []*model.RequiredPipeline{
+				{Name: "security", Position: model.RequiredPipelineAfter, Pipeline: requiredSteps, Active: true},
+			},
+			steps: yaml.StepSlice{&yaml.Step{Image: "golang:latest", Name: "scan"}},
+		},
+		{
+			name:    "invalid pipeline",
+			failure: true,
+			required: []*model.RequiredPipeline{
+				{Name: "security", Position: model.RequiredPipelineAfter, Pipeline: `version: "1"`, Active: true},
+			},
+			steps: steps(),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compiler, err := New(c)
+			if err != nil {
+				t.Errorf("Unable to create new compiler: %v", err)
+			}
+
+			got, err := compiler.WithRequiredPipelines(test.required).RequiredSteps(test.steps)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("RequiredSteps should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("RequiredSteps returned err: %v", err)
+			}
+
+			names := []string{}
+
+			for _, step := range got {
+				names = append(names, step.Name)
+
+				for _, injected := range test.injected {
+					if step.Name == injected.Step && step.Environment[requiredEnv] != injected.Pipeline {
+						t.Errorf("RequiredSteps %s env is %v, want %s", step.Name, step.Environment, injected.Pipeline)
+					}
+				}
+			}
+
+			if diff := cmp.Diff(test.want, names); diff != "" {
+				t.Errorf("RequiredSteps mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(test.injected, compiler.InjectedSteps()); diff != "" {
+				t.Errorf("InjectedSteps mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNative_RequiredStages(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	stages := func() yaml.StageSlice {
+		return yaml.StageSlice{
+			&yaml.Stage{Name: initStageName},
+			&yaml.Stage{Name: cloneStageName, Needs: []string{initStageName}},
+			&yaml.Stage{
+				Name:  "test",
+				Needs: []string{cloneStageName},
+				Steps: yaml.StepSlice{&yaml.Step{Image: "golang:latest", Name: "test"}},
+			},
+		}
+	}
+
+	// setup tests
+	tests := []struct {
+		name     string
+		failure  bool
+		required []*model.RequiredPipeline
+		stages   yaml.StageSlice
+		want     map[string][]string
+		order    []string
+		injected []*model.InjectedStep
+	}{
+		{
+			name: "steps wrapped in stage before",
+			required: []*model.RequiredPipeline{
+				{Name: "security", Position: model.RequiredPipelineBefore, Pipeline: requiredSteps, Active: true},
+			},
+			stages: stages(),
+			order:  []string{initStageName, cloneStageName, "security", "test"},
+			want: map[string][]string{
+				"security": {cloneStageName},
+				"test":     {cloneStageName, "security"},
+			},
+			injected: []*model.InjectedStep{{Pipeline: "security", Stage: "security", Step: "scan"}},
+		},
+		{
+			name: "stages after",
+			required: []*model.RequiredPipeline{
+				{Name: "audit", Position: model.RequiredPipelineAfter, Pipeline: requiredStages, Active: true},
+			},
+			stages: stages(),
+			order:  []string{initStageName, cloneStageName, "test", "audit"},
+			want: map[string][]string{
+				"audit": {cloneStageName, "test"},
+				"test":  {cloneStageName},
+			},
+			injected: []*model.InjectedStep{{Pipeline: "audit", Stage: "audit", Step: "audit"}},
+		},
+		{
+			name:    "conflicting stage name",
+			failure: true,
+			required: []*model.RequiredPipeline{
+				{Name: "test", Position: model.RequiredPipelineAfter, Pipeline: requiredSteps, Active: true},
+			},
+			stages: stages(),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compiler, err := New(c)
+			if err != nil {
+				t.Errorf("Unable to create new compiler: %v", err)
+			}
+
+			got, err := compiler.WithRequiredPipelines(test.required).RequiredStages(test.stages)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("RequiredStages should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("RequiredStages returned err: %v", err)
+			}
+
+			order := []string{}
+
+			for _, stage := range got {
+				order = append(order, stage.Name)
+
+				needs, ok := test.want[stage.Name]
+				if !ok {
+					continue
+				}
+
+				if diff := cmp.Diff(needs, []string(stage.Needs)); diff != "" {
+					t.Errorf("RequiredStages %s needs mismatch (-want +got):\n%s", stage.Name, diff)
+				}
+			}
+
+			if diff := cmp.Diff(test.order, order); diff != "" {
+				t.Errorf("RequiredStages mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(test.injected, compiler.InjectedSteps()); diff != "" {
+				t.Errorf("InjectedSteps mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/raw"
+	"github.com/go-vela/types/yaml"
+)
+
+type (
+	// rulesetExtension represents the rules for the pull request
+	// of a build in a ruleset that aren't part of the yaml types.
+	// The rules are combined with the rules of the ruleset from
+	// the yaml types under the operator of the ruleset.
+	rulesetExtension struct {
+		If       rulesExtension
+		Unless   rulesExtension
+		Matcher  string
+		Operator string
+	}
+
+	// rulesExtension represents the rules for
+	// the pull request of a build in a ruleset.
+	rulesExtension struct {
+		Label             raw.StringSlice `yaml:"label,omitempty"`
+		Draft             *bool           `yaml:"draft,omitempty"`
+		AuthorAssociation raw.StringSlice `yaml:"author_association,omitempty"`
+	}
+)
+
+// UnmarshalYAML implements the Unmarshaler interface for the rulesetExtension type.
+func (r *rulesetExtension) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// simple struct we try unmarshalling to
+	simple := new(rulesExtension)
+
+	// advanced struct we try unmarshalling to
+	advanced := new(struct {
+		If       rulesExtension `yaml:"if,omitempty"`
+		Unless   rulesExtension `yaml:"unless,omitempty"`
+		Matcher  string         `yaml:"matcher,omitempty"`
+		Operator string         `yaml:"operator,omitempty"`
+	})
+
+	// attempt to unmarshal simple ruleset
+	// nolint:errcheck // intentionally not handling error
+	unmarshal(simple)
+	// attempt to unmarshal advanced ruleset
+	// nolint:errcheck // intentionally not handling error
+	unmarshal(advanced)
+
+	r.Unless = advanced.Unless
+	r.Matcher = advanced.Matcher
+	r.Operator = advanced.Operator
+
+	// implicitly add simple ruleset to the advanced ruleset for each rule type
+	advanced.If.Label = append(advanced.If.Label, simple.Label...)
+	advanced.If.AuthorAssociation = append(advanced.If.AuthorAssociation, simple.AuthorAssociation...)
+
+	if advanced.If.Draft == nil {
+		advanced.If.Draft = simple.Draft
+	}
+
+	r.If = advanced.If
+
+	// implicitly set `matcher` field if empty for ruleset
+	if len(r.Matcher) == 0 {
+		r.Matcher = constants.MatcherFilepath
+	}
+
+	// implicitly set `operator` field if empty for ruleset
+	if len(r.Operator) == 0 {
+		r.Operator = constants.OperatorAnd
+	}
+
+	return nil
+}
+
+// match returns true when the pull request matches the ruleset.
+// A build without a pull request is matched as a pull request
+// without any labels, draft state or author association.
+func (r *rulesetExtension) match(p *model.BuildPullRequest) (bool, error) {
+	// return true when no rules are provided
+	if r == nil || (r.If.empty() && r.Unless.empty()) {
+		return true, nil
+	}
+
+	if p == nil {
+		p = new(model.BuildPullRequest)
+	}
+
+	if !r.If.empty() {
+		match, err := r.If.match(p, r.Matcher, r.Operator)
+		if err != nil || !match {
+			return false, err
+		}
+	}
+
+	if !r.Unless.empty() {
+		match, err := r.Unless.match(p, r.Matcher, r.Operator)
+		if err != nil || match {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// apply returns true when the pull request matches the ruleset
+// combined with the ruleset of the step from the yaml types under
+// the shared operator. The rules from the yaml types are matched
+// later by the compiler and the worker, so they are updated when
+// the result of the combined rules is already decided by the pull
+// request. The if rules are replaced when the pull request matches
+// them with the or operator and the unless rules are removed when
+// the pull request doesn't match them with the and operator.
+func (r *rulesetExtension) apply(ruleset *yaml.Ruleset, p *model.BuildPullRequest) (bool, error) {
+	// return true when no rules are provided
+	if r == nil || (r.If.empty() && r.Unless.empty()) {
+		return true, nil
+	}
+
+	if p == nil {
+		p = new(model.BuildPullRequest)
+	}
+
+	or := strings.EqualFold(r.Operator, constants.OperatorOr)
+
+	if !r.If.empty() {
+		match, err := r.If.match(p, r.Matcher, r.Operator)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case match && or:
+			ruleset.If = satisfiedRules(ruleset.If)
+		case !match && (!or || ruleset.If.ToPipeline().Empty()):
+			return false, nil
+		}
+	}
+
+	if !r.Unless.empty() {
+		match, err := r.Unless.match(p, r.Matcher, r.Operator)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case match && (or || ruleset.Unless.ToPipeline().Empty()):
+			return false, nil
+		case !match && !or:
+			ruleset.Unless = yaml.Rules{}
+		}
+	}
+
+	return true, nil
+}
+
+// satisfiedRules is a helper function to replace the if rules
+// from the yaml types that are satisfied by the pull request.
+// The rules for the status of the build are kept as a match
+// for any status so the step still runs after a failure.
+func satisfiedRules(rules yaml.Rules) yaml.Rules {
+	if len(rules.Status) == 0 {
+		return yaml.Rules{}
+	}
+
+	return yaml.Rules{
+		Status: []string{constants.StatusSuccess, constants.StatusFailure},
+	}
+}
+
+// empty returns true when no rules are provided.
+func (r *rulesExtension) empty() bool {
+	return len(r.Label) == 0 && r.Draft == nil && len(r.AuthorAssociation) == 0
+}
+
+// match returns true when the pull request matches the
+// rules. With the or operator any of the provided rules
+// must match, otherwise all of them must match.
+func (r *rulesExtension) match(p *model.BuildPullRequest, matcher, operator string) (bool, error) {
+	results := []bool{}
+
+	if len(r.Label) > 0 {
+		match, err := matchLabels(r.Label, p.Labels, matcher)
+		if err != nil {
+			return false, err
+		}
+
+		results = append(results, match)
+	}
+
+	if r.Draft != nil {
+		results = append(results, *r.Draft == p.Draft)
+	}
+
+	if len(r.AuthorAssociation) > 0 {
+		match := false
+
+		for _, association := range r.AuthorAssociation {
+			if strings.EqualFold(association, p.AuthorAssociation) {
+				match = true
+			}
+		}
+
+		results = append(results, match)
+	}
+
+	for _, result := range results {
+		if operator == constants.OperatorOr && result {
+			return true, nil
+		}
+
+		if operator != constants.OperatorOr && !result {
+			return false, nil
+		}
+	}
+
+	return operator != constants.OperatorOr, nil
+}
+
+// matchLabels is a helper function that returns true
+// when any label matches any of the provided patterns.
+func matchLabels(patterns, labels []string, matcher string) (bool, error) {
+	for _, pattern := range patterns {
+		for _, label := range labels {
+			var (
+				match bool
+				err   error
+			)
+
+			switch matcher {
+			case constants.MatcherRegex:
+				match, err = regexp.MatchString(pattern, label)
+			default:
+				match, err = filepath.Match(pattern, label)
+			}
+
+			if err != nil {
+				return false, fmt.Errorf("invalid label pattern %s: %v", pattern, err)
+			}
+
+			if match {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// PurgeSteps removes every step where the pull request of the
+// build doesn't match the rules provided in the extensions.
+func (c *client) PurgeSteps(s yaml.StepSlice) (yaml.StepSlice, error) {
+	return c.purgeSteps(s, c.extensions.steps(""))
+}
+
+// purgeSteps is a helper function that removes every step
+// where the pull request of the build doesn't match the
+// rules provided in the extensions for the step combined
+// with the ruleset of the step.
+func (c *client) purgeSteps(s yaml.StepSlice, exts []*stepExtension) (yaml.StepSlice, error) {
+	rulesets := make(map[string]*rulesetExtension)
+
+	for _, ext := range exts {
+		if ext.Ruleset != nil {
+			rulesets[ext.Name] = ext.Ruleset
+		}
+	}
+
+	// skip if no ruleset is provided for the steps
+	if len(rulesets) == 0 {
+		return s, nil
+	}
+
+	steps := yaml.StepSlice{}
+
+	for _, step := range s {
+		match, err := rulesets[step.Name].apply(&step.Ruleset, c.pull)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ruleset for step %s: %v", step.Name, err)
+		}
+
+		if match {
+			steps = append(steps, step)
+		}
+	}
+
+	return steps, nil
+}
+
+// PurgeStages removes every stage where the pull request of
+// the build doesn't match the rules provided in the extensions
+// for the stage. The stages without any steps left after
+// removing the steps are removed as well.
+func (c *client) PurgeStages(s yaml.StageSlice) (yaml.StageSlice, error) {
+	stages := yaml.StageSlice{}
+	// purged will hold the names of the removed stages
+	purged := make(map[string]bool)
+
+	for _, stage := range s {
+		var ruleset *rulesetExtension
+
+		if ext := c.extensions.stage(stage.Name); ext != nil {
+			ruleset = ext.Ruleset
+		}
+
+		match, err := ruleset.match(c.pull)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ruleset for stage %s: %v", stage.Name, err)
+		}
+
+		if !match {
+			purged[stage.Name] = true
+
+			continue
+		}
+
+		steps, err := c.purgeSteps(stage.Steps, c.extensions.steps(stage.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		// remove the stage when all of the steps were removed
+		if len(steps) == 0 && len(stage.Steps) > 0 {
+			purged[stage.Name] = true
+
+			continue
+		}
+
+		stage.Steps = steps
+
+		stages = append(stages, stage)
+	}
+
+	// skip if no stage was removed
+	if len(purged) == 0 {
+		return stages, nil
+	}
+
+	// remove the needs for the removed stages
+	for _, stage := range stages {
+		if len(stage.Needs) == 0 {
+			continue
+		}
+
+		needs := []string{}
+
+		for _, need := range stage.Needs {
+			if !purged[need] {
+				needs = append(needs, need)
+			}
+		}
+
+		stage.Needs = needs
+	}
+
+	return stages, nil
+}
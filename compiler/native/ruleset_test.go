@@ -0,0 +1,455 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/buildkite/yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/urfave/cli/v2"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types"
+	"github.com/go-vela/types/library"
+)
+
+func TestNative_rulesetExtension_UnmarshalYAML(t *testing.T) {
+	// setup types
+	draft := true
+
+	// setup tests
+	tests := []struct {
+		name   string
+		config string
+		want   *rulesetExtension
+	}{
+		{
+			name: "simple",
+			config: `
+label: [ deploy ]
+draft: true
+event: [ pull_request ]
+`,
+			want: &rulesetExtension{
+				If:       rulesExtension{Label: []string{"deploy"}, Draft: &draft},
+				Matcher:  "filepath",
+				Operator: "and",
+			},
+		},
+		{
+			name: "advanced",
+			config: `
+if:
+  author_association: MEMBER
+unless:
+  label: [ skip-* ]
+matcher: regexp
+operator: or
+`,
+			want: &rulesetExtension{
+				If:       rulesExtension{AuthorAssociation: []string{"MEMBER"}},
+				Unless:   rulesExtension{Label: []string{"skip-*"}},
+				Matcher:  "regexp",
+				Operator: "or",
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := new(rulesetExtension)
+
+			err := yaml.Unmarshal([]byte(test.config), got)
+			if err != nil {
+				t.Errorf("UnmarshalYAML returned err: %v", err)
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("UnmarshalYAML() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNative_rulesetExtension_match(t *testing.T) {
+	// setup types
+	draft, ready := true, false
+
+	pull := &model.BuildPullRequest{
+		Labels:            model.Labels{"deploy-staging", "docs"},
+		Draft:             true,
+		AuthorAssociation: "CONTRIBUTOR",
+	}
+
+	// setup tests
+	tests := []struct {
+		name    string
+		ruleset *rulesetExtension
+		pull    *model.BuildPullRequest
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "no ruleset",
+			pull: pull,
+			want: true,
+		},
+		{
+			name:    "label",
+			ruleset: &rulesetExtension{If: rulesExtension{Label: []string{"deploy-*"}}},
+			pull:    pull,
+			want:    true,
+		},
+		{
+			name:    "label regexp",
+			ruleset: &rulesetExtension{If: rulesExtension{Label: []string{"^deploy-(staging|prod)$"}}, Matcher: "regexp"},
+			pull:    pull,
+			want:    true,
+		},
+		{
+			name:    "label invalid regexp",
+			ruleset: &rulesetExtension{If: rulesExtension{Label: []string{"^deploy-("}}, Matcher: "regexp"},
+			pull:    pull,
+			wantErr: true,
+		},
+		{
+			name:    "draft",
+			ruleset: &rulesetExtension{If: rulesExtension{Draft: &ready}},
+			pull:    pull,
+			want:    false,
+		},
+		{
+			name:    "author association",
+			ruleset: &rulesetExtension{If: rulesExtension{AuthorAssociation: []string{"owner", "contributor"}}},
+			pull:    pull,
+			want:    true,
+		},
+		{
+			name:    "and operator",
+			ruleset: &rulesetExtension{If: rulesExtension{Label: []string{"docs"}, Draft: &ready}, Operator: "and"},
+			pull:    pull,
+			want:    false,
+		},
+		{
+			name:    "or operator",
+			ruleset: &rulesetExtension{If: rulesExtension{Label: []string{"docs"}, Draft: &ready}, Operator: "or"},
+			pull:    pull,
+			want:    true,
+		},
+		{
+			name:    "unless",
+			ruleset: &rulesetExtension{Unless: rulesExtension{Draft: &draft}},
+			pull:    pull,
+			want:    false,
+		},
+		{
+			name:    "no pull request",
+			ruleset: &rulesetExtension{If: rulesExtension{Label: []string{"*"}}},
+			want:    false,
+		},
+		{
+			name:    "no pull request unless",
+			ruleset: &rulesetExtension{Unless: rulesExtension{Draft: &draft}},
+			want:    true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.ruleset.match(test.pull)
+
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("match should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("match returned err: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("match is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNative_Compile_PullRequest(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	str := "foo"
+	r := &library.Repo{Org: &str, Name: &str, FullName: &str}
+
+	b := new(library.Build)
+	b.SetEvent("pull_request")
+	b.SetRef("refs/pull/1/head")
+
+	m := &types.Metadata{
+		Database: &types.Database{Driver: str, Host: str},
+		Queue:    &types.Queue{Channel: str, Driver: str, Host: str},
+		Source:   &types.Source{Driver: str, Host: str},
+		Vela:     &types.Vela{Address: str, WebAddress: str},
+	}
+
+	steps := `
+version: "1"
+steps:
+  - name: test
+    image: alpine
+    commands:
+      - echo test
+
+  - name: preview
+    image: alpine
+    commands:
+      - echo preview
+    ruleset:
+      event: [ pull_request ]
+      label: [ preview ]
+
+  - name: review
+    image: alpine
+    commands:
+      - echo review
+    ruleset:
+      unless:
+        draft: true
+`
+
+	stages := `
+version: "1"
+stages:
+  test:
+    steps:
+      - name: test
+        image: alpine
+        commands:
+          - echo test
+
+  preview:
+    ruleset:
+      label: [ preview ]
+    steps:
+      - name: preview
+        image: alpine
+        commands:
+          - echo preview
+
+  review:
+    needs: [ preview ]
+    steps:
+      - name: review
+        image: alpine
+        commands:
+          - echo review
+        ruleset:
+          author_association: [ MEMBER, OWNER ]
+`
+
+	// setup tests
+	tests := []struct {
+		name      string
+		config    string
+		pull      *model.BuildPullRequest
+		wantSteps []string
+		wantNeeds map[string][]string
+	}{
+		{
+			name:      "steps",
+			config:    steps,
+			pull:      &model.BuildPullRequest{Labels: model.Labels{"preview"}},
+			wantSteps: []string{"init", "clone", "test", "preview", "review"},
+		},
+		{
+			name:      "steps draft",
+			config:    steps,
+			pull:      &model.BuildPullRequest{Draft: true},
+			wantSteps: []string{"init", "clone", "test"},
+		},
+		{
+			name:      "steps without pull request",
+			config:    steps,
+			wantSteps: []string{"init", "clone", "test", "review"},
+		},
+		{
+			name:      "stages",
+			config:    stages,
+			pull:      &model.BuildPullRequest{Labels: model.Labels{"preview"}, AuthorAssociation: "MEMBER"},
+			wantSteps: []string{"test", "preview", "review"},
+			wantNeeds: map[string][]string{
+				"test":    {"clone"},
+				"preview": {"clone"},
+				"review":  {"preview", "clone"},
+			},
+		},
+		{
+			name:      "stages purged",
+			config:    stages,
+			pull:      &model.BuildPullRequest{AuthorAssociation: "CONTRIBUTOR"},
+			wantSteps: []string{"test"},
+			wantNeeds: map[string][]string{
+				"test": {"clone"},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compiler, err := New(c)
+			if err != nil {
+				t.Errorf("Creating new compiler returned err: %v", err)
+			}
+
+			got, err := compiler.WithBuild(b).WithRepo(r).WithMetadata(m).WithPullRequest(test.pull).Compile([]byte(test.config))
+			if err != nil {
+				t.Errorf("Compile returned err: %v", err)
+			}
+
+			names := []string{}
+			for _, step := range got.Steps {
+				names = append(names, step.Name)
+			}
+
+			needs := map[string][]string{}
+
+			for _, stage := range got.Stages {
+				if stage.Name == "init" || stage.Name == "clone" {
+					continue
+				}
+
+				needs[stage.Name] = stage.Needs
+
+				for _, step := range stage.Steps {
+					names = append(names, step.Name)
+				}
+			}
+
+			if diff := cmp.Diff(test.wantSteps, names); diff != "" {
+				t.Errorf("Compile() steps mismatch (-want +got):\n%s", diff)
+			}
+
+			if test.wantNeeds != nil {
+				if diff := cmp.Diff(test.wantNeeds, needs); diff != "" {
+					t.Errorf("Compile() needs mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestNative_Compile_PullRequest_Or(t *testing.T) {
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	c := cli.NewContext(nil, set, nil)
+
+	str := "foo"
+	r := &library.Repo{Org: &str, Name: &str, FullName: &str}
+
+	b := new(library.Build)
+	b.SetEvent("pull_request")
+	b.SetBranch("feature")
+	b.SetRef("refs/pull/1/head")
+
+	m := &types.Metadata{
+		Database: &types.Database{Driver: str, Host: str},
+		Queue:    &types.Queue{Channel: str, Driver: str, Host: str},
+		Source:   &types.Source{Driver: str, Host: str},
+		Vela:     &types.Vela{Address: str, WebAddress: str},
+	}
+
+	config := `
+version: "1"
+steps:
+  - name: e2e
+    image: alpine
+    commands:
+      - echo e2e
+    ruleset:
+      if:
+        branch: main
+        label: e2e
+      operator: or
+
+  - name: docs
+    image: alpine
+    commands:
+      - echo docs
+    ruleset:
+      if:
+        label: docs
+        draft: true
+      operator: or
+`
+
+	// setup tests
+	tests := []struct {
+		name       string
+		pull       *model.BuildPullRequest
+		wantSteps  []string
+		wantBranch map[string][]string
+	}{
+		{
+			name:       "label matches",
+			pull:       &model.BuildPullRequest{Labels: model.Labels{"e2e"}},
+			wantSteps:  []string{"init", "clone", "e2e"},
+			wantBranch: map[string][]string{"e2e": nil},
+		},
+		{
+			name:       "label doesn't match",
+			pull:       &model.BuildPullRequest{Draft: true},
+			wantSteps:  []string{"init", "clone", "e2e", "docs"},
+			wantBranch: map[string][]string{"e2e": {"main"}, "docs": nil},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compiler, err := New(c)
+			if err != nil {
+				t.Errorf("Creating new compiler returned err: %v", err)
+			}
+
+			got, err := compiler.WithBuild(b).WithRepo(r).WithMetadata(m).WithPullRequest(test.pull).Compile([]byte(config))
+			if err != nil {
+				t.Errorf("Compile returned err: %v", err)
+			}
+
+			names := []string{}
+			branches := map[string][]string{}
+
+			for _, step := range got.Steps {
+				names = append(names, step.Name)
+
+				if step.Name == "init" || step.Name == "clone" {
+					continue
+				}
+
+				branches[step.Name] = step.Ruleset.If.Branch
+			}
+
+			if diff := cmp.Diff(test.wantSteps, names); diff != "" {
+				t.Errorf("Compile() steps mismatch (-want +got):\n%s", diff)
+			}
+
+			// the branch rule is left for the worker when the pull request doesn't match
+			if diff := cmp.Diff(test.wantBranch, branches, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Compile() branch rules mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
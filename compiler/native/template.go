@@ -0,0 +1,102 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-vela/server/compiler/registry"
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/yaml"
+)
+
+// digestPrefix represents the algorithm supported
+// for verifying the digest of a template.
+const digestPrefix = "sha256:"
+
+// digest is a helper function that
+// returns the digest for a template.
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return digestPrefix + hex.EncodeToString(sum[:])
+}
+
+// requirePinned is a helper function that returns true
+// if the repo is in an org that must pin its templates.
+func (c *client) requirePinned() bool {
+	for _, org := range c.TemplatePinnedOrgs {
+		if strings.EqualFold(org, c.repo.GetOrg()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pinnedCommit is a helper function that returns the commit
+// recorded for the template when the templates for a build
+// are provided.
+func (c *client) pinnedCommit(name string) string {
+	for _, t := range c.pinned {
+		if t.Name == name && strings.EqualFold(t.Type, "github") {
+			return t.Commit
+		}
+	}
+
+	return ""
+}
+
+// verifyTemplate is a helper function that verifies the template
+// is pinned and matches the digest provided for it. The template
+// is recorded with the commit it was captured from and its digest.
+func (c *client) verifyTemplate(tmpl *yaml.Template, ref, commit string, data []byte) error {
+	want := c.extensions.digest(tmpl.Name)
+
+	// ensure the template is pinned when required for the repo
+	if c.requirePinned() {
+		switch {
+		case strings.EqualFold(tmpl.Type, "github") && !registry.IsSHA(ref):
+			return fmt.Errorf("template %s must be pinned to a commit SHA for repos in the %s org", tmpl.Name, c.repo.GetOrg())
+		case strings.EqualFold(tmpl.Type, "http") && len(want) == 0:
+			return fmt.Errorf("template %s must provide a digest for repos in the %s org", tmpl.Name, c.repo.GetOrg())
+		}
+	}
+
+	got := digest(data)
+
+	// ensure the template matches the digest provided for it
+	if len(want) > 0 {
+		if !strings.HasPrefix(strings.ToLower(want), digestPrefix) {
+			return fmt.Errorf("unsupported digest %s provided for template %s", want, tmpl.Name)
+		}
+
+		if !strings.EqualFold(want, got) {
+			return fmt.Errorf("digest %s for template %s does not match provided digest %s", got, tmpl.Name, want)
+		}
+	}
+
+	// only record the template once for every step using it
+	for _, t := range c.templates {
+		if t.Name == tmpl.Name {
+			return nil
+		}
+	}
+
+	c.templates = append(c.templates, &model.BuildTemplate{
+		Name:   tmpl.Name,
+		Source: tmpl.Source,
+		Type:   tmpl.Type,
+		Ref:    ref,
+		Commit: commit,
+		Digest: got,
+	})
+
+	return nil
+}
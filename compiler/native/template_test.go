@@ -0,0 +1,310 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package native
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/raw"
+	"github.com/go-vela/types/yaml"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/urfave/cli/v2"
+)
+
+func TestNative_ExpandSteps_TemplatePinning(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	// track the refs used to capture the template
+	refs := []string{}
+
+	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/commits/:ref", func(c *gin.Context) {
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
+	engine.GET("/api/v3/repos/foo/bar/contents/:path", func(c *gin.Context) {
+		refs = append(refs, c.Query("ref"))
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/template.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	set.Bool("github-driver", true, "doc")
+	set.String("github-url", s.URL, "doc")
+	set.String("github-token", "", "doc")
+	set.Var(cli.NewStringSlice("pinned"), "template-pinned-orgs", "doc")
+	c := cli.NewContext(nil, set, nil)
+
+	steps := yaml.StepSlice{
+		&yaml.Step{
+			Name: "sample",
+			Template: yaml.StepTemplate{
+				Name: "gradle",
+				Variables: map[string]interface{}{
+					"image":       "openjdk:latest",
+					"environment": "{ GRADLE_USER_HOME: .gradle }",
+					"pull_policy": "pull: true",
+				},
+			},
+		},
+		&yaml.Step{
+			Name: "other",
+			Template: yaml.StepTemplate{
+				Name: "gradle",
+				Variables: map[string]interface{}{
+					"image":       "openjdk:latest",
+					"environment": "{ GRADLE_USER_HOME: .gradle }",
+					"pull_policy": "pull: true",
+				},
+			},
+		},
+	}
+
+	compiler, err := New(c)
+	if err != nil {
+		t.Errorf("Creating new compiler returned err: %v", err)
+	}
+
+	// capture the digest of the template
+	_, _, _, _, err = compiler.ExpandSteps(&yaml.Build{Steps: steps}, map[string]*yaml.Template{
+		"gradle": {Name: "gradle", Source: "github.example.com/foo/bar/template.yml", Type: "github"},
+	})
+	if err != nil {
+		t.Errorf("ExpandSteps returned err: %v", err)
+	}
+
+	if len(compiler.Templates()) != 1 {
+		t.Fatalf("Templates returned %d templates, want 1", len(compiler.Templates()))
+	}
+
+	sum := compiler.Templates()[0].Digest
+
+	// setup tests
+	tests := []struct {
+		name    string
+		org     string
+		source  string
+		digest  string
+		want    []*model.BuildTemplate
+		wantErr bool
+	}{
+		{
+			name:   "default branch",
+			org:    "foo",
+			source: "github.example.com/foo/bar/template.yml",
+			want: []*model.BuildTemplate{
+				{
+					Name:   "gradle",
+					Source: "github.example.com/foo/bar/template.yml",
+					Type:   "github",
+					Commit: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+					Digest: sum,
+				},
+			},
+		},
+		{
+			name:   "branch with digest",
+			org:    "foo",
+			source: "github.example.com/foo/bar/template.yml@main",
+			digest: sum,
+			want: []*model.BuildTemplate{
+				{
+					Name:   "gradle",
+					Source: "github.example.com/foo/bar/template.yml@main",
+					Type:   "github",
+					Ref:    "main",
+					Commit: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+					Digest: sum,
+				},
+			},
+		},
+		{
+			name:   "pinned org with commit",
+			org:    "pinned",
+			source: "github.example.com/foo/bar/template.yml@48afb5bdc41ad69bf22588491333f7cf71135163",
+			want: []*model.BuildTemplate{
+				{
+					Name:   "gradle",
+					Source: "github.example.com/foo/bar/template.yml@48afb5bdc41ad69bf22588491333f7cf71135163",
+					Type:   "github",
+					Ref:    "48afb5bdc41ad69bf22588491333f7cf71135163",
+					Commit: "48afb5bdc41ad69bf22588491333f7cf71135163",
+					Digest: sum,
+				},
+			},
+		},
+		{
+			name:    "pinned org with branch",
+			org:     "pinned",
+			source:  "github.example.com/foo/bar/template.yml@main",
+			wantErr: true,
+		},
+		{
+			name:    "digest mismatch",
+			org:     "foo",
+			source:  "github.example.com/foo/bar/template.yml",
+			digest:  "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported digest",
+			org:     "foo",
+			source:  "github.example.com/foo/bar/template.yml",
+			digest:  "md5:00000000000000000000000000000000",
+			wantErr: true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			org := test.org
+			comp := compiler.Duplicate().WithRepo(&library.Repo{Org: &org}).(*client)
+			comp.extensions = &extensions{
+				Templates: []*templateExtension{{Name: "gradle", Digest: test.digest}},
+			}
+
+			tmpls := map[string]*yaml.Template{
+				"gradle": {Name: "gradle", Source: test.source, Type: "github"},
+			}
+
+			refs = []string{}
+
+			_, _, _, _, err := comp.ExpandSteps(&yaml.Build{Steps: steps, Services: yaml.ServiceSlice{}, Environment: raw.StringSliceMap{}}, tmpls)
+
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("ExpandSteps should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ExpandSteps returned err: %v", err)
+			}
+
+			if diff := cmp.Diff(test.want, comp.Templates()); diff != "" {
+				t.Errorf("Templates() mismatch (-want +got):\n%s", diff)
+			}
+
+			// the template should always be captured from the resolved commit
+			for _, ref := range refs {
+				if ref != test.want[0].Commit {
+					t.Errorf("ExpandSteps captured template from ref %s, want %s", ref, test.want[0].Commit)
+				}
+			}
+		})
+	}
+}
+
+func TestNative_ExpandSteps_TemplateRecorded(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	// track the refs used to capture the template
+	refs := []string{}
+
+	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/commits/:ref", func(c *gin.Context) {
+		// resolve the commit SHAs to themselves and branches to the latest commit
+		if len(c.Param("ref")) == 40 {
+			c.String(http.StatusOK, c.Param("ref"))
+
+			return
+		}
+
+		c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	})
+
+	engine.GET("/api/v3/repos/foo/bar/contents/:path", func(c *gin.Context) {
+		refs = append(refs, c.Query("ref"))
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/template.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	set := flag.NewFlagSet("test", 0)
+	set.Bool("github-driver", true, "doc")
+	set.String("github-url", s.URL, "doc")
+	set.String("github-token", "", "doc")
+	c := cli.NewContext(nil, set, nil)
+
+	steps := yaml.StepSlice{
+		&yaml.Step{
+			Name: "sample",
+			Template: yaml.StepTemplate{
+				Name: "gradle",
+				Variables: map[string]interface{}{
+					"image":       "openjdk:latest",
+					"environment": "{ GRADLE_USER_HOME: .gradle }",
+					"pull_policy": "pull: true",
+				},
+			},
+		},
+	}
+
+	tmpls := map[string]*yaml.Template{
+		"gradle": {Name: "gradle", Source: "github.example.com/foo/bar/template.yml@main", Type: "github"},
+	}
+
+	compiler, err := New(c)
+	if err != nil {
+		t.Errorf("Creating new compiler returned err: %v", err)
+	}
+
+	// run test with the templates recorded for a build
+	org := "foo"
+
+	comp := compiler.Duplicate().WithRepo(&library.Repo{Org: &org}).WithTemplates([]*model.BuildTemplate{
+		{
+			Name:   "gradle",
+			Source: "github.example.com/foo/bar/template.yml@main",
+			Type:   "github",
+			Ref:    "main",
+			Commit: "48afb5bdc41ad69bf22588491333f7cf71135163",
+		},
+	})
+
+	_, _, _, _, err = comp.ExpandSteps(&yaml.Build{Steps: steps, Services: yaml.ServiceSlice{}, Environment: raw.StringSliceMap{}}, tmpls)
+	if err != nil {
+		t.Errorf("ExpandSteps returned err: %v", err)
+	}
+
+	if len(refs) != 1 || refs[0] != "48afb5bdc41ad69bf22588491333f7cf71135163" {
+		t.Errorf("ExpandSteps captured template from refs %v, want the recorded commit", refs)
+	}
+
+	got := comp.Templates()
+	if len(got) != 1 || got[0].Ref != "main" || got[0].Commit != "48afb5bdc41ad69bf22588491333f7cf71135163" {
+		t.Errorf("Templates is %v, want the recorded ref and commit", got)
+	}
+}
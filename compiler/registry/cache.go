@@ -0,0 +1,210 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package registry
+
+import (
+	"container/list"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// predefine Prometheus metrics else they will be regenerated
+// each function call which will throw error:
+// "duplicate metrics collector registration attempted".
+var (
+	cacheRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "vela_template_cache_requests",
+			Help: "Template Cache Requests collect the number of template cache lookups by result.",
+		},
+		[]string{"result"},
+	)
+
+	cacheEntries = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "vela_template_cache_entries",
+			Help: "Template Cache Entries collect the number of templates stored in the cache.",
+		},
+	)
+)
+
+// sha represents the pattern for a full commit SHA.
+var sha = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// Cache represents a size bounded cache for templates
+// captured from a registry. Entries for mutable refs
+// expire after the TTL and are expected to be revalidated
+// by the registry with a conditional request.
+type Cache struct {
+	ttl  time.Duration
+	size int
+
+	sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+}
+
+// CacheEntry represents a template stored in the Cache.
+type CacheEntry struct {
+	Key       string
+	Data      []byte
+	ETag      string
+	Immutable bool
+	Expires   time.Time
+}
+
+// NewCache returns a Cache that stores up to size templates
+// and expires entries for mutable refs after the ttl.
+func NewCache(ttl time.Duration, size int) *Cache {
+	return &Cache{
+		ttl:   ttl,
+		size:  size,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+// Key returns the key for storing the template from
+// the source in the Cache. The scope is used to separate
+// templates captured with different credentials.
+func Key(host string, s *Source, scope string) string {
+	key := strings.Join([]string{strings.TrimSuffix(host, "/"), s.Org, s.Repo, s.Name}, "/")
+
+	if len(s.Ref) > 0 {
+		key += "@" + s.Ref
+	}
+
+	if len(scope) > 0 {
+		key += "#" + scope
+	}
+
+	return key
+}
+
+// CommitKey returns the key for storing the commit the
+// reference for the source resolves to in the Cache. The
+// scope is used to separate commits captured with different
+// credentials.
+func CommitKey(host string, s *Source, scope string) string {
+	return "commit:" + Key(host, &Source{Org: s.Org, Repo: s.Repo, Ref: s.Ref}, scope)
+}
+
+// IsSHA returns true if the reference
+// for a template is a full commit SHA.
+func IsSHA(ref string) bool {
+	return sha.MatchString(ref)
+}
+
+// IsImmutable returns true if the reference for a template
+// is not expected to change i.e. a commit SHA or a release tag.
+func IsImmutable(ref string) bool {
+	if IsSHA(ref) {
+		return true
+	}
+
+	// only consider full versions i.e. v1.2.3 or 1.2 as tags
+	// so branches like v1 or main are always revalidated
+	if !strings.Contains(ref, ".") {
+		return false
+	}
+
+	_, err := semver.NewVersion(ref)
+
+	return err == nil
+}
+
+// Fresh returns true if the entry can be used
+// without revalidating it with the registry.
+func (e *CacheEntry) Fresh() bool {
+	return e.Immutable || time.Now().Before(e.Expires)
+}
+
+// Get returns the entry stored in the Cache for the key.
+//
+// The entry is returned even when it has expired
+// so it may be revalidated with the registry.
+func (c *Cache) Get(key string) *CacheEntry {
+	c.Lock()
+	defer c.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		cacheRequests.WithLabelValues("miss").Inc()
+
+		return nil
+	}
+
+	c.order.MoveToFront(elem)
+
+	entry := elem.Value.(*CacheEntry)
+
+	if entry.Fresh() {
+		cacheRequests.WithLabelValues("hit").Inc()
+	} else {
+		cacheRequests.WithLabelValues("stale").Inc()
+	}
+
+	return entry
+}
+
+// Set stores the template for the key in the Cache.
+func (c *Cache) Set(key string, data []byte, etag string, immutable bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry := &CacheEntry{
+		Key:       key,
+		Data:      data,
+		ETag:      etag,
+		Immutable: immutable,
+		Expires:   time.Now().Add(c.ttl),
+	}
+
+	// replace the existing entry for the key
+	if elem, ok := c.items[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	c.items[key] = c.order.PushFront(entry)
+
+	// evict the least recently used entries
+	for c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*CacheEntry).Key)
+	}
+
+	cacheEntries.Set(float64(c.order.Len()))
+}
+
+// Revalidate extends the expiration for the entry stored in the
+// Cache for the key after the registry reports it is unchanged.
+func (c *Cache) Revalidate(key string) {
+	c.Lock()
+	defer c.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return
+	}
+
+	cacheRequests.WithLabelValues("revalidated").Inc()
+
+	// copy the entry since it may be in use by other callers
+	entry := *elem.Value.(*CacheEntry)
+	entry.Expires = time.Now().Add(c.ttl)
+
+	elem.Value = &entry
+}
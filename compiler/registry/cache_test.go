@@ -0,0 +1,120 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package registry
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Key(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		source *Source
+		scope  string
+		want   string
+	}{
+		{
+			source: &Source{Org: "github", Repo: "octocat", Name: "template.yml"},
+			want:   "https://api.github.com/github/octocat/template.yml",
+		},
+		{
+			source: &Source{Org: "github", Repo: "octocat", Name: "template.yml", Ref: "main"},
+			scope:  "octocat",
+			want:   "https://api.github.com/github/octocat/template.yml@main#octocat",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := Key("https://api.github.com/", test.source, test.scope)
+
+		if got != test.want {
+			t.Errorf("Key is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestRegistry_IsImmutable(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{ref: "", want: false},
+		{ref: "main", want: false},
+		{ref: "v1", want: false},
+		{ref: "release/1.2", want: false},
+		{ref: "v1.2.3", want: true},
+		{ref: "1.2.3-rc1", want: true},
+		{ref: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", want: true},
+		{ref: "7fd1a60", want: false},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := IsImmutable(test.ref)
+
+		if got != test.want {
+			t.Errorf("IsImmutable for %s is %v, want %v", test.ref, got, test.want)
+		}
+	}
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	// setup types
+	c := NewCache(time.Hour, 2)
+
+	// run test
+	c.Set("foo", []byte("foo"), "etag-foo", false)
+	c.Set("bar", []byte("bar"), "etag-bar", false)
+
+	// access foo so bar is the least recently used
+	got := c.Get("foo")
+	if got == nil || !reflect.DeepEqual(got.Data, []byte("foo")) || !got.Fresh() {
+		t.Errorf("Get for foo is %v, want fresh foo", got)
+	}
+
+	c.Set("baz", []byte("baz"), "etag-baz", false)
+
+	if c.Get("bar") != nil {
+		t.Errorf("Get for bar should have been evicted")
+	}
+
+	if c.Get("foo") == nil || c.Get("baz") == nil {
+		t.Errorf("Get for foo and baz should not have been evicted")
+	}
+}
+
+func TestRegistry_Cache_Expiration(t *testing.T) {
+	// setup types
+	c := NewCache(0, 0)
+
+	// run test
+	c.Set("mutable", []byte("foo"), "etag", false)
+	c.Set("immutable", []byte("foo"), "etag", true)
+
+	got := c.Get("mutable")
+	if got == nil || got.Fresh() {
+		t.Errorf("Get for mutable is %v, want stale entry", got)
+	}
+
+	got = c.Get("immutable")
+	if got == nil || !got.Fresh() {
+		t.Errorf("Get for immutable is %v, want fresh entry", got)
+	}
+
+	c = NewCache(time.Hour, 0)
+	c.Set("mutable", []byte("foo"), "etag", false)
+	c.items["mutable"].Value.(*CacheEntry).Expires = time.Now().Add(-time.Minute)
+
+	c.Revalidate("mutable")
+
+	got = c.Get("mutable")
+	if got == nil || !got.Fresh() {
+		t.Errorf("Get after Revalidate is %v, want fresh entry", got)
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package file
+
+import (
+	"github.com/go-vela/server/compiler/registry"
+
+	"github.com/go-vela/types/library"
+)
+
+// Commit returns an empty commit SHA since templates
+// from the filesystem aren't captured from a repo.
+func (c *client) Commit(u *library.User, s *registry.Source) (string, error) {
+	return "", nil
+}
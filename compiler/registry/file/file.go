@@ -0,0 +1,44 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package file
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+type client struct {
+	Root string
+}
+
+// New returns a Registry implementation that integrates
+// with a directory of templates on the filesystem.
+//
+// nolint: revive // ignore returning unexported client
+func New(root string) (*client, error) {
+	// ensure the root directory is provided
+	if len(root) == 0 {
+		return nil, fmt.Errorf("no template root directory provided")
+	}
+
+	// capture the absolute path for the root directory
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template root directory %s: %w", root, err)
+	}
+
+	// resolve any symlinks for the root directory
+	abs, err = filepath.EvalSymlinks(abs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template root directory %s: %w", root, err)
+	}
+
+	// create the client object
+	c := &client{
+		Root: abs,
+	}
+
+	return c, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package file
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFile_New(t *testing.T) {
+	// setup types
+	root := t.TempDir()
+
+	want, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		t.Errorf("EvalSymlinks returned err: %v", err)
+	}
+
+	// run test
+	got, err := New(root)
+	if err != nil {
+		t.Errorf("New returned err: %v", err)
+	}
+
+	if got.Root != want {
+		t.Errorf("New Root is %v, want %v", got.Root, want)
+	}
+}
+
+func TestFile_New_Failure(t *testing.T) {
+	// setup tests
+	tests := []string{
+		"",
+		filepath.Join(t.TempDir(), "missing"),
+	}
+
+	// run tests
+	for _, test := range tests {
+		_, err := New(test)
+		if err == nil {
+			t.Errorf("New for %s should have returned err", test)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package file
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/go-vela/server/compiler/registry"
+)
+
+// Parse creates the registry source object from
+// a template path relative to the root directory.
+func (c *client) Parse(p string) (*registry.Source, error) {
+	// this will handle multiple cases for the path:
+	// * <filename>
+	// * file://<path>/<to>/<filename>
+	name := strings.TrimPrefix(p, "file://")
+
+	// clean the path and strip the leading slash so
+	// the path is always relative to the root directory
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+
+	// ensure a filename was provided
+	if len(name) == 0 {
+		return &registry.Source{}, fmt.Errorf("invalid template source %s, must contain path_to_template", p)
+	}
+
+	return &registry.Source{
+		Name: name,
+	}, nil
+}
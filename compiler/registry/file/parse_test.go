@@ -0,0 +1,49 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package file
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/compiler/registry"
+)
+
+func TestFile_Parse(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		path    string
+		want    *registry.Source
+		wantErr bool
+	}{
+		{path: "template.yml", want: &registry.Source{Name: "template.yml"}},
+		{path: "go/template.yml", want: &registry.Source{Name: "go/template.yml"}},
+		{path: "file://go/template.yml", want: &registry.Source{Name: "go/template.yml"}},
+		{path: "/go/template.yml", want: &registry.Source{Name: "go/template.yml"}},
+		{path: "../../etc/passwd", want: &registry.Source{Name: "etc/passwd"}},
+		{path: "go/../../template.yml", want: &registry.Source{Name: "template.yml"}},
+		{path: "", want: &registry.Source{}, wantErr: true},
+		{path: "file://", want: &registry.Source{}, wantErr: true},
+	}
+
+	c := &client{Root: t.TempDir()}
+
+	// run tests
+	for _, test := range tests {
+		got, err := c.Parse(test.path)
+
+		if test.wantErr && err == nil {
+			t.Errorf("Parse for %s should have returned err", test.path)
+		}
+
+		if !test.wantErr && err != nil {
+			t.Errorf("Parse for %s returned err: %v", test.path, err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Parse for %s is %v, want %v", test.path, got, test.want)
+		}
+	}
+}
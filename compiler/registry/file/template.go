@@ -0,0 +1,56 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package file
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-vela/server/compiler/registry"
+
+	"github.com/go-vela/types/library"
+)
+
+// Template captures the templated pipeline configuration from the root directory.
+func (c *client) Template(u *library.User, s *registry.Source) ([]byte, error) {
+	// create the full path to the template
+	path := filepath.Join(c.Root, filepath.FromSlash(s.Name))
+
+	// resolve any symlinks so the real location of the template is verified
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no Vela template found at %s", s.Name)
+		}
+
+		return nil, fmt.Errorf("unexpected error fetching template %s: %v", s.Name, err)
+	}
+
+	// ensure the template does not exist outside of the root directory
+	if !within(c.Root, resolved) {
+		return nil, fmt.Errorf("invalid template source %s, must be within the template root directory", s.Name)
+	}
+
+	data, err := ioutil.ReadFile(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching template %s: %v", s.Name, err)
+	}
+
+	return data, nil
+}
+
+// within is a helper function to determine if
+// the path provided is inside of the root.
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/compiler/registry"
+)
+
+func TestFile_Template(t *testing.T) {
+	// setup filesystem
+	dir := t.TempDir()
+	root := filepath.Join(dir, "templates")
+
+	err := os.MkdirAll(filepath.Join(root, "go"), 0755)
+	if err != nil {
+		t.Errorf("MkdirAll returned err: %v", err)
+	}
+
+	want := []byte("version: \"1\"\n")
+
+	err = ioutil.WriteFile(filepath.Join(root, "go", "template.yml"), want, 0600)
+	if err != nil {
+		t.Errorf("WriteFile returned err: %v", err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "secret.yml"), want, 0600)
+	if err != nil {
+		t.Errorf("WriteFile returned err: %v", err)
+	}
+
+	err = os.Symlink(filepath.Join(dir, "secret.yml"), filepath.Join(root, "link.yml"))
+	if err != nil {
+		t.Errorf("Symlink returned err: %v", err)
+	}
+
+	c, err := New(root)
+	if err != nil {
+		t.Errorf("New returned err: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		source  *registry.Source
+		want    []byte
+		wantErr bool
+	}{
+		{source: &registry.Source{Name: "go/template.yml"}, want: want},
+		{source: &registry.Source{Name: "go/missing.yml"}, wantErr: true},
+		{source: &registry.Source{Name: "../secret.yml"}, wantErr: true},
+		{source: &registry.Source{Name: "link.yml"}, wantErr: true},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := c.Template(nil, test.source)
+
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Template for %s should have returned err", test.source.Name)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Template for %s returned err: %v", test.source.Name, err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Template for %s is %v, want %v", test.source.Name, got, test.want)
+		}
+	}
+}
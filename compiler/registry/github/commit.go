@@ -0,0 +1,87 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-vela/server/compiler/registry"
+
+	"github.com/go-vela/types/library"
+)
+
+// Commit captures the commit SHA the reference for the template resolves to from the GitHub repo.
+func (c *client) Commit(u *library.User, s *registry.Source) (string, error) {
+	// a full commit SHA doesn't need to be resolved
+	if registry.IsSHA(s.Ref) {
+		return s.Ref, nil
+	}
+
+	// use default GitHub OAuth client we provide
+	cli := c.Github
+	// scope will hold the identity used to resolve the commit
+	scope := ""
+	if u != nil {
+		// create GitHub OAuth client with user's token
+		cli = c.newClientToken(u.GetToken())
+		// separate commits in the cache by user since
+		// access to the repo may differ between users
+		scope = u.GetName()
+	}
+
+	// key will hold the identifier for the commit in the cache
+	key := ""
+	// last will hold the commit captured from the cache
+	last := ""
+
+	if c.Cache != nil {
+		key = registry.CommitKey(c.API, s, scope)
+
+		// send the cached commit if it hasn't expired
+		entry := c.Cache.Get(key)
+		if entry != nil {
+			if entry.Fresh() {
+				return string(entry.Data), nil
+			}
+
+			last = string(entry.Data)
+		}
+	}
+
+	// resolve the default branch for the repo when no ref is set
+	ref := s.Ref
+	if len(ref) == 0 {
+		ref = "HEAD"
+	}
+
+	// send API call to capture the commit SHA for the reference
+	//
+	// the cached commit is sent to only capture the commit when it changed
+	//
+	// https://docs.github.com/en/rest/reference/repos#get-a-commit
+	sha, resp, err := cli.Repositories.GetCommitSHA1(context.Background(), s.Org, s.Repo, ref, last)
+
+	// send the cached commit if the reference still resolves to it
+	//
+	// https://docs.github.com/en/rest/overview/resources-in-the-rest-api#conditional-requests
+	if len(last) > 0 && resp != nil && resp.StatusCode == http.StatusNotModified {
+		c.Cache.Revalidate(key)
+
+		return last, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve commit for template %s/%s/%s@%s: %v", s.Org, s.Repo, s.Name, ref, err)
+	}
+
+	// store the commit in the cache
+	if c.Cache != nil {
+		c.Cache.Set(key, []byte(sha), "", registry.IsImmutable(s.Ref))
+	}
+
+	return sha, nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-vela/server/compiler/registry"
+
+	"github.com/go-vela/types/library"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGithub_Commit(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	// setup mock server
+	engine.GET("/api/v3/repos/:owner/:name/commits/:ref", func(c *gin.Context) {
+		switch c.Param("ref") {
+		case "HEAD", "main":
+			c.String(http.StatusOK, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+		default:
+			c.Status(http.StatusNotFound)
+		}
+	})
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	str := "foo"
+	u := &library.User{
+		Name:  &str,
+		Token: &str,
+	}
+
+	// setup tests
+	tests := []struct {
+		name    string
+		user    *library.User
+		ref     string
+		want    string
+		failure bool
+	}{
+		{
+			name: "default branch",
+			user: u,
+			ref:  "",
+			want: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		},
+		{
+			name: "branch",
+			ref:  "main",
+			want: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		},
+		{
+			name: "commit",
+			ref:  "48afb5bdc41ad69bf22588491333f7cf71135163",
+			want: "48afb5bdc41ad69bf22588491333f7cf71135163",
+		},
+		{
+			name:    "missing ref",
+			ref:     "foo",
+			failure: true,
+		},
+	}
+
+	// run tests
+	c, err := New(s.URL, "")
+	if err != nil {
+		t.Errorf("Creating client returned err: %v", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := &registry.Source{
+				Org:  "github",
+				Repo: "octocat",
+				Name: "template.yml",
+				Ref:  test.ref,
+			}
+
+			got, err := c.Commit(test.user, src)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("Commit should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Commit returned err: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("Commit is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithub_Commit_Cache(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	sha := "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"
+
+	requests := 0
+	revalidated := 0
+
+	// setup mock server
+	engine.GET("/api/v3/repos/:owner/:name/commits/:ref", func(c *gin.Context) {
+		requests++
+
+		if c.GetHeader("If-None-Match") == fmt.Sprintf("%q", sha) {
+			revalidated++
+
+			c.Status(http.StatusNotModified)
+
+			return
+		}
+
+		c.String(http.StatusOK, sha)
+	})
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	src := &registry.Source{
+		Org:  "github",
+		Repo: "octocat",
+		Name: "template.yml",
+		Ref:  "main",
+	}
+
+	// setup tests
+	tests := []struct {
+		name            string
+		ttl             time.Duration
+		wantRequests    int
+		wantRevalidated int
+	}{
+		{
+			name:         "fresh commit",
+			ttl:          time.Minute,
+			wantRequests: 1,
+		},
+		{
+			name:            "expired commit",
+			ttl:             0,
+			wantRequests:    2,
+			wantRevalidated: 1,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests, revalidated = 0, 0
+
+			c, err := New(s.URL, "")
+			if err != nil {
+				t.Errorf("Creating client returned err: %v", err)
+			}
+
+			c.Cache = registry.NewCache(test.ttl, 10)
+
+			for i := 0; i < 2; i++ {
+				got, err := c.Commit(nil, src)
+				if err != nil {
+					t.Errorf("Commit returned err: %v", err)
+				}
+
+				if got != sha {
+					t.Errorf("Commit is %v, want %v", got, sha)
+				}
+			}
+
+			if requests != test.wantRequests {
+				t.Errorf("Commit sent %d requests, want %d", requests, test.wantRequests)
+			}
+
+			if revalidated != test.wantRevalidated {
+				t.Errorf("Commit revalidated %d times, want %d", revalidated, test.wantRevalidated)
+			}
+		})
+	}
+}
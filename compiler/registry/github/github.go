@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/go-vela/server/compiler/registry"
+
 	"github.com/google/go-github/v42/github"
 	"golang.org/x/oauth2"
 )
@@ -20,6 +22,7 @@ const (
 
 type client struct {
 	Github *github.Client
+	Cache  *registry.Cache
 	URL    string
 	API    string
 }
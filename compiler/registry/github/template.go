@@ -8,6 +8,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/go-vela/server/compiler/registry"
 
@@ -17,12 +19,34 @@ import (
 )
 
 // Template captures the templated pipeline configuration from the GitHub repo.
+//
+// nolint: funlen // ignore function length due to comments
 func (c *client) Template(u *library.User, s *registry.Source) ([]byte, error) {
 	// use default GitHub OAuth client we provide
 	cli := c.Github
+	// scope will hold the identity used to capture the template
+	scope := ""
 	if u != nil {
 		// create GitHub OAuth client with user's token
 		cli = c.newClientToken(u.GetToken())
+		// separate templates in the cache by user since
+		// access to the repo may differ between users
+		scope = u.GetName()
+	}
+
+	// key will hold the identifier for the template in the cache
+	key := ""
+	// entry will hold the template captured from the cache
+	var entry *registry.CacheEntry
+
+	if c.Cache != nil {
+		key = registry.Key(c.API, s, scope)
+
+		// send the cached template if it hasn't expired
+		entry = c.Cache.Get(key)
+		if entry != nil && entry.Fresh() {
+			return entry.Data, nil
+		}
 	}
 
 	// create the options to pass
@@ -36,10 +60,24 @@ func (c *client) Template(u *library.User, s *registry.Source) ([]byte, error) {
 		opts.Ref = s.Ref
 	}
 
+	// etag will hold the identifier for the cached template
+	etag := ""
+	if entry != nil {
+		etag = entry.ETag
+	}
+
 	// send API call to capture the templated pipeline configuration
+	data, resp, err := getContents(cli, s, opts, etag)
+
+	// send the cached template if it hasn't been modified
 	//
-	// nolint: lll // ignore long line length due to variable names
-	data, _, resp, err := cli.Repositories.GetContents(context.Background(), s.Org, s.Repo, s.Name, opts)
+	// https://docs.github.com/en/rest/overview/resources-in-the-rest-api#conditional-requests
+	if entry != nil && resp != nil && resp.StatusCode == http.StatusNotModified {
+		c.Cache.Revalidate(key)
+
+		return entry.Data, nil
+	}
+
 	if err != nil {
 		if resp != nil && resp.StatusCode != http.StatusNotFound {
 			// return different error message depending on if a branch was provided
@@ -65,6 +103,11 @@ func (c *client) Template(u *library.User, s *registry.Source) ([]byte, error) {
 			return nil, err
 		}
 
+		// store the template in the cache
+		if c.Cache != nil {
+			c.Cache.Set(key, []byte(strData), resp.Header.Get("ETag"), registry.IsImmutable(s.Ref))
+		}
+
 		return []byte(strData), nil
 	}
 
@@ -74,3 +117,38 @@ func (c *client) Template(u *library.User, s *registry.Source) ([]byte, error) {
 	}
 	return nil, fmt.Errorf("no Vela template found at %s/%s/%s@%s", s.Org, s.Repo, s.Name, s.Ref)
 }
+
+// getContents is a helper function to capture the contents of a file from
+// a GitHub repo with an optional ETag for sending a conditional request.
+//
+// nolint: lll // ignore long line length due to variable names
+func getContents(cli *github.Client, s *registry.Source, opts *github.RepositoryContentGetOptions, etag string) (*github.RepositoryContent, *github.Response, error) {
+	// escape the path to the file the same way the GitHub client does
+	//
+	// https://pkg.go.dev/github.com/google/go-github/v42/github#RepositoriesService.GetContents
+	path := (&url.URL{Path: strings.TrimSuffix(s.Name, "/")}).String()
+
+	u := fmt.Sprintf("repos/%s/%s/contents/%s", s.Org, s.Repo, path)
+
+	if len(opts.Ref) > 0 {
+		u = fmt.Sprintf("%s?ref=%s", u, url.QueryEscape(opts.Ref))
+	}
+
+	req, err := cli.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(etag) > 0 {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	data := new(github.RepositoryContent)
+
+	resp, err := cli.Do(context.Background(), req, data)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return data, resp, nil
+}
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/go-vela/server/compiler/registry"
 
@@ -290,3 +291,91 @@ func TestGithub_Template_NotFound(t *testing.T) {
 		t.Errorf("Template is %v, want nil", got)
 	}
 }
+
+func TestGithub_TemplateCache(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	// store the requests to the mock server
+	requests := 0
+	notModified := 0
+
+	// setup mock server
+	engine.GET("/api/v3/repos/:owner/:name/contents/:path", func(c *gin.Context) {
+		requests++
+
+		if c.GetHeader("If-None-Match") == `"foo"` {
+			notModified++
+			c.Status(http.StatusNotModified)
+
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.Header("ETag", `"foo"`)
+		c.Status(http.StatusOK)
+		c.File("testdata/template.json")
+	})
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	want, err := ioutil.ReadFile("testdata/template.yml")
+	if err != nil {
+		t.Errorf("Reading file returned err: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		name            string
+		ttl             time.Duration
+		ref             string
+		wantRequests    int
+		wantNotModified int
+	}{
+		{name: "fresh", ttl: time.Hour, ref: "main", wantRequests: 1, wantNotModified: 0},
+		{name: "expired", ttl: 0, ref: "main", wantRequests: 2, wantNotModified: 1},
+		{name: "immutable", ttl: 0, ref: "v1.0.0", wantRequests: 1, wantNotModified: 0},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests, notModified = 0, 0
+
+			c, err := New(s.URL, "")
+			if err != nil {
+				t.Errorf("Creating client returned err: %v", err)
+			}
+
+			c.Cache = registry.NewCache(test.ttl, 10)
+
+			src := &registry.Source{
+				Org:  "github",
+				Repo: "octocat",
+				Name: "template.yml",
+				Ref:  test.ref,
+			}
+
+			for i := 0; i < 2; i++ {
+				got, err := c.Template(nil, src)
+				if err != nil {
+					t.Errorf("Template returned err: %v", err)
+				}
+
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Template is %v, want %v", got, want)
+				}
+			}
+
+			if requests != test.wantRequests {
+				t.Errorf("Template sent %d requests, want %d", requests, test.wantRequests)
+			}
+
+			if notModified != test.wantNotModified {
+				t.Errorf("Template received %d not modified responses, want %d", notModified, test.wantNotModified)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package http provides the ability for Vela to
+// integrate with a generic HTTPS endpoint as a
+// template registry.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/server/compiler/registry/http"
+package http
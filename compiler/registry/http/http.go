@@ -0,0 +1,83 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+const (
+	defaultTimeout = 30 * time.Second // Default timeout for fetching templates
+	defaultLimit   = 1 << 20          // Default size limit in bytes for templates
+)
+
+type client struct {
+	Client  *http.Client
+	Headers map[string]http.Header
+}
+
+// New returns a Registry implementation that integrates
+// with templates hosted behind generic HTTPS endpoints.
+//
+// The headers are provided in <host>=<name>:<value> format
+// and are only sent with requests to the matching host.
+//
+// nolint: revive // ignore returning unexported client
+func New(headers []string) (*client, error) {
+	// create the client object
+	c := &client{
+		Client:  cleanhttp.DefaultPooledClient(),
+		Headers: make(map[string]http.Header),
+	}
+
+	// ensure the requests do not take longer than the timeout
+	c.Client.Timeout = defaultTimeout
+
+	// ensure the requests are not redirected to other hosts
+	c.Client.CheckRedirect = checkRedirect
+
+	for _, header := range headers {
+		// capture the host from the header
+		//
+		// nolint: gomnd // ignore magic number
+		parts := strings.SplitN(header, "=", 2)
+		// nolint: gomnd // ignore magic number
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			return nil, fmt.Errorf("invalid template header %s, must be <host>=<name>:<value>", header)
+		}
+
+		// capture the name and value from the header
+		//
+		// nolint: gomnd // ignore magic number
+		kv := strings.SplitN(parts[1], ":", 2)
+		// nolint: gomnd // ignore magic number
+		if len(kv) != 2 || len(kv[0]) == 0 {
+			return nil, fmt.Errorf("invalid template header %s, must be <host>=<name>:<value>", header)
+		}
+
+		host := strings.ToLower(strings.TrimSpace(parts[0]))
+
+		if _, ok := c.Headers[host]; !ok {
+			c.Headers[host] = make(http.Header)
+		}
+
+		c.Headers[host].Add(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+	}
+
+	return c, nil
+}
+
+// checkRedirect is a helper function to reject the redirects
+// for the requests. Following a redirect would bypass the
+// https-only check for the template source and would send
+// the configured headers for the host to the redirect target.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	return fmt.Errorf("redirect to %s is not allowed", req.URL.Redacted())
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package http
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHTTP_New(t *testing.T) {
+	// setup types
+	headers := []string{
+		"templates.example.com=Authorization: Bearer foobar",
+		"Templates.Example.com=X-Foo:bar",
+	}
+
+	want := map[string]http.Header{
+		"templates.example.com": {
+			"Authorization": []string{"Bearer foobar"},
+			"X-Foo":         []string{"bar"},
+		},
+	}
+
+	// run test
+	got, err := New(headers)
+	if err != nil {
+		t.Errorf("New returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.Headers, want) {
+		t.Errorf("New Headers is %v, want %v", got.Headers, want)
+	}
+
+	if got.Client == nil {
+		t.Errorf("New Client is nil")
+	}
+}
+
+func TestHTTP_New_Failure(t *testing.T) {
+	// setup tests
+	tests := []string{
+		"Authorization: Bearer foobar",
+		"=Authorization: Bearer foobar",
+		"templates.example.com=Authorization",
+		"templates.example.com=:foobar",
+	}
+
+	// run tests
+	for _, test := range tests {
+		_, err := New([]string{test})
+		if err == nil {
+			t.Errorf("New for %s should have returned err", test)
+		}
+	}
+}
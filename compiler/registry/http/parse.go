@@ -0,0 +1,37 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package http
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-vela/server/compiler/registry"
+)
+
+// Parse creates the registry source object from a template URL.
+func (c *client) Parse(path string) (*registry.Source, error) {
+	// parse the path provided
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// ensure the template is only fetched over https
+	if !strings.EqualFold(u.Scheme, "https") {
+		return &registry.Source{}, fmt.Errorf("invalid template source %s, must be an https URL", path)
+	}
+
+	// ensure the host and path exist
+	if len(u.Host) == 0 || len(strings.Trim(u.Path, "/")) == 0 {
+		return &registry.Source{}, fmt.Errorf("invalid template source %s, must contain host/path_to_template", path)
+	}
+
+	return &registry.Source{
+		Host: u.Host,
+		Name: strings.TrimPrefix(u.RequestURI(), "/"),
+	}, nil
+}
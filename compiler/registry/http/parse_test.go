@@ -0,0 +1,55 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package http
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/compiler/registry"
+)
+
+func TestHTTP_Parse(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		path    string
+		want    *registry.Source
+		wantErr bool
+	}{
+		{
+			path: "https://templates.example.com/go/template.yml",
+			want: &registry.Source{Host: "templates.example.com", Name: "go/template.yml"},
+		},
+		{
+			path: "https://templates.example.com:8443/template.yml?version=1",
+			want: &registry.Source{Host: "templates.example.com:8443", Name: "template.yml?version=1"},
+		},
+		{path: "http://templates.example.com/template.yml", want: &registry.Source{}, wantErr: true},
+		{path: "templates.example.com/template.yml", want: &registry.Source{}, wantErr: true},
+		{path: "https://templates.example.com/", want: &registry.Source{}, wantErr: true},
+	}
+
+	c, err := New(nil)
+	if err != nil {
+		t.Errorf("New returned err: %v", err)
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := c.Parse(test.path)
+
+		if test.wantErr && err == nil {
+			t.Errorf("Parse for %s should have returned err", test.path)
+		}
+
+		if !test.wantErr && err != nil {
+			t.Errorf("Parse for %s returned err: %v", test.path, err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Parse for %s is %v, want %v", test.path, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/go-vela/server/compiler/registry"
+
+	"github.com/go-vela/types/library"
+)
+
+// Template captures the templated pipeline configuration from the URL.
+func (c *client) Template(u *library.User, s *registry.Source) ([]byte, error) {
+	// create the URL for the template
+	url := fmt.Sprintf("https://%s/%s", s.Host, s.Name)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template source %s: %v", url, err)
+	}
+
+	// add the configured headers for the host
+	for name, values := range c.Headers[strings.ToLower(s.Host)] {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	// send API call to capture the templated pipeline configuration
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching template %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("no Vela template found at %s", url)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected error fetching template %s: status code %d", url, resp.StatusCode)
+	}
+
+	// read one byte past the limit to detect templates that are too large
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, defaultLimit+1))
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error fetching template %s: %v", url, err)
+	}
+
+	if len(data) > defaultLimit {
+		return nil, fmt.Errorf("template %s exceeds the size limit of %d bytes", url, defaultLimit)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-vela/server/compiler/registry"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHTTP_Template(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	want := []byte("version: \"1\"\n")
+
+	// setup mock server
+	engine.GET("/go/template.yml", func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "Bearer foobar" {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		c.Data(http.StatusOK, "text/plain", want)
+	})
+	engine.GET("/large.yml", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/plain", []byte(strings.Repeat("a", defaultLimit+1)))
+	})
+
+	// setup redirect target server
+	leaked := false
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.Header.Get("Authorization")) > 0 {
+			leaked = true
+		}
+
+		_, _ = w.Write(want)
+	}))
+	defer target.Close()
+
+	engine.GET("/insecure.yml", func(c *gin.Context) {
+		c.Redirect(http.StatusFound, target.URL+"/go/template.yml")
+	})
+
+	s := httptest.NewTLSServer(engine)
+	defer s.Close()
+
+	external := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.Header.Get("Authorization")) > 0 {
+			leaked = true
+		}
+
+		_, _ = w.Write(want)
+	}))
+	defer external.Close()
+
+	engine.GET("/external.yml", func(c *gin.Context) {
+		c.Redirect(http.StatusFound, external.URL+"/go/template.yml")
+	})
+
+	u, _ := url.Parse(s.URL)
+
+	// setup tests
+	tests := []struct {
+		headers []string
+		name    string
+		want    []byte
+		wantErr bool
+	}{
+		{headers: []string{u.Host + "=Authorization: Bearer foobar"}, name: "go/template.yml", want: want},
+		{headers: []string{"other.example.com=Authorization: Bearer foobar"}, name: "go/template.yml", wantErr: true},
+		{name: "go/missing.yml", wantErr: true},
+		{name: "large.yml", wantErr: true},
+		{headers: []string{u.Host + "=Authorization: Bearer foobar"}, name: "insecure.yml", wantErr: true},
+		{headers: []string{u.Host + "=Authorization: Bearer foobar"}, name: "external.yml", wantErr: true},
+	}
+
+	// run tests
+	for _, test := range tests {
+		c, err := New(test.headers)
+		if err != nil {
+			t.Errorf("New returned err: %v", err)
+		}
+
+		// trust the certificate for the mock server
+		c.Client.Transport = s.Client().Transport
+
+		got, err := c.Template(nil, &registry.Source{Host: u.Host, Name: test.name})
+
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Template for %s should have returned err", test.name)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Template for %s returned err: %v", test.name, err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Template for %s is %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	if leaked {
+		t.Errorf("Template sent the headers for %s to the redirect target", u.Host)
+	}
+}
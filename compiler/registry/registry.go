@@ -16,4 +16,8 @@ type Service interface {
 	// Template defines a function that captures the
 	// templated pipeline configuration from a repo.
 	Template(*library.User, *Source) ([]byte, error)
+
+	// Commit defines a function that captures the commit
+	// SHA the reference for a template resolves to.
+	Commit(*library.User, *Source) (string, error)
 }
@@ -66,9 +66,27 @@ func RenderStep(tmpl string, s *types.Step) (types.StepSlice, types.SecretSlice,
 
 // RenderBuild renders the templated build.
 func RenderBuild(b string, envs map[string]string) (*types.Build, error) {
-	buffer := new(bytes.Buffer)
 	config := new(types.Build)
 
+	// render the template to the raw pipeline
+	out, err := RenderBuildRaw(b, envs)
+	if err != nil {
+		return nil, err
+	}
+
+	// unmarshal the template to the pipeline
+	err = yaml.Unmarshal([]byte(out), config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal yaml: %w", err)
+	}
+
+	return config, nil
+}
+
+// RenderBuildRaw renders the templated build to the raw pipeline.
+func RenderBuildRaw(b string, envs map[string]string) (string, error) {
+	buffer := new(bytes.Buffer)
+
 	velaFuncs := funcHandler{envs: convertPlatformVars(envs, "")}
 	templateFuncMap := map[string]interface{}{
 		"vela":   velaFuncs.returnPlatformVar,
@@ -87,20 +105,14 @@ func RenderBuild(b string, envs map[string]string) (*types.Build, error) {
 	// https://pkg.go.dev/github.com/Masterminds/sprig?tab=doc#TxtFuncMap
 	t, err := template.New("build").Funcs(sf).Funcs(templateFuncMap).Parse(b)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// execute the template
 	err = t.Execute(buffer, "")
 	if err != nil {
-		return nil, fmt.Errorf("unable to execute template: %w", err)
+		return "", fmt.Errorf("unable to execute template: %w", err)
 	}
 
-	// unmarshal the template to the pipeline
-	err = yaml.Unmarshal(buffer.Bytes(), config)
-	if err != nil {
-		return nil, fmt.Errorf("unable to unmarshal yaml: %w", err)
-	}
-
-	return config, nil
+	return buffer.String(), nil
 }
@@ -0,0 +1,102 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package starlark
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.starlark.net/starlark"
+)
+
+// ErrLoadCycle defines the error type when a module
+// attempts to load itself directly or indirectly.
+var ErrLoadCycle = errors.New("cycle in load graph for module")
+
+// FetchFunc defines a function that captures the raw
+// contents of a Starlark module from the path provided
+// to the load() statement.
+type FetchFunc func(module string) ([]byte, error)
+
+// Loader represents the implementation of the load() statement
+// for Starlark templates. Modules are resolved with the provided
+// FetchFunc and the globals for each module are cached, so the
+// Loader is meant to be used for a single pipeline compilation.
+type Loader struct {
+	fetch FetchFunc
+	cache map[string]*module
+}
+
+// module represents the result of executing a loaded module.
+type module struct {
+	globals starlark.StringDict
+	err     error
+}
+
+// NewLoader returns a Loader that resolves
+// modules using the provided FetchFunc.
+func NewLoader(fetch FetchFunc) *Loader {
+	return &Loader{
+		fetch: fetch,
+		cache: make(map[string]*module),
+	}
+}
+
+// Load captures and executes the module for the provided load() path.
+//
+// The module is executed on the thread that requested it so the
+// execution step budget for the thread is shared across every
+// module loaded by a template.
+func (l *Loader) Load(thread *starlark.Thread, name string) (starlark.StringDict, error) {
+	m, ok := l.cache[name]
+	if ok {
+		// a nil entry means the module is still being loaded
+		if m == nil {
+			return nil, fmt.Errorf("%s: %s", ErrLoadCycle, name)
+		}
+
+		logrus.Tracef("using cached starlark module %s", name)
+
+		return m.globals, m.err
+	}
+
+	logrus.Tracef("loading starlark module %s", name)
+
+	// mark the module as loading to detect cycles
+	l.cache[name] = nil
+
+	m = new(module)
+
+	data, err := l.fetch(name)
+	if err != nil {
+		m.err = fmt.Errorf("unable to load module %s: %w", name, err)
+	} else {
+		m.globals, m.err = starlark.ExecFile(thread, name, data, nil)
+	}
+
+	l.cache[name] = m
+
+	return m.globals, m.err
+}
+
+// newThread is a helper function to create the Starlark thread used
+// to execute a template with the optional loader for modules.
+func newThread(name string, loader *Loader) *starlark.Thread {
+	thread := &starlark.Thread{Name: name}
+
+	if loader != nil {
+		thread.Load = loader.Load
+	}
+
+	// arbitrarily limiting the steps of the thread to 5000 to help prevent infinite loops
+	// may need to further investigate spawning a separate POSIX process if user input is problematic
+	// see https://github.com/google/starlark-go/issues/160#issuecomment-466794230 for further details
+	//
+	// nolint: gomnd // ignore magic number
+	thread.SetMaxExecutionSteps(5000)
+
+	return thread
+}
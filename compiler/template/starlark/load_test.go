@@ -0,0 +1,77 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package starlark
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.starlark.net/starlark"
+)
+
+func TestStarlark_Loader_Load(t *testing.T) {
+	// setup types
+	modules := map[string]string{
+		"lib.star":   "load('image.star', 'image')\nname = 'foo'",
+		"image.star": "image = 'alpine:latest'",
+		"cycle.star": "load('cycle.star', 'name')",
+	}
+
+	fetches := make(map[string]int)
+
+	fetch := func(module string) ([]byte, error) {
+		fetches[module]++
+
+		data, ok := modules[module]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+
+		return []byte(data), nil
+	}
+
+	// setup tests
+	tests := []struct {
+		module  string
+		want    string
+		wantErr string
+	}{
+		{module: "lib.star", want: "foo"},
+		{module: "lib.star", want: "foo"},
+		{module: "cycle.star", wantErr: ErrLoadCycle.Error()},
+		{module: "missing.star", wantErr: "unable to load module missing.star"},
+	}
+
+	loader := NewLoader(fetch)
+
+	// run tests
+	for _, test := range tests {
+		got, err := loader.Load(newThread("test", loader), test.module)
+
+		if len(test.wantErr) > 0 {
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Load for %s returned err %v, want %s", test.module, err, test.wantErr)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Load for %s returned err: %v", test.module, err)
+		}
+
+		if got["name"] != starlark.String(test.want) {
+			t.Errorf("Load for %s is %v, want %v", test.module, got["name"], test.want)
+		}
+	}
+
+	// each module should only be fetched once
+	for module, count := range fetches {
+		if count != 1 {
+			t.Errorf("Load fetched %s %d times, want 1", module, count)
+		}
+	}
+}
@@ -32,17 +32,14 @@ var (
 
 // RenderStep combines the template with the step in the yaml pipeline.
 //
+// The optional loader is used to resolve load() statements in the template.
+//
 // nolint: funlen,lll // ignore function length due to comments
-func RenderStep(tmpl string, s *types.Step) (types.StepSlice, types.SecretSlice, types.ServiceSlice, raw.StringSliceMap, error) {
+func RenderStep(tmpl string, s *types.Step, loader *Loader) (types.StepSlice, types.SecretSlice, types.ServiceSlice, raw.StringSliceMap, error) {
 	config := new(types.Build)
 
-	thread := &starlark.Thread{Name: s.Name}
-	// arbitrarily limiting the steps of the thread to 5000 to help prevent infinite loops
-	// may need to further investigate spawning a separate POSIX process if user input is problematic
-	// see https://github.com/google/starlark-go/issues/160#issuecomment-466794230 for further details
-	//
-	// nolint: gomnd // ignore magic number
-	thread.SetMaxExecutionSteps(5000)
+	thread := newThread(s.Name, loader)
+
 	globals, err := starlark.ExecFile(thread, s.Template.Name, tmpl, nil)
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -132,37 +129,53 @@ func RenderStep(tmpl string, s *types.Step) (types.StepSlice, types.SecretSlice,
 }
 
 // RenderBuild renders the templated build.
-func RenderBuild(b string, envs map[string]string) (*types.Build, error) {
+//
+// The optional loader is used to resolve load() statements in the template.
+func RenderBuild(b string, envs map[string]string, loader *Loader) (*types.Build, error) {
 	config := new(types.Build)
 
-	thread := &starlark.Thread{Name: "templated-base"}
-	// arbitrarily limiting the steps of the thread to 5000 to help prevent infinite loops
-	// may need to further investigate spawning a separate POSIX process if user input is problematic
-	// see https://github.com/google/starlark-go/issues/160#issuecomment-466794230 for further details
-	//
-	// nolint: gomnd // ignore magic number
-	thread.SetMaxExecutionSteps(5000)
-	globals, err := starlark.ExecFile(thread, "templated-base", b, nil)
+	// render the template to the raw pipeline
+	out, err := RenderBuildRaw(b, envs, loader)
 	if err != nil {
 		return nil, err
 	}
 
+	// unmarshal the template to the pipeline
+	err = yaml.Unmarshal([]byte(out), config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal yaml: %v", err)
+	}
+
+	return config, nil
+}
+
+// RenderBuildRaw renders the templated build to the raw pipeline.
+//
+// The optional loader is used to resolve load() statements in the template.
+func RenderBuildRaw(b string, envs map[string]string, loader *Loader) (string, error) {
+	thread := newThread("templated-base", loader)
+
+	globals, err := starlark.ExecFile(thread, "templated-base", b, nil)
+	if err != nil {
+		return "", err
+	}
+
 	// check the provided template has a main function
 	mainVal, ok := globals["main"]
 	if !ok {
-		return nil, fmt.Errorf("%s: %s", ErrMissingMainFunc, "templated-base")
+		return "", fmt.Errorf("%s: %s", ErrMissingMainFunc, "templated-base")
 	}
 
 	// check the provided main is a function
 	main, ok := mainVal.(starlark.Callable)
 	if !ok {
-		return nil, fmt.Errorf("%s: %s", ErrInvalidMainFunc, "templated-base")
+		return "", fmt.Errorf("%s: %s", ErrInvalidMainFunc, "templated-base")
 	}
 
 	// load the platform provided vars into a starlark type
 	velaVars, err := convertPlatformVars(envs, "")
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// add the user and platform vars to a context to be used
@@ -170,7 +183,7 @@ func RenderBuild(b string, envs map[string]string) (*types.Build, error) {
 	context := starlark.NewDict(0)
 	err = context.SetKey(starlark.String("vela"), velaVars)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	args := starlark.Tuple([]starlark.Value{context})
@@ -178,7 +191,7 @@ func RenderBuild(b string, envs map[string]string) (*types.Build, error) {
 	// execute Starlark program from Go.
 	mainVal, err = starlark.Call(thread, main, args, nil)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	buf := new(bytes.Buffer)
@@ -191,7 +204,7 @@ func RenderBuild(b string, envs map[string]string) (*types.Build, error) {
 			buf.WriteString("---\n")
 			err = writeJSON(buf, item)
 			if err != nil {
-				return nil, err
+				return "", err
 			}
 			buf.WriteString("\n")
 		}
@@ -199,17 +212,11 @@ func RenderBuild(b string, envs map[string]string) (*types.Build, error) {
 		buf.WriteString("---\n")
 		err = writeJSON(buf, v)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 	default:
-		return nil, fmt.Errorf("%s: %s", ErrInvalidPipelineReturn, mainVal.Type())
+		return "", fmt.Errorf("%s: %s", ErrInvalidPipelineReturn, mainVal.Type())
 	}
 
-	// unmarshal the template to the pipeline
-	err = yaml.Unmarshal(buf.Bytes(), config)
-	if err != nil {
-		return nil, fmt.Errorf("unable to unmarshal yaml: %v", err)
-	}
-
-	return config, nil
+	return buf.String(), nil
 }
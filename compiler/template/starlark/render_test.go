@@ -30,6 +30,9 @@ func TestStarlark_RenderStep(t *testing.T) {
 		{"user vars", args{velaFile: "testdata/step/with_vars/step.yml", starlarkFile: "testdata/step/with_vars/template.star"}, "testdata/step/with_vars/want.yml", false},
 		{"platform vars", args{velaFile: "testdata/step/with_vars_plat/step.yml", starlarkFile: "testdata/step/with_vars_plat/template.star"}, "testdata/step/with_vars_plat/want.yml", false},
 		{"cancel due to complexity", args{velaFile: "testdata/step/cancel/step.yml", starlarkFile: "testdata/step/cancel/template.star"}, "", true},
+		{"with load", args{velaFile: "testdata/step/with_load/step.yml", starlarkFile: "testdata/step/with_load/template.star"}, "testdata/step/with_load/want.yml", false},
+		{"with load cycle", args{velaFile: "testdata/step/with_load/step.yml", starlarkFile: "testdata/step/with_load/cycle.star"}, "", true},
+		{"with load missing", args{velaFile: "testdata/step/with_load/step.yml", starlarkFile: "testdata/step/with_load/missing.star"}, "", true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -51,7 +54,7 @@ func TestStarlark_RenderStep(t *testing.T) {
 				t.Error(err)
 			}
 
-			steps, secrets, services, environment, err := RenderStep(string(tmpl), b.Steps[0])
+			steps, secrets, services, environment, err := RenderStep(string(tmpl), b.Steps[0], NewLoader(ioutil.ReadFile))
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RenderStep() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -102,6 +105,7 @@ func TestNative_RenderBuild(t *testing.T) {
 		{"steps", args{velaFile: "testdata/build/basic/build.star"}, "testdata/build/basic/want.yml", false},
 		{"stages", args{velaFile: "testdata/build/basic_stages/build.star"}, "testdata/build/basic_stages/want.yml", false},
 		{"conditional match", args{velaFile: "testdata/build/conditional/build.star"}, "testdata/build/conditional/want.yml", false},
+		{"with load", args{velaFile: "testdata/build/with_load/build.star"}, "testdata/build/with_load/want.yml", false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -113,7 +117,7 @@ func TestNative_RenderBuild(t *testing.T) {
 			got, err := RenderBuild(string(sFile), map[string]string{
 				"VELA_REPO_FULL_NAME": "octocat/hello-world",
 				"VELA_BUILD_BRANCH":   "master",
-			})
+			}, NewLoader(ioutil.ReadFile))
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RenderBuild() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,257 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+)
+
+func TestPostgres_Client_GetBuildApproval(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	_approval := testBuildApproval()
+	_approval.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.SelectBuildApproval, 1).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "build_id", "reason", "pipeline", "approved_by", "approved_at"},
+	).AddRow(1, 1, "pull request is from a fork", `{"version":"1"}`, "octocat", 1563474077)
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+	// ensure the mock expects the error for test case 2
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnError(gorm.ErrRecordNotFound)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.BuildApproval
+	}{
+		{
+			failure: false,
+			want:    _approval,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetBuildApproval(_build)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildApproval should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildApproval returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildApproval is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreateBuildApproval(t *testing.T) {
+	// setup types
+	_approval := testBuildApproval()
+	_approval.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "build_approvals" ("build_id","reason","pipeline","approved_by","approved_at","id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`).
+		WithArgs(1, "pull request is from a fork", `{"version":"1"}`, "octocat", 1563474077, 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		approval *model.BuildApproval
+	}{
+		{
+			failure:  false,
+			approval: _approval,
+		},
+		{
+			failure:  true,
+			approval: new(model.BuildApproval),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildApproval(test.approval)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildApproval should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildApproval returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_UpdateBuildApproval(t *testing.T) {
+	// setup types
+	_approval := testBuildApproval()
+	_approval.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(`UPDATE "build_approvals" SET "build_id"=$1,"reason"=$2,"pipeline"=$3,"approved_by"=$4,"approved_at"=$5 WHERE "id" = $6`).
+		WithArgs(1, "pull request is from a fork", `{"version":"1"}`, "octocat", 1563474077, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		approval *model.BuildApproval
+	}{
+		{
+			failure:  false,
+			approval: _approval,
+		},
+		{
+			failure:  true,
+			approval: new(model.BuildApproval),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.UpdateBuildApproval(test.approval)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdateBuildApproval should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdateBuildApproval returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_ApproveBuildApproval(t *testing.T) {
+	// setup types
+	_approval := testBuildApproval()
+	_approval.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Exec(dml.ApproveBuildApproval, "octocat", 1563474077, 1).Statement
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectExec(_query.SQL.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+	// ensure the mock expects the query for test case 2
+	_mock.ExpectExec(_query.SQL.String()).WillReturnResult(sqlmock.NewResult(1, 0))
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		approval *model.BuildApproval
+	}{
+		{
+			failure:  false,
+			approval: _approval,
+		},
+		{
+			failure:  true,
+			approval: _approval,
+		},
+		{
+			failure:  true,
+			approval: new(model.BuildApproval),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.ApproveBuildApproval(test.approval)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("ApproveBuildApproval should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ApproveBuildApproval returned err: %v", err)
+		}
+	}
+}
+
+// testBuildApproval is a test helper function to create a
+// model BuildApproval type with all fields set to a fake value.
+func testBuildApproval() *model.BuildApproval {
+	return &model.BuildApproval{
+		BuildID:    1,
+		Reason:     "pull request is from a fork",
+		Pipeline:   `{"version":"1"}`,
+		ApprovedBy: "octocat",
+		ApprovedAt: 1563474077,
+	}
+}
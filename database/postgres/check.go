@@ -0,0 +1,71 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"errors"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+
+	"gorm.io/gorm"
+)
+
+// GetBuildCheck gets the check for a build from the database.
+func (c *client) GetBuildCheck(b *library.Build) (*model.BuildCheck, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+	}).Tracef("getting check for build %d from the database", b.GetNumber())
+
+	// variable to store query results
+	check := new(model.BuildCheck)
+
+	// send query to the database and store result in variable
+	result := c.Postgres.
+		Table(model.TableBuildCheck).
+		Raw(dml.SelectBuildCheck, b.GetID()).
+		Scan(check)
+
+	// check if the query returned a record not found error or no rows were returned
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return check, result.Error
+}
+
+// CreateBuildCheck creates a new build check in the database.
+func (c *client) CreateBuildCheck(check *model.BuildCheck) error {
+	c.Logger.Tracef("creating check for build %d in the database", check.BuildID)
+
+	// validate the necessary fields are populated
+	err := check.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Postgres.
+		Table(model.TableBuildCheck).
+		Create(check).Error
+}
+
+// UpdateBuildCheck updates a build check in the database.
+func (c *client) UpdateBuildCheck(check *model.BuildCheck) error {
+	c.Logger.Tracef("updating check for build %d in the database", check.BuildID)
+
+	// validate the necessary fields are populated
+	err := check.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Postgres.
+		Table(model.TableBuildCheck).
+		Save(check).Error
+}
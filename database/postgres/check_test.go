@@ -0,0 +1,196 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+)
+
+func TestPostgres_Client_GetBuildCheck(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	_check := testBuildCheck()
+	_check.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.SelectBuildCheck, 1).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "build_id", "check_run_id", "annotated"},
+	).AddRow(1, 1, 4, true)
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+	// ensure the mock expects the error for test case 2
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnError(gorm.ErrRecordNotFound)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.BuildCheck
+	}{
+		{
+			failure: false,
+			want:    _check,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetBuildCheck(_build)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildCheck should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildCheck returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildCheck is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreateBuildCheck(t *testing.T) {
+	// setup types
+	_check := testBuildCheck()
+	_check.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "build_checks" ("build_id","check_run_id","annotated","id") VALUES ($1,$2,$3,$4) RETURNING "id"`).
+		WithArgs(1, 4, true, 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		check   *model.BuildCheck
+	}{
+		{
+			failure: false,
+			check:   _check,
+		},
+		{
+			failure: true,
+			check:   new(model.BuildCheck),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildCheck(test.check)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildCheck should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildCheck returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_UpdateBuildCheck(t *testing.T) {
+	// setup types
+	_check := testBuildCheck()
+	_check.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(`UPDATE "build_checks" SET "build_id"=$1,"check_run_id"=$2,"annotated"=$3 WHERE "id" = $4`).
+		WithArgs(1, 4, true, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		check   *model.BuildCheck
+	}{
+		{
+			failure: false,
+			check:   _check,
+		},
+		{
+			failure: true,
+			check:   new(model.BuildCheck),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.UpdateBuildCheck(test.check)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdateBuildCheck should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdateBuildCheck returned err: %v", err)
+		}
+	}
+}
+
+// testBuildCheck is a test helper function to create a
+// model BuildCheck type with all fields set to a fake value.
+func testBuildCheck() *model.BuildCheck {
+	return &model.BuildCheck{
+		BuildID:    1,
+		CheckRunID: 4,
+		Annotated:  true,
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+)
+
+func TestPostgres_Client_GetPullComment(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+
+	_comment := testPullComment()
+	_comment.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.SelectPullComment, 1, 1, "pull_request", "api").Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "repo_id", "number", "event", "pipeline", "comment_id", "build"},
+	).AddRow(1, 1, 1, "pull_request", "api", 1, 2)
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+	// ensure the mock expects the error for test case 2
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnError(gorm.ErrRecordNotFound)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.PullComment
+	}{
+		{
+			failure: false,
+			want:    _comment,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetPullComment(_repo, 1, "pull_request", "api")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetPullComment should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetPullComment returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetPullComment is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreatePullComment(t *testing.T) {
+	// setup types
+	_comment := testPullComment()
+	_comment.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "pull_comments" ("repo_id","number","event","pipeline","comment_id","build","id") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`).
+		WithArgs(1, 1, "pull_request", "api", 1, 2, 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		comment *model.PullComment
+	}{
+		{
+			failure: false,
+			comment: _comment,
+		},
+		{
+			failure: true,
+			comment: new(model.PullComment),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreatePullComment(test.comment)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreatePullComment should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreatePullComment returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_UpdatePullComment(t *testing.T) {
+	// setup types
+	_comment := testPullComment()
+	_comment.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(`UPDATE "pull_comments" SET "repo_id"=$1,"number"=$2,"event"=$3,"pipeline"=$4,"comment_id"=$5,"build"=$6 WHERE "id" = $7`).
+		WithArgs(1, 1, "pull_request", "api", 1, 2, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		comment *model.PullComment
+	}{
+		{
+			failure: false,
+			comment: _comment,
+		},
+		{
+			failure: true,
+			comment: new(model.PullComment),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.UpdatePullComment(test.comment)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdatePullComment should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdatePullComment returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_ClaimPullComment(t *testing.T) {
+	// setup types
+	_comment := testPullComment()
+	_comment.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Exec(dml.ClaimPullComment, 2, 1, 2).Statement
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectExec(_query.SQL.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+	// ensure the mock expects the query for test case 2
+	_mock.ExpectExec(_query.SQL.String()).WillReturnResult(sqlmock.NewResult(1, 0))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		comment *model.PullComment
+	}{
+		{
+			failure: false,
+			comment: _comment,
+		},
+		{
+			failure: true,
+			comment: _comment,
+		},
+		{
+			failure: true,
+			comment: new(model.PullComment),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.ClaimPullComment(test.comment)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("ClaimPullComment should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ClaimPullComment returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_DeletePullComment(t *testing.T) {
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Exec(dml.DeletePullComment, 1).Statement
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(_query.SQL.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.DeletePullComment(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeletePullComment should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeletePullComment returned err: %v", err)
+		}
+	}
+}
+
+// testPullComment is a test helper function to create a
+// model PullComment type with all fields set to a fake value.
+func testPullComment() *model.PullComment {
+	return &model.PullComment{
+		RepoID:    1,
+		Number:    1,
+		Event:     "pull_request",
+		Pipeline:  "api",
+		CommentID: 1,
+		Build:     2,
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+// CreateBuildApprovalTable represents a query to
+// create the build_approvals table for Vela.
+const CreateBuildApprovalTable = `
+CREATE TABLE
+IF NOT EXISTS
+build_approvals (
+	id          SERIAL PRIMARY KEY,
+	build_id    INTEGER,
+	reason      VARCHAR(250),
+	pipeline    TEXT,
+	approved_by VARCHAR(250),
+	approved_at INTEGER,
+	UNIQUE(build_id)
+);
+`
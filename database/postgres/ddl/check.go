@@ -0,0 +1,19 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+// CreateBuildCheckTable represents a query to
+// create the build_checks table for Vela.
+const CreateBuildCheckTable = `
+CREATE TABLE
+IF NOT EXISTS
+build_checks (
+	id           SERIAL PRIMARY KEY,
+	build_id     INTEGER,
+	check_run_id BIGINT,
+	annotated    BOOLEAN,
+	UNIQUE(build_id)
+);
+`
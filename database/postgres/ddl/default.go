@@ -0,0 +1,35 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+const (
+	// CreateDefaultPipelineTable represents a query to
+	// create the default_pipelines table for Vela.
+	CreateDefaultPipelineTable = `
+CREATE TABLE
+IF NOT EXISTS
+default_pipelines (
+	id        SERIAL PRIMARY KEY,
+	org       VARCHAR(250),
+	pipeline  TEXT,
+	template  VARCHAR(1000),
+	type      VARCHAR(250),
+	active    BOOLEAN,
+	UNIQUE(org)
+);
+`
+
+	// CreateDefaultPipelineOptOutTable represents a query to
+	// create the default_pipeline_opt_outs table for Vela.
+	CreateDefaultPipelineOptOutTable = `
+CREATE TABLE
+IF NOT EXISTS
+default_pipeline_opt_outs (
+	id        SERIAL PRIMARY KEY,
+	repo_id   INTEGER,
+	UNIQUE(repo_id)
+);
+`
+)
@@ -0,0 +1,59 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+const (
+	// CreatePolicyTable represents a query to
+	// create the policies table for Vela.
+	CreatePolicyTable = `
+CREATE TABLE
+IF NOT EXISTS
+policies (
+	id       SERIAL PRIMARY KEY,
+	org      VARCHAR(250),
+	repo     VARCHAR(250),
+	name     VARCHAR(250),
+	action   VARCHAR(250),
+	message  VARCHAR(1000),
+	rule     VARCHAR(5000),
+	active   BOOLEAN,
+	UNIQUE(org, repo, name)
+);
+`
+
+	// CreatePolicyOrgRepoIndex represents a query to create an
+	// index on the policies table for the org and repo columns.
+	CreatePolicyOrgRepoIndex = `
+CREATE INDEX
+IF NOT EXISTS
+policies_org_repo
+ON policies (org, repo);
+`
+
+	// CreateBuildPolicyTable represents a query to
+	// create the build_policies table for Vela.
+	CreateBuildPolicyTable = `
+CREATE TABLE
+IF NOT EXISTS
+build_policies (
+	id         SERIAL PRIMARY KEY,
+	build_id   INTEGER,
+	policy_id  INTEGER,
+	policy     VARCHAR(250),
+	action     VARCHAR(250),
+	step       VARCHAR(250),
+	message    VARCHAR(1000)
+);
+`
+
+	// CreateBuildPolicyBuildIDIndex represents a query to create an
+	// index on the build_policies table for the build_id column.
+	CreateBuildPolicyBuildIDIndex = `
+CREATE INDEX
+IF NOT EXISTS
+build_policies_build_id
+ON build_policies (build_id);
+`
+)
@@ -0,0 +1,44 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+const (
+	// CreatePipelineSettingsTable represents a query to
+	// create the pipeline_settings table for Vela.
+	CreatePipelineSettingsTable = `
+CREATE TABLE
+IF NOT EXISTS
+pipeline_settings (
+	id                  SERIAL PRIMARY KEY,
+	repo_id             INTEGER,
+	path                VARCHAR(500),
+	pipelines           TEXT,
+	stage_status        BOOLEAN,
+	allow_release       BOOLEAN,
+	allow_delete        BOOLEAN,
+	allow_pull_closed   BOOLEAN,
+	allow_pull_labeled  BOOLEAN,
+	allow_pull_reopened BOOLEAN,
+	approve_fork        BOOLEAN,
+	approve_first_time  BOOLEAN,
+	pull_comment        BOOLEAN,
+	UNIQUE(repo_id)
+);
+`
+
+	// CreateBuildPipelineTable represents a query to
+	// create the build_pipelines table for Vela.
+	CreateBuildPipelineTable = `
+CREATE TABLE
+IF NOT EXISTS
+build_pipelines (
+	id        SERIAL PRIMARY KEY,
+	build_id  INTEGER,
+	name      VARCHAR(500),
+	path      VARCHAR(500),
+	UNIQUE(build_id)
+);
+`
+)
@@ -0,0 +1,386 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+)
+
+func TestPostgres_Client_GetDefaultPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testDefaultPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.SelectDefaultPipeline, "github").Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "org", "pipeline", "template", "type", "active"},
+	).AddRow(1, "github", _pipeline.Pipeline, "", "", true)
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+	// ensure the mock expects the error for test case 2
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnError(gorm.ErrRecordNotFound)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.DefaultPipeline
+	}{
+		{
+			failure: false,
+			want:    _pipeline,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetDefaultPipeline("github")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetDefaultPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetDefaultPipeline returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetDefaultPipeline is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreateDefaultPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testDefaultPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "default_pipelines" ("org","pipeline","template","type","active","id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`).
+		WithArgs("github", _pipeline.Pipeline, "", "", true, 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *model.DefaultPipeline
+	}{
+		{
+			failure:  false,
+			pipeline: _pipeline,
+		},
+		{
+			failure:  true,
+			pipeline: new(model.DefaultPipeline),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateDefaultPipeline(test.pipeline)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateDefaultPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateDefaultPipeline returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_UpdateDefaultPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testDefaultPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(`UPDATE "default_pipelines" SET "org"=$1,"pipeline"=$2,"template"=$3,"type"=$4,"active"=$5 WHERE "id" = $6`).
+		WithArgs("github", _pipeline.Pipeline, "", "", true, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.UpdateDefaultPipeline(_pipeline)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdateDefaultPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdateDefaultPipeline returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_DeleteDefaultPipeline(t *testing.T) {
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Exec(dml.DeleteDefaultPipeline, 1).Statement
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(_query.SQL.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.DeleteDefaultPipeline(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeleteDefaultPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeleteDefaultPipeline returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_GetDefaultPipelineOptOut(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+
+	_optOut := &model.DefaultPipelineOptOut{ID: 1, RepoID: 1}
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.SelectDefaultPipelineOptOut, 1).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id", "repo_id"}).AddRow(1, 1)
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+	// ensure the mock expects the error for test case 2
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnError(gorm.ErrRecordNotFound)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.DefaultPipelineOptOut
+	}{
+		{
+			failure: false,
+			want:    _optOut,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetDefaultPipelineOptOut(_repo)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetDefaultPipelineOptOut should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetDefaultPipelineOptOut returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetDefaultPipelineOptOut is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreateDefaultPipelineOptOut(t *testing.T) {
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "default_pipeline_opt_outs" ("repo_id","id") VALUES ($1,$2) RETURNING "id"`).
+		WithArgs(1, 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		optOut  *model.DefaultPipelineOptOut
+	}{
+		{
+			failure: false,
+			optOut:  &model.DefaultPipelineOptOut{ID: 1, RepoID: 1},
+		},
+		{
+			failure: true,
+			optOut:  new(model.DefaultPipelineOptOut),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateDefaultPipelineOptOut(test.optOut)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateDefaultPipelineOptOut should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateDefaultPipelineOptOut returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_DeleteDefaultPipelineOptOut(t *testing.T) {
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Exec(dml.DeleteDefaultPipelineOptOut, 1).Statement
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(_query.SQL.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.DeleteDefaultPipelineOptOut(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeleteDefaultPipelineOptOut should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeleteDefaultPipelineOptOut returned err: %v", err)
+		}
+	}
+}
+
+// testDefaultPipeline is a test helper function to create a
+// model DefaultPipeline type with all fields set to a fake value.
+func testDefaultPipeline() *model.DefaultPipeline {
+	return &model.DefaultPipeline{
+		Org:      "github",
+		Pipeline: "steps:\n  - name: test\n    image: alpine:latest\n",
+		Active:   true,
+	}
+}
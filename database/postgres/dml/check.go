@@ -0,0 +1,14 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package dml
+
+// SelectBuildCheck represents a query to select
+// the check for a build_id in the database.
+const SelectBuildCheck = `
+SELECT *
+FROM build_checks
+WHERE build_id = ?
+LIMIT 1;
+`
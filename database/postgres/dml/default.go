@@ -0,0 +1,41 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package dml
+
+const (
+	// SelectDefaultPipeline represents a query to select
+	// the default pipeline for an org in the database.
+	SelectDefaultPipeline = `
+SELECT *
+FROM default_pipelines
+WHERE org = ?
+LIMIT 1;
+`
+
+	// DeleteDefaultPipeline represents a query to
+	// remove a default pipeline from the database.
+	DeleteDefaultPipeline = `
+DELETE
+FROM default_pipelines
+WHERE id = ?;
+`
+
+	// SelectDefaultPipelineOptOut represents a query to select
+	// the default pipeline opt out for a repo_id in the database.
+	SelectDefaultPipelineOptOut = `
+SELECT *
+FROM default_pipeline_opt_outs
+WHERE repo_id = ?
+LIMIT 1;
+`
+
+	// DeleteDefaultPipelineOptOut represents a query to
+	// remove a default pipeline opt out from the database.
+	DeleteDefaultPipelineOptOut = `
+DELETE
+FROM default_pipeline_opt_outs
+WHERE id = ?;
+`
+)
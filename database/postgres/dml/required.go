@@ -0,0 +1,43 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package dml
+
+const (
+	// ListRequiredPipelines represents a query to list
+	// all required pipelines for an org in the database.
+	ListRequiredPipelines = `
+SELECT *
+FROM required_pipelines
+WHERE org = ?
+ORDER BY id;
+`
+
+	// SelectRequiredPipeline represents a query to select a
+	// required pipeline for an org and name in the database.
+	SelectRequiredPipeline = `
+SELECT *
+FROM required_pipelines
+WHERE org = ?
+AND name = ?
+LIMIT 1;
+`
+
+	// DeleteRequiredPipeline represents a query to
+	// remove a required pipeline from the database.
+	DeleteRequiredPipeline = `
+DELETE
+FROM required_pipelines
+WHERE id = ?;
+`
+
+	// ListBuildInjectedSteps represents a query to list
+	// all injected steps for a build_id in the database.
+	ListBuildInjectedSteps = `
+SELECT *
+FROM build_injected_steps
+WHERE build_id = ?
+ORDER BY id;
+`
+)
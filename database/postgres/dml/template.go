@@ -0,0 +1,16 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package dml
+
+const (
+	// ListBuildTemplates represents a query to list
+	// all templates for a build_id in the database.
+	ListBuildTemplates = `
+SELECT *
+FROM build_templates
+WHERE build_id = ?
+ORDER BY id;
+`
+)
@@ -0,0 +1,179 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"errors"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+
+	"gorm.io/gorm"
+)
+
+// GetPolicy gets a policy by unique ID from the database.
+func (c *client) GetPolicy(id int64) (*model.Policy, error) {
+	c.Logger.Tracef("getting policy %d from the database", id)
+
+	// variable to store query results
+	p := new(model.Policy)
+
+	// send query to the database and store result in variable
+	result := c.Postgres.
+		Table(model.TablePolicy).
+		Raw(dml.SelectPolicy, id).
+		Scan(p)
+
+	// check if the query returned a record not found error or no rows were returned
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return p, result.Error
+}
+
+// GetPolicyList gets a list of all policies from the database.
+func (c *client) GetPolicyList() ([]*model.Policy, error) {
+	c.Logger.Trace("listing policies from the database")
+
+	// variable to store query results
+	p := new([]*model.Policy)
+
+	// send query to the database and store result in variable
+	err := c.Postgres.
+		Table(model.TablePolicy).
+		Raw(dml.ListPolicies).
+		Scan(p).Error
+
+	// variable we want to return
+	policies := []*model.Policy{}
+
+	// only return non-empty results
+	if len(*p) > 0 {
+		policies = *p
+	}
+
+	return policies, err
+}
+
+// GetRepoPolicyList gets a list of active policies
+// that apply to a repo from the database.
+func (c *client) GetRepoPolicyList(r *library.Repo) ([]*model.Policy, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+	}).Tracef("listing policies for repo %s from the database", r.GetFullName())
+
+	// variable to store query results
+	p := new([]*model.Policy)
+
+	// send query to the database and store result in variable
+	err := c.Postgres.
+		Table(model.TablePolicy).
+		Raw(dml.ListRepoPolicies, true, r.GetOrg(), r.GetName()).
+		Scan(p).Error
+
+	// variable we want to return
+	policies := []*model.Policy{}
+
+	// only return non-empty results
+	if len(*p) > 0 {
+		policies = *p
+	}
+
+	return policies, err
+}
+
+// CreatePolicy creates a new policy in the database.
+func (c *client) CreatePolicy(p *model.Policy) error {
+	c.Logger.WithFields(logrus.Fields{
+		"policy": p.Name,
+	}).Tracef("creating policy %s in the database", p.Name)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Postgres.
+		Table(model.TablePolicy).
+		Create(p).Error
+}
+
+// UpdatePolicy updates a policy in the database.
+func (c *client) UpdatePolicy(p *model.Policy) error {
+	c.Logger.WithFields(logrus.Fields{
+		"policy": p.Name,
+	}).Tracef("updating policy %s in the database", p.Name)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Postgres.
+		Table(model.TablePolicy).
+		Save(p).Error
+}
+
+// DeletePolicy deletes a policy by unique ID from the database.
+func (c *client) DeletePolicy(id int64) error {
+	c.Logger.Tracef("deleting policy %d in the database", id)
+
+	// send query to the database
+	return c.Postgres.
+		Table(model.TablePolicy).
+		Exec(dml.DeletePolicy, id).Error
+}
+
+// GetBuildPolicyResultList gets a list of policy results by build ID from the database.
+func (c *client) GetBuildPolicyResultList(b *library.Build) ([]*model.PolicyResult, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+	}).Tracef("listing policy results for build %d from the database", b.GetNumber())
+
+	// variable to store query results
+	r := new([]*model.PolicyResult)
+
+	// send query to the database and store result in variable
+	err := c.Postgres.
+		Table(model.TableBuildPolicy).
+		Raw(dml.ListBuildPolicies, b.GetID()).
+		Scan(r).Error
+
+	// variable we want to return
+	results := []*model.PolicyResult{}
+
+	// only return non-empty results
+	if len(*r) > 0 {
+		results = *r
+	}
+
+	return results, err
+}
+
+// CreateBuildPolicyResult creates a new policy result for a build in the database.
+func (c *client) CreateBuildPolicyResult(r *model.PolicyResult) error {
+	c.Logger.WithFields(logrus.Fields{
+		"policy": r.Policy,
+	}).Tracef("creating policy result %s for build %d in the database", r.Policy, r.BuildID)
+
+	// validate the necessary fields are populated
+	err := r.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Postgres.
+		Table(model.TableBuildPolicy).
+		Create(r).Error
+}
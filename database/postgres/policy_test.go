@@ -0,0 +1,509 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+)
+
+func TestPostgres_Client_GetPolicy(t *testing.T) {
+	// setup types
+	_policy := testPolicy()
+	_policy.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.SelectPolicy, 1).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "org", "repo", "name", "action", "message", "rule", "active"},
+	).AddRow(1, "github", "octocat", "sample", "deny", "privileged steps are not allowed", `{"privileged":true}`, true)
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+	// ensure the mock expects the error for test case 2
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnError(gorm.ErrRecordNotFound)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.Policy
+	}{
+		{
+			failure: false,
+			want:    _policy,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetPolicy(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetPolicy should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetPolicy returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetPolicy is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_GetPolicyList(t *testing.T) {
+	// setup types
+	_policyOne := testPolicy()
+	_policyOne.ID = 1
+	_policyOne.Name = "foo"
+
+	_policyTwo := testPolicy()
+	_policyTwo.ID = 2
+	_policyTwo.Name = "bar"
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.ListPolicies).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "org", "repo", "name", "action", "message", "rule", "active"},
+	).AddRow(1, "github", "octocat", "foo", "deny", "privileged steps are not allowed", `{"privileged":true}`, true).
+		AddRow(2, "github", "octocat", "bar", "deny", "privileged steps are not allowed", `{"privileged":true}`, true)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    []*model.Policy
+	}{
+		{
+			failure: false,
+			want:    []*model.Policy{_policyOne, _policyTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetPolicyList()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetPolicyList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetPolicyList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetPolicyList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_GetRepoPolicyList(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+	_repo.SetOrg("github")
+	_repo.SetName("octocat")
+	_repo.SetFullName("github/octocat")
+
+	_policyOne := testPolicy()
+	_policyOne.ID = 1
+	_policyOne.Name = "foo"
+	_policyOne.Org = ""
+	_policyOne.Repo = ""
+
+	_policyTwo := testPolicy()
+	_policyTwo.ID = 2
+	_policyTwo.Name = "bar"
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.ListRepoPolicies, true, "github", "octocat").Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "org", "repo", "name", "action", "message", "rule", "active"},
+	).AddRow(1, "", "", "foo", "deny", "privileged steps are not allowed", `{"privileged":true}`, true).
+		AddRow(2, "github", "octocat", "bar", "deny", "privileged steps are not allowed", `{"privileged":true}`, true)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    []*model.Policy
+	}{
+		{
+			failure: false,
+			want:    []*model.Policy{_policyOne, _policyTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetRepoPolicyList(_repo)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetRepoPolicyList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetRepoPolicyList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetRepoPolicyList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreatePolicy(t *testing.T) {
+	// setup types
+	_policy := testPolicy()
+	_policy.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "policies" ("org","repo","name","action","message","rule","active","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`).
+		WithArgs("github", "octocat", "sample", "deny", "privileged steps are not allowed", `{"privileged":true}`, true, 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		policy  *model.Policy
+	}{
+		{
+			failure: false,
+			policy:  _policy,
+		},
+		{
+			failure: true,
+			policy:  new(model.Policy),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreatePolicy(test.policy)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreatePolicy should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreatePolicy returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_UpdatePolicy(t *testing.T) {
+	// setup types
+	_policy := testPolicy()
+	_policy.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(`UPDATE "policies" SET "org"=$1,"repo"=$2,"name"=$3,"action"=$4,"message"=$5,"rule"=$6,"active"=$7 WHERE "id" = $8`).
+		WithArgs("github", "octocat", "sample", "deny", "privileged steps are not allowed", `{"privileged":true}`, true, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.UpdatePolicy(_policy)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdatePolicy should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdatePolicy returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_DeletePolicy(t *testing.T) {
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Exec(dml.DeletePolicy, 1).Statement
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(_query.SQL.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.DeletePolicy(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeletePolicy should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeletePolicy returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_GetBuildPolicyResultList(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+	_build.SetRepoID(1)
+	_build.SetNumber(1)
+
+	_resultOne := testPolicyResult()
+	_resultOne.ID = 1
+	_resultOne.Step = "foo"
+
+	_resultTwo := testPolicyResult()
+	_resultTwo.ID = 2
+	_resultTwo.Step = "bar"
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.ListBuildPolicies, 1).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "build_id", "policy_id", "policy", "action", "step", "message"},
+	).AddRow(1, 1, 1, "sample", "warn", "foo", "privileged steps are not allowed").
+		AddRow(2, 1, 1, "sample", "warn", "bar", "privileged steps are not allowed")
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    []*model.PolicyResult
+	}{
+		{
+			failure: false,
+			want:    []*model.PolicyResult{_resultOne, _resultTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetBuildPolicyResultList(_build)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildPolicyResultList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildPolicyResultList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildPolicyResultList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreateBuildPolicyResult(t *testing.T) {
+	// setup types
+	_result := testPolicyResult()
+	_result.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "build_policies" ("build_id","policy_id","policy","action","step","message","id") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`).
+		WithArgs(1, 1, "sample", "warn", "test", "privileged steps are not allowed", 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		result  *model.PolicyResult
+	}{
+		{
+			failure: false,
+			result:  _result,
+		},
+		{
+			failure: true,
+			result:  new(model.PolicyResult),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildPolicyResult(test.result)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildPolicyResult should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildPolicyResult returned err: %v", err)
+		}
+	}
+}
+
+// testPolicy is a test helper function to create a
+// model Policy type with all fields set to a fake value.
+func testPolicy() *model.Policy {
+	b := true
+
+	return &model.Policy{
+		Org:     "github",
+		Repo:    "octocat",
+		Name:    "sample",
+		Action:  model.PolicyDeny,
+		Message: "privileged steps are not allowed",
+		Rule: model.PolicyRule{
+			Privileged: &b,
+		},
+		Active: true,
+	}
+}
+
+// testPolicyResult is a test helper function to create a
+// model PolicyResult type with all fields set to a fake value.
+func testPolicyResult() *model.PolicyResult {
+	return &model.PolicyResult{
+		BuildID:  1,
+		PolicyID: 1,
+		Policy:   "sample",
+		Action:   model.PolicyWarn,
+		Step:     "test",
+		Message:  "privileged steps are not allowed",
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-vela/server/database/postgres/ddl"
+	"github.com/go-vela/server/model"
 	"github.com/go-vela/types/constants"
 	"github.com/sirupsen/logrus"
 
@@ -209,6 +210,60 @@ func createTables(c *client) error {
 		return fmt.Errorf("unable to create %s table: %v", constants.TableBuild, err)
 	}
 
+	// create the build_approvals table
+	err = c.Postgres.Exec(ddl.CreateBuildApprovalTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TableBuildApproval, err)
+	}
+
+	// create the build_checks table
+	err = c.Postgres.Exec(ddl.CreateBuildCheckTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TableBuildCheck, err)
+	}
+
+	// create the build_injected_steps table
+	err = c.Postgres.Exec(ddl.CreateBuildInjectedStepTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TableBuildInjectedStep, err)
+	}
+
+	// create the build_pipelines table
+	err = c.Postgres.Exec(ddl.CreateBuildPipelineTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TableBuildPipeline, err)
+	}
+
+	// create the build_policies table
+	err = c.Postgres.Exec(ddl.CreateBuildPolicyTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TableBuildPolicy, err)
+	}
+
+	// create the build_pull_requests table
+	err = c.Postgres.Exec(ddl.CreateBuildPullRequestTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TableBuildPullRequest, err)
+	}
+
+	// create the build_templates table
+	err = c.Postgres.Exec(ddl.CreateBuildTemplateTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TableBuildTemplate, err)
+	}
+
+	// create the default_pipelines table
+	err = c.Postgres.Exec(ddl.CreateDefaultPipelineTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TableDefaultPipeline, err)
+	}
+
+	// create the default_pipeline_opt_outs table
+	err = c.Postgres.Exec(ddl.CreateDefaultPipelineOptOutTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TableDefaultPipelineOptOut, err)
+	}
+
 	// create the hooks table
 	err = c.Postgres.Exec(ddl.CreateHookTable).Error
 	if err != nil {
@@ -221,12 +276,36 @@ func createTables(c *client) error {
 		return fmt.Errorf("unable to create %s table: %v", constants.TableLog, err)
 	}
 
+	// create the pipeline_settings table
+	err = c.Postgres.Exec(ddl.CreatePipelineSettingsTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TablePipelineSettings, err)
+	}
+
+	// create the policies table
+	err = c.Postgres.Exec(ddl.CreatePolicyTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TablePolicy, err)
+	}
+
+	// create the pull comments table
+	err = c.Postgres.Exec(ddl.CreatePullCommentTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TablePullComment, err)
+	}
+
 	// create the repos table
 	err = c.Postgres.Exec(ddl.CreateRepoTable).Error
 	if err != nil {
 		return fmt.Errorf("unable to create %s table: %v", constants.TableRepo, err)
 	}
 
+	// create the required_pipelines table
+	err = c.Postgres.Exec(ddl.CreateRequiredPipelineTable).Error
+	if err != nil {
+		return fmt.Errorf("unable to create %s table: %v", model.TableRequiredPipeline, err)
+	}
+
 	// create the secrets table
 	err = c.Postgres.Exec(ddl.CreateSecretTable).Error
 	if err != nil {
@@ -285,6 +364,24 @@ func createIndexes(c *client) error {
 		return fmt.Errorf("unable to create builds_created index for the %s table: %v", constants.TableBuild, err)
 	}
 
+	// create the build_injected_steps_build_id index for the build_injected_steps table
+	err = c.Postgres.Exec(ddl.CreateBuildInjectedStepBuildIDIndex).Error
+	if err != nil {
+		return fmt.Errorf("unable to create build_injected_steps_build_id index for the %s table: %v", model.TableBuildInjectedStep, err)
+	}
+
+	// create the build_policies_build_id index for the build_policies table
+	err = c.Postgres.Exec(ddl.CreateBuildPolicyBuildIDIndex).Error
+	if err != nil {
+		return fmt.Errorf("unable to create build_policies_build_id index for the %s table: %v", model.TableBuildPolicy, err)
+	}
+
+	// create the build_templates_build_id index for the build_templates table
+	err = c.Postgres.Exec(ddl.CreateBuildTemplateBuildIDIndex).Error
+	if err != nil {
+		return fmt.Errorf("unable to create build_templates_build_id index for the %s table: %v", model.TableBuildTemplate, err)
+	}
+
 	// create the hooks_repo_id index for the hooks table
 	err = c.Postgres.Exec(ddl.CreateHookRepoIDIndex).Error
 	if err != nil {
@@ -297,12 +394,24 @@ func createIndexes(c *client) error {
 		return fmt.Errorf("unable to create logs_build_id index for the %s table: %v", constants.TableLog, err)
 	}
 
+	// create the policies_org_repo index for the policies table
+	err = c.Postgres.Exec(ddl.CreatePolicyOrgRepoIndex).Error
+	if err != nil {
+		return fmt.Errorf("unable to create policies_org_repo index for the %s table: %v", model.TablePolicy, err)
+	}
+
 	// create the repos_org_name index for the repos table
 	err = c.Postgres.Exec(ddl.CreateRepoOrgNameIndex).Error
 	if err != nil {
 		return fmt.Errorf("unable to create repos_org_name index for the %s table: %v", constants.TableRepo, err)
 	}
 
+	// create the required_pipelines_org index for the required_pipelines table
+	err = c.Postgres.Exec(ddl.CreateRequiredPipelineOrgIndex).Error
+	if err != nil {
+		return fmt.Errorf("unable to create required_pipelines_org index for the %s table: %v", model.TableRequiredPipeline, err)
+	}
+
 	// create the secrets_type_org_repo index for the secrets table
 	err = c.Postgres.Exec(ddl.CreateSecretTypeOrgRepo).Error
 	if err != nil {
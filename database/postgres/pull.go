@@ -0,0 +1,55 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"errors"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+
+	"gorm.io/gorm"
+)
+
+// GetBuildPullRequest gets the pull request for a build from the database.
+func (c *client) GetBuildPullRequest(b *library.Build) (*model.BuildPullRequest, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+	}).Tracef("getting pull request for build %d from the database", b.GetNumber())
+
+	// variable to store query results
+	p := new(model.BuildPullRequest)
+
+	// send query to the database and store result in variable
+	result := c.Postgres.
+		Table(model.TableBuildPullRequest).
+		Raw(dml.SelectBuildPullRequest, b.GetID()).
+		Scan(p)
+
+	// check if the query returned a record not found error or no rows were returned
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return p, result.Error
+}
+
+// CreateBuildPullRequest creates a new build pull request in the database.
+func (c *client) CreateBuildPullRequest(p *model.BuildPullRequest) error {
+	c.Logger.Tracef("creating pull request for build %d in the database", p.BuildID)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Postgres.
+		Table(model.TableBuildPullRequest).
+		Create(p).Error
+}
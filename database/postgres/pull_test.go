@@ -0,0 +1,147 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+)
+
+func TestPostgres_Client_GetBuildPullRequest(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	_pull := &model.BuildPullRequest{
+		ID:                1,
+		BuildID:           1,
+		Labels:            model.Labels{"e2e"},
+		Draft:             true,
+		AuthorAssociation: "MEMBER",
+		Fork:              true,
+		Action:            "labeled",
+	}
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.SelectBuildPullRequest, 1).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "build_id", "labels", "draft", "author_association", "fork", "action"},
+	).AddRow(1, 1, `["e2e"]`, true, "MEMBER", true, "labeled")
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+	// ensure the mock expects the error for test case 2
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnError(gorm.ErrRecordNotFound)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.BuildPullRequest
+	}{
+		{
+			failure: false,
+			want:    _pull,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetBuildPullRequest(_build)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildPullRequest should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildPullRequest returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildPullRequest is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreateBuildPullRequest(t *testing.T) {
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "build_pull_requests" ("build_id","labels","draft","author_association","fork","action","id") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`).
+		WithArgs(1, `["e2e"]`, true, "MEMBER", true, "labeled", 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		pull    *model.BuildPullRequest
+	}{
+		{
+			failure: false,
+			pull: &model.BuildPullRequest{
+				ID:                1,
+				BuildID:           1,
+				Labels:            model.Labels{"e2e"},
+				Draft:             true,
+				AuthorAssociation: "MEMBER",
+				Fork:              true,
+				Action:            "labeled",
+			},
+		},
+		{
+			failure: true,
+			pull:    new(model.BuildPullRequest),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildPullRequest(test.pull)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildPullRequest should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildPullRequest returned err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,429 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+)
+
+func TestPostgres_Client_GetRequiredPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testRequiredPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.SelectRequiredPipeline, "github", "scan").Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "org", "name", "position", "pipeline", "active"},
+	).AddRow(1, "github", "scan", "before", _pipeline.Pipeline, true)
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+	// ensure the mock expects the error for test case 2
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnError(gorm.ErrRecordNotFound)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.RequiredPipeline
+	}{
+		{
+			failure: false,
+			want:    _pipeline,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetRequiredPipeline("github", "scan")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetRequiredPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetRequiredPipeline returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetRequiredPipeline is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_GetRequiredPipelineList(t *testing.T) {
+	// setup types
+	_pipelineOne := testRequiredPipeline()
+	_pipelineOne.ID = 1
+	_pipelineOne.Name = "foo"
+
+	_pipelineTwo := testRequiredPipeline()
+	_pipelineTwo.ID = 2
+	_pipelineTwo.Name = "bar"
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.ListRequiredPipelines, "github").Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "org", "name", "position", "pipeline", "active"},
+	).AddRow(1, "github", "foo", "before", _pipelineOne.Pipeline, true).
+		AddRow(2, "github", "bar", "before", _pipelineTwo.Pipeline, true)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    []*model.RequiredPipeline
+	}{
+		{
+			failure: false,
+			want:    []*model.RequiredPipeline{_pipelineOne, _pipelineTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetRequiredPipelineList("github")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetRequiredPipelineList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetRequiredPipelineList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetRequiredPipelineList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreateRequiredPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testRequiredPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "required_pipelines" ("org","name","position","pipeline","active","id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`).
+		WithArgs("github", "scan", "before", _pipeline.Pipeline, true, 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *model.RequiredPipeline
+	}{
+		{
+			failure:  false,
+			pipeline: _pipeline,
+		},
+		{
+			failure:  true,
+			pipeline: new(model.RequiredPipeline),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateRequiredPipeline(test.pipeline)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateRequiredPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateRequiredPipeline returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_UpdateRequiredPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testRequiredPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(`UPDATE "required_pipelines" SET "org"=$1,"name"=$2,"position"=$3,"pipeline"=$4,"active"=$5 WHERE "id" = $6`).
+		WithArgs("github", "scan", "before", _pipeline.Pipeline, true, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.UpdateRequiredPipeline(_pipeline)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdateRequiredPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdateRequiredPipeline returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_DeleteRequiredPipeline(t *testing.T) {
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Exec(dml.DeleteRequiredPipeline, 1).Statement
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(_query.SQL.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.DeleteRequiredPipeline(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeleteRequiredPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeleteRequiredPipeline returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_GetBuildInjectedStepList(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+	_build.SetRepoID(1)
+	_build.SetNumber(1)
+
+	_stepOne := testInjectedStep()
+	_stepOne.ID = 1
+	_stepOne.Step = "foo"
+
+	_stepTwo := testInjectedStep()
+	_stepTwo.ID = 2
+	_stepTwo.Step = "bar"
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.ListBuildInjectedSteps, 1).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "build_id", "pipeline", "stage", "step"},
+	).AddRow(1, 1, "scan", "scan", "foo").
+		AddRow(2, 1, "scan", "scan", "bar")
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    []*model.InjectedStep
+	}{
+		{
+			failure: false,
+			want:    []*model.InjectedStep{_stepOne, _stepTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetBuildInjectedStepList(_build)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildInjectedStepList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildInjectedStepList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildInjectedStepList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreateBuildInjectedStep(t *testing.T) {
+	// setup types
+	_step := testInjectedStep()
+	_step.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "build_injected_steps" ("build_id","pipeline","stage","step","id") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`).
+		WithArgs(1, "scan", "scan", "secrets", 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		step    *model.InjectedStep
+	}{
+		{
+			failure: false,
+			step:    _step,
+		},
+		{
+			failure: true,
+			step:    new(model.InjectedStep),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildInjectedStep(test.step)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildInjectedStep should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildInjectedStep returned err: %v", err)
+		}
+	}
+}
+
+// testRequiredPipeline is a test helper function to create a
+// model RequiredPipeline type with all fields set to a fake value.
+func testRequiredPipeline() *model.RequiredPipeline {
+	return &model.RequiredPipeline{
+		Org:      "github",
+		Name:     "scan",
+		Position: model.RequiredPipelineBefore,
+		Pipeline: "steps:\n  - name: secrets\n    image: target/vela-secret-scan:latest\n",
+		Active:   true,
+	}
+}
+
+// testInjectedStep is a test helper function to create a
+// model InjectedStep type with all fields set to a fake value.
+func testInjectedStep() *model.InjectedStep {
+	return &model.InjectedStep{
+		BuildID:  1,
+		Pipeline: "scan",
+		Stage:    "scan",
+		Step:     "secrets",
+	}
+}
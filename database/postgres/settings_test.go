@@ -0,0 +1,363 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+)
+
+func TestPostgres_Client_GetPipelineSettings(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+
+	_settings := testPipelineSettings()
+	_settings.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.SelectPipelineSettings, 1).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "repo_id", "path", "pipelines", "stage_status", "allow_release", "allow_delete", "allow_pull_closed", "allow_pull_labeled", "allow_pull_reopened", "approve_fork", "approve_first_time", "pull_comment"},
+	).AddRow(1, 1, "ci/vela.yml", `[{"name":"api","path":"api/.vela.yml","rules":["api/*"]}]`, true, true, true, true, true, true, true, true, true)
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+	// ensure the mock expects the error for test case 2
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnError(gorm.ErrRecordNotFound)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.PipelineSettings
+	}{
+		{
+			failure: false,
+			want:    _settings,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetPipelineSettings(_repo)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetPipelineSettings should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetPipelineSettings returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetPipelineSettings is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreatePipelineSettings(t *testing.T) {
+	// setup types
+	_settings := testPipelineSettings()
+	_settings.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "pipeline_settings" ("repo_id","path","pipelines","stage_status","allow_release","allow_delete","allow_pull_closed","allow_pull_labeled","allow_pull_reopened","approve_fork","approve_first_time","pull_comment","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) RETURNING "id"`).
+		WithArgs(1, "ci/vela.yml", `[{"name":"api","path":"api/.vela.yml","rules":["api/*"]}]`, true, true, true, true, true, true, true, true, true, 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		settings *model.PipelineSettings
+	}{
+		{
+			failure:  false,
+			settings: _settings,
+		},
+		{
+			failure:  true,
+			settings: new(model.PipelineSettings),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreatePipelineSettings(test.settings)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreatePipelineSettings should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreatePipelineSettings returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_UpdatePipelineSettings(t *testing.T) {
+	// setup types
+	_settings := testPipelineSettings()
+	_settings.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(`UPDATE "pipeline_settings" SET "repo_id"=$1,"path"=$2,"pipelines"=$3,"stage_status"=$4,"allow_release"=$5,"allow_delete"=$6,"allow_pull_closed"=$7,"allow_pull_labeled"=$8,"allow_pull_reopened"=$9,"approve_fork"=$10,"approve_first_time"=$11,"pull_comment"=$12 WHERE "id" = $13`).
+		WithArgs(1, "ci/vela.yml", `[{"name":"api","path":"api/.vela.yml","rules":["api/*"]}]`, true, true, true, true, true, true, true, true, true, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.UpdatePipelineSettings(_settings)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdatePipelineSettings should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdatePipelineSettings returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_DeletePipelineSettings(t *testing.T) {
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Exec(dml.DeletePipelineSettings, 1).Statement
+
+	// ensure the mock expects the query
+	_mock.ExpectExec(_query.SQL.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.DeletePipelineSettings(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeletePipelineSettings should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeletePipelineSettings returned err: %v", err)
+		}
+	}
+}
+
+func TestPostgres_Client_GetBuildPipeline(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	_pipeline := &model.BuildPipeline{ID: 1, BuildID: 1, Name: "api", Path: "api/.vela.yml"}
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.SelectBuildPipeline, 1).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "build_id", "name", "path"},
+	).AddRow(1, 1, "api", "api/.vela.yml")
+
+	// ensure the mock expects the query for test case 1
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+	// ensure the mock expects the error for test case 2
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnError(gorm.ErrRecordNotFound)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.BuildPipeline
+	}{
+		{
+			failure: false,
+			want:    _pipeline,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetBuildPipeline(_build)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildPipeline returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildPipeline is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreateBuildPipeline(t *testing.T) {
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "build_pipelines" ("build_id","name","path","id") VALUES ($1,$2,$3,$4) RETURNING "id"`).
+		WithArgs(1, "api", "api/.vela.yml", 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *model.BuildPipeline
+	}{
+		{
+			failure:  false,
+			pipeline: &model.BuildPipeline{ID: 1, BuildID: 1, Name: "api", Path: "api/.vela.yml"},
+		},
+		{
+			failure:  true,
+			pipeline: new(model.BuildPipeline),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildPipeline(test.pipeline)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildPipeline returned err: %v", err)
+		}
+	}
+}
+
+// testPipelineSettings is a test helper function to create a
+// model PipelineSettings type with all fields set to a fake value.
+func testPipelineSettings() *model.PipelineSettings {
+	return &model.PipelineSettings{
+		RepoID: 1,
+		Path:   "ci/vela.yml",
+		Pipelines: model.RepoPipelines{
+			{
+				Name:  "api",
+				Path:  "api/.vela.yml",
+				Rules: []string{"api/*"},
+			},
+		},
+		StageStatus:       true,
+		AllowRelease:      true,
+		AllowDelete:       true,
+		AllowPullClosed:   true,
+		AllowPullLabeled:  true,
+		AllowPullReopened: true,
+		ApproveFork:       true,
+		ApproveFirstTime:  true,
+		PullComment:       true,
+	}
+}
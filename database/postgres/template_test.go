@@ -0,0 +1,152 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/go-vela/server/database/postgres/dml"
+	"github.com/go-vela/server/model"
+)
+
+func TestPostgres_Client_GetBuildTemplateList(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+	_build.SetRepoID(1)
+	_build.SetNumber(1)
+
+	_templateOne := testBuildTemplate()
+	_templateOne.ID = 1
+	_templateOne.Name = "foo"
+
+	_templateTwo := testBuildTemplate()
+	_templateTwo.ID = 2
+	_templateTwo.Name = "bar"
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// capture the current expected SQL query
+	//
+	// https://gorm.io/docs/sql_builder.html#DryRun-Mode
+	_query := _database.Postgres.Session(&gorm.Session{DryRun: true}).Raw(dml.ListBuildTemplates, 1).Statement
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows(
+		[]string{"id", "build_id", "name", "source", "type", "ref", "commit", "digest"},
+	).AddRow(1, 1, "foo", "github.com/github/octocat/template.yml@main", "github", "main", "48afb5bdc41ad69bf22588491333f7cf71135163", "sha256:abc").
+		AddRow(2, 1, "bar", "github.com/github/octocat/template.yml@main", "github", "main", "48afb5bdc41ad69bf22588491333f7cf71135163", "sha256:abc")
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(_query.SQL.String()).WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    []*model.BuildTemplate
+	}{
+		{
+			failure: false,
+			want:    []*model.BuildTemplate{_templateOne, _templateTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := _database.GetBuildTemplateList(_build)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildTemplateList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildTemplateList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildTemplateList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestPostgres_Client_CreateBuildTemplate(t *testing.T) {
+	// setup types
+	_template := testBuildTemplate()
+	_template.ID = 1
+
+	// setup the test database client
+	_database, _mock, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new postgres test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Postgres.DB(); _sql.Close() }()
+
+	// create expected return in mock
+	_rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+
+	// ensure the mock expects the query
+	_mock.ExpectQuery(`INSERT INTO "build_templates" ("build_id","name","source","type","ref","commit","digest","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`).
+		WithArgs(1, "sample", "github.com/github/octocat/template.yml@main", "github", "main", "48afb5bdc41ad69bf22588491333f7cf71135163", "sha256:abc", 1).
+		WillReturnRows(_rows)
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		template *model.BuildTemplate
+	}{
+		{
+			failure:  false,
+			template: _template,
+		},
+		{
+			failure:  true,
+			template: new(model.BuildTemplate),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildTemplate(test.template)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildTemplate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildTemplate returned err: %v", err)
+		}
+	}
+}
+
+// testBuildTemplate is a test helper function to create a
+// model BuildTemplate type with all fields set to a fake value.
+func testBuildTemplate() *model.BuildTemplate {
+	return &model.BuildTemplate{
+		BuildID: 1,
+		Name:    "sample",
+		Source:  "github.com/github/octocat/template.yml@main",
+		Type:    "github",
+		Ref:     "main",
+		Commit:  "48afb5bdc41ad69bf22588491333f7cf71135163",
+		Digest:  "sha256:abc",
+	}
+}
@@ -5,6 +5,7 @@
 package database
 
 import (
+	"github.com/go-vela/server/model"
 	"github.com/go-vela/types/library"
 )
 
@@ -65,6 +66,30 @@ type Service interface {
 	// deletes a build by unique ID.
 	DeleteBuild(int64) error
 
+	// Default Pipeline Database Interface Functions
+
+	// GetDefaultPipeline defines a function that
+	// gets the default pipeline by org.
+	GetDefaultPipeline(string) (*model.DefaultPipeline, error)
+	// CreateDefaultPipeline defines a function that
+	// creates a new default pipeline.
+	CreateDefaultPipeline(*model.DefaultPipeline) error
+	// UpdateDefaultPipeline defines a function that
+	// updates a default pipeline.
+	UpdateDefaultPipeline(*model.DefaultPipeline) error
+	// DeleteDefaultPipeline defines a function that
+	// deletes a default pipeline by unique ID.
+	DeleteDefaultPipeline(int64) error
+	// GetDefaultPipelineOptOut defines a function that
+	// gets the default pipeline opt out for a repo.
+	GetDefaultPipelineOptOut(*library.Repo) (*model.DefaultPipelineOptOut, error)
+	// CreateDefaultPipelineOptOut defines a function that
+	// creates a new default pipeline opt out.
+	CreateDefaultPipelineOptOut(*model.DefaultPipelineOptOut) error
+	// DeleteDefaultPipelineOptOut defines a function that
+	// deletes a default pipeline opt out by unique ID.
+	DeleteDefaultPipelineOptOut(int64) error
+
 	// Hook Database Interface Functions
 
 	// GetHook defines a function that
@@ -113,6 +138,109 @@ type Service interface {
 	// deletes a log by unique ID.
 	DeleteLog(int64) error
 
+	// Pipeline Settings Database Interface Functions
+
+	// GetPipelineSettings defines a function that
+	// gets the pipeline settings for a repo.
+	GetPipelineSettings(*library.Repo) (*model.PipelineSettings, error)
+	// CreatePipelineSettings defines a function that
+	// creates new pipeline settings.
+	CreatePipelineSettings(*model.PipelineSettings) error
+	// UpdatePipelineSettings defines a function that
+	// updates pipeline settings.
+	UpdatePipelineSettings(*model.PipelineSettings) error
+	// DeletePipelineSettings defines a function that
+	// deletes pipeline settings by unique ID.
+	DeletePipelineSettings(int64) error
+	// GetBuildPipeline defines a function that
+	// gets the pipeline compiled for a build.
+	GetBuildPipeline(*library.Build) (*model.BuildPipeline, error)
+	// CreateBuildPipeline defines a function that
+	// creates a new pipeline for a build.
+	CreateBuildPipeline(*model.BuildPipeline) error
+
+	// Build Approval Database Interface Functions
+
+	// GetBuildApproval defines a function that
+	// gets the approval for a build.
+	GetBuildApproval(*library.Build) (*model.BuildApproval, error)
+	// CreateBuildApproval defines a function that
+	// creates a new approval for a build.
+	CreateBuildApproval(*model.BuildApproval) error
+	// UpdateBuildApproval defines a function that
+	// updates an approval for a build.
+	UpdateBuildApproval(*model.BuildApproval) error
+	// ApproveBuildApproval defines a function that
+	// approves an approval for a build once.
+	ApproveBuildApproval(*model.BuildApproval) error
+
+	// Build Check Database Interface Functions
+
+	// GetBuildCheck defines a function that
+	// gets the check for a build.
+	GetBuildCheck(*library.Build) (*model.BuildCheck, error)
+	// CreateBuildCheck defines a function that
+	// creates a new check for a build.
+	CreateBuildCheck(*model.BuildCheck) error
+	// UpdateBuildCheck defines a function that
+	// updates a check for a build.
+	UpdateBuildCheck(*model.BuildCheck) error
+
+	// Build Pull Request Database Interface Functions
+
+	// GetBuildPullRequest defines a function that
+	// gets the pull request for a build.
+	GetBuildPullRequest(*library.Build) (*model.BuildPullRequest, error)
+	// CreateBuildPullRequest defines a function that
+	// creates a new pull request for a build.
+	CreateBuildPullRequest(*model.BuildPullRequest) error
+
+	// Pull Comment Database Interface Functions
+
+	// GetPullComment defines a function that gets the
+	// comment for a pull request by event and pipeline.
+	GetPullComment(*library.Repo, int, string, string) (*model.PullComment, error)
+	// CreatePullComment defines a function that
+	// creates a new comment for a pull request.
+	CreatePullComment(*model.PullComment) error
+	// UpdatePullComment defines a function that
+	// updates a comment for a pull request.
+	UpdatePullComment(*model.PullComment) error
+	// ClaimPullComment defines a function that records
+	// the build for a pull request comment once it's
+	// not claimed by a newer build.
+	ClaimPullComment(*model.PullComment) error
+	// DeletePullComment defines a function that
+	// deletes a comment for a pull request by unique ID.
+	DeletePullComment(int64) error
+
+	// Policy Database Interface Functions
+
+	// GetPolicy defines a function that
+	// gets a policy by unique ID.
+	GetPolicy(int64) (*model.Policy, error)
+	// GetPolicyList defines a function that
+	// gets a list of all policies.
+	GetPolicyList() ([]*model.Policy, error)
+	// GetRepoPolicyList defines a function that
+	// gets a list of active policies for a repo.
+	GetRepoPolicyList(*library.Repo) ([]*model.Policy, error)
+	// CreatePolicy defines a function that
+	// creates a new policy.
+	CreatePolicy(*model.Policy) error
+	// UpdatePolicy defines a function that
+	// updates a policy.
+	UpdatePolicy(*model.Policy) error
+	// DeletePolicy defines a function that
+	// deletes a policy by unique ID.
+	DeletePolicy(int64) error
+	// GetBuildPolicyResultList defines a function that
+	// gets a list of policy results by build ID.
+	GetBuildPolicyResultList(*library.Build) ([]*model.PolicyResult, error)
+	// CreateBuildPolicyResult defines a function that
+	// creates a new policy result for a build.
+	CreateBuildPolicyResult(*model.PolicyResult) error
+
 	// Repo Database Interface Functions
 
 	// GetRepo defines a function that
@@ -146,6 +274,30 @@ type Service interface {
 	// deletes a repo by unique ID.
 	DeleteRepo(int64) error
 
+	// Required Pipeline Database Interface Functions
+
+	// GetRequiredPipeline defines a function that
+	// gets a required pipeline by org and name.
+	GetRequiredPipeline(string, string) (*model.RequiredPipeline, error)
+	// GetRequiredPipelineList defines a function that
+	// gets a list of required pipelines by org.
+	GetRequiredPipelineList(string) ([]*model.RequiredPipeline, error)
+	// CreateRequiredPipeline defines a function that
+	// creates a new required pipeline.
+	CreateRequiredPipeline(*model.RequiredPipeline) error
+	// UpdateRequiredPipeline defines a function that
+	// updates a required pipeline.
+	UpdateRequiredPipeline(*model.RequiredPipeline) error
+	// DeleteRequiredPipeline defines a function that
+	// deletes a required pipeline by unique ID.
+	DeleteRequiredPipeline(int64) error
+	// GetBuildInjectedStepList defines a function that
+	// gets a list of injected steps by build ID.
+	GetBuildInjectedStepList(*library.Build) ([]*model.InjectedStep, error)
+	// CreateBuildInjectedStep defines a function that
+	// creates a new injected step for a build.
+	CreateBuildInjectedStep(*model.InjectedStep) error
+
 	// Secret Database Interface Functions
 
 	// GetSecret defines a function that gets a secret
@@ -234,6 +386,15 @@ type Service interface {
 	// deletes a step by unique ID.
 	DeleteService(int64) error
 
+	// Template Database Interface Functions
+
+	// GetBuildTemplateList defines a function that
+	// gets a list of templates by build ID.
+	GetBuildTemplateList(*library.Build) ([]*model.BuildTemplate, error)
+	// CreateBuildTemplate defines a function that
+	// creates a new template for a build.
+	CreateBuildTemplate(*model.BuildTemplate) error
+
 	// User Database Interface Functions
 
 	// GetUser defines a function that
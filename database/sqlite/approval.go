@@ -0,0 +1,99 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/go-vela/server/database/sqlite/dml"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+
+	"gorm.io/gorm"
+)
+
+// GetBuildApproval gets the approval for a build from the database.
+func (c *client) GetBuildApproval(b *library.Build) (*model.BuildApproval, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+	}).Tracef("getting approval for build %d from the database", b.GetNumber())
+
+	// variable to store query results
+	a := new(model.BuildApproval)
+
+	// send query to the database and store result in variable
+	result := c.Sqlite.
+		Table(model.TableBuildApproval).
+		Raw(dml.SelectBuildApproval, b.GetID()).
+		Scan(a)
+
+	// check if the query returned a record not found error or no rows were returned
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return a, result.Error
+}
+
+// CreateBuildApproval creates a new build approval in the database.
+func (c *client) CreateBuildApproval(a *model.BuildApproval) error {
+	c.Logger.Tracef("creating approval for build %d in the database", a.BuildID)
+
+	// validate the necessary fields are populated
+	err := a.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableBuildApproval).
+		Create(a).Error
+}
+
+// UpdateBuildApproval updates a build approval in the database.
+func (c *client) UpdateBuildApproval(a *model.BuildApproval) error {
+	c.Logger.Tracef("updating approval for build %d in the database", a.BuildID)
+
+	// validate the necessary fields are populated
+	err := a.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableBuildApproval).
+		Save(a).Error
+}
+
+// ApproveBuildApproval records the approval for a build approval in
+// the database. The approval is only recorded when the build wasn't
+// approved yet so the build is only approved once.
+func (c *client) ApproveBuildApproval(a *model.BuildApproval) error {
+	c.Logger.Tracef("approving approval for build %d in the database", a.BuildID)
+
+	// validate the necessary fields are populated
+	err := a.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	result := c.Sqlite.
+		Table(model.TableBuildApproval).
+		Exec(dml.ApproveBuildApproval, a.ApprovedBy, a.ApprovedAt, a.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// check if the build was already approved
+	if result.RowsAffected == 0 {
+		return model.ErrBuildApprovalApproved
+	}
+
+	return nil
+}
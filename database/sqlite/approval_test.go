@@ -0,0 +1,254 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/model"
+)
+
+func TestSqlite_Client_GetBuildApproval(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	_approval := testBuildApproval()
+	_approval.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.BuildApproval
+	}{
+		{
+			failure: false,
+			want:    _approval,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.want != nil {
+			// create the build approval in the database
+			err := _database.CreateBuildApproval(test.want)
+			if err != nil {
+				t.Errorf("unable to create test build approval: %v", err)
+			}
+		}
+
+		got, err := _database.GetBuildApproval(_build)
+
+		// cleanup the build_approvals table
+		_ = _database.Sqlite.Exec("DELETE FROM build_approvals;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildApproval should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildApproval returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildApproval is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreateBuildApproval(t *testing.T) {
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		approval *model.BuildApproval
+	}{
+		{
+			failure:  false,
+			approval: testBuildApproval(),
+		},
+		{
+			failure:  true,
+			approval: new(model.BuildApproval),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildApproval(test.approval)
+
+		// cleanup the build_approvals table
+		_ = _database.Sqlite.Exec("DELETE FROM build_approvals;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildApproval should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildApproval returned err: %v", err)
+		}
+	}
+}
+
+func TestSqlite_Client_UpdateBuildApproval(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		approval *model.BuildApproval
+	}{
+		{
+			failure:  false,
+			approval: testBuildApproval(),
+		},
+		{
+			failure:  true,
+			approval: new(model.BuildApproval),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if !test.failure {
+			// create the build approval in the database
+			a := &model.BuildApproval{BuildID: 1, Reason: test.approval.Reason}
+
+			err := _database.CreateBuildApproval(a)
+			if err != nil {
+				t.Errorf("unable to create test build approval: %v", err)
+			}
+
+			test.approval.ID = a.ID
+		}
+
+		err := _database.UpdateBuildApproval(test.approval)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdateBuildApproval should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdateBuildApproval returned err: %v", err)
+		}
+
+		got, _ := _database.GetBuildApproval(_build)
+
+		// cleanup the build_approvals table
+		_ = _database.Sqlite.Exec("DELETE FROM build_approvals;")
+
+		if !reflect.DeepEqual(got, test.approval) {
+			t.Errorf("UpdateBuildApproval is %v, want %v", got, test.approval)
+		}
+	}
+}
+
+func TestSqlite_Client_ApproveBuildApproval(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// create the build approval in the database
+	a := &model.BuildApproval{BuildID: 1, Reason: "pull request is from a fork"}
+
+	err = _database.CreateBuildApproval(a)
+	if err != nil {
+		t.Errorf("unable to create test build approval: %v", err)
+	}
+
+	// run test
+	a.ApprovedBy = "octocat"
+	a.ApprovedAt = 1563474077
+
+	err = _database.ApproveBuildApproval(a)
+	if err != nil {
+		t.Errorf("ApproveBuildApproval returned err: %v", err)
+	}
+
+	got, _ := _database.GetBuildApproval(_build)
+
+	if !reflect.DeepEqual(got, a) {
+		t.Errorf("ApproveBuildApproval is %v, want %v", got, a)
+	}
+
+	// run test with the approved build approval
+	approval := &model.BuildApproval{ID: a.ID, BuildID: 1, ApprovedBy: "octokitty", ApprovedAt: 1563474078}
+
+	err = _database.ApproveBuildApproval(approval)
+	if !errors.Is(err, model.ErrBuildApprovalApproved) {
+		t.Errorf("ApproveBuildApproval returned err %v, want %v", err, model.ErrBuildApprovalApproved)
+	}
+
+	got, _ = _database.GetBuildApproval(_build)
+
+	if !reflect.DeepEqual(got, a) {
+		t.Errorf("ApproveBuildApproval is %v, want %v", got, a)
+	}
+
+	// run test with the invalid build approval
+	err = _database.ApproveBuildApproval(new(model.BuildApproval))
+	if err == nil {
+		t.Errorf("ApproveBuildApproval should have returned err")
+	}
+}
+
+// testBuildApproval is a test helper function to create a
+// model BuildApproval type with all fields set to a fake value.
+func testBuildApproval() *model.BuildApproval {
+	return &model.BuildApproval{
+		BuildID:    1,
+		Reason:     "pull request is from a fork",
+		Pipeline:   `{"version":"1"}`,
+		ApprovedBy: "octocat",
+		ApprovedAt: 1563474077,
+	}
+}
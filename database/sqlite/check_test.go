@@ -0,0 +1,195 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/model"
+)
+
+func TestSqlite_Client_GetBuildCheck(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	_check := testBuildCheck()
+	_check.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.BuildCheck
+	}{
+		{
+			failure: false,
+			want:    _check,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.want != nil {
+			// create the build check in the database
+			err := _database.CreateBuildCheck(test.want)
+			if err != nil {
+				t.Errorf("unable to create test build check: %v", err)
+			}
+		}
+
+		got, err := _database.GetBuildCheck(_build)
+
+		// cleanup the build_checks table
+		_ = _database.Sqlite.Exec("DELETE FROM build_checks;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildCheck should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildCheck returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildCheck is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreateBuildCheck(t *testing.T) {
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		check   *model.BuildCheck
+	}{
+		{
+			failure: false,
+			check:   testBuildCheck(),
+		},
+		{
+			failure: true,
+			check:   new(model.BuildCheck),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildCheck(test.check)
+
+		// cleanup the build_checks table
+		_ = _database.Sqlite.Exec("DELETE FROM build_checks;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildCheck should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildCheck returned err: %v", err)
+		}
+	}
+}
+
+func TestSqlite_Client_UpdateBuildCheck(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		check   *model.BuildCheck
+	}{
+		{
+			failure: false,
+			check:   testBuildCheck(),
+		},
+		{
+			failure: true,
+			check:   new(model.BuildCheck),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if !test.failure {
+			// create the build check in the database
+			c := &model.BuildCheck{BuildID: 1, CheckRunID: test.check.CheckRunID}
+
+			err := _database.CreateBuildCheck(c)
+			if err != nil {
+				t.Errorf("unable to create test build check: %v", err)
+			}
+
+			test.check.ID = c.ID
+		}
+
+		err := _database.UpdateBuildCheck(test.check)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdateBuildCheck should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdateBuildCheck returned err: %v", err)
+		}
+
+		got, _ := _database.GetBuildCheck(_build)
+
+		// cleanup the build_checks table
+		_ = _database.Sqlite.Exec("DELETE FROM build_checks;")
+
+		if !reflect.DeepEqual(got, test.check) {
+			t.Errorf("UpdateBuildCheck is %v, want %v", got, test.check)
+		}
+	}
+}
+
+// testBuildCheck is a test helper function to create a
+// model BuildCheck type with all fields set to a fake value.
+func testBuildCheck() *model.BuildCheck {
+	return &model.BuildCheck{
+		BuildID:    1,
+		CheckRunID: 4,
+		Annotated:  true,
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/go-vela/server/database/sqlite/dml"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+
+	"gorm.io/gorm"
+)
+
+// GetPullComment gets the comment for a pull request
+// of a repo by event and pipeline from the database.
+func (c *client) GetPullComment(r *library.Repo, number int, event, pipeline string) (*model.PullComment, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+	}).Tracef("getting comment for pull request %s#%d from the database", r.GetFullName(), number)
+
+	// variable to store query results
+	p := new(model.PullComment)
+
+	// send query to the database and store result in variable
+	result := c.Sqlite.
+		Table(model.TablePullComment).
+		Raw(dml.SelectPullComment, r.GetID(), number, event, pipeline).
+		Scan(p)
+
+	// check if the query returned a record not found error or no rows were returned
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return p, result.Error
+}
+
+// CreatePullComment creates a new pull request comment in the database.
+func (c *client) CreatePullComment(p *model.PullComment) error {
+	c.Logger.Tracef("creating comment for pull request %d in the database", p.Number)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TablePullComment).
+		Create(p).Error
+}
+
+// UpdatePullComment updates a pull request comment in the database.
+func (c *client) UpdatePullComment(p *model.PullComment) error {
+	c.Logger.Tracef("updating comment for pull request %d in the database", p.Number)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TablePullComment).
+		Save(p).Error
+}
+
+// ClaimPullComment records the build for a pull request comment in
+// the database. The build is only recorded when the comment wasn't
+// claimed by a newer build so an older build can't overwrite it.
+func (c *client) ClaimPullComment(p *model.PullComment) error {
+	c.Logger.Tracef("claiming comment for pull request %d in the database", p.Number)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	result := c.Sqlite.
+		Table(model.TablePullComment).
+		Exec(dml.ClaimPullComment, p.Build, p.ID, p.Build)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// check if the comment was claimed by a newer build
+	if result.RowsAffected == 0 {
+		return model.ErrPullCommentStale
+	}
+
+	return nil
+}
+
+// DeletePullComment deletes a pull request comment by unique ID from the database.
+func (c *client) DeletePullComment(id int64) error {
+	c.Logger.Tracef("deleting pull comment %d in the database", id)
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TablePullComment).
+		Exec(dml.DeletePullComment, id).Error
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/model"
+)
+
+func TestSqlite_Client_GetPullComment(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+
+	_comment := testPullComment()
+	_comment.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.PullComment
+	}{
+		{
+			failure: false,
+			want:    _comment,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.want != nil {
+			// create the pull comment in the database
+			err := _database.CreatePullComment(test.want)
+			if err != nil {
+				t.Errorf("unable to create test pull comment: %v", err)
+			}
+		}
+
+		got, err := _database.GetPullComment(_repo, 1, "pull_request", "api")
+
+		// cleanup the pull_comments table
+		_ = _database.Sqlite.Exec("DELETE FROM pull_comments;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetPullComment should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetPullComment returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetPullComment is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreatePullComment(t *testing.T) {
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		comment *model.PullComment
+	}{
+		{
+			failure: false,
+			comment: testPullComment(),
+		},
+		{
+			failure: true,
+			comment: new(model.PullComment),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreatePullComment(test.comment)
+
+		// cleanup the pull_comments table
+		_ = _database.Sqlite.Exec("DELETE FROM pull_comments;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreatePullComment should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreatePullComment returned err: %v", err)
+		}
+	}
+}
+
+func TestSqlite_Client_UpdatePullComment(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		comment *model.PullComment
+	}{
+		{
+			failure: false,
+			comment: testPullComment(),
+		},
+		{
+			failure: true,
+			comment: new(model.PullComment),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if !test.failure {
+			// create the pull comment in the database
+			p := &model.PullComment{RepoID: 1, Number: 1, Event: "pull_request", Pipeline: "api"}
+
+			err := _database.CreatePullComment(p)
+			if err != nil {
+				t.Errorf("unable to create test pull comment: %v", err)
+			}
+
+			test.comment.ID = p.ID
+		}
+
+		err := _database.UpdatePullComment(test.comment)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdatePullComment should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdatePullComment returned err: %v", err)
+		}
+
+		got, _ := _database.GetPullComment(_repo, 1, "pull_request", "api")
+
+		// cleanup the pull_comments table
+		_ = _database.Sqlite.Exec("DELETE FROM pull_comments;")
+
+		if !reflect.DeepEqual(got, test.comment) {
+			t.Errorf("UpdatePullComment is %v, want %v", got, test.comment)
+		}
+	}
+}
+
+func TestSqlite_Client_ClaimPullComment(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// create the pull comment in the database
+	p := testPullComment()
+
+	err = _database.CreatePullComment(p)
+	if err != nil {
+		t.Errorf("unable to create test pull comment: %v", err)
+	}
+
+	// run test with the same build
+	err = _database.ClaimPullComment(p)
+	if err != nil {
+		t.Errorf("ClaimPullComment returned err: %v", err)
+	}
+
+	// run test with a newer build
+	p.Build = 3
+
+	err = _database.ClaimPullComment(p)
+	if err != nil {
+		t.Errorf("ClaimPullComment returned err: %v", err)
+	}
+
+	got, _ := _database.GetPullComment(_repo, 1, "pull_request", "api")
+
+	if !reflect.DeepEqual(got, p) {
+		t.Errorf("ClaimPullComment is %v, want %v", got, p)
+	}
+
+	// run test with an older build
+	older := &model.PullComment{ID: p.ID, RepoID: 1, Number: 1, Event: "pull_request", Pipeline: "api", CommentID: 1, Build: 2}
+
+	err = _database.ClaimPullComment(older)
+	if !errors.Is(err, model.ErrPullCommentStale) {
+		t.Errorf("ClaimPullComment returned err %v, want %v", err, model.ErrPullCommentStale)
+	}
+
+	got, _ = _database.GetPullComment(_repo, 1, "pull_request", "api")
+
+	if !reflect.DeepEqual(got, p) {
+		t.Errorf("ClaimPullComment is %v, want %v", got, p)
+	}
+
+	// run test with the invalid pull comment
+	err = _database.ClaimPullComment(new(model.PullComment))
+	if err == nil {
+		t.Errorf("ClaimPullComment should have returned err")
+	}
+}
+
+func TestSqlite_Client_DeletePullComment(t *testing.T) {
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// defer cleanup of the pull_comments table
+		defer _database.Sqlite.Exec("DELETE FROM pull_comments;")
+
+		// create the pull comment in the database
+		err := _database.CreatePullComment(&model.PullComment{ID: 1, RepoID: 1, Number: 1})
+		if err != nil {
+			t.Errorf("unable to create test pull comment: %v", err)
+		}
+
+		err = _database.DeletePullComment(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeletePullComment should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeletePullComment returned err: %v", err)
+		}
+	}
+}
+
+// testPullComment is a test helper function to create a
+// model PullComment type with all fields set to a fake value.
+func testPullComment() *model.PullComment {
+	return &model.PullComment{
+		RepoID:    1,
+		Number:    1,
+		Event:     "pull_request",
+		Pipeline:  "api",
+		CommentID: 1,
+		Build:     2,
+	}
+}
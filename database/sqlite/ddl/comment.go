@@ -0,0 +1,22 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+// CreatePullCommentTable represents a query to
+// create the pull_comments table for Vela.
+const CreatePullCommentTable = `
+CREATE TABLE
+IF NOT EXISTS
+pull_comments (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	repo_id    INTEGER,
+	number     INTEGER,
+	event      VARCHAR(250),
+	pipeline   VARCHAR(250),
+	comment_id BIGINT,
+	build      INTEGER,
+	UNIQUE(repo_id, number, event, pipeline)
+);
+`
@@ -0,0 +1,35 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+const (
+	// CreateDefaultPipelineTable represents a query to
+	// create the default_pipelines table for Vela.
+	CreateDefaultPipelineTable = `
+CREATE TABLE
+IF NOT EXISTS
+default_pipelines (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	org       TEXT,
+	pipeline  TEXT,
+	template  TEXT,
+	type      TEXT,
+	active    BOOLEAN,
+	UNIQUE(org)
+);
+`
+
+	// CreateDefaultPipelineOptOutTable represents a query to
+	// create the default_pipeline_opt_outs table for Vela.
+	CreateDefaultPipelineOptOutTable = `
+CREATE TABLE
+IF NOT EXISTS
+default_pipeline_opt_outs (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	repo_id   INTEGER,
+	UNIQUE(repo_id)
+);
+`
+)
@@ -0,0 +1,59 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+const (
+	// CreatePolicyTable represents a query to
+	// create the policies table for Vela.
+	CreatePolicyTable = `
+CREATE TABLE
+IF NOT EXISTS
+policies (
+	id       INTEGER PRIMARY KEY AUTOINCREMENT,
+	org      TEXT,
+	repo     TEXT,
+	name     TEXT,
+	action   TEXT,
+	message  TEXT,
+	rule     TEXT,
+	active   BOOLEAN,
+	UNIQUE(org, repo, name)
+);
+`
+
+	// CreatePolicyOrgRepoIndex represents a query to create an
+	// index on the policies table for the org and repo columns.
+	CreatePolicyOrgRepoIndex = `
+CREATE INDEX
+IF NOT EXISTS
+policies_org_repo
+ON policies (org, repo);
+`
+
+	// CreateBuildPolicyTable represents a query to
+	// create the build_policies table for Vela.
+	CreateBuildPolicyTable = `
+CREATE TABLE
+IF NOT EXISTS
+build_policies (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	build_id   INTEGER,
+	policy_id  INTEGER,
+	policy     TEXT,
+	action     TEXT,
+	step       TEXT,
+	message    TEXT
+);
+`
+
+	// CreateBuildPolicyBuildIDIndex represents a query to create an
+	// index on the build_policies table for the build_id column.
+	CreateBuildPolicyBuildIDIndex = `
+CREATE INDEX
+IF NOT EXISTS
+build_policies_build_id
+ON build_policies (build_id);
+`
+)
@@ -0,0 +1,22 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+// CreateBuildPullRequestTable represents a query to
+// create the build_pull_requests table for Vela.
+const CreateBuildPullRequestTable = `
+CREATE TABLE
+IF NOT EXISTS
+build_pull_requests (
+	id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+	build_id           INTEGER,
+	labels             TEXT,
+	draft              BOOLEAN,
+	author_association TEXT,
+	fork               BOOLEAN,
+	action             TEXT,
+	UNIQUE(build_id)
+);
+`
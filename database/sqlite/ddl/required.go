@@ -0,0 +1,55 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+const (
+	// CreateRequiredPipelineTable represents a query to
+	// create the required_pipelines table for Vela.
+	CreateRequiredPipelineTable = `
+CREATE TABLE
+IF NOT EXISTS
+required_pipelines (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	org       TEXT,
+	name      TEXT,
+	position  TEXT,
+	pipeline  TEXT,
+	active    BOOLEAN,
+	UNIQUE(org, name)
+);
+`
+
+	// CreateRequiredPipelineOrgIndex represents a query to create an
+	// index on the required_pipelines table for the org column.
+	CreateRequiredPipelineOrgIndex = `
+CREATE INDEX
+IF NOT EXISTS
+required_pipelines_org
+ON required_pipelines (org);
+`
+
+	// CreateBuildInjectedStepTable represents a query to
+	// create the build_injected_steps table for Vela.
+	CreateBuildInjectedStepTable = `
+CREATE TABLE
+IF NOT EXISTS
+build_injected_steps (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	build_id  INTEGER,
+	pipeline  TEXT,
+	stage     TEXT,
+	step      TEXT
+);
+`
+
+	// CreateBuildInjectedStepBuildIDIndex represents a query to create an
+	// index on the build_injected_steps table for the build_id column.
+	CreateBuildInjectedStepBuildIDIndex = `
+CREATE INDEX
+IF NOT EXISTS
+build_injected_steps_build_id
+ON build_injected_steps (build_id);
+`
+)
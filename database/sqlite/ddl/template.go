@@ -0,0 +1,34 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package ddl
+
+const (
+	// CreateBuildTemplateTable represents a query to
+	// create the build_templates table for Vela.
+	CreateBuildTemplateTable = `
+CREATE TABLE
+IF NOT EXISTS
+build_templates (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	build_id  INTEGER,
+	name      TEXT,
+	source    TEXT,
+	type      TEXT,
+	ref       TEXT,
+	'commit'  TEXT,
+	digest    TEXT,
+	UNIQUE(build_id, name)
+);
+`
+
+	// CreateBuildTemplateBuildIDIndex represents a query to create an
+	// index on the build_templates table for the build_id column.
+	CreateBuildTemplateBuildIDIndex = `
+CREATE INDEX
+IF NOT EXISTS
+build_templates_build_id
+ON build_templates (build_id);
+`
+)
@@ -0,0 +1,135 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/go-vela/server/database/sqlite/dml"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+
+	"gorm.io/gorm"
+)
+
+// GetDefaultPipeline gets the default pipeline by org from the database.
+func (c *client) GetDefaultPipeline(org string) (*model.DefaultPipeline, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org": org,
+	}).Tracef("getting default pipeline for org %s from the database", org)
+
+	// variable to store query results
+	p := new(model.DefaultPipeline)
+
+	// send query to the database and store result in variable
+	result := c.Sqlite.
+		Table(model.TableDefaultPipeline).
+		Raw(dml.SelectDefaultPipeline, org).
+		Scan(p)
+
+	// check if the query returned a record not found error or no rows were returned
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return p, result.Error
+}
+
+// CreateDefaultPipeline creates a new default pipeline in the database.
+func (c *client) CreateDefaultPipeline(p *model.DefaultPipeline) error {
+	c.Logger.WithFields(logrus.Fields{
+		"org": p.Org,
+	}).Tracef("creating default pipeline for org %s in the database", p.Org)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableDefaultPipeline).
+		Create(p).Error
+}
+
+// UpdateDefaultPipeline updates a default pipeline in the database.
+func (c *client) UpdateDefaultPipeline(p *model.DefaultPipeline) error {
+	c.Logger.WithFields(logrus.Fields{
+		"org": p.Org,
+	}).Tracef("updating default pipeline for org %s in the database", p.Org)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableDefaultPipeline).
+		Save(p).Error
+}
+
+// DeleteDefaultPipeline deletes a default pipeline by unique ID from the database.
+func (c *client) DeleteDefaultPipeline(id int64) error {
+	c.Logger.Tracef("deleting default pipeline %d in the database", id)
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableDefaultPipeline).
+		Exec(dml.DeleteDefaultPipeline, id).Error
+}
+
+// GetDefaultPipelineOptOut gets the default pipeline opt out for a repo from the database.
+func (c *client) GetDefaultPipelineOptOut(r *library.Repo) (*model.DefaultPipelineOptOut, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+	}).Tracef("getting default pipeline opt out for repo %s from the database", r.GetFullName())
+
+	// variable to store query results
+	o := new(model.DefaultPipelineOptOut)
+
+	// send query to the database and store result in variable
+	result := c.Sqlite.
+		Table(model.TableDefaultPipelineOptOut).
+		Raw(dml.SelectDefaultPipelineOptOut, r.GetID()).
+		Scan(o)
+
+	// check if the query returned a record not found error or no rows were returned
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return o, result.Error
+}
+
+// CreateDefaultPipelineOptOut creates a new default pipeline opt out in the database.
+func (c *client) CreateDefaultPipelineOptOut(o *model.DefaultPipelineOptOut) error {
+	c.Logger.Tracef("creating default pipeline opt out for repo %d in the database", o.RepoID)
+
+	// validate the necessary fields are populated
+	err := o.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableDefaultPipelineOptOut).
+		Create(o).Error
+}
+
+// DeleteDefaultPipelineOptOut deletes a default pipeline opt out by unique ID from the database.
+func (c *client) DeleteDefaultPipelineOptOut(id int64) error {
+	c.Logger.Tracef("deleting default pipeline opt out %d in the database", id)
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableDefaultPipelineOptOut).
+		Exec(dml.DeleteDefaultPipelineOptOut, id).Error
+}
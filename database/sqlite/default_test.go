@@ -0,0 +1,390 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/model"
+)
+
+func TestSqlite_Client_GetDefaultPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testDefaultPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.DefaultPipeline
+	}{
+		{
+			failure: false,
+			want:    _pipeline,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.want != nil {
+			// create the default pipeline in the database
+			err := _database.CreateDefaultPipeline(test.want)
+			if err != nil {
+				t.Errorf("unable to create test default pipeline: %v", err)
+			}
+		}
+
+		got, err := _database.GetDefaultPipeline("github")
+
+		// cleanup the default_pipelines table
+		_ = _database.Sqlite.Exec("DELETE FROM default_pipelines;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetDefaultPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetDefaultPipeline returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetDefaultPipeline is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreateDefaultPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testDefaultPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *model.DefaultPipeline
+	}{
+		{
+			failure:  false,
+			pipeline: _pipeline,
+		},
+		{
+			failure:  true,
+			pipeline: new(model.DefaultPipeline),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateDefaultPipeline(test.pipeline)
+
+		// cleanup the default_pipelines table
+		_ = _database.Sqlite.Exec("DELETE FROM default_pipelines;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateDefaultPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateDefaultPipeline returned err: %v", err)
+		}
+	}
+}
+
+func TestSqlite_Client_UpdateDefaultPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testDefaultPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// defer cleanup of the default_pipelines table
+		defer _database.Sqlite.Exec("DELETE FROM default_pipelines;")
+
+		// create the default pipeline in the database
+		err := _database.CreateDefaultPipeline(_pipeline)
+		if err != nil {
+			t.Errorf("unable to create test default pipeline: %v", err)
+		}
+
+		_pipeline.Pipeline = ""
+		_pipeline.Template = "github.com/github/templates/go.yml"
+
+		err = _database.UpdateDefaultPipeline(_pipeline)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdateDefaultPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdateDefaultPipeline returned err: %v", err)
+		}
+
+		got, _ := _database.GetDefaultPipeline("github")
+
+		if !reflect.DeepEqual(got, _pipeline) {
+			t.Errorf("UpdateDefaultPipeline is %v, want %v", got, _pipeline)
+		}
+	}
+}
+
+func TestSqlite_Client_DeleteDefaultPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testDefaultPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// defer cleanup of the default_pipelines table
+		defer _database.Sqlite.Exec("DELETE FROM default_pipelines;")
+
+		// create the default pipeline in the database
+		err := _database.CreateDefaultPipeline(_pipeline)
+		if err != nil {
+			t.Errorf("unable to create test default pipeline: %v", err)
+		}
+
+		err = _database.DeleteDefaultPipeline(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeleteDefaultPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeleteDefaultPipeline returned err: %v", err)
+		}
+
+		_, err = _database.GetDefaultPipeline("github")
+		if err == nil {
+			t.Errorf("GetDefaultPipeline should have returned err")
+		}
+	}
+}
+
+func TestSqlite_Client_GetDefaultPipelineOptOut(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+
+	_optOut := &model.DefaultPipelineOptOut{ID: 1, RepoID: 1}
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.DefaultPipelineOptOut
+	}{
+		{
+			failure: false,
+			want:    _optOut,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.want != nil {
+			// create the default pipeline opt out in the database
+			err := _database.CreateDefaultPipelineOptOut(test.want)
+			if err != nil {
+				t.Errorf("unable to create test default pipeline opt out: %v", err)
+			}
+		}
+
+		got, err := _database.GetDefaultPipelineOptOut(_repo)
+
+		// cleanup the default_pipeline_opt_outs table
+		_ = _database.Sqlite.Exec("DELETE FROM default_pipeline_opt_outs;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetDefaultPipelineOptOut should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetDefaultPipelineOptOut returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetDefaultPipelineOptOut is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreateDefaultPipelineOptOut(t *testing.T) {
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		optOut  *model.DefaultPipelineOptOut
+	}{
+		{
+			failure: false,
+			optOut:  &model.DefaultPipelineOptOut{ID: 1, RepoID: 1},
+		},
+		{
+			failure: true,
+			optOut:  new(model.DefaultPipelineOptOut),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateDefaultPipelineOptOut(test.optOut)
+
+		// cleanup the default_pipeline_opt_outs table
+		_ = _database.Sqlite.Exec("DELETE FROM default_pipeline_opt_outs;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateDefaultPipelineOptOut should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateDefaultPipelineOptOut returned err: %v", err)
+		}
+	}
+}
+
+func TestSqlite_Client_DeleteDefaultPipelineOptOut(t *testing.T) {
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// defer cleanup of the default_pipeline_opt_outs table
+		defer _database.Sqlite.Exec("DELETE FROM default_pipeline_opt_outs;")
+
+		// create the default pipeline opt out in the database
+		err := _database.CreateDefaultPipelineOptOut(&model.DefaultPipelineOptOut{ID: 1, RepoID: 1})
+		if err != nil {
+			t.Errorf("unable to create test default pipeline opt out: %v", err)
+		}
+
+		err = _database.DeleteDefaultPipelineOptOut(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeleteDefaultPipelineOptOut should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeleteDefaultPipelineOptOut returned err: %v", err)
+		}
+	}
+}
+
+// testDefaultPipeline is a test helper function to create a
+// model DefaultPipeline type with all fields set to a fake value.
+func testDefaultPipeline() *model.DefaultPipeline {
+	return &model.DefaultPipeline{
+		Org:      "github",
+		Pipeline: "steps:\n  - name: test\n    image: alpine:latest\n",
+		Active:   true,
+	}
+}
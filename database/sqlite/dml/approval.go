@@ -0,0 +1,25 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package dml
+
+const (
+	// SelectBuildApproval represents a query to select
+	// the approval for a build_id in the database.
+	SelectBuildApproval = `
+SELECT *
+FROM build_approvals
+WHERE build_id = ?
+LIMIT 1;
+`
+
+	// ApproveBuildApproval represents a query to record the
+	// approval for a build approval that wasn't approved yet.
+	ApproveBuildApproval = `
+UPDATE build_approvals
+SET approved_by = ?, approved_at = ?
+WHERE id = ?
+AND (approved_by IS NULL OR approved_by = '');
+`
+)
@@ -0,0 +1,36 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package dml
+
+const (
+	// SelectPullComment represents a query to select the comment
+	// for a repo_id, number, event and pipeline in the database.
+	SelectPullComment = `
+SELECT *
+FROM pull_comments
+WHERE repo_id = ?
+AND number = ?
+AND event = ?
+AND pipeline = ?
+LIMIT 1;
+`
+
+	// ClaimPullComment represents a query to record the build
+	// for a pull comment that wasn't claimed by a newer build.
+	ClaimPullComment = `
+UPDATE pull_comments
+SET build = ?
+WHERE id = ?
+AND (build IS NULL OR build <= ?);
+`
+
+	// DeletePullComment represents a query to
+	// remove a pull comment from the database.
+	DeletePullComment = `
+DELETE
+FROM pull_comments
+WHERE id = ?;
+`
+)
@@ -0,0 +1,52 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package dml
+
+const (
+	// ListPolicies represents a query to
+	// list all policies in the database.
+	ListPolicies = `
+SELECT *
+FROM policies
+ORDER BY id;
+`
+
+	// ListRepoPolicies represents a query to list all active
+	// policies that apply to an org and repo in the database.
+	ListRepoPolicies = `
+SELECT *
+FROM policies
+WHERE active = ?
+AND (org = '' OR org = ?)
+AND (repo = '' OR repo = ?)
+ORDER BY id;
+`
+
+	// SelectPolicy represents a query to select
+	// a policy by id in the database.
+	SelectPolicy = `
+SELECT *
+FROM policies
+WHERE id = ?
+LIMIT 1;
+`
+
+	// DeletePolicy represents a query to
+	// remove a policy from the database.
+	DeletePolicy = `
+DELETE
+FROM policies
+WHERE id = ?;
+`
+
+	// ListBuildPolicies represents a query to list
+	// all policy results for a build_id in the database.
+	ListBuildPolicies = `
+SELECT *
+FROM build_policies
+WHERE build_id = ?
+ORDER BY id;
+`
+)
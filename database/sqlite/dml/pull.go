@@ -0,0 +1,14 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package dml
+
+// SelectBuildPullRequest represents a query to select
+// the pull request for a build_id in the database.
+const SelectBuildPullRequest = `
+SELECT *
+FROM build_pull_requests
+WHERE build_id = ?
+LIMIT 1;
+`
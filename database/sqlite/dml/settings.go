@@ -0,0 +1,33 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package dml
+
+const (
+	// SelectPipelineSettings represents a query to select
+	// the pipeline settings for a repo_id in the database.
+	SelectPipelineSettings = `
+SELECT *
+FROM pipeline_settings
+WHERE repo_id = ?
+LIMIT 1;
+`
+
+	// DeletePipelineSettings represents a query to
+	// remove pipeline settings from the database.
+	DeletePipelineSettings = `
+DELETE
+FROM pipeline_settings
+WHERE id = ?;
+`
+
+	// SelectBuildPipeline represents a query to select
+	// the pipeline for a build_id in the database.
+	SelectBuildPipeline = `
+SELECT *
+FROM build_pipelines
+WHERE build_id = ?
+LIMIT 1;
+`
+)
@@ -0,0 +1,523 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/model"
+)
+
+func TestSqlite_Client_GetPolicy(t *testing.T) {
+	// setup types
+	_policy := testPolicy()
+	_policy.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.Policy
+	}{
+		{
+			failure: false,
+			want:    _policy,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.want != nil {
+			// create the policy in the database
+			err := _database.CreatePolicy(test.want)
+			if err != nil {
+				t.Errorf("unable to create test policy: %v", err)
+			}
+		}
+
+		got, err := _database.GetPolicy(1)
+
+		// cleanup the policies table
+		_ = _database.Sqlite.Exec("DELETE FROM policies;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetPolicy should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetPolicy returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetPolicy is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_GetPolicyList(t *testing.T) {
+	// setup types
+	_policyOne := testPolicy()
+	_policyOne.ID = 1
+	_policyOne.Name = "foo"
+
+	_policyTwo := testPolicy()
+	_policyTwo.ID = 2
+	_policyTwo.Name = "bar"
+	_policyTwo.Active = false
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    []*model.Policy
+	}{
+		{
+			failure: false,
+			want:    []*model.Policy{_policyOne, _policyTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		for _, policy := range test.want {
+			// create the policy in the database
+			err := _database.CreatePolicy(policy)
+			if err != nil {
+				t.Errorf("unable to create test policy: %v", err)
+			}
+		}
+
+		got, err := _database.GetPolicyList()
+
+		// cleanup the policies table
+		_ = _database.Sqlite.Exec("DELETE FROM policies;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetPolicyList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetPolicyList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetPolicyList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_GetRepoPolicyList(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+	_repo.SetOrg("foo")
+	_repo.SetName("bar")
+	_repo.SetFullName("foo/bar")
+
+	_instance := testPolicy()
+	_instance.ID = 1
+	_instance.Name = "instance"
+	_instance.Org = ""
+	_instance.Repo = ""
+
+	_org := testPolicy()
+	_org.ID = 2
+	_org.Name = "org"
+	_org.Org = "foo"
+	_org.Repo = ""
+
+	_repoPolicy := testPolicy()
+	_repoPolicy.ID = 3
+	_repoPolicy.Name = "repo"
+	_repoPolicy.Org = "foo"
+	_repoPolicy.Repo = "bar"
+
+	_otherRepo := testPolicy()
+	_otherRepo.ID = 4
+	_otherRepo.Name = "other"
+	_otherRepo.Org = "foo"
+	_otherRepo.Repo = "baz"
+
+	_inactive := testPolicy()
+	_inactive.ID = 5
+	_inactive.Name = "inactive"
+	_inactive.Org = "foo"
+	_inactive.Repo = "bar"
+	_inactive.Active = false
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		policies []*model.Policy
+		want     []*model.Policy
+	}{
+		{
+			failure:  false,
+			policies: []*model.Policy{_instance, _org, _repoPolicy, _otherRepo, _inactive},
+			want:     []*model.Policy{_instance, _org, _repoPolicy},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		for _, policy := range test.policies {
+			// create the policy in the database
+			err := _database.CreatePolicy(policy)
+			if err != nil {
+				t.Errorf("unable to create test policy: %v", err)
+			}
+		}
+
+		got, err := _database.GetRepoPolicyList(_repo)
+
+		// cleanup the policies table
+		_ = _database.Sqlite.Exec("DELETE FROM policies;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetRepoPolicyList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetRepoPolicyList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetRepoPolicyList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreatePolicy(t *testing.T) {
+	// setup types
+	_policy := testPolicy()
+	_policy.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		policy  *model.Policy
+	}{
+		{
+			failure: false,
+			policy:  _policy,
+		},
+		{
+			failure: true,
+			policy:  new(model.Policy),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreatePolicy(test.policy)
+
+		// cleanup the policies table
+		_ = _database.Sqlite.Exec("DELETE FROM policies;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreatePolicy should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreatePolicy returned err: %v", err)
+		}
+	}
+}
+
+func TestSqlite_Client_UpdatePolicy(t *testing.T) {
+	// setup types
+	_policy := testPolicy()
+	_policy.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// defer cleanup of the policies table
+		defer _database.Sqlite.Exec("DELETE FROM policies;")
+
+		// create the policy in the database
+		err := _database.CreatePolicy(_policy)
+		if err != nil {
+			t.Errorf("unable to create test policy: %v", err)
+		}
+
+		_policy.Action = model.PolicyWarn
+
+		err = _database.UpdatePolicy(_policy)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdatePolicy should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdatePolicy returned err: %v", err)
+		}
+
+		got, _ := _database.GetPolicy(1)
+
+		if !reflect.DeepEqual(got, _policy) {
+			t.Errorf("UpdatePolicy is %v, want %v", got, _policy)
+		}
+	}
+}
+
+func TestSqlite_Client_DeletePolicy(t *testing.T) {
+	// setup types
+	_policy := testPolicy()
+	_policy.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// defer cleanup of the policies table
+		defer _database.Sqlite.Exec("DELETE FROM policies;")
+
+		// create the policy in the database
+		err := _database.CreatePolicy(_policy)
+		if err != nil {
+			t.Errorf("unable to create test policy: %v", err)
+		}
+
+		err = _database.DeletePolicy(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeletePolicy should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeletePolicy returned err: %v", err)
+		}
+	}
+}
+
+func TestSqlite_Client_GetBuildPolicyResultList(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+	_build.SetRepoID(1)
+	_build.SetNumber(1)
+
+	_resultOne := testPolicyResult()
+	_resultOne.ID = 1
+	_resultOne.Step = "foo"
+
+	_resultTwo := testPolicyResult()
+	_resultTwo.ID = 2
+	_resultTwo.Step = "bar"
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    []*model.PolicyResult
+	}{
+		{
+			failure: false,
+			want:    []*model.PolicyResult{_resultOne, _resultTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		for _, result := range test.want {
+			// create the policy result in the database
+			err := _database.CreateBuildPolicyResult(result)
+			if err != nil {
+				t.Errorf("unable to create test policy result: %v", err)
+			}
+		}
+
+		got, err := _database.GetBuildPolicyResultList(_build)
+
+		// cleanup the build_policies table
+		_ = _database.Sqlite.Exec("DELETE FROM build_policies;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildPolicyResultList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildPolicyResultList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildPolicyResultList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreateBuildPolicyResult(t *testing.T) {
+	// setup types
+	_result := testPolicyResult()
+	_result.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		result  *model.PolicyResult
+	}{
+		{
+			failure: false,
+			result:  _result,
+		},
+		{
+			failure: true,
+			result:  new(model.PolicyResult),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildPolicyResult(test.result)
+
+		// cleanup the build_policies table
+		_ = _database.Sqlite.Exec("DELETE FROM build_policies;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildPolicyResult should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildPolicyResult returned err: %v", err)
+		}
+	}
+}
+
+// testPolicy is a test helper function to create a
+// model Policy type with all fields set to a fake value.
+func testPolicy() *model.Policy {
+	b := true
+
+	return &model.Policy{
+		Org:     "github",
+		Repo:    "octocat",
+		Name:    "sample",
+		Action:  model.PolicyDeny,
+		Message: "privileged steps are not allowed",
+		Rule: model.PolicyRule{
+			Privileged: &b,
+		},
+		Active: true,
+	}
+}
+
+// testPolicyResult is a test helper function to create a
+// model PolicyResult type with all fields set to a fake value.
+func testPolicyResult() *model.PolicyResult {
+	return &model.PolicyResult{
+		BuildID:  1,
+		PolicyID: 1,
+		Policy:   "sample",
+		Action:   model.PolicyWarn,
+		Step:     "test",
+		Message:  "privileged steps are not allowed",
+	}
+}
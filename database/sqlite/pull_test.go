@@ -0,0 +1,126 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/model"
+)
+
+func TestSqlite_Client_GetBuildPullRequest(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	_pull := &model.BuildPullRequest{
+		ID:                1,
+		BuildID:           1,
+		Labels:            model.Labels{"e2e"},
+		Draft:             true,
+		AuthorAssociation: "MEMBER",
+		Fork:              true,
+		Action:            "labeled",
+	}
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.BuildPullRequest
+	}{
+		{
+			failure: false,
+			want:    _pull,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.want != nil {
+			// create the build pull request in the database
+			err := _database.CreateBuildPullRequest(test.want)
+			if err != nil {
+				t.Errorf("unable to create test build pull request: %v", err)
+			}
+		}
+
+		got, err := _database.GetBuildPullRequest(_build)
+
+		// cleanup the build_pull_requests table
+		_ = _database.Sqlite.Exec("DELETE FROM build_pull_requests;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildPullRequest should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildPullRequest returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildPullRequest is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreateBuildPullRequest(t *testing.T) {
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		pull    *model.BuildPullRequest
+	}{
+		{
+			failure: false,
+			pull:    &model.BuildPullRequest{ID: 1, BuildID: 1, Labels: model.Labels{"e2e"}},
+		},
+		{
+			failure: true,
+			pull:    new(model.BuildPullRequest),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildPullRequest(test.pull)
+
+		// cleanup the build_pull_requests table
+		_ = _database.Sqlite.Exec("DELETE FROM build_pull_requests;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildPullRequest should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildPullRequest returned err: %v", err)
+		}
+	}
+}
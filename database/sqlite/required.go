@@ -0,0 +1,158 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/go-vela/server/database/sqlite/dml"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+
+	"gorm.io/gorm"
+)
+
+// GetRequiredPipeline gets a required pipeline by org and name from the database.
+func (c *client) GetRequiredPipeline(org, name string) (*model.RequiredPipeline, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":      org,
+		"pipeline": name,
+	}).Tracef("getting required pipeline %s/%s from the database", org, name)
+
+	// variable to store query results
+	p := new(model.RequiredPipeline)
+
+	// send query to the database and store result in variable
+	result := c.Sqlite.
+		Table(model.TableRequiredPipeline).
+		Raw(dml.SelectRequiredPipeline, org, name).
+		Scan(p)
+
+	// check if the query returned a record not found error or no rows were returned
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return p, result.Error
+}
+
+// GetRequiredPipelineList gets a list of required pipelines by org from the database.
+func (c *client) GetRequiredPipelineList(org string) ([]*model.RequiredPipeline, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org": org,
+	}).Tracef("listing required pipelines for org %s from the database", org)
+
+	// variable to store query results
+	p := new([]*model.RequiredPipeline)
+
+	// send query to the database and store result in variable
+	err := c.Sqlite.
+		Table(model.TableRequiredPipeline).
+		Raw(dml.ListRequiredPipelines, org).
+		Scan(p).Error
+
+	// variable we want to return
+	pipelines := []*model.RequiredPipeline{}
+
+	// only return non-empty results
+	if len(*p) > 0 {
+		pipelines = *p
+	}
+
+	return pipelines, err
+}
+
+// CreateRequiredPipeline creates a new required pipeline in the database.
+func (c *client) CreateRequiredPipeline(p *model.RequiredPipeline) error {
+	c.Logger.WithFields(logrus.Fields{
+		"org":      p.Org,
+		"pipeline": p.Name,
+	}).Tracef("creating required pipeline %s/%s in the database", p.Org, p.Name)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableRequiredPipeline).
+		Create(p).Error
+}
+
+// UpdateRequiredPipeline updates a required pipeline in the database.
+func (c *client) UpdateRequiredPipeline(p *model.RequiredPipeline) error {
+	c.Logger.WithFields(logrus.Fields{
+		"org":      p.Org,
+		"pipeline": p.Name,
+	}).Tracef("updating required pipeline %s/%s in the database", p.Org, p.Name)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableRequiredPipeline).
+		Save(p).Error
+}
+
+// DeleteRequiredPipeline deletes a required pipeline by unique ID from the database.
+func (c *client) DeleteRequiredPipeline(id int64) error {
+	c.Logger.Tracef("deleting required pipeline %d in the database", id)
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableRequiredPipeline).
+		Exec(dml.DeleteRequiredPipeline, id).Error
+}
+
+// GetBuildInjectedStepList gets a list of injected steps by build ID from the database.
+func (c *client) GetBuildInjectedStepList(b *library.Build) ([]*model.InjectedStep, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+	}).Tracef("listing injected steps for build %d from the database", b.GetNumber())
+
+	// variable to store query results
+	s := new([]*model.InjectedStep)
+
+	// send query to the database and store result in variable
+	err := c.Sqlite.
+		Table(model.TableBuildInjectedStep).
+		Raw(dml.ListBuildInjectedSteps, b.GetID()).
+		Scan(s).Error
+
+	// variable we want to return
+	steps := []*model.InjectedStep{}
+
+	// only return non-empty results
+	if len(*s) > 0 {
+		steps = *s
+	}
+
+	return steps, err
+}
+
+// CreateBuildInjectedStep creates a new injected step for a build in the database.
+func (c *client) CreateBuildInjectedStep(s *model.InjectedStep) error {
+	c.Logger.WithFields(logrus.Fields{
+		"step": s.Step,
+	}).Tracef("creating injected step %s for build %d in the database", s.Step, s.BuildID)
+
+	// validate the necessary fields are populated
+	err := s.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableBuildInjectedStep).
+		Create(s).Error
+}
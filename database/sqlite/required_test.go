@@ -0,0 +1,428 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/model"
+)
+
+func TestSqlite_Client_GetRequiredPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testRequiredPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.RequiredPipeline
+	}{
+		{
+			failure: false,
+			want:    _pipeline,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.want != nil {
+			// create the required pipeline in the database
+			err := _database.CreateRequiredPipeline(test.want)
+			if err != nil {
+				t.Errorf("unable to create test required pipeline: %v", err)
+			}
+		}
+
+		got, err := _database.GetRequiredPipeline("github", "scan")
+
+		// cleanup the required_pipelines table
+		_ = _database.Sqlite.Exec("DELETE FROM required_pipelines;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetRequiredPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetRequiredPipeline returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetRequiredPipeline is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_GetRequiredPipelineList(t *testing.T) {
+	// setup types
+	_pipelineOne := testRequiredPipeline()
+	_pipelineOne.ID = 1
+	_pipelineOne.Name = "foo"
+
+	_pipelineTwo := testRequiredPipeline()
+	_pipelineTwo.ID = 2
+	_pipelineTwo.Name = "bar"
+
+	_otherOrg := testRequiredPipeline()
+	_otherOrg.ID = 3
+	_otherOrg.Org = "octocat"
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure   bool
+		pipelines []*model.RequiredPipeline
+		want      []*model.RequiredPipeline
+	}{
+		{
+			failure:   false,
+			pipelines: []*model.RequiredPipeline{_pipelineOne, _pipelineTwo, _otherOrg},
+			want:      []*model.RequiredPipeline{_pipelineOne, _pipelineTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		for _, pipeline := range test.pipelines {
+			// create the required pipeline in the database
+			err := _database.CreateRequiredPipeline(pipeline)
+			if err != nil {
+				t.Errorf("unable to create test required pipeline: %v", err)
+			}
+		}
+
+		got, err := _database.GetRequiredPipelineList("github")
+
+		// cleanup the required_pipelines table
+		_ = _database.Sqlite.Exec("DELETE FROM required_pipelines;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetRequiredPipelineList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetRequiredPipelineList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetRequiredPipelineList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreateRequiredPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testRequiredPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *model.RequiredPipeline
+	}{
+		{
+			failure:  false,
+			pipeline: _pipeline,
+		},
+		{
+			failure:  true,
+			pipeline: new(model.RequiredPipeline),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateRequiredPipeline(test.pipeline)
+
+		// cleanup the required_pipelines table
+		_ = _database.Sqlite.Exec("DELETE FROM required_pipelines;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateRequiredPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateRequiredPipeline returned err: %v", err)
+		}
+	}
+}
+
+func TestSqlite_Client_UpdateRequiredPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testRequiredPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// defer cleanup of the required_pipelines table
+		defer _database.Sqlite.Exec("DELETE FROM required_pipelines;")
+
+		// create the required pipeline in the database
+		err := _database.CreateRequiredPipeline(_pipeline)
+		if err != nil {
+			t.Errorf("unable to create test required pipeline: %v", err)
+		}
+
+		_pipeline.Position = model.RequiredPipelineAfter
+
+		err = _database.UpdateRequiredPipeline(_pipeline)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdateRequiredPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdateRequiredPipeline returned err: %v", err)
+		}
+
+		got, _ := _database.GetRequiredPipeline("github", "scan")
+
+		if !reflect.DeepEqual(got, _pipeline) {
+			t.Errorf("UpdateRequiredPipeline is %v, want %v", got, _pipeline)
+		}
+	}
+}
+
+func TestSqlite_Client_DeleteRequiredPipeline(t *testing.T) {
+	// setup types
+	_pipeline := testRequiredPipeline()
+	_pipeline.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// defer cleanup of the required_pipelines table
+		defer _database.Sqlite.Exec("DELETE FROM required_pipelines;")
+
+		// create the required pipeline in the database
+		err := _database.CreateRequiredPipeline(_pipeline)
+		if err != nil {
+			t.Errorf("unable to create test required pipeline: %v", err)
+		}
+
+		err = _database.DeleteRequiredPipeline(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeleteRequiredPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeleteRequiredPipeline returned err: %v", err)
+		}
+	}
+}
+
+func TestSqlite_Client_GetBuildInjectedStepList(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+	_build.SetRepoID(1)
+	_build.SetNumber(1)
+
+	_stepOne := testInjectedStep()
+	_stepOne.ID = 1
+	_stepOne.Step = "foo"
+
+	_stepTwo := testInjectedStep()
+	_stepTwo.ID = 2
+	_stepTwo.Step = "bar"
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    []*model.InjectedStep
+	}{
+		{
+			failure: false,
+			want:    []*model.InjectedStep{_stepOne, _stepTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		for _, step := range test.want {
+			// create the injected step in the database
+			err := _database.CreateBuildInjectedStep(step)
+			if err != nil {
+				t.Errorf("unable to create test injected step: %v", err)
+			}
+		}
+
+		got, err := _database.GetBuildInjectedStepList(_build)
+
+		// cleanup the build_injected_steps table
+		_ = _database.Sqlite.Exec("DELETE FROM build_injected_steps;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildInjectedStepList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildInjectedStepList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildInjectedStepList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreateBuildInjectedStep(t *testing.T) {
+	// setup types
+	_step := testInjectedStep()
+	_step.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		step    *model.InjectedStep
+	}{
+		{
+			failure: false,
+			step:    _step,
+		},
+		{
+			failure: true,
+			step:    new(model.InjectedStep),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildInjectedStep(test.step)
+
+		// cleanup the build_injected_steps table
+		_ = _database.Sqlite.Exec("DELETE FROM build_injected_steps;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildInjectedStep should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildInjectedStep returned err: %v", err)
+		}
+	}
+}
+
+// testRequiredPipeline is a test helper function to create a
+// model RequiredPipeline type with all fields set to a fake value.
+func testRequiredPipeline() *model.RequiredPipeline {
+	return &model.RequiredPipeline{
+		Org:      "github",
+		Name:     "scan",
+		Position: model.RequiredPipelineBefore,
+		Pipeline: "steps:\n  - name: secrets\n    image: target/vela-secret-scan:latest\n",
+		Active:   true,
+	}
+}
+
+// testInjectedStep is a test helper function to create a
+// model InjectedStep type with all fields set to a fake value.
+func testInjectedStep() *model.InjectedStep {
+	return &model.InjectedStep{
+		BuildID:  1,
+		Pipeline: "scan",
+		Stage:    "scan",
+		Step:     "secrets",
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/go-vela/server/database/sqlite/dml"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+
+	"gorm.io/gorm"
+)
+
+// GetPipelineSettings gets the pipeline settings for a repo from the database.
+func (c *client) GetPipelineSettings(r *library.Repo) (*model.PipelineSettings, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+	}).Tracef("getting pipeline settings for repo %s from the database", r.GetFullName())
+
+	// variable to store query results
+	s := new(model.PipelineSettings)
+
+	// send query to the database and store result in variable
+	result := c.Sqlite.
+		Table(model.TablePipelineSettings).
+		Raw(dml.SelectPipelineSettings, r.GetID()).
+		Scan(s)
+
+	// check if the query returned a record not found error or no rows were returned
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return s, result.Error
+}
+
+// CreatePipelineSettings creates new pipeline settings in the database.
+func (c *client) CreatePipelineSettings(s *model.PipelineSettings) error {
+	c.Logger.Tracef("creating pipeline settings for repo %d in the database", s.RepoID)
+
+	// validate the necessary fields are populated
+	err := s.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TablePipelineSettings).
+		Create(s).Error
+}
+
+// UpdatePipelineSettings updates pipeline settings in the database.
+func (c *client) UpdatePipelineSettings(s *model.PipelineSettings) error {
+	c.Logger.Tracef("updating pipeline settings for repo %d in the database", s.RepoID)
+
+	// validate the necessary fields are populated
+	err := s.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TablePipelineSettings).
+		Save(s).Error
+}
+
+// DeletePipelineSettings deletes pipeline settings by unique ID from the database.
+func (c *client) DeletePipelineSettings(id int64) error {
+	c.Logger.Tracef("deleting pipeline settings %d in the database", id)
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TablePipelineSettings).
+		Exec(dml.DeletePipelineSettings, id).Error
+}
+
+// GetBuildPipeline gets the pipeline compiled for a build from the database.
+func (c *client) GetBuildPipeline(b *library.Build) (*model.BuildPipeline, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+	}).Tracef("getting pipeline for build %d from the database", b.GetNumber())
+
+	// variable to store query results
+	p := new(model.BuildPipeline)
+
+	// send query to the database and store result in variable
+	result := c.Sqlite.
+		Table(model.TableBuildPipeline).
+		Raw(dml.SelectBuildPipeline, b.GetID()).
+		Scan(p)
+
+	// check if the query returned a record not found error or no rows were returned
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return p, result.Error
+}
+
+// CreateBuildPipeline creates a new build pipeline in the database.
+func (c *client) CreateBuildPipeline(p *model.BuildPipeline) error {
+	c.Logger.Tracef("creating pipeline %s for build %d in the database", p.Name, p.BuildID)
+
+	// validate the necessary fields are populated
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableBuildPipeline).
+		Create(p).Error
+}
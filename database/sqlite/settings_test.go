@@ -0,0 +1,373 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/model"
+)
+
+func TestSqlite_Client_GetPipelineSettings(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+
+	_settings := testPipelineSettings()
+	_settings.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.PipelineSettings
+	}{
+		{
+			failure: false,
+			want:    _settings,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.want != nil {
+			// create the pipeline settings in the database
+			err := _database.CreatePipelineSettings(test.want)
+			if err != nil {
+				t.Errorf("unable to create test pipeline settings: %v", err)
+			}
+		}
+
+		got, err := _database.GetPipelineSettings(_repo)
+
+		// cleanup the pipeline_settings table
+		_ = _database.Sqlite.Exec("DELETE FROM pipeline_settings;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetPipelineSettings should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetPipelineSettings returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetPipelineSettings is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreatePipelineSettings(t *testing.T) {
+	// setup types
+	_settings := testPipelineSettings()
+	_settings.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		settings *model.PipelineSettings
+	}{
+		{
+			failure:  false,
+			settings: _settings,
+		},
+		{
+			failure:  true,
+			settings: new(model.PipelineSettings),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreatePipelineSettings(test.settings)
+
+		// cleanup the pipeline_settings table
+		_ = _database.Sqlite.Exec("DELETE FROM pipeline_settings;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreatePipelineSettings should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreatePipelineSettings returned err: %v", err)
+		}
+	}
+}
+
+func TestSqlite_Client_UpdatePipelineSettings(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+
+	_settings := testPipelineSettings()
+	_settings.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// defer cleanup of the pipeline_settings table
+		defer _database.Sqlite.Exec("DELETE FROM pipeline_settings;")
+
+		// create the pipeline settings in the database
+		err := _database.CreatePipelineSettings(_settings)
+		if err != nil {
+			t.Errorf("unable to create test pipeline settings: %v", err)
+		}
+
+		_settings.Path = ".vela/pipeline.yml"
+		_settings.Pipelines = append(_settings.Pipelines, &model.RepoPipeline{
+			Name: "web",
+			Path: "web/.vela.yml",
+		})
+
+		err = _database.UpdatePipelineSettings(_settings)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("UpdatePipelineSettings should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UpdatePipelineSettings returned err: %v", err)
+		}
+
+		got, _ := _database.GetPipelineSettings(_repo)
+
+		if !reflect.DeepEqual(got, _settings) {
+			t.Errorf("UpdatePipelineSettings is %v, want %v", got, _settings)
+		}
+	}
+}
+
+func TestSqlite_Client_DeletePipelineSettings(t *testing.T) {
+	// setup types
+	_repo := testRepo()
+	_repo.SetID(1)
+
+	_settings := testPipelineSettings()
+	_settings.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+	}{
+		{
+			failure: false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// defer cleanup of the pipeline_settings table
+		defer _database.Sqlite.Exec("DELETE FROM pipeline_settings;")
+
+		// create the pipeline settings in the database
+		err := _database.CreatePipelineSettings(_settings)
+		if err != nil {
+			t.Errorf("unable to create test pipeline settings: %v", err)
+		}
+
+		err = _database.DeletePipelineSettings(1)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("DeletePipelineSettings should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("DeletePipelineSettings returned err: %v", err)
+		}
+
+		_, err = _database.GetPipelineSettings(_repo)
+		if err == nil {
+			t.Errorf("GetPipelineSettings should have returned err")
+		}
+	}
+}
+
+func TestSqlite_Client_GetBuildPipeline(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+
+	_pipeline := &model.BuildPipeline{ID: 1, BuildID: 1, Name: "api", Path: "api/.vela.yml"}
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    *model.BuildPipeline
+	}{
+		{
+			failure: false,
+			want:    _pipeline,
+		},
+		{
+			failure: true,
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if test.want != nil {
+			// create the build pipeline in the database
+			err := _database.CreateBuildPipeline(test.want)
+			if err != nil {
+				t.Errorf("unable to create test build pipeline: %v", err)
+			}
+		}
+
+		got, err := _database.GetBuildPipeline(_build)
+
+		// cleanup the build_pipelines table
+		_ = _database.Sqlite.Exec("DELETE FROM build_pipelines;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildPipeline returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildPipeline is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreateBuildPipeline(t *testing.T) {
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *model.BuildPipeline
+	}{
+		{
+			failure:  false,
+			pipeline: &model.BuildPipeline{ID: 1, BuildID: 1, Name: "api", Path: "api/.vela.yml"},
+		},
+		{
+			failure:  true,
+			pipeline: new(model.BuildPipeline),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildPipeline(test.pipeline)
+
+		// cleanup the build_pipelines table
+		_ = _database.Sqlite.Exec("DELETE FROM build_pipelines;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildPipeline should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildPipeline returned err: %v", err)
+		}
+	}
+}
+
+// testPipelineSettings is a test helper function to create a
+// model PipelineSettings type with all fields set to a fake value.
+func testPipelineSettings() *model.PipelineSettings {
+	return &model.PipelineSettings{
+		RepoID: 1,
+		Path:   "ci/vela.yml",
+		Pipelines: model.RepoPipelines{
+			{
+				Name:  "api",
+				Path:  "api/.vela.yml",
+				Rules: []string{"api/*"},
+			},
+		},
+		StageStatus:       true,
+		AllowRelease:      true,
+		AllowDelete:       true,
+		AllowPullClosed:   true,
+		AllowPullLabeled:  true,
+		AllowPullReopened: true,
+		ApproveFork:       true,
+		ApproveFirstTime:  true,
+		PullComment:       true,
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"github.com/go-vela/server/database/sqlite/dml"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+)
+
+// GetBuildTemplateList gets a list of templates by build ID from the database.
+func (c *client) GetBuildTemplateList(b *library.Build) ([]*model.BuildTemplate, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+	}).Tracef("listing templates for build %d from the database", b.GetNumber())
+
+	// variable to store query results
+	t := new([]*model.BuildTemplate)
+
+	// send query to the database and store result in variable
+	err := c.Sqlite.
+		Table(model.TableBuildTemplate).
+		Raw(dml.ListBuildTemplates, b.GetID()).
+		Scan(t).Error
+
+	// variable we want to return
+	templates := []*model.BuildTemplate{}
+
+	// only return non-empty results
+	if len(*t) > 0 {
+		templates = *t
+	}
+
+	return templates, err
+}
+
+// CreateBuildTemplate creates a new template for a build in the database.
+func (c *client) CreateBuildTemplate(t *model.BuildTemplate) error {
+	c.Logger.WithFields(logrus.Fields{
+		"template": t.Name,
+	}).Tracef("creating template %s for build %d in the database", t.Name, t.BuildID)
+
+	// validate the necessary fields are populated
+	err := t.Validate()
+	if err != nil {
+		return err
+	}
+
+	// send query to the database
+	return c.Sqlite.
+		Table(model.TableBuildTemplate).
+		Create(t).Error
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/model"
+)
+
+func TestSqlite_Client_GetBuildTemplateList(t *testing.T) {
+	// setup types
+	_build := testBuild()
+	_build.SetID(1)
+	_build.SetRepoID(1)
+	_build.SetNumber(1)
+
+	_templateOne := testBuildTemplate()
+	_templateOne.ID = 1
+	_templateOne.Name = "foo"
+
+	_templateTwo := testBuildTemplate()
+	_templateTwo.ID = 2
+	_templateTwo.Name = "bar"
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		want    []*model.BuildTemplate
+	}{
+		{
+			failure: false,
+			want:    []*model.BuildTemplate{_templateOne, _templateTwo},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		for _, template := range test.want {
+			// create the template in the database
+			err := _database.CreateBuildTemplate(template)
+			if err != nil {
+				t.Errorf("unable to create test template: %v", err)
+			}
+		}
+
+		got, err := _database.GetBuildTemplateList(_build)
+
+		// cleanup the build_templates table
+		_ = _database.Sqlite.Exec("DELETE FROM build_templates;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("GetBuildTemplateList should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("GetBuildTemplateList returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetBuildTemplateList is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestSqlite_Client_CreateBuildTemplate(t *testing.T) {
+	// setup types
+	_template := testBuildTemplate()
+	_template.ID = 1
+
+	// setup the test database client
+	_database, err := NewTest()
+	if err != nil {
+		t.Errorf("unable to create new sqlite test database: %v", err)
+	}
+	defer func() { _sql, _ := _database.Sqlite.DB(); _sql.Close() }()
+
+	// setup tests
+	tests := []struct {
+		failure  bool
+		template *model.BuildTemplate
+	}{
+		{
+			failure:  false,
+			template: _template,
+		},
+		{
+			failure:  true,
+			template: new(model.BuildTemplate),
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := _database.CreateBuildTemplate(test.template)
+
+		// cleanup the build_templates table
+		_ = _database.Sqlite.Exec("DELETE FROM build_templates;")
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("CreateBuildTemplate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CreateBuildTemplate returned err: %v", err)
+		}
+	}
+}
+
+// testBuildTemplate is a test helper function to create a
+// model BuildTemplate type with all fields set to a fake value.
+func testBuildTemplate() *model.BuildTemplate {
+	return &model.BuildTemplate{
+		BuildID: 1,
+		Name:    "sample",
+		Source:  "github.com/github/octocat/template.yml@main",
+		Type:    "github",
+		Ref:     "main",
+		Commit:  "48afb5bdc41ad69bf22588491333f7cf71135163",
+		Digest:  "sha256:abc",
+	}
+}
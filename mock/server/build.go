@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/model"
 	"github.com/go-vela/types"
 	"github.com/go-vela/types/library"
 )
@@ -127,6 +128,20 @@ const (
   }
 ]`
 
+	// BuildTemplatesResp represents a JSON return for a list of build templates.
+	BuildTemplatesResp = `[
+  {
+    "id": 1,
+    "build_id": 1,
+    "name": "sample",
+    "source": "github.com/github/octocat/template.yml",
+    "type": "github",
+    "ref": "",
+    "commit": "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+    "digest": "sha256:6e3f2c6e4b1f0c7ed0b0b26d5a0f1f2dfb3cbd2a0ef4b6d33c4d62ba1c1e0a59"
+  }
+]`
+
 	// BuildQueueResp represents a JSON return for build queue.
 	BuildQueueResp = `[
   {
@@ -196,6 +211,28 @@ func getLogs(c *gin.Context) {
 	c.JSON(http.StatusOK, body)
 }
 
+// getBuildTemplates has a param :build returns mock JSON for a http GET.
+//
+// Pass "0" to :build to test receiving a http 404 response.
+func getBuildTemplates(c *gin.Context) {
+	b := c.Param("build")
+
+	if strings.EqualFold(b, "0") {
+		msg := fmt.Sprintf("Build %s does not exist", b)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(BuildTemplatesResp)
+
+	var body []model.BuildTemplate
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
 // addBuild returns mock JSON for a http POST.
 func addBuild(c *gin.Context) {
 	data := []byte(BuildResp)
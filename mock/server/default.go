@@ -0,0 +1,155 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types"
+)
+
+const (
+	// DefaultPipelineResp represents a JSON return for a default pipeline.
+	DefaultPipelineResp = `{
+  "id": 1,
+  "org": "github",
+  "pipeline": "",
+  "template": "github.com/github/templates/go.yml",
+  "type": "github",
+  "active": true
+}`
+
+	// DefaultPipelineOptOutResp represents a JSON return for a default pipeline opt out.
+	DefaultPipelineOptOutResp = `{
+  "id": 1,
+  "repo_id": 1
+}`
+)
+
+// getDefaultPipeline has a param :org returns mock JSON for a http GET.
+//
+// Pass "not-found" to :org to test receiving a http 404 response.
+func getDefaultPipeline(c *gin.Context) {
+	o := c.Param("org")
+
+	if strings.EqualFold(o, "not-found") {
+		msg := fmt.Sprintf("Default pipeline for org %s does not exist", o)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(DefaultPipelineResp)
+
+	var body model.DefaultPipeline
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// addDefaultPipeline returns mock JSON for a http POST.
+func addDefaultPipeline(c *gin.Context) {
+	data := []byte(DefaultPipelineResp)
+
+	var body model.DefaultPipeline
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusCreated, body)
+}
+
+// updateDefaultPipeline has a param :org returns mock JSON for a http PUT.
+//
+// Pass "not-found" to :org to test receiving a http 404 response.
+func updateDefaultPipeline(c *gin.Context) {
+	o := c.Param("org")
+
+	if strings.EqualFold(o, "not-found") {
+		msg := fmt.Sprintf("Default pipeline for org %s does not exist", o)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(DefaultPipelineResp)
+
+	var body model.DefaultPipeline
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// removeDefaultPipeline has a param :org returns mock JSON for a http DELETE.
+//
+// Pass "not-found" to :org to test receiving a http 404 response.
+func removeDefaultPipeline(c *gin.Context) {
+	o := c.Param("org")
+
+	if strings.EqualFold(o, "not-found") {
+		msg := fmt.Sprintf("Default pipeline for org %s does not exist", o)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fmt.Sprintf("default pipeline for org %s deleted", o))
+}
+
+// getDefaultPipelineOptOut has a param :repo returns mock JSON for a http GET.
+//
+// Pass "not-found" to :repo to test receiving a http 404 response.
+func getDefaultPipelineOptOut(c *gin.Context) {
+	r := c.Param("repo")
+
+	if strings.EqualFold(r, "not-found") {
+		msg := fmt.Sprintf("Default pipeline opt out for repo %s does not exist", r)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(DefaultPipelineOptOutResp)
+
+	var body model.DefaultPipelineOptOut
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// addDefaultPipelineOptOut returns mock JSON for a http POST.
+func addDefaultPipelineOptOut(c *gin.Context) {
+	data := []byte(DefaultPipelineOptOutResp)
+
+	var body model.DefaultPipelineOptOut
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusCreated, body)
+}
+
+// removeDefaultPipelineOptOut has a param :repo returns mock JSON for a http DELETE.
+//
+// Pass "not-found" to :repo to test receiving a http 404 response.
+func removeDefaultPipelineOptOut(c *gin.Context) {
+	o := c.Param("org")
+	r := c.Param("repo")
+
+	if strings.EqualFold(r, "not-found") {
+		msg := fmt.Sprintf("Default pipeline opt out for repo %s does not exist", r)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fmt.Sprintf("default pipeline opt out for repo %s/%s deleted", o, r))
+}
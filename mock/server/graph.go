@@ -0,0 +1,138 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types"
+)
+
+const (
+	// GraphResp represents a JSON return for the graph of a pipeline.
+	GraphResp = `{
+  "nodes": [
+    {
+      "id": "test",
+      "name": "test",
+      "type": "stage"
+    },
+    {
+      "id": "test/go_test",
+      "name": "go_test",
+      "type": "step",
+      "stage": "test"
+    },
+    {
+      "id": "build",
+      "name": "build",
+      "type": "stage"
+    },
+    {
+      "id": "build/go_build",
+      "name": "go_build",
+      "type": "step",
+      "stage": "build"
+    }
+  ],
+  "edges": [
+    {
+      "source": "test",
+      "target": "build",
+      "type": "needs"
+    }
+  ]
+}`
+
+	// BuildGraphResp represents a JSON return for the graph of a build.
+	BuildGraphResp = `{
+  "nodes": [
+    {
+      "id": "test",
+      "name": "test",
+      "type": "stage",
+      "status": "success",
+      "duration": 42
+    },
+    {
+      "id": "test/go_test",
+      "name": "go_test",
+      "type": "step",
+      "stage": "test",
+      "status": "success",
+      "duration": 42
+    },
+    {
+      "id": "build",
+      "name": "build",
+      "type": "stage",
+      "status": "running"
+    },
+    {
+      "id": "build/go_build",
+      "name": "go_build",
+      "type": "step",
+      "stage": "build",
+      "status": "running"
+    }
+  ],
+  "edges": [
+    {
+      "source": "test",
+      "target": "build",
+      "type": "needs"
+    }
+  ]
+}`
+)
+
+// getPipelineGraph has a param :repo returns mock JSON for a http GET.
+//
+// Pass "not-found" to :repo to test receiving a http 404 response.
+func getPipelineGraph(c *gin.Context) {
+	r := c.Param("repo")
+
+	if strings.Contains(r, "not-found") {
+		msg := fmt.Sprintf("Repo %s does not exist", r)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(GraphResp)
+
+	var body model.Graph
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// getBuildGraph has a param :build returns mock JSON for a http GET.
+//
+// Pass "0" to :build to test receiving a http 404 response.
+func getBuildGraph(c *gin.Context) {
+	b := c.Param("build")
+
+	if strings.EqualFold(b, "0") {
+		msg := fmt.Sprintf("Build %s does not exist", b)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(BuildGraphResp)
+
+	var body model.Graph
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
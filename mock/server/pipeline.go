@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/model"
 	"github.com/go-vela/types"
 	"github.com/go-vela/types/yaml"
 
@@ -60,6 +61,25 @@ templates:
     type: github
 `
 
+	// DiffResp represents a YAML return for a diff between pipelines.
+	DiffResp = `---
+from: main
+to: feature
+steps:
+  - name: go_test
+    change: changed
+    image:
+      change: changed
+      from: golang:1.17
+      to: golang:1.18
+  - name: non-template-echo
+    change: removed
+environment:
+  - name: GOARCH
+    change: added
+    to: amd64
+`
+
 	// ExpandResp represents a YAML return for an expanded pipeline.
 	ExpandResp = `---
 version: "1"
@@ -134,6 +154,27 @@ templates:
     type: github
 `
 
+	// PreviewResp represents a YAML return for a ruleset preview of a pipeline.
+	PreviewResp = `---
+data:
+  branch: main
+  event: push
+  repo: github/octocat
+steps:
+  - name: go_test
+    included: true
+    reason: no ruleset provided
+  - name: go_build
+    included: false
+    reason: if rules did not match
+  - name: publish
+    included: true
+    reason: if rules matched
+    if:
+      - branch
+      - event
+`
+
 	// TemplateResp represents a YAML return for templates in a pipeline.
 	TemplateResp = `---
 sample:
@@ -187,6 +228,50 @@ func compilePipeline(c *gin.Context) {
 	c.YAML(http.StatusOK, body)
 }
 
+// previewPipeline has a param :repo returns mock YAML for a http POST.
+//
+// Pass "not-found" to :repo to test receiving a http 404 response.
+func previewPipeline(c *gin.Context) {
+	r := c.Param("repo")
+
+	if strings.Contains(r, "not-found") {
+		msg := fmt.Sprintf("Repo %s does not exist", r)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(PreviewResp)
+
+	var body model.RulesetPreview
+	_ = yml.Unmarshal(data, &body)
+
+	c.YAML(http.StatusOK, body)
+}
+
+// diffPipeline has a param :repo returns mock YAML for a http GET.
+//
+// Pass "not-found" to :repo to test receiving a http 404 response.
+func diffPipeline(c *gin.Context) {
+	r := c.Param("repo")
+
+	if strings.Contains(r, "not-found") {
+		msg := fmt.Sprintf("Repo %s does not exist", r)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(DiffResp)
+
+	var body model.PipelineDiff
+	_ = yml.Unmarshal(data, &body)
+
+	c.YAML(http.StatusOK, body)
+}
+
 // expandPipeline has a param :repo returns mock YAML for a http GET.
 //
 // Pass "not-found" to :repo to test receiving a http 404 response.
@@ -0,0 +1,178 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types"
+)
+
+const (
+	// PolicyResp represents a JSON return for a single policy.
+	PolicyResp = `{
+  "id": 1,
+  "org": "github",
+  "repo": "",
+  "name": "no-privileged",
+  "action": "deny",
+  "message": "privileged steps are not allowed",
+  "rule": {
+    "privileged": true
+  },
+  "active": true
+}`
+
+	// PoliciesResp represents a JSON return for one to many policies.
+	PoliciesResp = `[
+  {
+    "id": 1,
+    "org": "github",
+    "repo": "",
+    "name": "no-privileged",
+    "action": "deny",
+    "message": "privileged steps are not allowed",
+    "rule": {
+      "privileged": true
+    },
+    "active": true
+  },
+  {
+    "id": 2,
+    "org": "",
+    "repo": "",
+    "name": "always-pull",
+    "action": "mutate",
+    "message": "images are always pulled",
+    "rule": {
+      "mutation": {
+        "pull": "always"
+      }
+    },
+    "active": true
+  }
+]`
+
+	// BuildPolicyResultsResp represents a JSON return for a list of build policy results.
+	BuildPolicyResultsResp = `[
+  {
+    "id": 1,
+    "build_id": 1,
+    "policy_id": 2,
+    "policy": "always-pull",
+    "action": "mutate",
+    "step": "test",
+    "message": "images are always pulled"
+  }
+]`
+)
+
+// getPolicies returns mock JSON for a http GET.
+func getPolicies(c *gin.Context) {
+	data := []byte(PoliciesResp)
+
+	var body []model.Policy
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// getPolicy has a param :policy returns mock JSON for a http GET.
+//
+// Pass "0" to :policy to test receiving a http 404 response.
+func getPolicy(c *gin.Context) {
+	p := c.Param("policy")
+
+	if strings.EqualFold(p, "0") {
+		msg := fmt.Sprintf("Policy %s does not exist", p)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(PolicyResp)
+
+	var body model.Policy
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// addPolicy returns mock JSON for a http POST.
+func addPolicy(c *gin.Context) {
+	data := []byte(PolicyResp)
+
+	var body model.Policy
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusCreated, body)
+}
+
+// updatePolicy has a param :policy returns mock JSON for a http PUT.
+//
+// Pass "0" to :policy to test receiving a http 404 response.
+func updatePolicy(c *gin.Context) {
+	p := c.Param("policy")
+
+	if strings.EqualFold(p, "0") {
+		msg := fmt.Sprintf("Policy %s does not exist", p)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(PolicyResp)
+
+	var body model.Policy
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// removePolicy has a param :policy returns mock JSON for a http DELETE.
+//
+// Pass "0" to :policy to test receiving a http 404 response.
+func removePolicy(c *gin.Context) {
+	p := c.Param("policy")
+
+	if strings.EqualFold(p, "0") {
+		msg := fmt.Sprintf("Policy %s does not exist", p)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fmt.Sprintf("policy %s deleted", p))
+}
+
+// getBuildPolicyResults has a param :build returns mock JSON for a http GET.
+//
+// Pass "0" to :build to test receiving a http 404 response.
+func getBuildPolicyResults(c *gin.Context) {
+	b := c.Param("build")
+
+	if strings.EqualFold(b, "0") {
+		msg := fmt.Sprintf("Build %s does not exist", b)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(BuildPolicyResultsResp)
+
+	var body []model.PolicyResult
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
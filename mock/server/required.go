@@ -0,0 +1,163 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types"
+)
+
+const (
+	// RequiredPipelineResp represents a JSON return for a single required pipeline.
+	RequiredPipelineResp = `{
+  "id": 1,
+  "org": "github",
+  "name": "security",
+  "position": "after",
+  "pipeline": "version: \"1\"\nsteps:\n  - name: scan\n    image: alpine:latest\n    commands:\n      - echo scan\n",
+  "active": true
+}`
+
+	// RequiredPipelinesResp represents a JSON return for one to many required pipelines.
+	RequiredPipelinesResp = `[
+  {
+    "id": 1,
+    "org": "github",
+    "name": "security",
+    "position": "after",
+    "pipeline": "version: \"1\"\nsteps:\n  - name: scan\n    image: alpine:latest\n    commands:\n      - echo scan\n",
+    "active": true
+  },
+  {
+    "id": 2,
+    "org": "github",
+    "name": "setup",
+    "position": "before",
+    "pipeline": "version: \"1\"\nsteps:\n  - name: setup\n    image: alpine:latest\n    commands:\n      - echo setup\n",
+    "active": true
+  }
+]`
+
+	// BuildInjectedStepsResp represents a JSON return for a list of build injected steps.
+	BuildInjectedStepsResp = `[
+  {
+    "id": 1,
+    "build_id": 1,
+    "pipeline": "security",
+    "stage": "",
+    "step": "scan"
+  }
+]`
+)
+
+// getRequiredPipelines returns mock JSON for a http GET.
+func getRequiredPipelines(c *gin.Context) {
+	data := []byte(RequiredPipelinesResp)
+
+	var body []model.RequiredPipeline
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// getRequiredPipeline has a param :pipeline returns mock JSON for a http GET.
+//
+// Pass "not-found" to :pipeline to test receiving a http 404 response.
+func getRequiredPipeline(c *gin.Context) {
+	p := c.Param("pipeline")
+
+	if strings.EqualFold(p, "not-found") {
+		msg := fmt.Sprintf("Required pipeline %s does not exist", p)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(RequiredPipelineResp)
+
+	var body model.RequiredPipeline
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// addRequiredPipeline returns mock JSON for a http POST.
+func addRequiredPipeline(c *gin.Context) {
+	data := []byte(RequiredPipelineResp)
+
+	var body model.RequiredPipeline
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusCreated, body)
+}
+
+// updateRequiredPipeline has a param :pipeline returns mock JSON for a http PUT.
+//
+// Pass "not-found" to :pipeline to test receiving a http 404 response.
+func updateRequiredPipeline(c *gin.Context) {
+	p := c.Param("pipeline")
+
+	if strings.EqualFold(p, "not-found") {
+		msg := fmt.Sprintf("Required pipeline %s does not exist", p)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(RequiredPipelineResp)
+
+	var body model.RequiredPipeline
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// removeRequiredPipeline has a param :pipeline returns mock JSON for a http DELETE.
+//
+// Pass "not-found" to :pipeline to test receiving a http 404 response.
+func removeRequiredPipeline(c *gin.Context) {
+	o := c.Param("org")
+	p := c.Param("pipeline")
+
+	if strings.EqualFold(p, "not-found") {
+		msg := fmt.Sprintf("Required pipeline %s does not exist", p)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fmt.Sprintf("required pipeline %s for org %s deleted", p, o))
+}
+
+// getBuildInjectedSteps has a param :build returns mock JSON for a http GET.
+//
+// Pass "0" to :build to test receiving a http 404 response.
+func getBuildInjectedSteps(c *gin.Context) {
+	b := c.Param("build")
+
+	if strings.EqualFold(b, "0") {
+		msg := fmt.Sprintf("Build %s does not exist", b)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(BuildInjectedStepsResp)
+
+	var body []model.InjectedStep
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
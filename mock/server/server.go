@@ -26,6 +26,11 @@ func FakeHandler() http.Handler {
 	e.PUT("/api/v1/admin/deployment", updateDeployment)
 	e.GET("/api/v1/admin/hooks", getHooks)
 	e.PUT("/api/v1/admin/hook", updateHook)
+	e.GET("/api/v1/admin/policies", getPolicies)
+	e.POST("/api/v1/admin/policies", addPolicy)
+	e.GET("/api/v1/admin/policies/:policy", getPolicy)
+	e.PUT("/api/v1/admin/policies/:policy", updatePolicy)
+	e.DELETE("/api/v1/admin/policies/:policy", removePolicy)
 	e.GET("/api/v1/admin/repos", getRepos)
 	e.PUT("/api/v1/admin/repo", updateRepo)
 	e.GET("/api/v1/admin/secrets", getSecrets)
@@ -41,12 +46,22 @@ func FakeHandler() http.Handler {
 	e.GET("/api/v1/repos/:org/:repo/builds/:build", getBuild)
 	e.POST("/api/v1/repos/:org/:repo/builds/:build", restartBuild)
 	e.DELETE("/api/v1/repos/:org/:repo/builds/:build/cancel", cancelBuild)
+	e.GET("/api/v1/repos/:org/:repo/builds/:build/graph", getBuildGraph)
+	e.GET("/api/v1/repos/:org/:repo/builds/:build/injected", getBuildInjectedSteps)
 	e.GET("/api/v1/repos/:org/:repo/builds/:build/logs", getLogs)
+	e.GET("/api/v1/repos/:org/:repo/builds/:build/policies", getBuildPolicyResults)
+	e.GET("/api/v1/repos/:org/:repo/builds/:build/templates", getBuildTemplates)
 	e.GET("/api/v1/repos/:org/:repo/builds", getBuilds)
 	e.POST("/api/v1/repos/:org/:repo/builds", addBuild)
 	e.PUT("/api/v1/repos/:org/:repo/builds/:build", updateBuild)
 	e.DELETE("/api/v1/repos/:org/:repo/builds/:build", removeBuild)
 
+	// mock endpoints for default pipeline calls
+	e.GET("/api/v1/default-pipelines/:org", getDefaultPipeline)
+	e.POST("/api/v1/default-pipelines/:org", addDefaultPipeline)
+	e.PUT("/api/v1/default-pipelines/:org", updateDefaultPipeline)
+	e.DELETE("/api/v1/default-pipelines/:org", removeDefaultPipeline)
+
 	// mock endpoints for deployment calls
 	e.GET("/api/v1/deployments/:org/:repo", getDeployments)
 	e.POST("/api/v1/deployments/:org/:repo", addDeployment)
@@ -72,10 +87,20 @@ func FakeHandler() http.Handler {
 	// mock endpoints for pipeline calls
 	e.GET("/api/v1/pipelines/:org/:repo", getPipeline)
 	e.POST("/api/v1/pipelines/:org/:repo/compile", compilePipeline)
+	e.GET("/api/v1/pipelines/:org/:repo/diff", diffPipeline)
 	e.POST("/api/v1/pipelines/:org/:repo/expand", expandPipeline)
+	e.GET("/api/v1/pipelines/:org/:repo/graph", getPipelineGraph)
+	e.POST("/api/v1/pipelines/:org/:repo/preview", previewPipeline)
 	e.GET("/api/v1/pipelines/:org/:repo/templates", getTemplates)
 	e.POST("/api/v1/pipelines/:org/:repo/validate", validatePipeline)
 
+	// mock endpoints for required pipeline calls
+	e.GET("/api/v1/required-pipelines/:org", getRequiredPipelines)
+	e.POST("/api/v1/required-pipelines/:org", addRequiredPipeline)
+	e.GET("/api/v1/required-pipelines/:org/:pipeline", getRequiredPipeline)
+	e.PUT("/api/v1/required-pipelines/:org/:pipeline", updateRequiredPipeline)
+	e.DELETE("/api/v1/required-pipelines/:org/:pipeline", removeRequiredPipeline)
+
 	// mock endpoints for repo calls
 	e.GET("/api/v1/repos/:org/:repo", getRepo)
 	e.GET("/api/v1/repos", getRepos)
@@ -84,6 +109,12 @@ func FakeHandler() http.Handler {
 	e.DELETE("/api/v1/repos/:org/:repo", removeRepo)
 	e.PATCH("/api/v1/repos/:org/:repo/repair", repairRepo)
 	e.PATCH("/api/v1/repos/:org/:repo/chown", chownRepo)
+	e.GET("/api/v1/repos/:org/:repo/default-pipeline/opt-out", getDefaultPipelineOptOut)
+	e.POST("/api/v1/repos/:org/:repo/default-pipeline/opt-out", addDefaultPipelineOptOut)
+	e.DELETE("/api/v1/repos/:org/:repo/default-pipeline/opt-out", removeDefaultPipelineOptOut)
+	e.GET("/api/v1/repos/:org/:repo/pipeline-settings", getPipelineSettings)
+	e.PUT("/api/v1/repos/:org/:repo/pipeline-settings", updatePipelineSettings)
+	e.DELETE("/api/v1/repos/:org/:repo/pipeline-settings", removePipelineSettings)
 	e.GET("/api/v1/scm/repos/:org/:repo/sync", syncRepo)
 	e.GET("/api/v1/scm/orgs/:org/sync", syncRepos)
 
@@ -0,0 +1,100 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types"
+)
+
+const (
+	// PipelineSettingsResp represents a JSON return for pipeline settings.
+	PipelineSettingsResp = `{
+  "id": 1,
+  "repo_id": 1,
+  "path": "ci/vela.yml",
+  "pipelines": [
+    {
+      "name": "api",
+      "path": "api/.vela.yml",
+      "rules": [
+        "api/*"
+      ]
+    },
+    {
+      "name": "web",
+      "path": "web/.vela.yml",
+      "rules": [
+        "web/*"
+      ]
+    }
+  ],
+  "stage_status": false,
+  "allow_release": false,
+  "allow_delete": false,
+  "allow_pull_closed": false,
+  "allow_pull_labeled": false,
+  "allow_pull_reopened": false,
+  "approve_fork": false,
+  "approve_first_time": false,
+  "pull_comment": false
+}`
+)
+
+// getPipelineSettings has a param :repo returns mock JSON for a http GET.
+//
+// Pass "not-found" to :repo to test receiving a http 404 response.
+func getPipelineSettings(c *gin.Context) {
+	r := c.Param("repo")
+
+	if strings.EqualFold(r, "not-found") {
+		msg := fmt.Sprintf("Pipeline settings for repo %s do not exist", r)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	data := []byte(PipelineSettingsResp)
+
+	var body model.PipelineSettings
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// updatePipelineSettings returns mock JSON for a http PUT.
+func updatePipelineSettings(c *gin.Context) {
+	data := []byte(PipelineSettingsResp)
+
+	var body model.PipelineSettings
+	_ = json.Unmarshal(data, &body)
+
+	c.JSON(http.StatusOK, body)
+}
+
+// removePipelineSettings has a param :repo returns mock JSON for a http DELETE.
+//
+// Pass "not-found" to :repo to test receiving a http 404 response.
+func removePipelineSettings(c *gin.Context) {
+	o := c.Param("org")
+	r := c.Param("repo")
+
+	if strings.EqualFold(r, "not-found") {
+		msg := fmt.Sprintf("Pipeline settings for repo %s do not exist", r)
+
+		c.AbortWithStatusJSON(http.StatusNotFound, types.Error{Message: &msg})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fmt.Sprintf("pipeline settings for repo %s/%s deleted", o, r))
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// StatusPendingApproval defines the status type for a build
+	// that waits for approval before it's published to the queue.
+	StatusPendingApproval = "pending approval"
+
+	// TableBuildApproval defines the table name for build approvals.
+	TableBuildApproval = "build_approvals"
+)
+
+// ErrEmptyBuildApprovalBuildID defines the error type when a
+// BuildApproval type has an empty BuildID field provided.
+var ErrEmptyBuildApprovalBuildID = errors.New("empty build approval build_id provided")
+
+// ErrBuildApprovalApproved defines the error type when a
+// BuildApproval type was already approved for the build.
+var ErrBuildApprovalApproved = errors.New("build was already approved")
+
+// BuildApproval is the record of a build that requires approval
+// before it runs. The compiled pipeline is stored with the
+// approval so the build is published to the queue as it was
+// compiled once a user with write access approves it.
+//
+// swagger:model BuildApproval
+type BuildApproval struct {
+	ID         int64  `json:"id"`
+	BuildID    int64  `json:"build_id"`
+	Reason     string `json:"reason"`
+	Pipeline   string `json:"-"`
+	ApprovedBy string `json:"approved_by"`
+	ApprovedAt int64  `json:"approved_at"`
+}
+
+// Approved returns true when the build was approved.
+func (a *BuildApproval) Approved() bool {
+	return len(a.ApprovedBy) > 0
+}
+
+// Validate verifies the necessary fields for
+// the BuildApproval type are populated correctly.
+func (a *BuildApproval) Validate() error {
+	// verify the BuildID field is populated
+	if a.BuildID <= 0 {
+		return ErrEmptyBuildApprovalBuildID
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the BuildApproval type.
+func (a *BuildApproval) String() string {
+	return fmt.Sprintf(`{
+  ApprovedAt: %d,
+  ApprovedBy: %s,
+  BuildID: %d,
+  ID: %d,
+  Reason: %s,
+}`,
+		a.ApprovedAt,
+		a.ApprovedBy,
+		a.BuildID,
+		a.ID,
+		a.Reason,
+	)
+}
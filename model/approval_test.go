@@ -0,0 +1,67 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"testing"
+)
+
+func TestModel_BuildApproval_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure  bool
+		approval *BuildApproval
+	}{
+		{
+			failure:  false,
+			approval: &BuildApproval{BuildID: 1, Reason: "pull request is from a fork"},
+		},
+		{ // no build id set for approval
+			failure:  true,
+			approval: &BuildApproval{Reason: "pull request is from a fork"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.approval.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+func TestModel_BuildApproval_Approved(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		approval *BuildApproval
+		want     bool
+	}{
+		{
+			approval: &BuildApproval{BuildID: 1, ApprovedBy: "octocat", ApprovedAt: 1563474077},
+			want:     true,
+		},
+		{
+			approval: &BuildApproval{BuildID: 1},
+			want:     false,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		if got := test.approval.Approved(); got != test.want {
+			t.Errorf("Approved is %v, want %v", got, test.want)
+		}
+	}
+}
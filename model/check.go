@@ -0,0 +1,236 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-vela/types/library"
+)
+
+const (
+	// ReportingStatus defines the reporting mode that
+	// sends a commit status for the builds of a repo.
+	ReportingStatus = "status"
+
+	// ReportingChecks defines the reporting mode that creates a
+	// check run with the results of the steps for the builds of a repo.
+	ReportingChecks = "checks"
+
+	// TableBuildCheck defines the table name for build checks.
+	TableBuildCheck = "build_checks"
+)
+
+// ErrEmptyBuildCheckBuildID defines the error type when a
+// BuildCheck type has an empty BuildID field provided.
+var ErrEmptyBuildCheckBuildID = errors.New("empty build check build_id provided")
+
+const (
+	// CheckAnnotationNotice defines the level for an annotation
+	// parsed from a notice command in the output of a step.
+	CheckAnnotationNotice = "notice"
+
+	// CheckAnnotationWarning defines the level for an annotation
+	// parsed from a warning command in the output of a step.
+	CheckAnnotationWarning = "warning"
+
+	// CheckAnnotationFailure defines the level for an annotation
+	// parsed from an error command in the output of a step.
+	CheckAnnotationFailure = "failure"
+)
+
+const (
+	// CheckLogTail defines the number of lines from the end
+	// of the output of a step parsed for annotations.
+	CheckLogTail = 200
+
+	// CheckAnnotationLimit defines the max number of
+	// annotations parsed from the output of a step.
+	CheckAnnotationLimit = 50
+)
+
+// BuildCheck is the record of the check run created for a build.
+// The ID of the check run is stored so the check run is updated
+// without looking it up and the annotations are only sent once.
+type BuildCheck struct {
+	ID         int64 `json:"id"`
+	BuildID    int64 `json:"build_id"`
+	CheckRunID int64 `json:"check_run_id"`
+	Annotated  bool  `json:"annotated"`
+}
+
+// Validate verifies the necessary fields for
+// the BuildCheck type are populated correctly.
+func (c *BuildCheck) Validate() error {
+	// verify the BuildID field is populated
+	if c.BuildID <= 0 {
+		return ErrEmptyBuildCheckBuildID
+	}
+
+	return nil
+}
+
+// CheckStep is the result of a step reported
+// in the check run for a build.
+type CheckStep struct {
+	Step        *library.Step
+	Annotations []*CheckAnnotation
+}
+
+// CheckAnnotation is a message from the output of a step
+// reported on a line of a file in the check run for a build.
+type CheckAnnotation struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	Level     string
+	Title     string
+	Message   string
+}
+
+// ParseCheckAnnotations parses the annotations from the tail of the
+// output of a step. The annotations are emitted by the step with
+// the workflow command format used by GitHub Actions:
+//
+//	::error file=main.go,line=10,endLine=12,title=Lint::message
+//	::warning file=main.go,line=4::message
+//	::notice file=main.go,line=1::message
+//
+// Commands without a file are ignored since an annotation
+// must reference a file in the repo.
+func ParseCheckAnnotations(data []byte) []*CheckAnnotation {
+	lines := []string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	// allow long lines from the output of a step
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	// only parse the tail of the output
+	if len(lines) > CheckLogTail {
+		lines = lines[len(lines)-CheckLogTail:]
+	}
+
+	annotations := []*CheckAnnotation{}
+
+	for _, line := range lines {
+		a := parseCheckAnnotation(line)
+		if a == nil {
+			continue
+		}
+
+		annotations = append(annotations, a)
+
+		if len(annotations) == CheckAnnotationLimit {
+			break
+		}
+	}
+
+	return annotations
+}
+
+// parseCheckAnnotation is a helper function to parse
+// an annotation from a line of the output of a step.
+func parseCheckAnnotation(line string) *CheckAnnotation {
+	line = strings.TrimSpace(line)
+
+	if !strings.HasPrefix(line, "::") {
+		return nil
+	}
+
+	// split the command from the message
+	//
+	// pattern: ::<command> <properties>::<message>
+	parts := strings.SplitN(strings.TrimPrefix(line, "::"), "::", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+
+	command := parts[0]
+	properties := ""
+
+	if i := strings.Index(command, " "); i >= 0 {
+		command, properties = command[:i], command[i+1:]
+	}
+
+	a := &CheckAnnotation{
+		Message: unescapeCheckData(parts[1]),
+	}
+
+	switch command {
+	case "error":
+		a.Level = CheckAnnotationFailure
+	case "warning":
+		a.Level = CheckAnnotationWarning
+	case "notice":
+		a.Level = CheckAnnotationNotice
+	default:
+		return nil
+	}
+
+	for _, property := range strings.Split(properties, ",") {
+		kv := strings.SplitN(strings.TrimSpace(property), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		value := unescapeCheckProperty(kv[1])
+
+		switch kv[0] {
+		case "file":
+			a.Path = value
+		case "line":
+			a.StartLine, _ = strconv.Atoi(value)
+		case "endLine":
+			a.EndLine, _ = strconv.Atoi(value)
+		case "title":
+			a.Title = value
+		}
+	}
+
+	if len(a.Path) == 0 {
+		return nil
+	}
+
+	// an annotation must reference at least the first line of the file
+	if a.StartLine < 1 {
+		a.StartLine = 1
+	}
+
+	if a.EndLine < a.StartLine {
+		a.EndLine = a.StartLine
+	}
+
+	return a
+}
+
+// unescapeCheckData is a helper function to
+// unescape the message of a workflow command.
+func unescapeCheckData(s string) string {
+	return strings.NewReplacer(
+		"%0D", "\r",
+		"%0A", "\n",
+		"%25", "%",
+	).Replace(s)
+}
+
+// unescapeCheckProperty is a helper function to
+// unescape the property of a workflow command.
+func unescapeCheckProperty(s string) string {
+	return strings.NewReplacer(
+		"%0D", "\r",
+		"%0A", "\n",
+		"%3A", ":",
+		"%2C", ",",
+		"%25", "%",
+	).Replace(s)
+}
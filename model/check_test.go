@@ -0,0 +1,142 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestModel_BuildCheck_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		check   *BuildCheck
+	}{
+		{
+			failure: false,
+			check:   &BuildCheck{BuildID: 1, CheckRunID: 4},
+		},
+		{ // no build id set for check
+			failure: true,
+			check:   &BuildCheck{CheckRunID: 4},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.check.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+func TestModel_ParseCheckAnnotations(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name string
+		data string
+		want []*CheckAnnotation
+	}{
+		{
+			name: "error with properties",
+			data: "$ go vet ./...\n::error file=main.go,line=10,endLine=12,title=Vet::unreachable code\nexit status 1\n",
+			want: []*CheckAnnotation{
+				{
+					Path:      "main.go",
+					StartLine: 10,
+					EndLine:   12,
+					Level:     CheckAnnotationFailure,
+					Title:     "Vet",
+					Message:   "unreachable code",
+				},
+			},
+		},
+		{
+			name: "warning and notice",
+			data: "::warning file=a.go,line=4::unused variable\n  ::notice file=b.go::formatted",
+			want: []*CheckAnnotation{
+				{
+					Path:      "a.go",
+					StartLine: 4,
+					EndLine:   4,
+					Level:     CheckAnnotationWarning,
+					Message:   "unused variable",
+				},
+				{
+					Path:      "b.go",
+					StartLine: 1,
+					EndLine:   1,
+					Level:     CheckAnnotationNotice,
+					Message:   "formatted",
+				},
+			},
+		},
+		{
+			name: "escaped values",
+			data: "::error file=dir%2Cname/a.go,line=2,title=a%3Ab::first%0Asecond 100%25",
+			want: []*CheckAnnotation{
+				{
+					Path:      "dir,name/a.go",
+					StartLine: 2,
+					EndLine:   2,
+					Level:     CheckAnnotationFailure,
+					Title:     "a:b",
+					Message:   "first\nsecond 100%",
+				},
+			},
+		},
+		{
+			name: "ignored commands",
+			data: "::error::no file\n::debug file=a.go::debug\n::group::build\nnot a command",
+			want: []*CheckAnnotation{},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ParseCheckAnnotations([]byte(test.data))
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ParseCheckAnnotations is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestModel_ParseCheckAnnotations_Tail(t *testing.T) {
+	// setup types
+	lines := []string{"::error file=head.go,line=1::outside of the tail"}
+
+	for i := 0; i < CheckLogTail; i++ {
+		lines = append(lines, fmt.Sprintf("::warning file=tail.go,line=%d::inside of the tail", i+1))
+	}
+
+	// run test
+	got := ParseCheckAnnotations([]byte(strings.Join(lines, "\n")))
+
+	if len(got) != CheckAnnotationLimit {
+		t.Errorf("ParseCheckAnnotations returned %d annotations, want %d", len(got), CheckAnnotationLimit)
+	}
+
+	for _, a := range got {
+		if a.Path != "tail.go" {
+			t.Errorf("ParseCheckAnnotations returned annotation for %s, want tail.go", a.Path)
+		}
+	}
+}
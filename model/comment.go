@@ -0,0 +1,82 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TablePullComment defines the table name for pull request comments.
+const TablePullComment = "pull_comments"
+
+var (
+	// ErrEmptyPullCommentRepoID defines the error type when a
+	// PullComment type has an empty RepoID field provided.
+	ErrEmptyPullCommentRepoID = errors.New("empty pull comment repo_id provided")
+
+	// ErrEmptyPullCommentNumber defines the error type when a
+	// PullComment type has an empty Number field provided.
+	ErrEmptyPullCommentNumber = errors.New("empty pull comment number provided")
+
+	// ErrPullCommentStale defines the error type when a
+	// PullComment type was claimed by a newer build.
+	ErrPullCommentStale = errors.New("pull comment was claimed by a newer build")
+)
+
+// PullComment is the record of the comment with the summary of
+// the builds on a pull request. A single comment is created for
+// every event and pipeline of the pull request and updated with
+// the summary of the latest build. The number of the build the
+// comment was last updated for is recorded so an older build
+// finishing late doesn't overwrite the comment.
+//
+// swagger:model PullComment
+type PullComment struct {
+	ID        int64  `json:"id"`
+	RepoID    int64  `json:"repo_id"`
+	Number    int    `json:"number"`
+	Event     string `json:"event"`
+	Pipeline  string `json:"pipeline"`
+	CommentID int64  `json:"comment_id"`
+	Build     int    `json:"build"`
+}
+
+// Validate verifies the necessary fields for
+// the PullComment type are populated correctly.
+func (p *PullComment) Validate() error {
+	// verify the RepoID field is populated
+	if p.RepoID <= 0 {
+		return ErrEmptyPullCommentRepoID
+	}
+
+	// verify the Number field is populated
+	if p.Number <= 0 {
+		return ErrEmptyPullCommentNumber
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the PullComment type.
+func (p *PullComment) String() string {
+	return fmt.Sprintf(`{
+  Build: %d,
+  CommentID: %d,
+  Event: %s,
+  ID: %d,
+  Number: %d,
+  Pipeline: %s,
+  RepoID: %d,
+}`,
+		p.Build,
+		p.CommentID,
+		p.Event,
+		p.ID,
+		p.Number,
+		p.Pipeline,
+		p.RepoID,
+	)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"testing"
+)
+
+func TestModel_PullComment_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		comment *PullComment
+	}{
+		{
+			failure: false,
+			comment: &PullComment{RepoID: 1, Number: 1, Event: "pull_request", CommentID: 1},
+		},
+		{ // no repo id set for comment
+			failure: true,
+			comment: &PullComment{Number: 1, Event: "pull_request"},
+		},
+		{ // no number set for comment
+			failure: true,
+			comment: &PullComment{RepoID: 1, Event: "pull_request"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.comment.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// TableDefaultPipeline defines the table name for default pipelines.
+	TableDefaultPipeline = "default_pipelines"
+
+	// TableDefaultPipelineOptOut defines the table name for default pipeline opt outs.
+	TableDefaultPipelineOptOut = "default_pipeline_opt_outs"
+)
+
+// DefaultPipelineTemplateType defines the template type used
+// for a default pipeline template with no type provided.
+const DefaultPipelineTemplateType = "github"
+
+// defaultPipelineTemplate is the pipeline used for a default
+// pipeline that references a template instead of an inline
+// pipeline. The template is expanded into the steps.
+const defaultPipelineTemplate = `version: "1"
+templates:
+  - name: default
+    source: %q
+    type: %q
+steps:
+  - name: default
+    template:
+      name: default
+`
+
+var (
+	// ErrNoPipelineConfig defines the error type when
+	// a repo has no pipeline configuration file.
+	ErrNoPipelineConfig = errors.New("no valid pipeline configuration file found")
+
+	// ErrEmptyDefaultPipelineOrg defines the error type when a
+	// DefaultPipeline type has an empty Org field provided.
+	ErrEmptyDefaultPipelineOrg = errors.New("empty default pipeline org provided")
+
+	// ErrEmptyDefaultPipeline defines the error type when a DefaultPipeline
+	// type has an empty Pipeline and Template field provided.
+	ErrEmptyDefaultPipeline = errors.New("empty default pipeline and template provided")
+
+	// ErrInvalidDefaultPipeline defines the error type when a DefaultPipeline
+	// type has both the Pipeline and Template field provided.
+	ErrInvalidDefaultPipeline = errors.New("default pipeline and template can not both be provided")
+
+	// ErrEmptyOptOutRepoID defines the error type when a
+	// DefaultPipelineOptOut type has an empty RepoID field provided.
+	ErrEmptyOptOutRepoID = errors.New("empty default pipeline opt out repo_id provided")
+)
+
+// DefaultPipeline is the pipeline, defined for an org, that
+// is used for the builds of the repos in the org that have
+// no pipeline configuration file. The pipeline is either
+// provided inline or as a reference to a template.
+//
+// swagger:model DefaultPipeline
+type DefaultPipeline struct {
+	ID       int64  `json:"id"`
+	Org      string `json:"org"`
+	Pipeline string `json:"pipeline"`
+	Template string `json:"template"`
+	Type     string `json:"type"`
+	Active   bool   `json:"active"`
+}
+
+// DefaultPipelineOptOut is the record of a repo that
+// does not use the default pipeline for the org.
+//
+// swagger:model DefaultPipelineOptOut
+type DefaultPipelineOptOut struct {
+	ID     int64 `json:"id"`
+	RepoID int64 `json:"repo_id"`
+}
+
+// Config returns the pipeline configuration for the default pipeline.
+func (p *DefaultPipeline) Config() []byte {
+	if len(p.Pipeline) > 0 {
+		return []byte(p.Pipeline)
+	}
+
+	t := p.Type
+	if len(t) == 0 {
+		t = DefaultPipelineTemplateType
+	}
+
+	return []byte(fmt.Sprintf(defaultPipelineTemplate, p.Template, t))
+}
+
+// Validate verifies the necessary fields for
+// the DefaultPipeline type are populated correctly.
+func (p *DefaultPipeline) Validate() error {
+	// verify the Org field is populated
+	if len(p.Org) == 0 {
+		return ErrEmptyDefaultPipelineOrg
+	}
+
+	// verify the Pipeline or Template field is populated
+	if len(p.Pipeline) == 0 && len(p.Template) == 0 {
+		return ErrEmptyDefaultPipeline
+	}
+
+	// verify only one of the Pipeline or Template field is populated
+	if len(p.Pipeline) > 0 && len(p.Template) > 0 {
+		return ErrInvalidDefaultPipeline
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the DefaultPipeline type.
+func (p *DefaultPipeline) String() string {
+	return fmt.Sprintf(`{
+  Active: %t,
+  ID: %d,
+  Org: %s,
+  Pipeline: %s,
+  Template: %s,
+  Type: %s,
+}`,
+		p.Active,
+		p.ID,
+		p.Org,
+		p.Pipeline,
+		p.Template,
+		p.Type,
+	)
+}
+
+// Validate verifies the necessary fields for the
+// DefaultPipelineOptOut type are populated correctly.
+func (o *DefaultPipelineOptOut) Validate() error {
+	// verify the RepoID field is populated
+	if o.RepoID <= 0 {
+		return ErrEmptyOptOutRepoID
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModel_DefaultPipeline_Config(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		pipeline *DefaultPipeline
+		want     []string
+	}{
+		{ // inline pipeline
+			pipeline: testDefaultPipeline(),
+			want:     []string{"name: test"},
+		},
+		{ // template with no type
+			pipeline: &DefaultPipeline{Org: "github", Template: "github.com/github/templates/go.yml"},
+			want:     []string{`source: "github.com/github/templates/go.yml"`, `type: "github"`},
+		},
+		{ // template with type
+			pipeline: &DefaultPipeline{Org: "github", Template: "templates/go.yml", Type: "file"},
+			want:     []string{`source: "templates/go.yml"`, `type: "file"`},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := string(test.pipeline.Config())
+
+		for _, want := range test.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("Config is %s, want %s", got, want)
+			}
+		}
+	}
+}
+
+func TestModel_DefaultPipeline_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *DefaultPipeline
+	}{
+		{
+			failure:  false,
+			pipeline: testDefaultPipeline(),
+		},
+		{
+			failure:  false,
+			pipeline: &DefaultPipeline{Org: "github", Template: "github.com/github/templates/go.yml"},
+		},
+		{ // no org set for default pipeline
+			failure:  true,
+			pipeline: &DefaultPipeline{Pipeline: "steps: []"},
+		},
+		{ // no pipeline or template set for default pipeline
+			failure:  true,
+			pipeline: &DefaultPipeline{Org: "github"},
+		},
+		{ // pipeline and template set for default pipeline
+			failure:  true,
+			pipeline: &DefaultPipeline{Org: "github", Pipeline: "steps: []", Template: "templates/go.yml"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.pipeline.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+func TestModel_DefaultPipelineOptOut_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		optOut  *DefaultPipelineOptOut
+	}{
+		{
+			failure: false,
+			optOut:  &DefaultPipelineOptOut{RepoID: 1},
+		},
+		{ // no repo_id set for opt out
+			failure: true,
+			optOut:  &DefaultPipelineOptOut{},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.optOut.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+// testDefaultPipeline is a test helper function to create a
+// DefaultPipeline type with all fields set to a fake value.
+func testDefaultPipeline() *DefaultPipeline {
+	return &DefaultPipeline{
+		ID:  1,
+		Org: "github",
+		Pipeline: `version: "1"
+steps:
+  - name: test
+    image: alpine:latest
+    commands:
+      - echo test
+`,
+		Active: true,
+	}
+}
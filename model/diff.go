@@ -0,0 +1,290 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/go-vela/types/yaml"
+)
+
+const (
+	// DiffAdded defines the change for a value
+	// that was added to a pipeline.
+	DiffAdded = "added"
+
+	// DiffRemoved defines the change for a value
+	// that was removed from a pipeline.
+	DiffRemoved = "removed"
+
+	// DiffChanged defines the change for a value
+	// that was changed in a pipeline.
+	DiffChanged = "changed"
+)
+
+// PipelineDiff is the semantic difference
+// between the pipelines for two refs or builds.
+//
+// swagger:model PipelineDiff
+type PipelineDiff struct {
+	From        string       `json:"from"`
+	To          string       `json:"to"`
+	Steps       []*StepDiff  `json:"steps,omitempty"`
+	Environment []*ValueDiff `json:"environment,omitempty"`
+	Secrets     []*ValueDiff `json:"secrets,omitempty"`
+	Templates   []*ValueDiff `json:"templates,omitempty"`
+}
+
+// StepDiff is the difference for a step between two
+// pipelines. The fields of the step are only compared
+// when the step exists in both pipelines.
+type StepDiff struct {
+	Name        string       `json:"name"`
+	Stage       string       `json:"stage,omitempty"`
+	Change      string       `json:"change"`
+	Image       *ValueDiff   `json:"image,omitempty"`
+	Environment []*ValueDiff `json:"environment,omitempty"`
+	Secrets     []*ValueDiff `json:"secrets,omitempty"`
+	Ruleset     *ValueDiff   `json:"ruleset,omitempty"`
+}
+
+// ValueDiff is the difference for a
+// named value between two pipelines.
+type ValueDiff struct {
+	Name   string `json:"name,omitempty"`
+	Change string `json:"change"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// Empty returns true if no differences
+// were found between the two pipelines.
+func (d *PipelineDiff) Empty() bool {
+	return len(d.Steps) == 0 &&
+		len(d.Environment) == 0 &&
+		len(d.Secrets) == 0 &&
+		len(d.Templates) == 0
+}
+
+// NewPipelineDiff returns the semantic difference between two
+// expanded pipeline configurations along with the templates
+// that were used to compile each of the pipelines.
+//
+// nolint: lll // ignore long line length due to parameters
+func NewPipelineDiff(from, to *yaml.Build, fromTemplates, toTemplates []*BuildTemplate) *PipelineDiff {
+	d := new(PipelineDiff)
+
+	d.Steps = diffSteps(pipelineSteps(from), pipelineSteps(to))
+	d.Environment = diffValues(from.Environment, to.Environment)
+	d.Secrets = diffValues(secretValues(from.Secrets), secretValues(to.Secrets))
+	d.Templates = diffValues(templateValues(fromTemplates), templateValues(toTemplates))
+
+	return d
+}
+
+// diffStep is a step from a pipeline
+// with the stage it belongs to.
+type diffStep struct {
+	stage string
+	step  *yaml.Step
+}
+
+// pipelineSteps is a helper function to capture
+// the steps of a pipeline in order, including
+// the steps in each stage of the pipeline.
+func pipelineSteps(p *yaml.Build) []*diffStep {
+	steps := []*diffStep{}
+
+	for _, stage := range p.Stages {
+		for _, step := range stage.Steps {
+			steps = append(steps, &diffStep{stage: stage.Name, step: step})
+		}
+	}
+
+	for _, step := range p.Steps {
+		steps = append(steps, &diffStep{step: step})
+	}
+
+	return steps
+}
+
+// diffSteps is a helper function to capture the steps
+// added, removed or changed between two pipelines.
+func diffSteps(from, to []*diffStep) []*StepDiff {
+	diffs := []*StepDiff{}
+
+	// capture the steps from the pipeline to compare with
+	existing := make(map[string]*diffStep)
+
+	for _, s := range from {
+		existing[s.stage+"/"+s.step.Name] = s
+	}
+
+	current := make(map[string]bool)
+
+	for _, s := range to {
+		key := s.stage + "/" + s.step.Name
+		current[key] = true
+
+		old, ok := existing[key]
+		if !ok {
+			diffs = append(diffs, &StepDiff{Name: s.step.Name, Stage: s.stage, Change: DiffAdded})
+
+			continue
+		}
+
+		d := &StepDiff{
+			Name:        s.step.Name,
+			Stage:       s.stage,
+			Change:      DiffChanged,
+			Image:       diffValue("", old.step.Image, s.step.Image),
+			Environment: diffValues(old.step.Environment, s.step.Environment),
+			Secrets:     diffValues(stepSecretValues(old.step.Secrets), stepSecretValues(s.step.Secrets)),
+			Ruleset:     diffValue("", rulesetValue(&old.step.Ruleset), rulesetValue(&s.step.Ruleset)),
+		}
+
+		if d.Image != nil || len(d.Environment) > 0 || len(d.Secrets) > 0 || d.Ruleset != nil {
+			diffs = append(diffs, d)
+		}
+	}
+
+	for _, s := range from {
+		if !current[s.stage+"/"+s.step.Name] {
+			diffs = append(diffs, &StepDiff{Name: s.step.Name, Stage: s.stage, Change: DiffRemoved})
+		}
+	}
+
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	return diffs
+}
+
+// diffValue is a helper function to capture the
+// difference for a value between two pipelines.
+func diffValue(name, from, to string) *ValueDiff {
+	switch {
+	case from == to:
+		return nil
+	case len(from) == 0:
+		return &ValueDiff{Name: name, Change: DiffAdded, To: to}
+	case len(to) == 0:
+		return &ValueDiff{Name: name, Change: DiffRemoved, From: from}
+	default:
+		return &ValueDiff{Name: name, Change: DiffChanged, From: from, To: to}
+	}
+}
+
+// diffValues is a helper function to capture the differences
+// for a map of named values between two pipelines, sorted
+// by the name of the value.
+func diffValues(from, to map[string]string) []*ValueDiff {
+	names := []string{}
+
+	for name := range from {
+		names = append(names, name)
+	}
+
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	diffs := []*ValueDiff{}
+
+	for _, name := range names {
+		old, oldOK := from[name]
+		value, ok := to[name]
+
+		switch {
+		case !oldOK:
+			diffs = append(diffs, &ValueDiff{Name: name, Change: DiffAdded, To: value})
+		case !ok:
+			diffs = append(diffs, &ValueDiff{Name: name, Change: DiffRemoved, From: old})
+		case old != value:
+			diffs = append(diffs, &ValueDiff{Name: name, Change: DiffChanged, From: old, To: value})
+		}
+	}
+
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	return diffs
+}
+
+// secretValues is a helper function to capture
+// the secrets of a pipeline as named values.
+func secretValues(secrets yaml.SecretSlice) map[string]string {
+	values := make(map[string]string)
+
+	for _, s := range secrets {
+		// capture the image for secrets from a secret plugin
+		if !s.Origin.Empty() {
+			values[s.Origin.Name] = s.Origin.Image
+
+			continue
+		}
+
+		values[s.Name] = fmt.Sprintf("%s (%s/%s)", s.Key, s.Engine, s.Type)
+	}
+
+	return values
+}
+
+// stepSecretValues is a helper function to capture
+// the secrets of a step as named values.
+func stepSecretValues(secrets yaml.StepSecretSlice) map[string]string {
+	values := make(map[string]string)
+
+	for _, s := range secrets {
+		values[s.Target] = s.Source
+	}
+
+	return values
+}
+
+// templateValues is a helper function to capture the
+// templates used to compile a pipeline as named values
+// including the commit the template was captured from.
+func templateValues(templates []*BuildTemplate) map[string]string {
+	values := make(map[string]string)
+
+	for _, t := range templates {
+		value := t.Source
+
+		if len(t.Commit) > 0 {
+			value = fmt.Sprintf("%s (%s)", t.Source, t.Commit)
+		}
+
+		values[t.Name] = value
+	}
+
+	return values
+}
+
+// rulesetValue is a helper function to capture the
+// ruleset of a step as a value for comparison.
+func rulesetValue(r *yaml.Ruleset) string {
+	p := r.ToPipeline()
+
+	// an empty ruleset has no value
+	if p.If.Empty() && p.Unless.Empty() {
+		return ""
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/go-vela/types/raw"
+	"github.com/go-vela/types/yaml"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModel_NewPipelineDiff(t *testing.T) {
+	// setup types
+	from := &yaml.Build{
+		Environment: raw.StringSliceMap{"GOOS": "linux"},
+		Secrets: yaml.SecretSlice{
+			{Name: "docker_username", Key: "org/docker/username", Engine: "native", Type: "org"},
+			{Name: "docker_password", Key: "org/docker/password", Engine: "native", Type: "org"},
+		},
+		Steps: yaml.StepSlice{
+			{
+				Name:        "test",
+				Image:       "golang:1.17",
+				Environment: raw.StringSliceMap{"CGO_ENABLED": "0"},
+			},
+			{
+				Name:  "publish",
+				Image: "target/vela-docker:v0.5.0",
+				Secrets: yaml.StepSecretSlice{
+					{Source: "docker_username", Target: "docker_username"},
+				},
+				Ruleset: yaml.Ruleset{
+					If: yaml.Rules{Event: []string{"push"}},
+				},
+			},
+			{
+				Name:  "lint",
+				Image: "golangci/golangci-lint:latest",
+			},
+		},
+	}
+
+	to := &yaml.Build{
+		Environment: raw.StringSliceMap{"GOOS": "linux", "GOARCH": "amd64"},
+		Secrets: yaml.SecretSlice{
+			{Name: "docker_username", Key: "org/docker/username", Engine: "native", Type: "org"},
+			{Name: "docker_password", Key: "org/docker/password", Engine: "vault", Type: "org"},
+		},
+		Steps: yaml.StepSlice{
+			{
+				Name:        "test",
+				Image:       "golang:1.18",
+				Environment: raw.StringSliceMap{"CGO_ENABLED": "1"},
+			},
+			{
+				Name:  "publish",
+				Image: "target/vela-docker:v0.5.0",
+				Secrets: yaml.StepSecretSlice{
+					{Source: "docker_username", Target: "docker_username"},
+					{Source: "docker_password", Target: "docker_password"},
+				},
+				Ruleset: yaml.Ruleset{
+					If: yaml.Rules{Event: []string{"push", "tag"}},
+				},
+			},
+			{
+				Name:  "build",
+				Image: "golang:1.18",
+			},
+		},
+	}
+
+	fromTemplates := []*BuildTemplate{
+		{Name: "go", Source: "github.com/github/octocat/go.yml", Commit: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"},
+	}
+
+	toTemplates := []*BuildTemplate{
+		{Name: "go", Source: "github.com/github/octocat/go.yml", Commit: "48afb5bdc41ad69bf22588491333f7cf71135163"},
+	}
+
+	want := &PipelineDiff{
+		Steps: []*StepDiff{
+			{
+				Name:   "test",
+				Change: DiffChanged,
+				Image:  &ValueDiff{Change: DiffChanged, From: "golang:1.17", To: "golang:1.18"},
+				Environment: []*ValueDiff{
+					{Name: "CGO_ENABLED", Change: DiffChanged, From: "0", To: "1"},
+				},
+			},
+			{
+				Name:   "publish",
+				Change: DiffChanged,
+				Secrets: []*ValueDiff{
+					{Name: "docker_password", Change: DiffAdded, To: "docker_password"},
+				},
+				Ruleset: &ValueDiff{
+					Change: DiffChanged,
+					From:   `{"if":{"event":["push"]},"unless":{}}`,
+					To:     `{"if":{"event":["push","tag"]},"unless":{}}`,
+				},
+			},
+			{Name: "build", Change: DiffAdded},
+			{Name: "lint", Change: DiffRemoved},
+		},
+		Environment: []*ValueDiff{
+			{Name: "GOARCH", Change: DiffAdded, To: "amd64"},
+		},
+		Secrets: []*ValueDiff{
+			{
+				Name:   "docker_password",
+				Change: DiffChanged,
+				From:   "org/docker/password (native/org)",
+				To:     "org/docker/password (vault/org)",
+			},
+		},
+		Templates: []*ValueDiff{
+			{
+				Name:   "go",
+				Change: DiffChanged,
+				From:   "github.com/github/octocat/go.yml (7fd1a60b01f91b314f59955a4e4d4e80d8edf11d)",
+				To:     "github.com/github/octocat/go.yml (48afb5bdc41ad69bf22588491333f7cf71135163)",
+			},
+		},
+	}
+
+	// run test
+	got := NewPipelineDiff(from, to, fromTemplates, toTemplates)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewPipelineDiff mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestModel_NewPipelineDiff_Empty(t *testing.T) {
+	// setup types
+	p := &yaml.Build{
+		Stages: yaml.StageSlice{
+			{
+				Name: "test",
+				Steps: yaml.StepSlice{
+					{Name: "test", Image: "golang:1.18"},
+				},
+			},
+		},
+	}
+
+	// run test
+	got := NewPipelineDiff(p, p, nil, nil)
+
+	if !got.Empty() {
+		t.Errorf("NewPipelineDiff is %+v, want empty", got)
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package model provides the resources managed by the
+// Vela server that are not shared with other Vela components.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/server/model"
+package model
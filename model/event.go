@@ -0,0 +1,67 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-vela/types/constants"
+)
+
+const (
+	// EventRelease defines the event type for a published release.
+	EventRelease = "release"
+
+	// EventDelete defines the event type for a deleted branch or tag.
+	EventDelete = "delete"
+)
+
+const (
+	// ActionCreated defines the action for a created comment.
+	ActionCreated = "created"
+
+	// ActionClosed defines the action for a closed pull request.
+	ActionClosed = "closed"
+
+	// ActionLabeled defines the action for a labeled pull request.
+	ActionLabeled = "labeled"
+
+	// ActionReopened defines the action for a reopened pull request.
+	ActionReopened = "reopened"
+)
+
+const (
+	// EventPullClosed defines the event for a closed pull request.
+	EventPullClosed = constants.EventPull + ":" + ActionClosed
+
+	// EventPullLabeled defines the event for a labeled pull request.
+	EventPullLabeled = constants.EventPull + ":" + ActionLabeled
+
+	// EventPullReopened defines the event for a reopened pull request.
+	EventPullReopened = constants.EventPull + ":" + ActionReopened
+)
+
+// EventAction returns the event qualified with the action
+// of the webhook, i.e. pull_request:closed. The event is
+// returned as is when no action is provided.
+func EventAction(event, action string) string {
+	if len(action) == 0 {
+		return event
+	}
+
+	return fmt.Sprintf("%s:%s", event, action)
+}
+
+// ParseEventAction returns the event and the action
+// of an event qualified with the action of the webhook.
+func ParseEventAction(event string) (string, string) {
+	parts := strings.SplitN(event, ":", 2)
+	if len(parts) != 2 {
+		return event, ""
+	}
+
+	return parts[0], parts[1]
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"testing"
+)
+
+func TestModel_EventAction(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		event  string
+		action string
+		want   string
+	}{
+		{event: "pull_request", action: "closed", want: EventPullClosed},
+		{event: "pull_request", action: "", want: "pull_request"},
+		{event: "release", action: "", want: EventRelease},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := EventAction(test.event, test.action)
+
+		if got != test.want {
+			t.Errorf("EventAction for %s and %s is %v, want %v", test.event, test.action, got, test.want)
+		}
+
+		event, action := ParseEventAction(got)
+
+		if event != test.event || action != test.action {
+			t.Errorf("ParseEventAction for %s is %s and %s, want %s and %s", got, event, action, test.event, test.action)
+		}
+	}
+}
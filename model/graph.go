@@ -0,0 +1,388 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+	"github.com/go-vela/types/yaml"
+)
+
+const (
+	// GraphNodeStage defines the node type for a stage in a graph.
+	GraphNodeStage = "stage"
+
+	// GraphNodeStep defines the node type for a step in a graph.
+	GraphNodeStep = "step"
+
+	// GraphEdgeNeeds defines the edge type for a stage
+	// that needs another stage to complete in a graph.
+	GraphEdgeNeeds = "needs"
+
+	// GraphEdgeStep defines the edge type for a step
+	// that runs after another step in a graph.
+	GraphEdgeStep = "step"
+)
+
+// Graph is the execution graph of the
+// stages and steps in a pipeline.
+//
+// swagger:model Graph
+type Graph struct {
+	Nodes []*GraphNode `json:"nodes"`
+	Edges []*GraphEdge `json:"edges"`
+}
+
+// GraphNode is a stage or step in an execution graph.
+// The Status and Duration fields are only populated
+// for the graph of a build.
+type GraphNode struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Stage    string `json:"stage,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Duration int64  `json:"duration,omitempty"`
+}
+
+// GraphEdge is a dependency between
+// two nodes in an execution graph.
+type GraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// NewGraph returns the execution graph for the
+// stages or steps of a pipeline configuration.
+func NewGraph(p *yaml.Build) *Graph {
+	g := &Graph{
+		Nodes: []*GraphNode{},
+		Edges: []*GraphEdge{},
+	}
+
+	if len(p.Stages) == 0 {
+		g.addSteps("", yamlStepNames(p.Steps))
+
+		return g
+	}
+
+	for _, stage := range p.Stages {
+		g.addStage(stage.Name, yamlStepNames(stage.Steps))
+	}
+
+	for _, stage := range p.Stages {
+		g.addNeeds(stage.Name, stage.Needs)
+	}
+
+	return g
+}
+
+// NewBuildGraph returns the execution graph for the
+// stages or steps of the pipeline compiled for a build,
+// including the steps injected while compiling it.
+func NewBuildGraph(p *pipeline.Build) *Graph {
+	g := &Graph{
+		Nodes: []*GraphNode{},
+		Edges: []*GraphEdge{},
+	}
+
+	if len(p.Stages) == 0 {
+		g.addSteps("", containerNames(p.Steps))
+
+		return g
+	}
+
+	for _, stage := range p.Stages {
+		g.addStage(stage.Name, containerNames(stage.Steps))
+	}
+
+	for _, stage := range p.Stages {
+		g.addNeeds(stage.Name, stage.Needs)
+	}
+
+	return g
+}
+
+// addStage is a helper function to add a stage
+// and the steps of the stage to the graph.
+func (g *Graph) addStage(name string, steps []string) {
+	g.Nodes = append(g.Nodes, &GraphNode{
+		ID:   name,
+		Name: name,
+		Type: GraphNodeStage,
+	})
+
+	g.addSteps(name, steps)
+}
+
+// addNeeds is a helper function to add an edge to the
+// graph for every stage the provided stage needs.
+func (g *Graph) addNeeds(stage string, needs []string) {
+	for _, need := range needs {
+		g.Edges = append(g.Edges, &GraphEdge{
+			Source: need,
+			Target: stage,
+			Type:   GraphEdgeNeeds,
+		})
+	}
+}
+
+// addSteps is a helper function to add the steps of
+// a stage, or of a pipeline without stages, to the
+// graph with an edge between each step in order.
+func (g *Graph) addSteps(stage string, steps []string) {
+	previous := ""
+
+	for _, step := range steps {
+		id := graphStepID(stage, step)
+
+		g.Nodes = append(g.Nodes, &GraphNode{
+			ID:    id,
+			Name:  step,
+			Type:  GraphNodeStep,
+			Stage: stage,
+		})
+
+		if len(previous) > 0 {
+			g.Edges = append(g.Edges, &GraphEdge{
+				Source: previous,
+				Target: id,
+				Type:   GraphEdgeStep,
+			})
+		}
+
+		previous = id
+	}
+}
+
+// yamlStepNames is a helper function to
+// return the names of the provided steps.
+func yamlStepNames(steps yaml.StepSlice) []string {
+	names := []string{}
+
+	for _, step := range steps {
+		names = append(names, step.Name)
+	}
+
+	return names
+}
+
+// containerNames is a helper function to return
+// the names of the provided compiled steps.
+func containerNames(steps pipeline.ContainerSlice) []string {
+	names := []string{}
+
+	for _, step := range steps {
+		names = append(names, step.Name)
+	}
+
+	return names
+}
+
+// Annotate sets the status and duration of the step
+// nodes in the graph from the steps of a build. The
+// status and duration of a stage node are derived
+// from the steps in the stage.
+func (g *Graph) Annotate(steps []*library.Step) {
+	nodes := make(map[string]*GraphNode)
+
+	for _, node := range g.Nodes {
+		nodes[node.ID] = node
+	}
+
+	for _, step := range steps {
+		node, ok := nodes[graphStepID(step.GetStage(), step.GetName())]
+		if !ok {
+			continue
+		}
+
+		node.Status = step.GetStatus()
+
+		if step.GetStarted() > 0 && step.GetFinished() >= step.GetStarted() {
+			node.Duration = step.GetFinished() - step.GetStarted()
+		}
+	}
+
+	for _, node := range g.Nodes {
+		if node.Type != GraphNodeStage {
+			continue
+		}
+
+		node.Status, node.Duration = g.stageStatus(node.Name)
+	}
+}
+
+// stageStatus is a helper function to derive the status and
+// duration of a stage from the step nodes in the stage. The
+// first status of a step that didn't succeed takes precedence.
+func (g *Graph) stageStatus(stage string) (string, int64) {
+	status := ""
+	duration := int64(0)
+
+	for _, node := range g.Nodes {
+		if node.Type != GraphNodeStep || node.Stage != stage {
+			continue
+		}
+
+		duration += node.Duration
+
+		if len(node.Status) == 0 {
+			continue
+		}
+
+		if len(status) == 0 || status == constants.StatusSuccess {
+			status = node.Status
+		}
+	}
+
+	return status, duration
+}
+
+// DOT returns the graph in the DOT language with
+// a cluster containing the steps of each stage.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph pipeline {\n")
+	b.WriteString("  compound=true;\n")
+
+	for _, node := range g.Nodes {
+		switch {
+		case node.Type == GraphNodeStage:
+			fmt.Fprintf(&b, "  subgraph %q {\n", "cluster_"+node.ID)
+			fmt.Fprintf(&b, "    label=%q;\n", node.label())
+
+			for _, step := range g.stageSteps(node.Name) {
+				fmt.Fprintf(&b, "    %q [label=%q];\n", step.ID, step.label())
+			}
+
+			b.WriteString("  }\n")
+		case len(node.Stage) == 0:
+			fmt.Fprintf(&b, "  %q [label=%q];\n", node.ID, node.label())
+		}
+	}
+
+	for _, edge := range g.Edges {
+		if edge.Type != GraphEdgeNeeds {
+			fmt.Fprintf(&b, "  %q -> %q;\n", edge.Source, edge.Target)
+
+			continue
+		}
+
+		// draw the edge between the clusters of the stages
+		source, target := g.stageSteps(edge.Source), g.stageSteps(edge.Target)
+		if len(source) == 0 || len(target) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %q -> %q [ltail=%q, lhead=%q];\n",
+			source[len(source)-1].ID,
+			target[0].ID,
+			"cluster_"+edge.Source,
+			"cluster_"+edge.Target,
+		)
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// Mermaid returns the graph as a Mermaid flowchart
+// with a subgraph containing the steps of each stage.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+
+	// mermaid identifiers can't contain special characters
+	ids := make(map[string]string)
+
+	for i, node := range g.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+	}
+
+	b.WriteString("flowchart TD\n")
+
+	for _, node := range g.Nodes {
+		switch {
+		case node.Type == GraphNodeStage:
+			fmt.Fprintf(&b, "  subgraph %s [\"%s\"]\n", ids[node.ID], node.mermaidLabel())
+
+			for _, step := range g.stageSteps(node.Name) {
+				fmt.Fprintf(&b, "    %s[\"%s\"]\n", ids[step.ID], step.mermaidLabel())
+			}
+
+			b.WriteString("  end\n")
+		case len(node.Stage) == 0:
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[node.ID], node.mermaidLabel())
+		}
+	}
+
+	for _, edge := range g.Edges {
+		source, ok := ids[edge.Source]
+		if !ok {
+			continue
+		}
+
+		target, ok := ids[edge.Target]
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %s --> %s\n", source, target)
+	}
+
+	return b.String()
+}
+
+// stageSteps is a helper function to capture
+// the step nodes of a stage in the graph.
+func (g *Graph) stageSteps(stage string) []*GraphNode {
+	steps := []*GraphNode{}
+
+	for _, node := range g.Nodes {
+		if node.Type == GraphNodeStep && node.Stage == stage {
+			steps = append(steps, node)
+		}
+	}
+
+	return steps
+}
+
+// label is a helper function to capture the label
+// of a node including the status and duration.
+func (n *GraphNode) label() string {
+	if len(n.Status) == 0 {
+		return n.Name
+	}
+
+	if n.Duration == 0 {
+		return fmt.Sprintf("%s\n%s", n.Name, n.Status)
+	}
+
+	return fmt.Sprintf("%s\n%s (%ds)", n.Name, n.Status, n.Duration)
+}
+
+// mermaidLabel is a helper function to capture the label of a
+// node with the line breaks and quotes supported by Mermaid.
+func (n *GraphNode) mermaidLabel() string {
+	label := strings.ReplaceAll(n.label(), "\n", "<br/>")
+
+	return strings.ReplaceAll(label, `"`, "#quot;")
+}
+
+// graphStepID is a helper function to
+// capture the node id of a step in a graph.
+func graphStepID(stage, step string) string {
+	if len(stage) == 0 {
+		return step
+	}
+
+	return stage + "/" + step
+}
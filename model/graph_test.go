@@ -0,0 +1,251 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/types/library"
+	"github.com/go-vela/types/pipeline"
+	"github.com/go-vela/types/yaml"
+)
+
+func TestModel_NewGraph_Stages(t *testing.T) {
+	// setup types
+	want := &Graph{
+		Nodes: []*GraphNode{
+			{ID: "test", Name: "test", Type: GraphNodeStage},
+			{ID: "test/install", Name: "install", Type: GraphNodeStep, Stage: "test"},
+			{ID: "test/test", Name: "test", Type: GraphNodeStep, Stage: "test"},
+			{ID: "build", Name: "build", Type: GraphNodeStage},
+			{ID: "build/build", Name: "build", Type: GraphNodeStep, Stage: "build"},
+		},
+		Edges: []*GraphEdge{
+			{Source: "test/install", Target: "test/test", Type: GraphEdgeStep},
+			{Source: "test", Target: "build", Type: GraphEdgeNeeds},
+		},
+	}
+
+	// run test
+	got := NewGraph(testGraphStagesPipeline())
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewGraph is %v, want %v", got, want)
+	}
+}
+
+func TestModel_NewGraph_Steps(t *testing.T) {
+	// setup types
+	want := &Graph{
+		Nodes: []*GraphNode{
+			{ID: "install", Name: "install", Type: GraphNodeStep},
+			{ID: "test", Name: "test", Type: GraphNodeStep},
+		},
+		Edges: []*GraphEdge{
+			{Source: "install", Target: "test", Type: GraphEdgeStep},
+		},
+	}
+
+	// run test
+	got := NewGraph(&yaml.Build{
+		Steps: yaml.StepSlice{
+			{Name: "install"},
+			{Name: "test"},
+		},
+	})
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewGraph is %v, want %v", got, want)
+	}
+}
+
+func TestModel_NewBuildGraph_Stages(t *testing.T) {
+	// setup types
+	want := &Graph{
+		Nodes: []*GraphNode{
+			{ID: "init", Name: "init", Type: GraphNodeStage},
+			{ID: "init/init", Name: "init", Type: GraphNodeStep, Stage: "init"},
+			{ID: "clone", Name: "clone", Type: GraphNodeStage},
+			{ID: "clone/clone", Name: "clone", Type: GraphNodeStep, Stage: "clone"},
+			{ID: "test", Name: "test", Type: GraphNodeStage},
+			{ID: "test/install", Name: "install", Type: GraphNodeStep, Stage: "test"},
+			{ID: "test/test", Name: "test", Type: GraphNodeStep, Stage: "test"},
+		},
+		Edges: []*GraphEdge{
+			{Source: "test/install", Target: "test/test", Type: GraphEdgeStep},
+			{Source: "clone", Target: "test", Type: GraphEdgeNeeds},
+		},
+	}
+
+	// run test
+	got := NewBuildGraph(&pipeline.Build{
+		Stages: pipeline.StageSlice{
+			{Name: "init", Steps: pipeline.ContainerSlice{{ID: "step_github_octocat_1_init_init", Name: "init"}}},
+			{Name: "clone", Steps: pipeline.ContainerSlice{{ID: "step_github_octocat_1_clone_clone", Name: "clone"}}},
+			{
+				Name:  "test",
+				Needs: []string{"clone"},
+				Steps: pipeline.ContainerSlice{
+					{ID: "step_github_octocat_1_test_install", Name: "install"},
+					{ID: "step_github_octocat_1_test_test", Name: "test"},
+				},
+			},
+		},
+	})
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewBuildGraph is %v, want %v", got, want)
+	}
+}
+
+func TestModel_NewBuildGraph_Steps(t *testing.T) {
+	// setup types
+	want := &Graph{
+		Nodes: []*GraphNode{
+			{ID: "init", Name: "init", Type: GraphNodeStep},
+			{ID: "clone", Name: "clone", Type: GraphNodeStep},
+			{ID: "test", Name: "test", Type: GraphNodeStep},
+		},
+		Edges: []*GraphEdge{
+			{Source: "init", Target: "clone", Type: GraphEdgeStep},
+			{Source: "clone", Target: "test", Type: GraphEdgeStep},
+		},
+	}
+
+	// run test
+	got := NewBuildGraph(&pipeline.Build{
+		Steps: pipeline.ContainerSlice{
+			{ID: "step_github_octocat_1_init", Name: "init"},
+			{ID: "step_github_octocat_1_clone", Name: "clone"},
+			{ID: "step_github_octocat_1_test", Name: "test"},
+		},
+	})
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewBuildGraph is %v, want %v", got, want)
+	}
+}
+
+func TestModel_Graph_Annotate(t *testing.T) {
+	// setup types
+	install := new(library.Step)
+	install.SetName("install")
+	install.SetStage("test")
+	install.SetStatus("success")
+	install.SetStarted(1563474077)
+	install.SetFinished(1563474087)
+
+	test := new(library.Step)
+	test.SetName("test")
+	test.SetStage("test")
+	test.SetStatus("failure")
+	test.SetStarted(1563474087)
+	test.SetFinished(1563474092)
+
+	clone := new(library.Step)
+	clone.SetName("clone")
+	clone.SetStage("init")
+	clone.SetStatus("success")
+
+	g := NewGraph(testGraphStagesPipeline())
+
+	// run test
+	g.Annotate([]*library.Step{install, test, clone})
+
+	want := map[string]*GraphNode{
+		"test":         {ID: "test", Name: "test", Type: GraphNodeStage, Status: "failure", Duration: 15},
+		"test/install": {ID: "test/install", Name: "install", Type: GraphNodeStep, Stage: "test", Status: "success", Duration: 10},
+		"test/test":    {ID: "test/test", Name: "test", Type: GraphNodeStep, Stage: "test", Status: "failure", Duration: 5},
+		"build":        {ID: "build", Name: "build", Type: GraphNodeStage},
+		"build/build":  {ID: "build/build", Name: "build", Type: GraphNodeStep, Stage: "build"},
+	}
+
+	for _, node := range g.Nodes {
+		if !reflect.DeepEqual(node, want[node.ID]) {
+			t.Errorf("Annotate node %s is %v, want %v", node.ID, node, want[node.ID])
+		}
+	}
+}
+
+func TestModel_Graph_DOT(t *testing.T) {
+	// setup types
+	want := `digraph pipeline {
+  compound=true;
+  subgraph "cluster_test" {
+    label="test";
+    "test/install" [label="install"];
+    "test/test" [label="test"];
+  }
+  subgraph "cluster_build" {
+    label="build";
+    "build/build" [label="build"];
+  }
+  "test/install" -> "test/test";
+  "test/test" -> "build/build" [ltail="cluster_test", lhead="cluster_build"];
+}
+`
+
+	// run test
+	got := NewGraph(testGraphStagesPipeline()).DOT()
+
+	if got != want {
+		t.Errorf("DOT is %s, want %s", got, want)
+	}
+}
+
+func TestModel_Graph_Mermaid(t *testing.T) {
+	// setup types
+	want := `flowchart TD
+  subgraph n0 ["test"]
+    n1["install"]
+    n2["test"]
+  end
+  subgraph n3 ["build<br/>running"]
+    n4["build<br/>running"]
+  end
+  n1 --> n2
+  n0 --> n3
+`
+
+	step := new(library.Step)
+	step.SetName("build")
+	step.SetStage("build")
+	step.SetStatus("running")
+	step.SetStarted(1563474077)
+
+	g := NewGraph(testGraphStagesPipeline())
+	g.Annotate([]*library.Step{step})
+
+	// run test
+	got := g.Mermaid()
+
+	if got != want {
+		t.Errorf("Mermaid is %s, want %s", got, want)
+	}
+}
+
+// testGraphStagesPipeline is a test helper function to
+// create a stages pipeline with a dependency between stages.
+func testGraphStagesPipeline() *yaml.Build {
+	return &yaml.Build{
+		Stages: yaml.StageSlice{
+			{
+				Name: "test",
+				Steps: yaml.StepSlice{
+					{Name: "install"},
+					{Name: "test"},
+				},
+			},
+			{
+				Name:  "build",
+				Needs: []string{"test"},
+				Steps: yaml.StepSlice{
+					{Name: "build"},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// TablePolicy defines the table name for policies.
+	TablePolicy = "policies"
+
+	// TableBuildPolicy defines the table name for build policy results.
+	TableBuildPolicy = "build_policies"
+)
+
+const (
+	// PolicyDeny defines the action for a policy
+	// that fails the compilation of the pipeline.
+	PolicyDeny = "deny"
+
+	// PolicyWarn defines the action for a policy
+	// that records a warning for the pipeline.
+	PolicyWarn = "warn"
+
+	// PolicyMutate defines the action for a policy
+	// that modifies the matching steps in the pipeline.
+	PolicyMutate = "mutate"
+)
+
+const (
+	// PolicyScopeInstance defines the scope for a
+	// policy that applies to every repo.
+	PolicyScopeInstance = "instance"
+
+	// PolicyScopeOrg defines the scope for a policy
+	// that applies to every repo in an org.
+	PolicyScopeOrg = "org"
+
+	// PolicyScopeRepo defines the scope for a
+	// policy that applies to a single repo.
+	PolicyScopeRepo = "repo"
+)
+
+var (
+	// ErrEmptyPolicyName defines the error type when a
+	// Policy type has an empty Name field provided.
+	ErrEmptyPolicyName = errors.New("empty policy name provided")
+
+	// ErrInvalidPolicyAction defines the error type when a
+	// Policy type has an invalid Action field provided.
+	ErrInvalidPolicyAction = errors.New("invalid policy action provided")
+
+	// ErrInvalidPolicyScope defines the error type when a
+	// Policy type has a Repo field provided without an Org.
+	ErrInvalidPolicyScope = errors.New("policy repo provided without an org")
+
+	// ErrEmptyPolicyMutation defines the error type when a
+	// mutate Policy type has an empty mutation provided.
+	ErrEmptyPolicyMutation = errors.New("empty policy mutation provided")
+
+	// ErrRequiredPolicyMutation defines the error type when a
+	// mutate Policy type is marked as required.
+	ErrRequiredPolicyMutation = errors.New("required policy can not mutate steps")
+
+	// ErrEmptyPolicyResultBuildID defines the error type when a
+	// PolicyResult type has an empty BuildID field provided.
+	ErrEmptyPolicyResultBuildID = errors.New("empty policy result build_id provided")
+)
+
+// Policy is a rule evaluated against the steps of a
+// pipeline while it is compiled. A policy with an empty
+// Org applies to the instance and a policy with an empty
+// Repo applies to every repo in the Org.
+//
+// swagger:model Policy
+type Policy struct {
+	ID      int64      `json:"id"`
+	Org     string     `json:"org"`
+	Repo    string     `json:"repo"`
+	Name    string     `json:"name"`
+	Action  string     `json:"action"`
+	Message string     `json:"message"`
+	Rule    PolicyRule `json:"rule"`
+	Active  bool       `json:"active"`
+}
+
+// PolicyRule is the condition a step has to
+// match for a policy to apply to the step.
+type PolicyRule struct {
+	// Images matches steps with an image matching one of the patterns.
+	Images []string `json:"images,omitempty"`
+	// ExceptImages matches steps with an image not matching any of the patterns.
+	ExceptImages []string `json:"except_images,omitempty"`
+	// Names matches steps with a name matching one of the patterns.
+	Names []string `json:"names,omitempty"`
+	// Privileged matches steps with the same privileged setting.
+	Privileged *bool `json:"privileged,omitempty"`
+	// Required reports the policy when no step matches the rule
+	// instead of reporting every step that matches the rule.
+	Required bool `json:"required,omitempty"`
+	// Mutation is applied to every matching step for a mutate policy.
+	Mutation *PolicyMutation `json:"mutation,omitempty"`
+}
+
+// PolicyMutation is the set of changes a mutate
+// policy applies to the steps matching the rule.
+type PolicyMutation struct {
+	Environment map[string]string `json:"environment,omitempty"`
+	Privileged  *bool             `json:"privileged,omitempty"`
+	Pull        string            `json:"pull,omitempty"`
+}
+
+// PolicyResult is the record of a policy that
+// applied to the pipeline compiled for a build.
+//
+// swagger:model PolicyResult
+type PolicyResult struct {
+	ID       int64  `json:"id"        yaml:"id"`
+	BuildID  int64  `json:"build_id"  yaml:"build_id"`
+	PolicyID int64  `json:"policy_id" yaml:"policy_id"`
+	Policy   string `json:"policy"    yaml:"policy"`
+	Action   string `json:"action"    yaml:"action"`
+	Step     string `json:"step"      yaml:"step"`
+	Message  string `json:"message"   yaml:"message"`
+}
+
+// Scope returns the scope the policy applies to.
+func (p *Policy) Scope() string {
+	switch {
+	case len(p.Repo) > 0:
+		return PolicyScopeRepo
+	case len(p.Org) > 0:
+		return PolicyScopeOrg
+	default:
+		return PolicyScopeInstance
+	}
+}
+
+// Validate verifies the necessary fields for
+// the Policy type are populated correctly.
+func (p *Policy) Validate() error {
+	// verify the Name field is populated
+	if len(p.Name) == 0 {
+		return ErrEmptyPolicyName
+	}
+
+	// verify the Repo field is only populated with an Org
+	if len(p.Repo) > 0 && len(p.Org) == 0 {
+		return ErrInvalidPolicyScope
+	}
+
+	switch p.Action {
+	case PolicyDeny, PolicyWarn:
+	case PolicyMutate:
+		// verify the mutation is populated
+		if p.Rule.Mutation == nil {
+			return ErrEmptyPolicyMutation
+		}
+
+		// verify the policy has steps to mutate
+		if p.Rule.Required {
+			return ErrRequiredPolicyMutation
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidPolicyAction, p.Action)
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the Policy type.
+func (p *Policy) String() string {
+	return fmt.Sprintf(`{
+  Action: %s,
+  Active: %t,
+  ID: %d,
+  Message: %s,
+  Name: %s,
+  Org: %s,
+  Repo: %s,
+  Rule: %+v,
+}`,
+		p.Action,
+		p.Active,
+		p.ID,
+		p.Message,
+		p.Name,
+		p.Org,
+		p.Repo,
+		p.Rule,
+	)
+}
+
+// Value implements the driver.Valuer interface to
+// store the PolicyRule type as JSON in the database.
+func (r PolicyRule) Value() (driver.Value, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan implements the sql.Scanner interface to
+// read the PolicyRule type as JSON from the database.
+func (r *PolicyRule) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*r = PolicyRule{}
+
+		return nil
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return fmt.Errorf("unable to scan policy rule from %T", value)
+	}
+}
+
+// Validate verifies the necessary fields for
+// the PolicyResult type are populated correctly.
+func (r *PolicyResult) Validate() error {
+	// verify the BuildID field is populated
+	if r.BuildID <= 0 {
+		return ErrEmptyPolicyResultBuildID
+	}
+
+	// verify the Policy field is populated
+	if len(r.Policy) == 0 {
+		return ErrEmptyPolicyName
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the PolicyResult type.
+func (r *PolicyResult) String() string {
+	return fmt.Sprintf(`{
+  Action: %s,
+  BuildID: %d,
+  ID: %d,
+  Message: %s,
+  Policy: %s,
+  PolicyID: %d,
+  Step: %s,
+}`,
+		r.Action,
+		r.BuildID,
+		r.ID,
+		r.Message,
+		r.Policy,
+		r.PolicyID,
+		r.Step,
+	)
+}
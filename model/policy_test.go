@@ -0,0 +1,225 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModel_Policy_Scope(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		policy *Policy
+		want   string
+	}{
+		{
+			policy: &Policy{},
+			want:   PolicyScopeInstance,
+		},
+		{
+			policy: &Policy{Org: "github"},
+			want:   PolicyScopeOrg,
+		},
+		{
+			policy: &Policy{Org: "github", Repo: "octocat"},
+			want:   PolicyScopeRepo,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := test.policy.Scope()
+
+		if got != test.want {
+			t.Errorf("Scope is %s, want %s", got, test.want)
+		}
+	}
+}
+
+func TestModel_Policy_Validate(t *testing.T) {
+	// setup types
+	_mutate := testPolicy()
+	_mutate.Action = PolicyMutate
+	_mutate.Rule = PolicyRule{
+		Images:   []string{"alpine*"},
+		Mutation: &PolicyMutation{Pull: "always"},
+	}
+
+	_required := testPolicy()
+	_required.Action = PolicyMutate
+	_required.Rule = PolicyRule{
+		Images:   []string{"alpine*"},
+		Required: true,
+		Mutation: &PolicyMutation{Pull: "always"},
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		policy  *Policy
+	}{
+		{
+			failure: false,
+			policy:  testPolicy(),
+		},
+		{
+			failure: false,
+			policy:  _mutate,
+		},
+		{ // no name set for policy
+			failure: true,
+			policy:  &Policy{Action: PolicyDeny},
+		},
+		{ // invalid action set for policy
+			failure: true,
+			policy:  &Policy{Name: "sample", Action: "foo"},
+		},
+		{ // repo set without org for policy
+			failure: true,
+			policy:  &Policy{Name: "sample", Action: PolicyDeny, Repo: "octocat"},
+		},
+		{ // no mutation set for mutate policy
+			failure: true,
+			policy:  &Policy{Name: "sample", Action: PolicyMutate},
+		},
+		{ // required set for mutate policy
+			failure: true,
+			policy:  _required,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.policy.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+func TestModel_PolicyRule_Scan(t *testing.T) {
+	// setup types
+	_rule := testPolicy().Rule
+
+	value, err := _rule.Value()
+	if err != nil {
+		t.Errorf("Value returned err: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		value   interface{}
+		want    PolicyRule
+	}{
+		{
+			failure: false,
+			value:   value,
+			want:    _rule,
+		},
+		{
+			failure: false,
+			value:   []byte(value.(string)),
+			want:    _rule,
+		},
+		{
+			failure: false,
+			value:   nil,
+			want:    PolicyRule{},
+		},
+		{
+			failure: true,
+			value:   1,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := PolicyRule{}
+
+		err := got.Scan(test.value)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Scan should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Scan returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Scan is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestModel_PolicyResult_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		result  *PolicyResult
+	}{
+		{
+			failure: false,
+			result:  &PolicyResult{BuildID: 1, Policy: "sample"},
+		},
+		{ // no build_id set for result
+			failure: true,
+			result:  &PolicyResult{Policy: "sample"},
+		},
+		{ // no policy set for result
+			failure: true,
+			result:  &PolicyResult{BuildID: 1},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.result.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+// testPolicy is a test helper function to create a Policy
+// type with all fields set to a fake value.
+func testPolicy() *Policy {
+	return &Policy{
+		ID:      1,
+		Org:     "github",
+		Repo:    "octocat",
+		Name:    "sample",
+		Action:  PolicyDeny,
+		Message: "privileged steps are not allowed",
+		Rule: PolicyRule{
+			Privileged: func(b bool) *bool { return &b }(true),
+		},
+		Active: true,
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"github.com/go-vela/types/pipeline"
+)
+
+const (
+	// PreviewNoRuleset defines the reason for a step
+	// without a ruleset that is always included.
+	PreviewNoRuleset = "no ruleset provided"
+
+	// PreviewIfMatched defines the reason for a step
+	// included because the if rules matched.
+	PreviewIfMatched = "if rules matched"
+
+	// PreviewIfNotMatched defines the reason for a step
+	// skipped because the if rules didn't match.
+	PreviewIfNotMatched = "if rules did not match"
+
+	// PreviewUnlessMatched defines the reason for a step
+	// skipped because the unless rules matched.
+	PreviewUnlessMatched = "unless rules matched"
+
+	// PreviewUnlessNotMatched defines the reason for a step
+	// included because the unless rules didn't match.
+	PreviewUnlessNotMatched = "unless rules did not match"
+)
+
+// PreviewData is the rule data along with the action of the
+// event and the pull request to evaluate the rulesets of a
+// pipeline against, including the pull request rules.
+//
+// swagger:model PreviewData
+type PreviewData struct {
+	pipeline.RuleData `yaml:",inline"`
+
+	Action            string `json:"action,omitempty"             yaml:"action,omitempty"`
+	Labels            Labels `json:"labels,omitempty"             yaml:"labels,omitempty"`
+	Draft             bool   `json:"draft,omitempty"              yaml:"draft,omitempty"`
+	AuthorAssociation string `json:"author_association,omitempty" yaml:"author_association,omitempty"`
+}
+
+// PullRequest returns the pull request of the build
+// the rulesets are evaluated against for the data.
+func (d *PreviewData) PullRequest() *BuildPullRequest {
+	return &BuildPullRequest{
+		Labels:            d.Labels,
+		Draft:             d.Draft,
+		AuthorAssociation: d.AuthorAssociation,
+	}
+}
+
+// RulesetPreview is the result of evaluating the rulesets
+// of a pipeline against hypothetical rule data to show
+// which stages and steps would run for a build.
+//
+// swagger:model RulesetPreview
+type RulesetPreview struct {
+	Data   *pipeline.RuleData `json:"data"`
+	Stages []*StagePreview    `json:"stages,omitempty"`
+	Steps  []*StepPreview     `json:"steps,omitempty"`
+}
+
+// StagePreview is the result of evaluating the rulesets
+// of the steps in a stage. A stage is only included when
+// at least one of the steps in the stage is included.
+type StagePreview struct {
+	Name     string         `json:"name"`
+	Included bool           `json:"included"`
+	Steps    []*StepPreview `json:"steps"`
+}
+
+// StepPreview is the result of evaluating the ruleset of a
+// step with the reason the step was included or skipped and
+// the rules from the ruleset that matched the rule data.
+type StepPreview struct {
+	Name     string   `json:"name"`
+	Included bool     `json:"included"`
+	Reason   string   `json:"reason"`
+	If       []string `json:"if,omitempty"`
+	Unless   []string `json:"unless,omitempty"`
+}
+
+// NewRulesetPreview returns the preview of the stages and steps
+// from a pipeline that would run for the rule data. The purged
+// pipeline is the result of transforming the pipeline with the
+// rule data, so the preview matches the steps of a real build.
+func NewRulesetPreview(p, purged *pipeline.Build, r *pipeline.RuleData) *RulesetPreview {
+	preview := &RulesetPreview{Data: r}
+
+	// capture the steps remaining after the pipeline was purged
+	included := make(map[string]bool)
+
+	for _, stage := range purged.Stages {
+		for _, step := range stage.Steps {
+			included[stage.Name+"/"+step.Name] = true
+		}
+	}
+
+	for _, step := range purged.Steps {
+		included[step.Name] = true
+	}
+
+	for _, stage := range p.Stages {
+		s := &StagePreview{Name: stage.Name}
+
+		for _, step := range stage.Steps {
+			result := newStepPreview(step, r, included[stage.Name+"/"+step.Name])
+
+			s.Included = s.Included || result.Included
+			s.Steps = append(s.Steps, result)
+		}
+
+		preview.Stages = append(preview.Stages, s)
+	}
+
+	for _, step := range p.Steps {
+		preview.Steps = append(preview.Steps, newStepPreview(step, r, included[step.Name]))
+	}
+
+	return preview
+}
+
+// newStepPreview is a helper function to capture the
+// reason a step was included or skipped along with the
+// rules from the ruleset of the step that matched.
+func newStepPreview(c *pipeline.Container, r *pipeline.RuleData, included bool) *StepPreview {
+	ruleset := c.Ruleset
+
+	s := &StepPreview{
+		Name:     c.Name,
+		Included: included,
+		If:       matchedRules(&ruleset.If, r, ruleset.Matcher),
+		Unless:   matchedRules(&ruleset.Unless, r, ruleset.Matcher),
+	}
+
+	// check if the unless rules match the rule data
+	unless := !ruleset.Unless.Empty() && ruleset.Unless.Match(r, ruleset.Matcher, ruleset.Operator)
+
+	switch {
+	case ruleset.If.Empty() && ruleset.Unless.Empty():
+		s.Reason = PreviewNoRuleset
+	case !included && unless:
+		s.Reason = PreviewUnlessMatched
+	case !included:
+		s.Reason = PreviewIfNotMatched
+	case ruleset.If.Empty():
+		s.Reason = PreviewUnlessNotMatched
+	default:
+		s.Reason = PreviewIfMatched
+	}
+
+	return s
+}
+
+// matchedRules is a helper function to capture the
+// names of the rules that match the rule data.
+func matchedRules(rules *pipeline.Rules, r *pipeline.RuleData, matcher string) []string {
+	matched := []string{}
+
+	if rules.Branch.MatchOr(r.Branch, matcher) {
+		matched = append(matched, "branch")
+	}
+
+	if rules.Comment.MatchOr(r.Comment, matcher) {
+		matched = append(matched, "comment")
+	}
+
+	if rules.Event.MatchOr(r.Event, matcher) {
+		matched = append(matched, "event")
+	}
+
+	for _, p := range r.Path {
+		if rules.Path.MatchOr(p, matcher) {
+			matched = append(matched, "path")
+
+			break
+		}
+	}
+
+	if rules.Repo.MatchOr(r.Repo, matcher) {
+		matched = append(matched, "repo")
+	}
+
+	if rules.Status.MatchOr(r.Status, matcher) {
+		matched = append(matched, "status")
+	}
+
+	if rules.Tag.MatchOr(r.Tag, matcher) {
+		matched = append(matched, "tag")
+	}
+
+	if rules.Target.MatchOr(r.Target, matcher) {
+		matched = append(matched, "target")
+	}
+
+	if len(matched) == 0 {
+		return nil
+	}
+
+	return matched
+}
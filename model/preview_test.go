@@ -0,0 +1,163 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/types/pipeline"
+)
+
+func TestModel_NewRulesetPreview_Steps(t *testing.T) {
+	// setup types
+	r := &pipeline.RuleData{
+		Branch: "main",
+		Event:  "push",
+		Path:   []string{"docs/README.md"},
+		Repo:   "github/octocat",
+	}
+
+	p := &pipeline.Build{
+		Steps: pipeline.ContainerSlice{
+			{Name: "test"},
+			{
+				Name: "publish",
+				Ruleset: pipeline.Ruleset{
+					If:       pipeline.Rules{Branch: []string{"main"}, Event: []string{"push"}},
+					Operator: "and",
+				},
+			},
+			{
+				Name: "deploy",
+				Ruleset: pipeline.Ruleset{
+					If:       pipeline.Rules{Event: []string{"deployment"}},
+					Operator: "and",
+				},
+			},
+			{
+				Name: "build",
+				Ruleset: pipeline.Ruleset{
+					Unless:   pipeline.Rules{Path: []string{"docs/*"}},
+					Operator: "and",
+				},
+			},
+			{
+				Name: "lint",
+				Ruleset: pipeline.Ruleset{
+					Unless:   pipeline.Rules{Branch: []string{"release/*"}},
+					Operator: "and",
+				},
+			},
+		},
+	}
+
+	purged := &pipeline.Build{Steps: *p.Steps.Purge(r)}
+
+	want := []*StepPreview{
+		{Name: "test", Included: true, Reason: PreviewNoRuleset},
+		{Name: "publish", Included: true, Reason: PreviewIfMatched, If: []string{"branch", "event"}},
+		{Name: "deploy", Included: false, Reason: PreviewIfNotMatched},
+		{Name: "build", Included: false, Reason: PreviewUnlessMatched, Unless: []string{"path"}},
+		{Name: "lint", Included: true, Reason: PreviewUnlessNotMatched},
+	}
+
+	// run test
+	got := NewRulesetPreview(p, purged, r)
+
+	if !reflect.DeepEqual(got.Steps, want) {
+		for i := range want {
+			t.Errorf("NewRulesetPreview step %d is %v, want %v", i, got.Steps[i], want[i])
+		}
+	}
+}
+
+func TestModel_NewRulesetPreview_Stages(t *testing.T) {
+	// setup types
+	r := &pipeline.RuleData{
+		Branch: "main",
+		Event:  "pull_request",
+		Repo:   "github/octocat",
+	}
+
+	p := &pipeline.Build{
+		Stages: pipeline.StageSlice{
+			{
+				Name: "test",
+				Steps: pipeline.ContainerSlice{
+					{Name: "test"},
+				},
+			},
+			{
+				Name: "deploy",
+				Steps: pipeline.ContainerSlice{
+					{
+						Name: "deploy",
+						Ruleset: pipeline.Ruleset{
+							If:       pipeline.Rules{Event: []string{"push"}},
+							Operator: "and",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	purged := &pipeline.Build{Stages: *p.Stages.Purge(r)}
+
+	want := []*StagePreview{
+		{
+			Name:     "test",
+			Included: true,
+			Steps: []*StepPreview{
+				{Name: "test", Included: true, Reason: PreviewNoRuleset},
+			},
+		},
+		{
+			Name:     "deploy",
+			Included: false,
+			Steps: []*StepPreview{
+				{Name: "deploy", Included: false, Reason: PreviewIfNotMatched},
+			},
+		},
+	}
+
+	// run test
+	got := NewRulesetPreview(p, purged, r)
+
+	if !reflect.DeepEqual(got.Stages, want) {
+		t.Errorf("NewRulesetPreview stages is %v, want %v", got.Stages, want)
+	}
+}
+
+func TestModel_PreviewData_PullRequest(t *testing.T) {
+	// setup types
+	body := `{"branch":"main","event":"pull_request","action":"labeled","labels":["e2e"],"draft":true,"author_association":"MEMBER"}`
+
+	want := &BuildPullRequest{
+		Labels:            Labels{"e2e"},
+		Draft:             true,
+		AuthorAssociation: "MEMBER",
+	}
+
+	// run test
+	d := new(PreviewData)
+
+	err := json.Unmarshal([]byte(body), d)
+	if err != nil {
+		t.Errorf("Unmarshal returned err: %v", err)
+	}
+
+	if d.Branch != "main" || d.Event != "pull_request" || d.Action != "labeled" {
+		t.Errorf("Unmarshal is %v, want branch main and event pull_request with action labeled", d)
+	}
+
+	got := d.PullRequest()
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PullRequest is %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-vela/types"
+)
+
+// TableBuildPullRequest defines the table name for build pull requests.
+const TableBuildPullRequest = "build_pull_requests"
+
+// ErrEmptyBuildPullRequestBuildID defines the error type when a
+// BuildPullRequest type has an empty BuildID field provided.
+var ErrEmptyBuildPullRequestBuildID = errors.New("empty build pull request build_id provided")
+
+// Webhook is the result of processing the webhook from a repo
+// with the data of the pull request that isn't part of the
+// webhook type. The PullRequest is only set for the events
+// of a pull request.
+type Webhook struct {
+	*types.Webhook
+
+	PullRequest *BuildPullRequest
+}
+
+// BuildPullRequest is the record of the pull request for a build
+// matched by the pull request rules in the rulesets of a pipeline.
+// Fork is set when the head of the pull request is in another repo
+// and Action is the action of the event the build was created for,
+// so a restarted build is compiled with the same action.
+//
+// swagger:model BuildPullRequest
+type BuildPullRequest struct {
+	ID                int64  `json:"id"`
+	BuildID           int64  `json:"build_id"`
+	Labels            Labels `json:"labels"`
+	Draft             bool   `json:"draft"`
+	AuthorAssociation string `json:"author_association"`
+	Fork              bool   `json:"fork"`
+	Action            string `json:"action"`
+}
+
+// Labels is the list of labels for a pull request.
+type Labels []string
+
+// FirstTime returns true when the author of the pull
+// request hasn't contributed to the repo before.
+func (p *BuildPullRequest) FirstTime() bool {
+	switch strings.ToUpper(p.AuthorAssociation) {
+	case "FIRST_TIME_CONTRIBUTOR", "FIRST_TIMER", "NONE":
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate verifies the necessary fields for
+// the BuildPullRequest type are populated correctly.
+func (p *BuildPullRequest) Validate() error {
+	// verify the BuildID field is populated
+	if p.BuildID <= 0 {
+		return ErrEmptyBuildPullRequestBuildID
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the BuildPullRequest type.
+func (p *BuildPullRequest) String() string {
+	return fmt.Sprintf(`{
+  Action: %s,
+  AuthorAssociation: %s,
+  BuildID: %d,
+  Draft: %t,
+  Fork: %t,
+  ID: %d,
+  Labels: %v,
+}`,
+		p.Action,
+		p.AuthorAssociation,
+		p.BuildID,
+		p.Draft,
+		p.Fork,
+		p.ID,
+		p.Labels,
+	)
+}
+
+// Value implements the driver.Valuer interface to
+// store the Labels type as JSON in the database.
+func (l Labels) Value() (driver.Value, error) {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan implements the sql.Scanner interface to
+// read the Labels type as JSON from the database.
+func (l *Labels) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*l = Labels{}
+
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("unable to scan labels from %T", value)
+	}
+}
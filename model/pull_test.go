@@ -0,0 +1,104 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModel_BuildPullRequest_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		pull    *BuildPullRequest
+	}{
+		{
+			failure: false,
+			pull:    &BuildPullRequest{BuildID: 1, Labels: Labels{"e2e"}},
+		},
+		{ // no build id set for pull request
+			failure: true,
+			pull:    &BuildPullRequest{Labels: Labels{"e2e"}},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.pull.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+func TestModel_Labels_Scan(t *testing.T) {
+	// setup types
+	want := Labels{"e2e", "bug"}
+
+	value, err := want.Value()
+	if err != nil {
+		t.Errorf("Value returned err: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		value   interface{}
+		want    Labels
+	}{
+		{
+			failure: false,
+			value:   value,
+			want:    want,
+		},
+		{
+			failure: false,
+			value:   []byte(value.(string)),
+			want:    want,
+		},
+		{
+			failure: false,
+			value:   nil,
+			want:    Labels{},
+		},
+		{
+			failure: true,
+			value:   1,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := Labels{}
+
+		err := got.Scan(test.value)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Scan should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Scan returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Scan is %v, want %v", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// TableRequiredPipeline defines the table name for required pipelines.
+	TableRequiredPipeline = "required_pipelines"
+
+	// TableBuildInjectedStep defines the table name for build injected steps.
+	TableBuildInjectedStep = "build_injected_steps"
+)
+
+const (
+	// RequiredPipelineBefore defines the position for a required
+	// pipeline that runs before the steps of the repo pipeline.
+	RequiredPipelineBefore = "before"
+
+	// RequiredPipelineAfter defines the position for a required
+	// pipeline that runs after the steps of the repo pipeline.
+	RequiredPipelineAfter = "after"
+)
+
+var (
+	// ErrEmptyRequiredPipelineOrg defines the error type when a
+	// RequiredPipeline type has an empty Org field provided.
+	ErrEmptyRequiredPipelineOrg = errors.New("empty required pipeline org provided")
+
+	// ErrEmptyRequiredPipelineName defines the error type when a
+	// RequiredPipeline type has an empty Name field provided.
+	ErrEmptyRequiredPipelineName = errors.New("empty required pipeline name provided")
+
+	// ErrEmptyRequiredPipeline defines the error type when a
+	// RequiredPipeline type has an empty Pipeline field provided.
+	ErrEmptyRequiredPipeline = errors.New("empty required pipeline provided")
+
+	// ErrInvalidRequiredPipelinePosition defines the error type when a
+	// RequiredPipeline type has an invalid Position field provided.
+	ErrInvalidRequiredPipelinePosition = errors.New("invalid required pipeline position provided")
+
+	// ErrEmptyInjectedStepBuildID defines the error type when a
+	// InjectedStep type has an empty BuildID field provided.
+	ErrEmptyInjectedStepBuildID = errors.New("empty injected step build_id provided")
+
+	// ErrEmptyInjectedStepName defines the error type when a
+	// InjectedStep type has an empty Step field provided.
+	ErrEmptyInjectedStepName = errors.New("empty injected step name provided")
+)
+
+// RequiredPipeline is a set of steps or stages, defined
+// for an org, that is injected into the pipeline for
+// every build of the repos in the org.
+//
+// swagger:model RequiredPipeline
+type RequiredPipeline struct {
+	ID       int64  `json:"id"`
+	Org      string `json:"org"`
+	Name     string `json:"name"`
+	Position string `json:"position"`
+	Pipeline string `json:"pipeline"`
+	Active   bool   `json:"active"`
+}
+
+// InjectedStep is the record of a step injected
+// from a required pipeline into the pipeline
+// compiled for a build.
+//
+// swagger:model InjectedStep
+type InjectedStep struct {
+	ID       int64  `json:"id"`
+	BuildID  int64  `json:"build_id"`
+	Pipeline string `json:"pipeline"`
+	Stage    string `json:"stage"`
+	Step     string `json:"step"`
+}
+
+// Validate verifies the necessary fields for
+// the RequiredPipeline type are populated correctly.
+func (p *RequiredPipeline) Validate() error {
+	// verify the Org field is populated
+	if len(p.Org) == 0 {
+		return ErrEmptyRequiredPipelineOrg
+	}
+
+	// verify the Name field is populated
+	if len(p.Name) == 0 {
+		return ErrEmptyRequiredPipelineName
+	}
+
+	// verify the Pipeline field is populated
+	if len(p.Pipeline) == 0 {
+		return ErrEmptyRequiredPipeline
+	}
+
+	// verify the Position field is valid
+	switch p.Position {
+	case RequiredPipelineBefore, RequiredPipelineAfter:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidRequiredPipelinePosition, p.Position)
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the RequiredPipeline type.
+func (p *RequiredPipeline) String() string {
+	return fmt.Sprintf(`{
+  Active: %t,
+  ID: %d,
+  Name: %s,
+  Org: %s,
+  Pipeline: %s,
+  Position: %s,
+}`,
+		p.Active,
+		p.ID,
+		p.Name,
+		p.Org,
+		p.Pipeline,
+		p.Position,
+	)
+}
+
+// Validate verifies the necessary fields for
+// the InjectedStep type are populated correctly.
+func (s *InjectedStep) Validate() error {
+	// verify the BuildID field is populated
+	if s.BuildID <= 0 {
+		return ErrEmptyInjectedStepBuildID
+	}
+
+	// verify the Step field is populated
+	if len(s.Step) == 0 {
+		return ErrEmptyInjectedStepName
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the InjectedStep type.
+func (s *InjectedStep) String() string {
+	return fmt.Sprintf(`{
+  BuildID: %d,
+  ID: %d,
+  Pipeline: %s,
+  Stage: %s,
+  Step: %s,
+}`,
+		s.BuildID,
+		s.ID,
+		s.Pipeline,
+		s.Stage,
+		s.Step,
+	)
+}
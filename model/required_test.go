@@ -0,0 +1,111 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"testing"
+)
+
+func TestModel_RequiredPipeline_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *RequiredPipeline
+	}{
+		{
+			failure:  false,
+			pipeline: testRequiredPipeline(),
+		},
+		{ // no org set for required pipeline
+			failure:  true,
+			pipeline: &RequiredPipeline{Name: "scan", Position: RequiredPipelineBefore, Pipeline: "steps: []"},
+		},
+		{ // no name set for required pipeline
+			failure:  true,
+			pipeline: &RequiredPipeline{Org: "github", Position: RequiredPipelineBefore, Pipeline: "steps: []"},
+		},
+		{ // no pipeline set for required pipeline
+			failure:  true,
+			pipeline: &RequiredPipeline{Org: "github", Name: "scan", Position: RequiredPipelineBefore},
+		},
+		{ // invalid position set for required pipeline
+			failure:  true,
+			pipeline: &RequiredPipeline{Org: "github", Name: "scan", Position: "middle", Pipeline: "steps: []"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.pipeline.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+func TestModel_InjectedStep_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		step    *InjectedStep
+	}{
+		{
+			failure: false,
+			step:    &InjectedStep{BuildID: 1, Pipeline: "scan", Step: "secrets"},
+		},
+		{ // no build_id set for injected step
+			failure: true,
+			step:    &InjectedStep{Pipeline: "scan", Step: "secrets"},
+		},
+		{ // no step set for injected step
+			failure: true,
+			step:    &InjectedStep{BuildID: 1, Pipeline: "scan"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.step.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+// testRequiredPipeline is a test helper function to create a
+// RequiredPipeline type with all fields set to a fake value.
+func testRequiredPipeline() *RequiredPipeline {
+	return &RequiredPipeline{
+		ID:       1,
+		Org:      "github",
+		Name:     "scan",
+		Position: RequiredPipelineBefore,
+		Pipeline: `steps:
+  - name: secrets
+    image: target/vela-secret-scan:latest
+    commands:
+      - scan
+`,
+		Active: true,
+	}
+}
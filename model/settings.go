@@ -0,0 +1,373 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	// TablePipelineSettings defines the table name for pipeline settings.
+	TablePipelineSettings = "pipeline_settings"
+
+	// TableBuildPipeline defines the table name for build pipelines.
+	TableBuildPipeline = "build_pipelines"
+)
+
+var (
+	// ErrEmptyPipelineSettingsRepoID defines the error type when a
+	// PipelineSettings type has an empty RepoID field provided.
+	ErrEmptyPipelineSettingsRepoID = errors.New("empty pipeline settings repo_id provided")
+
+	// ErrEmptyRepoPipelineName defines the error type when a
+	// RepoPipeline type has an empty Name field provided.
+	ErrEmptyRepoPipelineName = errors.New("empty repo pipeline name provided")
+
+	// ErrEmptyRepoPipelinePath defines the error type when a
+	// RepoPipeline type has an empty Path field provided.
+	ErrEmptyRepoPipelinePath = errors.New("empty repo pipeline path provided")
+
+	// ErrDuplicateRepoPipelineName defines the error type when more
+	// than one RepoPipeline type has the same Name field provided.
+	ErrDuplicateRepoPipelineName = errors.New("duplicate repo pipeline name provided")
+
+	// ErrInvalidRepoPipelineRule defines the error type when a
+	// RepoPipeline type has an invalid path rule provided.
+	ErrInvalidRepoPipelineRule = errors.New("invalid repo pipeline path rule provided")
+
+	// ErrEmptyBuildPipelineBuildID defines the error type when a
+	// BuildPipeline type has an empty BuildID field provided.
+	ErrEmptyBuildPipelineBuildID = errors.New("empty build pipeline build_id provided")
+)
+
+// PipelineSettings is the configuration for where the
+// pipelines of a repo are found. An empty Path uses the
+// default pipeline configuration files and an empty list
+// of Pipelines creates a single build for every event.
+// StageStatus publishes a commit status for every stage
+// of the builds for the repo. The Allow fields enable the
+// events that have no allow field on the repo. The Approve
+// fields require approval for the builds of pull requests
+// from forks or first-time contributors. PullComment
+// creates or updates a comment with the summary of the
+// builds on the pull request.
+//
+// swagger:model PipelineSettings
+type PipelineSettings struct {
+	ID                int64         `json:"id"`
+	RepoID            int64         `json:"repo_id"`
+	Path              string        `json:"path"`
+	Pipelines         RepoPipelines `json:"pipelines"`
+	StageStatus       bool          `json:"stage_status"`
+	AllowRelease      bool          `json:"allow_release"`
+	AllowDelete       bool          `json:"allow_delete"`
+	AllowPullClosed   bool          `json:"allow_pull_closed"`
+	AllowPullLabeled  bool          `json:"allow_pull_labeled"`
+	AllowPullReopened bool          `json:"allow_pull_reopened"`
+	ApproveFork       bool          `json:"approve_fork"`
+	ApproveFirstTime  bool          `json:"approve_first_time"`
+	PullComment       bool          `json:"pull_comment"`
+}
+
+// RepoPipeline is a named pipeline of a repo that
+// creates a build when the changed files of the
+// event match one of the path rules.
+type RepoPipeline struct {
+	// Name is the name of the pipeline used in the commit status context.
+	Name string `json:"name"`
+	// Path is the path to the pipeline configuration file in the repo.
+	Path string `json:"path"`
+	// Rules is the list of patterns matching the changed files for the pipeline.
+	// The patterns are matched per directory of the path so "*" doesn't match
+	// a "/" while "**" matches any number of directories, i.e. "api/**/*.go"
+	// matches "api/main.go" and "api/server/main.go". A pattern ending with
+	// "/" matches every file in the directory and its subdirectories.
+	// An empty list matches every event for the repo.
+	Rules []string `json:"rules,omitempty"`
+}
+
+// RepoPipelines is the list of named pipelines for a repo.
+type RepoPipelines []*RepoPipeline
+
+// BuildPipeline is the record of the pipeline
+// of a repo that was compiled for a build.
+//
+// swagger:model BuildPipeline
+type BuildPipeline struct {
+	ID      int64  `json:"id"`
+	BuildID int64  `json:"build_id"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+}
+
+// Match returns the pipelines matching the changed files.
+// Every pipeline matches when no changed files are provided.
+func (s *PipelineSettings) Match(files []string) RepoPipelines {
+	matched := RepoPipelines{}
+
+	for _, p := range s.Pipelines {
+		if len(files) == 0 || p.Match(files) {
+			matched = append(matched, p)
+		}
+	}
+
+	return matched
+}
+
+// AllowEvent returns true when the settings enable the event.
+// Events without an allow field in the settings are
+// enabled by the allow fields on the repo instead.
+func (s *PipelineSettings) AllowEvent(event string) bool {
+	switch event {
+	case EventRelease:
+		return s.AllowRelease
+	case EventDelete:
+		return s.AllowDelete
+	case EventPullClosed:
+		return s.AllowPullClosed
+	case EventPullLabeled:
+		return s.AllowPullLabeled
+	case EventPullReopened:
+		return s.AllowPullReopened
+	default:
+		return true
+	}
+}
+
+// ApprovalReason returns the reason the build for the pull
+// request requires approval before it runs. An empty reason
+// is returned when the build doesn't require approval.
+func (s *PipelineSettings) ApprovalReason(p *BuildPullRequest) string {
+	if s == nil || p == nil {
+		return ""
+	}
+
+	switch {
+	case s.ApproveFork && p.Fork:
+		return "pull request is from a fork"
+	case s.ApproveFirstTime && p.FirstTime():
+		return "pull request is from a first-time contributor"
+	default:
+		return ""
+	}
+}
+
+// Validate verifies the necessary fields for
+// the PipelineSettings type are populated correctly.
+func (s *PipelineSettings) Validate() error {
+	// verify the RepoID field is populated
+	if s.RepoID <= 0 {
+		return ErrEmptyPipelineSettingsRepoID
+	}
+
+	names := make(map[string]bool)
+
+	for _, p := range s.Pipelines {
+		err := p.Validate()
+		if err != nil {
+			return err
+		}
+
+		// verify the Name field is unique
+		if names[p.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateRepoPipelineName, p.Name)
+		}
+
+		names[p.Name] = true
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the PipelineSettings type.
+func (s *PipelineSettings) String() string {
+	return fmt.Sprintf(`{
+  AllowDelete: %t,
+  AllowPullClosed: %t,
+  AllowPullLabeled: %t,
+  AllowPullReopened: %t,
+  AllowRelease: %t,
+  ApproveFirstTime: %t,
+  ApproveFork: %t,
+  ID: %d,
+  Path: %s,
+  Pipelines: %v,
+  PullComment: %t,
+  RepoID: %d,
+  StageStatus: %t,
+}`,
+		s.AllowDelete,
+		s.AllowPullClosed,
+		s.AllowPullLabeled,
+		s.AllowPullReopened,
+		s.AllowRelease,
+		s.ApproveFirstTime,
+		s.ApproveFork,
+		s.ID,
+		s.Path,
+		s.Pipelines,
+		s.PullComment,
+		s.RepoID,
+		s.StageStatus,
+	)
+}
+
+// Match returns true when one of the changed files
+// matches a path rule for the pipeline.
+func (p *RepoPipeline) Match(files []string) bool {
+	if len(p.Rules) == 0 {
+		return true
+	}
+
+	for _, rule := range p.Rules {
+		for _, file := range files {
+			if matchRule(rule, file) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchRule is a helper function to match a changed file with
+// a path rule. The directories of the rule are matched with the
+// directories of the file so "*" only matches within a directory
+// while "**" matches any number of directories.
+func matchRule(rule, file string) bool {
+	// a rule for a directory matches every file in it
+	if strings.HasSuffix(rule, "/") {
+		rule += "**"
+	}
+
+	return matchSegments(strings.Split(rule, "/"), strings.Split(file, "/"))
+}
+
+// matchSegments is a helper function to match the
+// directories of a file with the directories of a rule.
+func matchSegments(rules, files []string) bool {
+	for len(rules) > 0 {
+		if rules[0] == "**" {
+			// skip repeated "**" directories in the rule
+			for len(rules) > 0 && rules[0] == "**" {
+				rules = rules[1:]
+			}
+
+			// match the remaining rule after any number of directories
+			for i := 0; i <= len(files); i++ {
+				if matchSegments(rules, files[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(files) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(rules[0], files[0]); !ok {
+			return false
+		}
+
+		rules, files = rules[1:], files[1:]
+	}
+
+	return len(files) == 0
+}
+
+// Validate verifies the necessary fields for
+// the RepoPipeline type are populated correctly.
+func (p *RepoPipeline) Validate() error {
+	// verify the Name field is populated
+	if len(p.Name) == 0 {
+		return ErrEmptyRepoPipelineName
+	}
+
+	// verify the Path field is populated
+	if len(p.Path) == 0 {
+		return fmt.Errorf("%w: %s", ErrEmptyRepoPipelinePath, p.Name)
+	}
+
+	// verify the Rules field is valid
+	for _, rule := range p.Rules {
+		_, err := path.Match(rule, "")
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidRepoPipelineRule, rule)
+		}
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the RepoPipeline type.
+func (p *RepoPipeline) String() string {
+	return fmt.Sprintf("{Name: %s, Path: %s, Rules: %v}", p.Name, p.Path, p.Rules)
+}
+
+// Value implements the driver.Valuer interface to
+// store the RepoPipelines type as JSON in the database.
+func (p RepoPipelines) Value() (driver.Value, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan implements the sql.Scanner interface to
+// read the RepoPipelines type as JSON from the database.
+func (p *RepoPipelines) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*p = RepoPipelines{}
+
+		return nil
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("unable to scan repo pipelines from %T", value)
+	}
+}
+
+// Validate verifies the necessary fields for
+// the BuildPipeline type are populated correctly.
+func (p *BuildPipeline) Validate() error {
+	// verify the BuildID field is populated
+	if p.BuildID <= 0 {
+		return ErrEmptyBuildPipelineBuildID
+	}
+
+	// verify the Name field is populated
+	if len(p.Name) == 0 {
+		return ErrEmptyRepoPipelineName
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the BuildPipeline type.
+func (p *BuildPipeline) String() string {
+	return fmt.Sprintf(`{
+  BuildID: %d,
+  ID: %d,
+  Name: %s,
+  Path: %s,
+}`,
+		p.BuildID,
+		p.ID,
+		p.Name,
+		p.Path,
+	)
+}
@@ -0,0 +1,375 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModel_PipelineSettings_Match(t *testing.T) {
+	// setup types
+	s := testPipelineSettings()
+
+	// setup tests
+	tests := []struct {
+		files []string
+		want  []string
+	}{
+		{ // no changed files matches every pipeline
+			files: []string{},
+			want:  []string{"api", "web", "docs", "all"},
+		},
+		{ // changed file matches one pipeline
+			files: []string{"api/main.go"},
+			want:  []string{"api", "all"},
+		},
+		{ // changed files match multiple pipelines
+			files: []string{"api/main.go", "web/index.html"},
+			want:  []string{"api", "web", "all"},
+		},
+		{ // nested changed file does not match single level pattern
+			files: []string{"api/server/main.go"},
+			want:  []string{"all"},
+		},
+		{ // nested changed file matches directory pattern
+			files: []string{"docs/api/index.md"},
+			want:  []string{"docs", "all"},
+		},
+		{ // nested changed file matches recursive pattern
+			files: []string{"web/src/app/main.ts"},
+			want:  []string{"web", "all"},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := []string{}
+
+		for _, p := range s.Match(test.files) {
+			got = append(got, p.Name)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Match for %v is %v, want %v", test.files, got, test.want)
+		}
+	}
+}
+
+func TestModel_RepoPipeline_Match(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		rule string
+		file string
+		want bool
+	}{
+		{"api/*", "api/main.go", true},
+		{"api/*", "api/server/main.go", false},
+		{"*.go", "main.go", true},
+		{"*.go", "api/main.go", false},
+		{"api/**", "api/main.go", true},
+		{"api/**", "api/server/main.go", true},
+		{"api/**", "web/main.go", false},
+		{"api/**/*.go", "api/main.go", true},
+		{"api/**/*.go", "api/server/http/main.go", true},
+		{"api/**/*.go", "api/server/README.md", false},
+		{"**/*.md", "README.md", true},
+		{"**/*.md", "docs/api/index.md", true},
+		{"api/", "api/main.go", true},
+		{"api/", "api/server/main.go", true},
+		{"api/", "apis/main.go", false},
+		{"api/**/server/*", "api/v1/server/main.go", true},
+		{"api/**/server/*", "api/v1/client/main.go", false},
+	}
+
+	// run tests
+	for _, test := range tests {
+		p := &RepoPipeline{Name: "api", Path: "api/.vela.yml", Rules: []string{test.rule}}
+
+		if got := p.Match([]string{test.file}); got != test.want {
+			t.Errorf("Match for rule %s and file %s is %v, want %v", test.rule, test.file, got, test.want)
+		}
+	}
+}
+
+func TestModel_PipelineSettings_AllowEvent(t *testing.T) {
+	// setup types
+	s := &PipelineSettings{
+		RepoID:          1,
+		AllowRelease:    true,
+		AllowPullClosed: true,
+	}
+
+	// setup tests
+	tests := []struct {
+		event string
+		want  bool
+	}{
+		{event: EventRelease, want: true},
+		{event: EventDelete, want: false},
+		{event: EventPullClosed, want: true},
+		{event: EventPullLabeled, want: false},
+		{event: EventPullReopened, want: false},
+		{ // events without an allow field are enabled by the repo
+			event: "push",
+			want:  true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := s.AllowEvent(test.event)
+
+		if got != test.want {
+			t.Errorf("AllowEvent for %s is %v, want %v", test.event, got, test.want)
+		}
+	}
+}
+
+func TestModel_PipelineSettings_ApprovalReason(t *testing.T) {
+	// setup types
+	s := &PipelineSettings{
+		RepoID:           1,
+		ApproveFork:      true,
+		ApproveFirstTime: true,
+	}
+
+	// setup tests
+	tests := []struct {
+		name     string
+		settings *PipelineSettings
+		pull     *BuildPullRequest
+		want     string
+	}{
+		{
+			name:     "fork",
+			settings: s,
+			pull:     &BuildPullRequest{Fork: true, AuthorAssociation: "MEMBER"},
+			want:     "pull request is from a fork",
+		},
+		{
+			name:     "first-time contributor",
+			settings: s,
+			pull:     &BuildPullRequest{AuthorAssociation: "FIRST_TIME_CONTRIBUTOR"},
+			want:     "pull request is from a first-time contributor",
+		},
+		{
+			name:     "trusted author",
+			settings: s,
+			pull:     &BuildPullRequest{AuthorAssociation: "COLLABORATOR"},
+			want:     "",
+		},
+		{
+			name:     "approval disabled",
+			settings: &PipelineSettings{RepoID: 1},
+			pull:     &BuildPullRequest{Fork: true, AuthorAssociation: "NONE"},
+			want:     "",
+		},
+		{
+			name:     "no pull request",
+			settings: s,
+			want:     "",
+		},
+		{
+			name: "no settings",
+			pull: &BuildPullRequest{Fork: true},
+			want: "",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.settings.ApprovalReason(test.pull)
+
+			if got != test.want {
+				t.Errorf("ApprovalReason is %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestModel_PipelineSettings_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure  bool
+		settings *PipelineSettings
+	}{
+		{
+			failure:  false,
+			settings: testPipelineSettings(),
+		},
+		{ // only a custom path set for pipeline settings
+			failure:  false,
+			settings: &PipelineSettings{RepoID: 1, Path: "ci/vela.yml"},
+		},
+		{ // no repo_id set for pipeline settings
+			failure:  true,
+			settings: &PipelineSettings{Path: "ci/vela.yml"},
+		},
+		{ // no name set for pipeline
+			failure: true,
+			settings: &PipelineSettings{
+				RepoID:    1,
+				Pipelines: RepoPipelines{{Path: "api/.vela.yml"}},
+			},
+		},
+		{ // no path set for pipeline
+			failure: true,
+			settings: &PipelineSettings{
+				RepoID:    1,
+				Pipelines: RepoPipelines{{Name: "api"}},
+			},
+		},
+		{ // duplicate name set for pipelines
+			failure: true,
+			settings: &PipelineSettings{
+				RepoID: 1,
+				Pipelines: RepoPipelines{
+					{Name: "api", Path: "api/.vela.yml"},
+					{Name: "api", Path: "web/.vela.yml"},
+				},
+			},
+		},
+		{ // invalid rule set for pipeline
+			failure: true,
+			settings: &PipelineSettings{
+				RepoID:    1,
+				Pipelines: RepoPipelines{{Name: "api", Path: "api/.vela.yml", Rules: []string{"api/["}}},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.settings.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+func TestModel_RepoPipelines_Scan(t *testing.T) {
+	// setup types
+	want := testPipelineSettings().Pipelines
+
+	value, err := want.Value()
+	if err != nil {
+		t.Errorf("Value returned err: %v", err)
+	}
+
+	// setup tests
+	tests := []struct {
+		failure bool
+		value   interface{}
+		want    RepoPipelines
+	}{
+		{
+			failure: false,
+			value:   value,
+			want:    want,
+		},
+		{
+			failure: false,
+			value:   []byte(value.(string)),
+			want:    want,
+		},
+		{
+			failure: false,
+			value:   nil,
+			want:    RepoPipelines{},
+		},
+		{
+			failure: true,
+			value:   1,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got := RepoPipelines{}
+
+		err := got.Scan(test.value)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Scan should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Scan returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Scan is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestModel_BuildPipeline_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure  bool
+		pipeline *BuildPipeline
+	}{
+		{
+			failure:  false,
+			pipeline: &BuildPipeline{BuildID: 1, Name: "api", Path: "api/.vela.yml"},
+		},
+		{ // no build_id set for build pipeline
+			failure:  true,
+			pipeline: &BuildPipeline{Name: "api"},
+		},
+		{ // no name set for build pipeline
+			failure:  true,
+			pipeline: &BuildPipeline{BuildID: 1},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.pipeline.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+// testPipelineSettings is a test helper function to create a
+// PipelineSettings type with all fields set to a fake value.
+func testPipelineSettings() *PipelineSettings {
+	return &PipelineSettings{
+		ID:     1,
+		RepoID: 1,
+		Path:   "ci/vela.yml",
+		Pipelines: RepoPipelines{
+			{Name: "api", Path: "api/.vela.yml", Rules: []string{"api/*"}},
+			{Name: "web", Path: "web/.vela.yml", Rules: []string{"web/*", "*.html", "web/**/*.ts"}},
+			{Name: "docs", Path: "docs/.vela.yml", Rules: []string{"docs/"}},
+			{Name: "all", Path: ".vela.yml"},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TableBuildTemplate defines the table name for build templates.
+const TableBuildTemplate = "build_templates"
+
+// ErrEmptyTemplateBuildID defines the error type when a
+// BuildTemplate type has an empty BuildID field provided.
+var ErrEmptyTemplateBuildID = errors.New("empty template build_id provided")
+
+// ErrEmptyTemplateName defines the error type when a
+// BuildTemplate type has an empty Name field provided.
+var ErrEmptyTemplateName = errors.New("empty template name provided")
+
+// BuildTemplate is the record of a template that was
+// used to compile the pipeline for a build.
+//
+// swagger:model BuildTemplate
+type BuildTemplate struct {
+	ID      int64  `json:"id"`
+	BuildID int64  `json:"build_id"`
+	Name    string `json:"name"`
+	Source  string `json:"source"`
+	Type    string `json:"type"`
+	Ref     string `json:"ref"`
+	Commit  string `json:"commit"`
+	Digest  string `json:"digest"`
+}
+
+// Validate verifies the necessary fields for
+// the BuildTemplate type are populated correctly.
+func (t *BuildTemplate) Validate() error {
+	// verify the BuildID field is populated
+	if t.BuildID <= 0 {
+		return ErrEmptyTemplateBuildID
+	}
+
+	// verify the Name field is populated
+	if len(t.Name) == 0 {
+		return ErrEmptyTemplateName
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface for the BuildTemplate type.
+func (t *BuildTemplate) String() string {
+	return fmt.Sprintf(`{
+  BuildID: %d,
+  Commit: %s,
+  Digest: %s,
+  ID: %d,
+  Name: %s,
+  Ref: %s,
+  Source: %s,
+  Type: %s,
+}`,
+		t.BuildID,
+		t.Commit,
+		t.Digest,
+		t.ID,
+		t.Name,
+		t.Ref,
+		t.Source,
+		t.Type,
+	)
+}
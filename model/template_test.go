@@ -0,0 +1,62 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package model
+
+import (
+	"testing"
+)
+
+func TestModel_BuildTemplate_Validate(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure  bool
+		template *BuildTemplate
+	}{
+		{
+			failure:  false,
+			template: testBuildTemplate(),
+		},
+		{ // no build_id set for template
+			failure:  true,
+			template: &BuildTemplate{Name: "sample"},
+		},
+		{ // no name set for template
+			failure:  true,
+			template: &BuildTemplate{BuildID: 1},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		err := test.template.Validate()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Validate should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Validate returned err: %v", err)
+		}
+	}
+}
+
+// testBuildTemplate is a test helper function to create a BuildTemplate
+// type with all fields set to a fake value.
+func testBuildTemplate() *BuildTemplate {
+	return &BuildTemplate{
+		ID:      1,
+		BuildID: 1,
+		Name:    "sample",
+		Source:  "github.com/github/octocat/template.yml@7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		Type:    "github",
+		Ref:     "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		Commit:  "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		Digest:  "sha256:6e3f2c6e4b1f0c7ed0b0b26d5a0f1f2dfb3cbd2a0ef4b6d33c4d62ba1c1e0a59",
+	}
+}
@@ -20,6 +20,11 @@ import (
 // PUT    /api/v1/admin/deployment
 // GET    /api/v1/admin/hooks
 // PUT    /api/v1/admin/hook
+// GET    /api/v1/admin/policies
+// POST   /api/v1/admin/policies
+// GET    /api/v1/admin/policies/:policy
+// PUT    /api/v1/admin/policies/:policy
+// DELETE /api/v1/admin/policies/:policy
 // GET    /api/v1/admin/repos
 // PUT    /api/v1/admin/repo
 // GET    /api/v1/admin/secrets
@@ -47,6 +52,13 @@ func AdminHandlers(base *gin.RouterGroup) {
 		_admin.GET("/hooks", admin.AllHooks)
 		_admin.PUT("/hook", admin.UpdateHook)
 
+		// Admin policy endpoints
+		_admin.GET("/policies", admin.AllPolicies)
+		_admin.POST("/policies", admin.CreatePolicy)
+		_admin.GET("/policies/:policy", admin.GetPolicy)
+		_admin.PUT("/policies/:policy", admin.UpdatePolicy)
+		_admin.DELETE("/policies/:policy", admin.DeletePolicy)
+
 		// Admin repo endpoints
 		_admin.GET("/repos", admin.AllRepos)
 		_admin.PUT("/repo", admin.UpdateRepo)
@@ -22,8 +22,13 @@ import (
 // GET    /api/v1/repos/:org/:repo/builds/:build
 // PUT    /api/v1/repos/:org/:repo/builds/:build
 // DELETE /api/v1/repos/:org/:repo/builds/:build
+// POST   /api/v1/repos/:org/:repo/builds/:build/approve
 // DELETE /api/v1/repos/:org/:repo/builds/:build/cancel
+// GET    /api/v1/repos/:org/:repo/builds/:build/graph
+// GET    /api/v1/repos/:org/:repo/builds/:build/injected
 // GET    /api/v1/repos/:org/:repo/builds/:build/logs
+// GET    /api/v1/repos/:org/:repo/builds/:build/policies
+// GET    /api/v1/repos/:org/:repo/builds/:build/templates
 // POST   /api/v1/repos/:org/:repo/builds/:build/services
 // GET    /api/v1/repos/:org/:repo/builds/:build/services
 // GET    /api/v1/repos/:org/:repo/builds/:build/services/:service
@@ -56,8 +61,13 @@ func BuildHandlers(base *gin.RouterGroup) {
 			build.GET("", perm.MustRead(), api.GetBuild)
 			build.PUT("", perm.MustWrite(), middleware.Payload(), api.UpdateBuild)
 			build.DELETE("", perm.MustPlatformAdmin(), api.DeleteBuild)
+			build.POST("/approve", perm.MustWrite(), api.ApproveBuild)
 			build.DELETE("/cancel", executors.Establish(), perm.MustWrite(), api.CancelBuild)
+			build.GET("/graph", perm.MustRead(), api.GetBuildGraph)
+			build.GET("/injected", perm.MustRead(), api.GetBuildInjectedSteps)
 			build.GET("/logs", perm.MustRead(), api.GetBuildLogs)
+			build.GET("/policies", perm.MustRead(), api.GetBuildPolicyResults)
+			build.GET("/templates", perm.MustRead(), api.GetBuildTemplates)
 
 			// Service endpoints
 			// * Log endpoints
@@ -0,0 +1,30 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/api"
+	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/perm"
+)
+
+// DefaultPipelineHandlers is a function that extends the provided base
+// router group with the API handlers for default pipeline functionality.
+//
+// GET    /api/v1/default-pipelines/:org
+// POST   /api/v1/default-pipelines/:org
+// PUT    /api/v1/default-pipelines/:org
+// DELETE /api/v1/default-pipelines/:org .
+func DefaultPipelineHandlers(base *gin.RouterGroup) {
+	// Default pipelines endpoints
+	defaults := base.Group("/default-pipelines/:org", org.Establish(), perm.MustOrgAdmin())
+	{
+		defaults.GET("", api.GetDefaultPipeline)
+		defaults.POST("", api.CreateDefaultPipeline)
+		defaults.PUT("", api.UpdateDefaultPipeline)
+		defaults.DELETE("", api.DeleteDefaultPipeline)
+	} // end of default pipelines endpoints
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/cache"
+)
+
+// Cache is a middleware function that initializes the cache and
+// attaches to the context of every http.Request.
+func Cache(s cache.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cache.WithGinContext(c, s)
+		c.Next()
+	}
+}
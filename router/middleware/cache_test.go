@@ -0,0 +1,50 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/cache"
+	"github.com/go-vela/server/cache/redis"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware_Cache(t *testing.T) {
+	// setup types
+	var got cache.Service
+
+	want, _ := redis.NewTest()
+
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	context, engine := gin.CreateTestContext(resp)
+	context.Request, _ = http.NewRequest(http.MethodGet, "/health", nil)
+
+	// setup mock server
+	engine.Use(Cache(want))
+	engine.GET("/health", func(c *gin.Context) {
+		got = cache.FromGinContext(c)
+
+		c.Status(http.StatusOK)
+	})
+
+	// run test
+	engine.ServeHTTP(context.Writer, context.Request)
+
+	if resp.Code != http.StatusOK {
+		t.Errorf("Cache returned %v, want %v", resp.Code, http.StatusOK)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Cache is %v, want %v", got, want)
+	}
+}
@@ -5,6 +5,8 @@
 package executors
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -32,3 +34,68 @@ func TestExecutors_Retrieve(t *testing.T) {
 		t.Errorf("Retrieve is %v, want %v", got, want)
 	}
 }
+
+func TestExecutors_Capture(t *testing.T) {
+	// setup types
+	eID := int64(1)
+	want := []library.Executor{{ID: &eID}}
+
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	// setup mock worker
+	engine.GET("/api/v1/executors", func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "Bearer superSecret" {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		c.JSON(http.StatusOK, want)
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	context, _ := gin.CreateTestContext(httptest.NewRecorder())
+	context.Set("secret", "superSecret")
+
+	w := new(library.Worker)
+	w.SetAddress(s.URL)
+
+	// run test
+	got, err := Capture(context, w)
+	if err != nil {
+		t.Errorf("Capture returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Capture is %v, want %v", got, want)
+	}
+}
+
+func TestExecutors_Capture_Unavailable(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	context, _ := gin.CreateTestContext(httptest.NewRecorder())
+	context.Set("secret", "superSecret")
+
+	// setup worker that no longer exists
+	s := httptest.NewServer(http.NotFoundHandler())
+	s.Close()
+
+	w := new(library.Worker)
+	w.SetAddress(s.URL)
+
+	// run test
+	got, err := Capture(context, w)
+	if err != nil {
+		t.Errorf("Capture returned err: %v", err)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("Capture is %v, want no executors", got)
+	}
+}
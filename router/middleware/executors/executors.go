@@ -29,7 +29,6 @@ func Retrieve(c *gin.Context) []library.Executor {
 // Establish sets the executors in the given context.
 func Establish() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		e := new([]library.Executor)
 		b := build.Retrieve(c)
 		// retrieve the worker
 		w, err := database.FromContext(c).GetWorker(b.GetHost())
@@ -39,47 +38,52 @@ func Establish() gin.HandlerFunc {
 			return
 		}
 
-		// prepare the request to the worker to retrieve executors
-		client := http.DefaultClient
-		client.Timeout = 30 * time.Second
-		endpoint := fmt.Sprintf("%s/api/v1/executors", w.GetAddress())
-		req, err := http.NewRequest("GET", endpoint, nil)
+		e, err := Capture(c, w)
 		if err != nil {
-			retErr := fmt.Errorf("unable to form request to %s: %w", endpoint, err)
-			util.HandleError(c, http.StatusBadRequest, retErr)
+			util.HandleError(c, http.StatusBadRequest, err)
 			return
 		}
 
-		// add the token to authenticate to the worker as a header
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.MustGet("secret").(string)))
+		ToContext(c, e)
+		c.Next()
+	}
+}
 
-		// make the request to the worker and check the response
-		resp, err := client.Do(req)
-		if err != nil || resp == nil {
-			// abandoned builds might have ran on a worker that no longer exists
-			// if the worker is unavailable write an empty slice ToContext
-			ToContext(c, *e)
-			c.Next()
-		}
-		defer resp.Body.Close()
+// Capture captures the executors from the worker. No executors
+// are returned when the worker is unavailable, since abandoned
+// builds might have ran on a worker that no longer exists.
+func Capture(c *gin.Context, w *library.Worker) ([]library.Executor, error) {
+	e := []library.Executor{}
 
-		// Read Response Body
-		respBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			retErr := fmt.Errorf("unable to read response from %s: %w", endpoint, err)
-			util.HandleError(c, http.StatusBadRequest, retErr)
-			return
-		}
+	// prepare the request to the worker to retrieve executors
+	client := &http.Client{Timeout: 30 * time.Second}
+	endpoint := fmt.Sprintf("%s/api/v1/executors", w.GetAddress())
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to form request to %s: %w", endpoint, err)
+	}
 
-		// parse response and validate at least one item was returned
-		err = json.Unmarshal(respBody, e)
-		if err != nil {
-			retErr := fmt.Errorf("unable to parse response from %s: %w", endpoint, err)
-			util.HandleError(c, http.StatusBadRequest, retErr)
-			return
-		}
+	// add the token to authenticate to the worker as a header
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.MustGet("secret").(string)))
 
-		ToContext(c, *e)
-		c.Next()
+	// make the request to the worker and check the response
+	resp, err := client.Do(req)
+	if err != nil {
+		return e, nil
+	}
+	defer resp.Body.Close()
+
+	// Read Response Body
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response from %s: %w", endpoint, err)
+	}
+
+	// parse response and validate at least one item was returned
+	err = json.Unmarshal(respBody, &e)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse response from %s: %w", endpoint, err)
 	}
+
+	return e, nil
 }
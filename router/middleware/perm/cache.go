@@ -0,0 +1,174 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package perm
+
+import (
+	"fmt"
+
+	"github.com/go-vela/server/cache"
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/scm"
+
+	"github.com/go-vela/types/library"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+// predefine Prometheus metrics else they will be regenerated
+// each function call which will throw error:
+// "duplicate metrics collector registration attempted".
+var cacheRequests = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "vela_permission_cache_requests_total",
+		Help: "The Vela permission cache requests collect the number of cache hits and misses for a resource type.",
+	},
+	[]string{"resource", "result"},
+)
+
+// orgAccess is a helper function to capture the permissions
+// of the user for the org from the cache or from the source.
+func orgAccess(c *gin.Context, logger *logrus.Entry, u *library.User, org string) string {
+	key := cache.OrgAccessKey(u.GetName(), org)
+
+	// check the cache for the permissions of the user for the org
+	perm, ok := cacheGet(c, logger, "org", key)
+	if ok {
+		return perm
+	}
+
+	// query source to determine requesters permissions for the org
+	perm, err := scm.FromContext(c).OrgAccess(u, org)
+	if err != nil {
+		logger.Errorf("unable to get user %s access level for org %s: %v", u.GetName(), org, err)
+
+		return ""
+	}
+
+	cacheSet(c, logger, key, perm)
+
+	return perm
+}
+
+// repoAccess is a helper function to capture the permissions
+// of the user for the repo from the cache or from the source.
+// The token of the repo owner is used for the source when the
+// token of the user is unable to capture the permissions.
+func repoAccess(c *gin.Context, logger *logrus.Entry, u *library.User, r *library.Repo) (string, error) {
+	perm, err := userRepoAccess(c, logger, u, r.GetOrg(), r.GetName())
+	if err == nil {
+		return perm, nil
+	}
+
+	// requester may not have permissions to use the Github API endpoint (requires read access)
+	// try again using the repo owner token
+	//
+	// https://docs.github.com/en/rest/reference/repos#get-repository-permissions-for-a-user
+	ro, err := database.FromContext(c).GetUser(r.GetUserID())
+	if err != nil {
+		return "", fmt.Errorf("unable to get owner for %s: %w", r.GetFullName(), err)
+	}
+
+	perm, err = scm.FromContext(c).RepoAccess(u, ro.GetToken(), r.GetOrg(), r.GetName())
+	if err != nil {
+		logger.Errorf("unable to get user %s access level for repo %s", u.GetName(), r.GetFullName())
+
+		return "", nil
+	}
+
+	cacheSet(c, logger, cache.RepoAccessKey(u.GetName(), r.GetOrg(), r.GetName()), perm)
+
+	return perm, nil
+}
+
+// userRepoAccess is a helper function to capture the permissions
+// of the user for the repo from the cache or from the source with
+// the token of the user.
+func userRepoAccess(c *gin.Context, logger *logrus.Entry, u *library.User, org, name string) (string, error) {
+	key := cache.RepoAccessKey(u.GetName(), org, name)
+
+	// check the cache for the permissions of the user for the repo
+	perm, ok := cacheGet(c, logger, "repo", key)
+	if ok {
+		return perm, nil
+	}
+
+	// query source to determine requesters permissions for the repo using the requester's token
+	perm, err := scm.FromContext(c).RepoAccess(u, u.GetToken(), org, name)
+	if err != nil {
+		return "", err
+	}
+
+	cacheSet(c, logger, key, perm)
+
+	return perm, nil
+}
+
+// teamAccess is a helper function to capture the permissions
+// of the user for the team from the cache or from the source.
+func teamAccess(c *gin.Context, logger *logrus.Entry, u *library.User, org, team string) string {
+	key := cache.TeamAccessKey(u.GetName(), org, team)
+
+	// check the cache for the permissions of the user for the team
+	perm, ok := cacheGet(c, logger, "team", key)
+	if ok {
+		return perm
+	}
+
+	// query source to determine requesters permissions for the team
+	perm, err := scm.FromContext(c).TeamAccess(u, org, team)
+	if err != nil {
+		logger.Errorf("unable to get user %s access level for team %s/%s: %v", u.GetName(), org, team, err)
+
+		return ""
+	}
+
+	cacheSet(c, logger, key, perm)
+
+	return perm
+}
+
+// cacheGet is a helper function to capture the permissions
+// for the key from the cache and to record the cache hit
+// or miss for the resource type.
+func cacheGet(c *gin.Context, logger *logrus.Entry, resource, key string) (string, bool) {
+	// the cache is optional for the permissions
+	s := cache.FromGinContext(c)
+	if s == nil {
+		return "", false
+	}
+
+	perm, ok, err := s.Get(c, key)
+	if err != nil {
+		logger.Errorf("unable to get key %s from the cache: %v", key, err)
+	}
+
+	if !ok {
+		cacheRequests.WithLabelValues(resource, "miss").Inc()
+
+		return "", false
+	}
+
+	cacheRequests.WithLabelValues(resource, "hit").Inc()
+
+	return perm, true
+}
+
+// cacheSet is a helper function to store
+// the permissions for the key in the cache.
+func cacheSet(c *gin.Context, logger *logrus.Entry, key, perm string) {
+	// the cache is optional for the permissions
+	s := cache.FromGinContext(c)
+	if s == nil {
+		return
+	}
+
+	err := s.Set(c, key, perm)
+	if err != nil {
+		logger.Errorf("unable to set key %s in the cache: %v", key, err)
+	}
+}
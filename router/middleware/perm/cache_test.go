@@ -0,0 +1,134 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package perm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/cache"
+	"github.com/go-vela/server/cache/memory"
+	"github.com/go-vela/server/database"
+	"github.com/go-vela/server/database/sqlite"
+	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/repo"
+	"github.com/go-vela/server/router/middleware/token"
+	"github.com/go-vela/server/router/middleware/user"
+	"github.com/go-vela/server/scm"
+	"github.com/go-vela/server/scm/github"
+	"github.com/go-vela/types/library"
+)
+
+func TestPerm_MustAdmin_Cache(t *testing.T) {
+	// setup types
+	secret := "superSecret"
+
+	r := new(library.Repo)
+	r.SetID(1)
+	r.SetUserID(1)
+	r.SetHash("baz")
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+	r.SetVisibility("public")
+
+	u := new(library.User)
+	u.SetID(1)
+	u.SetName("foo")
+	u.SetToken("bar")
+	u.SetHash("baz")
+	u.SetAdmin(false)
+
+	tok, _ := token.CreateAccessToken(u, accessTokenDuration)
+
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	// setup database
+	db, _ := sqlite.NewTest()
+
+	defer func() {
+		db.Sqlite.Exec("delete from repos;")
+		db.Sqlite.Exec("delete from users;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreateRepo(r)
+	_ = db.CreateUser(u)
+
+	// setup cache
+	_cache, _ := memory.New(memory.WithTTL(time.Minute))
+
+	lookups := 0
+
+	// setup github mock server
+	engine.GET("/api/v3/repos/:org/:repo/collaborators/:username/permission", func(c *gin.Context) {
+		lookups++
+
+		c.String(http.StatusOK, permAdminPayload)
+	})
+	engine.GET("/api/v3/user", func(c *gin.Context) {
+		c.String(http.StatusOK, userPayload)
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup client
+	client, _ := github.NewTest(s.URL)
+
+	// setup vela mock server
+	engine.Use(func(c *gin.Context) { c.Set("secret", secret) })
+	engine.Use(func(c *gin.Context) { database.ToContext(c, db) })
+	engine.Use(func(c *gin.Context) { scm.ToContext(c, client) })
+	engine.Use(func(c *gin.Context) { cache.WithGinContext(c, _cache) })
+	engine.Use(user.Establish())
+	engine.Use(org.Establish())
+	engine.Use(repo.Establish())
+	engine.Use(MustAdmin())
+	engine.GET("/test/:org/:repo", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// run tests
+	for i := 0; i < 2; i++ {
+		resp := httptest.NewRecorder()
+
+		req, _ := http.NewRequest(http.MethodGet, "/test/foo/bar", nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tok))
+
+		engine.ServeHTTP(resp, req)
+
+		if resp.Code != http.StatusOK {
+			t.Errorf("MustAdmin returned %v, want %v", resp.Code, http.StatusOK)
+		}
+	}
+
+	if lookups != 1 {
+		t.Errorf("MustAdmin looked up permissions %d times, want 1", lookups)
+	}
+
+	// invalidate the cached permissions for the repo
+	_ = _cache.DeletePrefix(context.Background(), cache.RepoAccessPrefix("foo", "bar"))
+
+	resp := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/test/foo/bar", nil)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tok))
+
+	engine.ServeHTTP(resp, req)
+
+	if lookups != 2 {
+		t.Errorf("MustAdmin looked up permissions %d times, want 2", lookups)
+	}
+}
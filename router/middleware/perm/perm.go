@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/go-vela/server/database"
 	"github.com/go-vela/server/router/middleware/org"
 	"github.com/go-vela/server/router/middleware/repo"
 	"github.com/go-vela/server/router/middleware/user"
@@ -94,10 +93,7 @@ func MustSecretAdmin() gin.HandlerFunc {
 		case constants.SecretOrg:
 			logger.Debugf("verifying user %s has 'admin' permissions for org %s", u.GetName(), o)
 
-			perm, err := scm.FromContext(c).OrgAccess(u, o)
-			if err != nil {
-				logger.Errorf("unable to get user %s access level for org %s: %v", u.GetName(), o, err)
-			}
+			perm := orgAccess(c, logger, u, o)
 
 			if !strings.EqualFold(perm, "admin") {
 				retErr := fmt.Errorf("user %s does not have 'admin' permissions for the org %s", u.GetName(), o)
@@ -109,7 +105,7 @@ func MustSecretAdmin() gin.HandlerFunc {
 		case constants.SecretRepo:
 			logger.Debugf("verifying user %s has 'admin' permissions for repo %s/%s", u.GetName(), o, n)
 
-			perm, err := scm.FromContext(c).RepoAccess(u, u.GetToken(), o, n)
+			perm, err := userRepoAccess(c, logger, u, o, n)
 			if err != nil {
 				logger.Errorf("unable to get user %s access level for repo %s/%s: %v", u.GetName(), o, n, err)
 			}
@@ -141,10 +137,7 @@ func MustSecretAdmin() gin.HandlerFunc {
 			} else {
 				logger.Debugf("verifying user %s has 'admin' permissions for team %s/%s", u.GetName(), o, n)
 
-				perm, err := scm.FromContext(c).TeamAccess(u, o, n)
-				if err != nil {
-					logger.Errorf("unable to get user %s access level for team %s/%s: %v", u.GetName(), o, n, err)
-				}
+				perm := teamAccess(c, logger, u, o, n)
 
 				if !strings.EqualFold(perm, "admin") {
 					// nolint: lll // ignore long line length due to error message
@@ -189,26 +182,12 @@ func MustAdmin() gin.HandlerFunc {
 			return
 		}
 
-		// query source to determine requesters permissions for the repo using the requester's token
-		perm, err := scm.FromContext(c).RepoAccess(u, u.GetToken(), r.GetOrg(), r.GetName())
+		// capture requesters permissions for the repo
+		perm, err := repoAccess(c, logger, u, r)
 		if err != nil {
-			// requester may not have permissions to use the Github API endpoint (requires read access)
-			// try again using the repo owner token
-			//
-			// https://docs.github.com/en/rest/reference/repos#get-repository-permissions-for-a-user
-			ro, err := database.FromContext(c).GetUser(r.GetUserID())
-			if err != nil {
-				retErr := fmt.Errorf("unable to get owner for %s: %w", r.GetFullName(), err)
-
-				util.HandleError(c, http.StatusBadRequest, retErr)
-
-				return
-			}
+			util.HandleError(c, http.StatusBadRequest, err)
 
-			perm, err = scm.FromContext(c).RepoAccess(u, ro.GetToken(), r.GetOrg(), r.GetName())
-			if err != nil {
-				logger.Errorf("unable to get user %s access level for repo %s", u.GetName(), r.GetFullName())
-			}
+			return
 		}
 
 		switch perm {
@@ -226,6 +205,39 @@ func MustAdmin() gin.HandlerFunc {
 	}
 }
 
+// MustOrgAdmin ensures the user has admin access to the org.
+func MustOrgAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		o := org.Retrieve(c)
+		u := user.Retrieve(c)
+
+		// update engine logger with API metadata
+		//
+		// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+		logger := logrus.WithFields(logrus.Fields{
+			"org":  o,
+			"user": u.GetName(),
+		})
+
+		logger.Debugf("verifying user %s has 'admin' permissions for org %s", u.GetName(), o)
+
+		if globalPerms(u) {
+			return
+		}
+
+		// capture requesters permissions for the org
+		perm := orgAccess(c, logger, u, o)
+
+		if !strings.EqualFold(perm, "admin") {
+			retErr := fmt.Errorf("user %s does not have 'admin' permissions for the org %s", u.GetName(), o)
+
+			util.HandleError(c, http.StatusUnauthorized, retErr)
+
+			return
+		}
+	}
+}
+
 // MustWrite ensures the user has admin or write access to the repo.
 func MustWrite() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -249,26 +261,12 @@ func MustWrite() gin.HandlerFunc {
 			return
 		}
 
-		// query source to determine requesters permissions for the repo using the requester's token
-		perm, err := scm.FromContext(c).RepoAccess(u, u.GetToken(), r.GetOrg(), r.GetName())
+		// capture requesters permissions for the repo
+		perm, err := repoAccess(c, logger, u, r)
 		if err != nil {
-			// requester may not have permissions to use the Github API endpoint (requires read access)
-			// try again using the repo owner token
-			//
-			// https://docs.github.com/en/rest/reference/repos#get-repository-permissions-for-a-user
-			ro, err := database.FromContext(c).GetUser(r.GetUserID())
-			if err != nil {
-				retErr := fmt.Errorf("unable to get owner for %s: %w", r.GetFullName(), err)
+			util.HandleError(c, http.StatusBadRequest, err)
 
-				util.HandleError(c, http.StatusBadRequest, retErr)
-
-				return
-			}
-
-			perm, err = scm.FromContext(c).RepoAccess(u, ro.GetToken(), r.GetOrg(), r.GetName())
-			if err != nil {
-				logger.Errorf("unable to get user %s access level for repo %s", u.GetName(), r.GetFullName())
-			}
+			return
 		}
 
 		switch perm {
@@ -287,6 +285,38 @@ func MustWrite() gin.HandlerFunc {
 	}
 }
 
+// AllowWrite returns true when the user has admin or write access
+// to the repo. Unlike MustWrite the request isn't rejected, so
+// handlers can limit parts of a response to users with write access.
+func AllowWrite(c *gin.Context) bool {
+	o := org.Retrieve(c)
+	r := repo.Retrieve(c)
+	u := user.Retrieve(c)
+
+	// update engine logger with API metadata
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#Entry.WithFields
+	logger := logrus.WithFields(logrus.Fields{
+		"org":  o,
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	})
+
+	if globalPerms(u) {
+		return true
+	}
+
+	// capture requesters permissions for the repo
+	perm, err := repoAccess(c, logger, u, r)
+	if err != nil {
+		logger.Errorf("unable to get user %s access level for repo %s: %v", u.GetName(), r.GetFullName(), err)
+
+		return false
+	}
+
+	return perm == "admin" || perm == "write"
+}
+
 // MustRead ensures the user has admin, write or read access to the repo.
 func MustRead() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -318,26 +348,12 @@ func MustRead() gin.HandlerFunc {
 			return
 		}
 
-		// query source to determine requesters permissions for the repo using the requester's token
-		perm, err := scm.FromContext(c).RepoAccess(u, u.GetToken(), r.GetOrg(), r.GetName())
+		// capture requesters permissions for the repo
+		perm, err := repoAccess(c, logger, u, r)
 		if err != nil {
-			// requester may not have permissions to use the Github API endpoint (requires read access)
-			// try again using the repo owner token
-			//
-			// https://docs.github.com/en/rest/reference/repos#get-repository-permissions-for-a-user
-			ro, err := database.FromContext(c).GetUser(r.GetUserID())
-			if err != nil {
-				retErr := fmt.Errorf("unable to get owner for %s: %w", r.GetFullName(), err)
+			util.HandleError(c, http.StatusBadRequest, err)
 
-				util.HandleError(c, http.StatusBadRequest, retErr)
-
-				return
-			}
-
-			perm, err = scm.FromContext(c).RepoAccess(u, ro.GetToken(), r.GetOrg(), r.GetName())
-			if err != nil {
-				logger.Errorf("unable to get user %s access level for repo %s", u.GetName(), r.GetFullName())
-			}
+			return
 		}
 
 		switch perm {
@@ -399,6 +399,144 @@ func TestPerm_MustAdmin_NotAdmin(t *testing.T) {
 	}
 }
 
+func TestPerm_MustOrgAdmin(t *testing.T) {
+	// setup types
+	secret := "superSecret"
+
+	u := new(library.User)
+	u.SetID(1)
+	u.SetName("foo")
+	u.SetToken("bar")
+	u.SetHash("baz")
+	u.SetAdmin(false)
+
+	tok, _ := token.CreateAccessToken(u, accessTokenDuration)
+
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	context, engine := gin.CreateTestContext(resp)
+
+	// setup database
+	db, _ := sqlite.NewTest()
+
+	defer func() {
+		db.Sqlite.Exec("delete from users;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreateUser(u)
+
+	context.Request, _ = http.NewRequest(http.MethodGet, "/test/github", nil)
+	context.Request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tok))
+
+	// setup github mock server
+	engine.GET("/api/v3/orgs/:org/memberships/:username", func(c *gin.Context) {
+		c.String(http.StatusOK, orgAdminPayload)
+	})
+	engine.GET("/api/v3/user", func(c *gin.Context) {
+		c.String(http.StatusOK, userPayload)
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup client
+	client, _ := github.NewTest(s.URL)
+
+	// setup vela mock server
+	engine.Use(func(c *gin.Context) { c.Set("secret", secret) })
+	engine.Use(func(c *gin.Context) { database.ToContext(c, db) })
+	engine.Use(func(c *gin.Context) { scm.ToContext(c, client) })
+	engine.Use(user.Establish())
+	engine.Use(org.Establish())
+	engine.Use(MustOrgAdmin())
+	engine.GET("/test/:org", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	s1 := httptest.NewServer(engine)
+	defer s1.Close()
+
+	// run test
+	engine.ServeHTTP(context.Writer, context.Request)
+
+	if resp.Code != http.StatusOK {
+		t.Errorf("MustOrgAdmin returned %v, want %v", resp.Code, http.StatusOK)
+	}
+}
+
+func TestPerm_MustOrgAdmin_NotAdmin(t *testing.T) {
+	// setup types
+	secret := "superSecret"
+
+	u := new(library.User)
+	u.SetID(1)
+	u.SetName("foo")
+	u.SetToken("bar")
+	u.SetHash("baz")
+	u.SetAdmin(false)
+
+	tok, _ := token.CreateAccessToken(u, accessTokenDuration)
+
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	context, engine := gin.CreateTestContext(resp)
+
+	// setup database
+	db, _ := sqlite.NewTest()
+
+	defer func() {
+		db.Sqlite.Exec("delete from users;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreateUser(u)
+
+	context.Request, _ = http.NewRequest(http.MethodGet, "/test/github", nil)
+	context.Request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tok))
+
+	// setup github mock server
+	engine.GET("/api/v3/orgs/:org/memberships/:username", func(c *gin.Context) {
+		c.String(http.StatusOK, orgMemberPayload)
+	})
+	engine.GET("/api/v3/user", func(c *gin.Context) {
+		c.String(http.StatusOK, userPayload)
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup client
+	client, _ := github.NewTest(s.URL)
+
+	// setup vela mock server
+	engine.Use(func(c *gin.Context) { c.Set("secret", secret) })
+	engine.Use(func(c *gin.Context) { database.ToContext(c, db) })
+	engine.Use(func(c *gin.Context) { scm.ToContext(c, client) })
+	engine.Use(user.Establish())
+	engine.Use(org.Establish())
+	engine.Use(MustOrgAdmin())
+	engine.GET("/test/:org", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	s1 := httptest.NewServer(engine)
+	defer s1.Close()
+
+	// run test
+	engine.ServeHTTP(context.Writer, context.Request)
+
+	if resp.Code != http.StatusUnauthorized {
+		t.Errorf("MustOrgAdmin returned %v, want %v", resp.Code, http.StatusUnauthorized)
+	}
+}
+
 func TestPerm_MustWrite(t *testing.T) {
 	// setup types
 	secret := "superSecret"
@@ -723,6 +861,107 @@ func TestPerm_MustWrite_NotWrite(t *testing.T) {
 	}
 }
 
+func TestPerm_AllowWrite(t *testing.T) {
+	// setup types
+	secret := "superSecret"
+
+	r := new(library.Repo)
+	r.SetID(1)
+	r.SetUserID(1)
+	r.SetHash("baz")
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+	r.SetVisibility("public")
+
+	u := new(library.User)
+	u.SetID(1)
+	u.SetName("foo")
+	u.SetToken("bar")
+	u.SetHash("baz")
+	u.SetAdmin(false)
+
+	tok, _ := token.CreateAccessToken(u, accessTokenDuration)
+
+	// setup database
+	db, _ := sqlite.NewTest()
+
+	defer func() {
+		db.Sqlite.Exec("delete from repos;")
+		db.Sqlite.Exec("delete from users;")
+		_sql, _ := db.Sqlite.DB()
+		_sql.Close()
+	}()
+
+	_ = db.CreateRepo(r)
+	_ = db.CreateUser(u)
+
+	// setup tests
+	tests := []struct {
+		payload string
+		want    bool
+	}{
+		{payload: permAdminPayload, want: true},
+		{payload: permWritePayload, want: true},
+		{payload: permReadPayload, want: false},
+		{payload: permNonePayload, want: false},
+	}
+
+	// run tests
+	for _, test := range tests {
+		// setup context
+		gin.SetMode(gin.TestMode)
+
+		resp := httptest.NewRecorder()
+		context, engine := gin.CreateTestContext(resp)
+
+		context.Request, _ = http.NewRequest(http.MethodGet, "/test/foo/bar", nil)
+		context.Request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tok))
+
+		// setup github mock server
+		payload := test.payload
+
+		engine.GET("/api/v3/repos/:org/:repo/collaborators/:username/permission", func(c *gin.Context) {
+			c.String(http.StatusOK, payload)
+		})
+		engine.GET("/api/v3/user", func(c *gin.Context) {
+			c.String(http.StatusOK, userPayload)
+		})
+
+		s := httptest.NewServer(engine)
+
+		// setup client
+		client, _ := github.NewTest(s.URL)
+
+		got := false
+
+		// setup vela mock server
+		engine.Use(func(c *gin.Context) { c.Set("secret", secret) })
+		engine.Use(func(c *gin.Context) { database.ToContext(c, db) })
+		engine.Use(func(c *gin.Context) { scm.ToContext(c, client) })
+		engine.Use(user.Establish())
+		engine.Use(org.Establish())
+		engine.Use(repo.Establish())
+		engine.GET("/test/:org/:repo", func(c *gin.Context) {
+			got = AllowWrite(c)
+
+			c.Status(http.StatusOK)
+		})
+
+		engine.ServeHTTP(context.Writer, context.Request)
+
+		s.Close()
+
+		if resp.Code != http.StatusOK {
+			t.Errorf("AllowWrite returned %v, want %v", resp.Code, http.StatusOK)
+		}
+
+		if got != test.want {
+			t.Errorf("AllowWrite is %v, want %v", got, test.want)
+		}
+	}
+}
+
 func TestPerm_MustRead(t *testing.T) {
 	// setup types
 	secret := "superSecret"
@@ -1364,6 +1603,36 @@ const permNonePayload = `
 }
 `
 
+const orgAdminPayload = `
+{
+  "url": "https://api.github.com/orgs/github/memberships/foo",
+  "state": "active",
+  "role": "admin",
+  "organization_url": "https://api.github.com/orgs/github",
+  "user": {
+    "login": "foo",
+    "id": 1,
+    "type": "User",
+    "site_admin": false
+  }
+}
+`
+
+const orgMemberPayload = `
+{
+  "url": "https://api.github.com/orgs/github/memberships/foo",
+  "state": "active",
+  "role": "member",
+  "organization_url": "https://api.github.com/orgs/github",
+  "user": {
+    "login": "foo",
+    "id": 1,
+    "type": "User",
+    "site_admin": false
+  }
+}
+`
+
 const userPayload = `
 {
   "login": "foo",
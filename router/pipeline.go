@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-vela/server/api"
 	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/perm"
 	"github.com/go-vela/server/router/middleware/repo"
 )
 
@@ -16,17 +17,23 @@ import (
 //
 // GET  /api/v1/pipelines/:org/:repo
 // GET  /api/v1/pipelines/:org/:repo/templates
+// GET  /api/v1/pipelines/:org/:repo/graph
+// GET  /api/v1/pipelines/:org/:repo/diff
 // POST /api/v1/pipelines/:org/:repo/expand
 // POST /api/v1/pipelines/:org/:repo/compile
+// POST /api/v1/pipelines/:org/:repo/preview
 // POST /api/v1/pipelines/:org/:repo/validate .
 func PipelineHandlers(base *gin.RouterGroup) {
 	// Pipelines endpoints
-	pipelines := base.Group("pipelines/:org/:repo", org.Establish(), repo.Establish())
+	pipelines := base.Group("pipelines/:org/:repo", org.Establish(), repo.Establish(), perm.MustRead())
 	{
 		pipelines.GET("", api.GetPipeline)
 		pipelines.GET("/templates", api.GetTemplates)
+		pipelines.GET("/graph", api.GetPipelineGraph)
+		pipelines.GET("/diff", api.DiffPipeline)
 		pipelines.POST("/expand", api.ExpandPipeline)
 		pipelines.POST("/validate", api.ValidatePipeline)
 		pipelines.POST("/compile", api.CompilePipeline)
+		pipelines.POST("/preview", api.PreviewPipeline)
 	} // end of pipelines endpoints
 }
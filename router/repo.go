@@ -25,6 +25,12 @@ import (
 // DELETE /api/v1/repos/:org/:repo
 // PATCH  /api/v1/repos/:org/:repo/repair
 // PATCH  /api/v1/repos/:org/:repo/chown
+// GET    /api/v1/repos/:org/:repo/default-pipeline/opt-out
+// POST   /api/v1/repos/:org/:repo/default-pipeline/opt-out
+// DELETE /api/v1/repos/:org/:repo/default-pipeline/opt-out
+// GET    /api/v1/repos/:org/:repo/pipeline-settings
+// PUT    /api/v1/repos/:org/:repo/pipeline-settings
+// DELETE /api/v1/repos/:org/:repo/pipeline-settings
 // POST   /api/v1/repos/:org/:repo/builds
 // GET    /api/v1/repos/:org/:repo/builds
 // POST   /api/v1/repos/:org/:repo/builds/:build
@@ -71,6 +77,12 @@ func RepoHandlers(base *gin.RouterGroup) {
 				repo.DELETE("", perm.MustAdmin(), api.DeleteRepo)
 				repo.PATCH("/repair", perm.MustAdmin(), api.RepairRepo)
 				repo.PATCH("/chown", perm.MustAdmin(), api.ChownRepo)
+				repo.GET("/default-pipeline/opt-out", perm.MustRead(), api.GetDefaultPipelineOptOut)
+				repo.POST("/default-pipeline/opt-out", perm.MustAdmin(), api.CreateDefaultPipelineOptOut)
+				repo.DELETE("/default-pipeline/opt-out", perm.MustAdmin(), api.DeleteDefaultPipelineOptOut)
+				repo.GET("/pipeline-settings", perm.MustRead(), api.GetPipelineSettings)
+				repo.PUT("/pipeline-settings", perm.MustAdmin(), api.UpdatePipelineSettings)
+				repo.DELETE("/pipeline-settings", perm.MustAdmin(), api.DeletePipelineSettings)
 
 				// Build endpoints
 				// * Service endpoints
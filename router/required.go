@@ -0,0 +1,32 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/api"
+	"github.com/go-vela/server/router/middleware/org"
+	"github.com/go-vela/server/router/middleware/perm"
+)
+
+// RequiredPipelineHandlers is a function that extends the provided base
+// router group with the API handlers for required pipeline functionality.
+//
+// GET    /api/v1/required-pipelines/:org
+// POST   /api/v1/required-pipelines/:org
+// GET    /api/v1/required-pipelines/:org/:pipeline
+// PUT    /api/v1/required-pipelines/:org/:pipeline
+// DELETE /api/v1/required-pipelines/:org/:pipeline .
+func RequiredPipelineHandlers(base *gin.RouterGroup) {
+	// Required pipelines endpoints
+	required := base.Group("/required-pipelines/:org", org.Establish(), perm.MustOrgAdmin())
+	{
+		required.GET("", api.GetRequiredPipelines)
+		required.POST("", api.CreateRequiredPipeline)
+		required.GET("/:pipeline", api.GetRequiredPipeline)
+		required.PUT("/:pipeline", api.UpdateRequiredPipeline)
+		required.DELETE("/:pipeline", api.DeleteRequiredPipeline)
+	} // end of required pipelines endpoints
+}
@@ -97,6 +97,9 @@ func Load(options ...gin.HandlerFunc) *gin.Engine {
 		// Admin endpoints
 		AdminHandlers(baseAPI)
 
+		// Default pipeline endpoints
+		DefaultPipelineHandlers(baseAPI)
+
 		// Deployment endpoints
 		DeploymentHandlers(baseAPI)
 
@@ -125,6 +128,9 @@ func Load(options ...gin.HandlerFunc) *gin.Engine {
 
 		// Pipeline endpoints
 		PipelineHandlers(baseAPI)
+
+		// Required pipeline endpoints
+		RequiredPipelineHandlers(baseAPI)
 	} // end of api
 
 	return r
@@ -69,4 +69,23 @@ var Flags = []cli.Flag{
 			"is behind a Firewall or NAT, or when using something like ngrok to forward webhooks. " +
 			"(defaults to VELA_ADDR).",
 	},
+	&cli.Int64Flag{
+		EnvVars:  []string{"VELA_SCM_APP_ID", "SCM_APP_ID"},
+		FilePath: "/vela/scm/app_id",
+		Name:     "scm.app.id",
+		Usage:    "ID of the GitHub App used to authenticate repo operations as an installation",
+	},
+	&cli.StringFlag{
+		EnvVars:  []string{"VELA_SCM_APP_PRIVATE_KEY", "SCM_APP_PRIVATE_KEY"},
+		FilePath: "/vela/scm/app_private_key",
+		Name:     "scm.app.private-key",
+		Usage:    "PEM encoded private key of the GitHub App used to authenticate repo operations as an installation",
+	},
+	&cli.StringFlag{
+		EnvVars:  []string{"VELA_SCM_REPORTING", "SCM_REPORTING"},
+		FilePath: "/vela/scm/reporting",
+		Name:     "scm.reporting",
+		Usage:    "mode for reporting builds to the version control system (status or checks)",
+		Value:    "status",
+	},
 }
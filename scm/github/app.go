@@ -0,0 +1,240 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/go-github/v42/github"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+
+	"github.com/go-vela/types/library"
+)
+
+const (
+	// appJWTExpiration defines how long the JSON Web Token
+	// used to authenticate as the GitHub App is valid.
+	//
+	// https://docs.github.com/en/developers/apps/building-github-apps/authenticating-with-github-apps#authenticating-as-a-github-app
+	appJWTExpiration = 10 * time.Minute
+
+	// appTokenRefresh defines how long before an installation
+	// token expires that a new token should be created.
+	appTokenRefresh = time.Minute
+)
+
+// installationCache represents the cache of the GitHub App
+// installations for repos and the installation tokens.
+type installationCache struct {
+	sync.Mutex
+
+	// installation IDs indexed by the full name of the repo
+	repos map[string]int64
+	// installation tokens indexed by the installation ID
+	tokens map[int64]*github.InstallationToken
+	// locks for capturing the installations and tokens
+	// indexed by the full name of the repo or the
+	// installation ID, so the API calls for one repo
+	// or installation don't block the others
+	locks map[string]*sync.Mutex
+}
+
+// newInstallationCache returns an empty cache of GitHub App installations.
+func newInstallationCache() *installationCache {
+	return &installationCache{
+		repos:  make(map[string]int64),
+		tokens: make(map[int64]*github.InstallationToken),
+		locks:  make(map[string]*sync.Mutex),
+	}
+}
+
+// lock returns the lock for the key from the cache.
+func (i *installationCache) lock(key string) *sync.Mutex {
+	i.Lock()
+	defer i.Unlock()
+
+	l, ok := i.locks[key]
+	if !ok {
+		l = new(sync.Mutex)
+		i.locks[key] = l
+	}
+
+	return l
+}
+
+// repo returns the installation ID for the repo from the cache.
+func (i *installationCache) repo(repo string) (int64, bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	id, ok := i.repos[repo]
+
+	return id, ok
+}
+
+// token returns the token for the installation from the cache.
+func (i *installationCache) token(id int64) (*github.InstallationToken, bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	token, ok := i.tokens[id]
+
+	return token, ok
+}
+
+// appTokenSource represents a token source that
+// authenticates requests as the GitHub App.
+type appTokenSource struct {
+	client *client
+}
+
+// Token creates the JSON Web Token for the GitHub App.
+func (t *appTokenSource) Token() (*oauth2.Token, error) {
+	token, err := t.client.appJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{AccessToken: token, TokenType: "Bearer"}, nil
+}
+
+// appJWT creates the JSON Web Token to authenticate as the GitHub App.
+func (c *client) appJWT() (string, error) {
+	now := time.Now()
+
+	claims := jwt.StandardClaims{
+		// account for clock drift between the server and GitHub
+		IssuedAt:  now.Add(-time.Minute).Unix(),
+		ExpiresAt: now.Add(appJWTExpiration).Unix(),
+		Issuer:    strconv.FormatInt(c.config.AppID, 10),
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(c.config.AppPrivateKey)
+}
+
+// helper function to return the GitHub client authenticated as the GitHub App.
+func (c *client) newClientApp() *github.Client {
+	// create the OAuth client from the GitHub App token source
+	// so the requests use the same transport as the OAuth client
+	tc := oauth2.NewClient(context.Background(), &appTokenSource{client: c})
+
+	// create the GitHub client from the OAuth client
+	github := github.NewClient(tc)
+
+	// ensure the proper URL is set in the GitHub client
+	github.BaseURL, _ = url.Parse(c.config.API)
+
+	return github
+}
+
+// installationToken captures the token for the GitHub App installation
+// of a repo. The token is cached until shortly before it expires.
+func (c *client) installationToken(org, name string) (string, error) {
+	repo := strings.ToLower(fmt.Sprintf("%s/%s", org, name))
+
+	client := c.newClientApp()
+
+	id, err := c.installationID(client, org, name, repo)
+	if err != nil {
+		return "", err
+	}
+
+	// lock the installation while the token is created so the
+	// token is only created once for concurrent requests
+	lock := c.installations.lock(strconv.FormatInt(id, 10))
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	// return the cached token if it isn't about to expire
+	token, ok := c.installations.token(id)
+	if ok && time.Until(token.GetExpiresAt()) > appTokenRefresh {
+		return token.GetToken(), nil
+	}
+
+	// send API call to create a token for the installation
+	token, _, err = client.Apps.CreateInstallationToken(ctx, id, nil)
+	if err != nil {
+		// remove the installation in case the app was uninstalled
+		c.installations.Lock()
+		delete(c.installations.repos, repo)
+		delete(c.installations.tokens, id)
+		c.installations.Unlock()
+
+		return "", err
+	}
+
+	c.installations.Lock()
+	c.installations.tokens[id] = token
+	c.installations.Unlock()
+
+	return token.GetToken(), nil
+}
+
+// installationID captures the ID of the GitHub App installation
+// for a repo. The ID is cached until creating a token fails.
+func (c *client) installationID(client *github.Client, org, name, repo string) (int64, error) {
+	// lock the repo while the installation is captured so the
+	// installation is only captured once for concurrent requests
+	lock := c.installations.lock(repo)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	// check if the installation for the repo was already captured
+	id, ok := c.installations.repo(repo)
+	if ok {
+		return id, nil
+	}
+
+	// send API call to capture the installation for the repo
+	installation, _, err := client.Apps.FindRepositoryInstallation(ctx, org, name)
+	if err != nil {
+		return 0, err
+	}
+
+	c.installations.Lock()
+	c.installations.repos[repo] = installation.GetID()
+	c.installations.Unlock()
+
+	return installation.GetID(), nil
+}
+
+// newClientRepo returns the GitHub client for repo-level operations.
+// When the server is configured as a GitHub App, the client is
+// authenticated as the installation for the repo. Otherwise, or
+// when the GitHub App isn't installed for the repo, the client
+// is authenticated with the user's OAuth token.
+func (c *client) newClientRepo(u *library.User, org, name string) (*github.Client, error) {
+	if c.config.AppID == 0 {
+		return c.newClientToken(u.GetToken()), nil
+	}
+
+	token, err := c.installationToken(org, name)
+	if err != nil {
+		// fall back to the user's token if the app isn't installed for the repo
+		if gerr, ok := err.(*github.ErrorResponse); ok && gerr.Response.StatusCode == http.StatusNotFound {
+			c.Logger.WithFields(logrus.Fields{
+				"org":  org,
+				"repo": name,
+			}).Warnf("github app not installed for %s/%s, using token for user %s", org, name, u.GetName())
+
+			return c.newClientToken(u.GetToken()), nil
+		}
+
+		return nil, fmt.Errorf("unable to get github app installation token for %s/%s: %w", org, name, err)
+	}
+
+	return c.newClientToken(token), nil
+}
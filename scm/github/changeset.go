@@ -21,8 +21,11 @@ func (c *client) Changeset(u *library.User, r *library.Repo, sha string) ([]stri
 		"user": u.GetName(),
 	}).Tracef("capturing commit changeset for %s/commit/%s", r.GetFullName(), sha)
 
-	// create GitHub OAuth client with user's token
-	client := c.newClientToken(u.GetToken())
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, r.GetOrg(), r.GetName())
+	if err != nil {
+		return nil, err
+	}
 	s := []string{}
 
 	// set the max per page for the options to capture the commit
@@ -50,8 +53,11 @@ func (c *client) ChangesetPR(u *library.User, r *library.Repo, number int) ([]st
 		"user": u.GetName(),
 	}).Tracef("capturing pull request changeset for %s/pull/%d", r.GetFullName(), number)
 
-	// create GitHub OAuth client with user's token
-	client := c.newClientToken(u.GetToken())
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, r.GetOrg(), r.GetName())
+	if err != nil {
+		return nil, err
+	}
 	s := []string{}
 	f := []*github.CommitFile{}
 
@@ -0,0 +1,234 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+	"github.com/google/go-github/v42/github"
+)
+
+const (
+	// check run statuses
+	checkQueued     = "queued"
+	checkInProgress = "in_progress"
+	checkCompleted  = "completed"
+)
+
+// Reporting outputs the configured mode for reporting builds.
+func (c *client) Reporting() string {
+	return c.config.Reporting
+}
+
+// PipelineChecks reports the build for the given SHA from the GitHub repo
+// as a check run with the results of the steps and the name of the repo
+// pipeline the build was created for. The check run is created for the
+// first report of the build and the ID of the created check run is
+// captured in the check so every following report updates it.
+//
+// nolint: lll // ignore long line length due to input arguments
+func (c *client) PipelineChecks(u *library.User, b *library.Build, check *model.BuildCheck, steps []*model.CheckStep, org, name, pipeline string) error {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+		"org":   org,
+		"repo":  name,
+		"user":  u.GetName(),
+	}).Tracef("setting check run for %s/%s/%d @ %s", org, name, b.GetNumber(), b.GetCommit())
+
+	// deployments are reported with a deployment status
+	if strings.EqualFold(b.GetEvent(), constants.EventDeploy) {
+		return c.PipelineStatus(u, b, org, name, pipeline)
+	}
+
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, org, name)
+	if err != nil {
+		return err
+	}
+
+	context := c.statusContext(b, pipeline)
+	url := fmt.Sprintf("%s/%s/%s/%d", c.config.WebUIAddress, org, name, b.GetNumber())
+	externalID := strconv.FormatInt(b.GetID(), 10)
+
+	status, conclusion, title := checkStatus(b)
+
+	output := &github.CheckRunOutput{
+		Title:   github.String(title),
+		Summary: github.String(c.checkSummary(url, steps)),
+	}
+
+	// GitHub appends the annotations to the check run with every
+	// update so they are only sent when provided with the steps
+	if annotations := checkAnnotations(steps); len(annotations) > 0 {
+		output.Annotations = annotations
+	}
+
+	var (
+		detailsURL  *string
+		completedAt *github.Timestamp
+	)
+
+	// provide "Details" link in GitHub UI if server was configured with it
+	if len(c.config.WebUIAddress) > 0 {
+		detailsURL = github.String(url)
+	}
+
+	if status == checkCompleted {
+		completedAt = &github.Timestamp{Time: time.Now().UTC()}
+
+		if b.GetFinished() > 0 {
+			completedAt = &github.Timestamp{Time: time.Unix(b.GetFinished(), 0).UTC()}
+		}
+	}
+
+	// check if the check run for the build was already created
+	if check.CheckRunID > 0 {
+		opts := github.UpdateCheckRunOptions{
+			Name:        context,
+			DetailsURL:  detailsURL,
+			ExternalID:  github.String(externalID),
+			Status:      github.String(status),
+			CompletedAt: completedAt,
+			Output:      output,
+		}
+
+		if len(conclusion) > 0 {
+			opts.Conclusion = github.String(conclusion)
+		}
+
+		// send API call to update the check run for the build
+		_, _, err = client.Checks.UpdateCheckRun(ctx, org, name, check.CheckRunID, opts)
+
+		return err
+	}
+
+	opts := github.CreateCheckRunOptions{
+		Name:        context,
+		HeadSHA:     b.GetCommit(),
+		DetailsURL:  detailsURL,
+		ExternalID:  github.String(externalID),
+		Status:      github.String(status),
+		CompletedAt: completedAt,
+		Output:      output,
+	}
+
+	if len(conclusion) > 0 {
+		opts.Conclusion = github.String(conclusion)
+	}
+
+	if b.GetStarted() > 0 {
+		opts.StartedAt = &github.Timestamp{Time: time.Unix(b.GetStarted(), 0).UTC()}
+	}
+
+	// send API call to create the check run for the build
+	run, _, err := client.Checks.CreateCheckRun(ctx, org, name, opts)
+	if err != nil {
+		return err
+	}
+
+	check.CheckRunID = run.GetID()
+
+	return nil
+}
+
+// checkSummary is a helper function to create the markdown
+// summary of the steps reported in the check run for a build.
+func (c *client) checkSummary(url string, steps []*model.CheckStep) string {
+	if len(steps) == 0 {
+		return "no steps have been reported for the build"
+	}
+
+	summary := new(strings.Builder)
+
+	summary.WriteString("| Step | Status | Duration |\n")
+	summary.WriteString("| --- | --- | --- |\n")
+
+	for _, step := range steps {
+		s := step.Step
+
+		name := strings.ReplaceAll(s.GetName(), "|", "\\|")
+
+		// link the step to the logs in the Vela web UI
+		if len(c.config.WebUIAddress) > 0 {
+			name = fmt.Sprintf("[%s](%s#step:%d)", name, url, s.GetNumber())
+		}
+
+		duration := "-"
+		if s.GetStarted() > 0 && s.GetFinished() >= s.GetStarted() {
+			duration = (time.Duration(s.GetFinished()-s.GetStarted()) * time.Second).String()
+		}
+
+		fmt.Fprintf(summary, "| %s | %s | %s |\n", name, s.GetStatus(), duration)
+	}
+
+	return summary.String()
+}
+
+// checkStatus is a helper function to convert the status of a build
+// to the status, conclusion and title for the check run of the build.
+func checkStatus(b *library.Build) (string, string, string) {
+	switch b.GetStatus() {
+	case constants.StatusPending:
+		return checkQueued, "", "the build is pending"
+	case model.StatusPendingApproval:
+		return checkQueued, "", "the build is waiting for approval"
+	case constants.StatusRunning:
+		return checkInProgress, "", "the build is running"
+	case constants.StatusSuccess:
+		return checkCompleted, "success", "the build was successful"
+	case constants.StatusFailure:
+		return checkCompleted, "failure", "the build has failed"
+	case constants.StatusCanceled:
+		return checkCompleted, "cancelled", "the build was canceled"
+	case constants.StatusKilled:
+		return checkCompleted, "cancelled", "the build was killed"
+	case constants.StatusSkipped:
+		return checkCompleted, "skipped", "build was skipped as no steps/stages found"
+	default:
+		return checkCompleted, "failure", "there was an error"
+	}
+}
+
+// checkAnnotations is a helper function to convert the annotations
+// of the steps to the annotations for the check run of a build.
+func checkAnnotations(steps []*model.CheckStep) []*github.CheckRunAnnotation {
+	annotations := []*github.CheckRunAnnotation{}
+
+	for _, step := range steps {
+		for _, a := range step.Annotations {
+			// GitHub limits the annotations for a single request
+			if len(annotations) == model.CheckAnnotationLimit {
+				return annotations
+			}
+
+			annotation := &github.CheckRunAnnotation{
+				Path:            github.String(a.Path),
+				StartLine:       github.Int(a.StartLine),
+				EndLine:         github.Int(a.EndLine),
+				AnnotationLevel: github.String(a.Level),
+				Message:         github.String(a.Message),
+			}
+
+			// default the title to the step the annotation was parsed from
+			annotation.Title = github.String(step.Step.GetName())
+			if len(a.Title) > 0 {
+				annotation.Title = github.String(a.Title)
+			}
+
+			annotations = append(annotations, annotation)
+		}
+	}
+
+	return annotations
+}
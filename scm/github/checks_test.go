@@ -0,0 +1,328 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/server/model"
+	"github.com/go-vela/types/library"
+	"github.com/google/go-github/v42/github"
+)
+
+func TestGithub_PipelineChecks(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name       string
+		status     string
+		checkRunID int64
+		annotate   bool
+		created    bool
+		updated    bool
+		wantStatus string
+		wantResult string
+		wantNotes  int
+	}{
+		{
+			name:       "create running",
+			status:     "running",
+			created:    true,
+			wantStatus: "in_progress",
+			wantNotes:  0,
+		},
+		{
+			name:       "update failure",
+			status:     "failure",
+			checkRunID: 4,
+			annotate:   true,
+			updated:    true,
+			wantStatus: "completed",
+			wantResult: "failure",
+			wantNotes:  1,
+		},
+		{
+			name:       "update failure without annotations",
+			status:     "failure",
+			checkRunID: 4,
+			updated:    true,
+			wantStatus: "completed",
+			wantResult: "failure",
+			wantNotes:  0,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// setup context
+			gin.SetMode(gin.TestMode)
+
+			_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+			var (
+				created bool
+				updated bool
+				got     map[string]interface{}
+			)
+
+			// setup mock server
+			engine.GET("/api/v3/repos/foo/bar/installation", func(c *gin.Context) {
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusOK)
+				c.File("testdata/installation.json")
+			})
+			engine.POST("/api/v3/app/installations/:id/access_tokens", func(c *gin.Context) {
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusCreated)
+				c.File("testdata/installation_token.json")
+			})
+			engine.GET("/api/v3/repos/foo/bar/commits/:sha/check-runs", func(c *gin.Context) {
+				t.Errorf("PipelineChecks should not list check runs")
+
+				c.Status(http.StatusNotFound)
+			})
+			engine.POST("/api/v3/repos/foo/bar/check-runs", func(c *gin.Context) {
+				created = true
+
+				_ = json.NewDecoder(c.Request.Body).Decode(&got)
+
+				c.Header("Content-Type", "application/json")
+				c.String(http.StatusCreated, `{"id": 4}`)
+			})
+			engine.PATCH("/api/v3/repos/foo/bar/check-runs/:id", func(c *gin.Context) {
+				if c.Param("id") != "4" {
+					t.Errorf("UpdateCheckRun id is %s, want 4", c.Param("id"))
+				}
+
+				updated = true
+
+				_ = json.NewDecoder(c.Request.Body).Decode(&got)
+
+				c.Header("Content-Type", "application/json")
+				c.String(http.StatusOK, `{"id": 4}`)
+			})
+
+			s := httptest.NewServer(engine)
+			defer s.Close()
+
+			// setup types
+			u := new(library.User)
+			u.SetName("foo")
+			u.SetToken("bar")
+
+			b := new(library.Build)
+			b.SetID(1)
+			b.SetNumber(1)
+			b.SetEvent("push")
+			b.SetStatus(test.status)
+			b.SetCommit("6dcb09b5b57875f334f61aebed695e2e4193db5e")
+
+			step := new(library.Step)
+			step.SetNumber(2)
+			step.SetName("test")
+			step.SetStatus("failure")
+			step.SetStarted(1563474077)
+			step.SetFinished(1563474090)
+
+			steps := []*model.CheckStep{{Step: step}}
+
+			if test.annotate {
+				steps[0].Annotations = []*model.CheckAnnotation{
+					{
+						Path:      "main.go",
+						StartLine: 1,
+						EndLine:   1,
+						Level:     model.CheckAnnotationFailure,
+						Message:   "undefined: foo",
+					},
+				}
+			}
+
+			check := &model.BuildCheck{BuildID: 1, CheckRunID: test.checkRunID}
+
+			client, _ := NewTestApp(s.URL)
+
+			// run test
+			err := client.PipelineChecks(u, b, check, steps, "foo", "bar", "")
+
+			if err != nil {
+				t.Errorf("PipelineChecks returned err: %v", err)
+			}
+
+			if created != test.created {
+				t.Errorf("PipelineChecks created is %v, want %v", created, test.created)
+			}
+
+			if updated != test.updated {
+				t.Errorf("PipelineChecks updated is %v, want %v", updated, test.updated)
+			}
+
+			if check.CheckRunID != 4 {
+				t.Errorf("PipelineChecks check run is %d, want 4", check.CheckRunID)
+			}
+
+			if got["status"] != test.wantStatus {
+				t.Errorf("PipelineChecks status is %v, want %v", got["status"], test.wantStatus)
+			}
+
+			if test.wantResult != "" && got["conclusion"] != test.wantResult {
+				t.Errorf("PipelineChecks conclusion is %v, want %v", got["conclusion"], test.wantResult)
+			}
+
+			output, _ := got["output"].(map[string]interface{})
+			annotations, _ := output["annotations"].([]interface{})
+
+			if len(annotations) != test.wantNotes {
+				t.Errorf("PipelineChecks annotations is %d, want %d", len(annotations), test.wantNotes)
+			}
+
+			summary, _ := output["summary"].(string)
+
+			if !strings.Contains(summary, "| [test]("+s.URL+"/foo/bar/1#step:2) | failure | 13s |") {
+				t.Errorf("PipelineChecks summary is %v", summary)
+			}
+		})
+	}
+}
+
+func TestGithub_PipelineChecks_Deployment(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	deployed := false
+
+	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/installation", func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/installation.json")
+	})
+	engine.POST("/api/v3/app/installations/:id/access_tokens", func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusCreated)
+		c.File("testdata/installation_token.json")
+	})
+	engine.POST("/api/v3/repos/foo/bar/deployments/:deployment/statuses", func(c *gin.Context) {
+		deployed = true
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/status.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	b := new(library.Build)
+	b.SetID(1)
+	b.SetNumber(1)
+	b.SetEvent("deployment")
+	b.SetStatus("success")
+	b.SetCommit("6dcb09b5b57875f334f61aebed695e2e4193db5e")
+	b.SetSource("https://github.com/foo/bar/deployments/1")
+
+	client, _ := NewTestApp(s.URL)
+
+	// run test
+	err := client.PipelineChecks(u, b, &model.BuildCheck{BuildID: 1}, nil, "foo", "bar", "")
+
+	if err != nil {
+		t.Errorf("PipelineChecks returned err: %v", err)
+	}
+
+	if !deployed {
+		t.Errorf("PipelineChecks should have created deployment status")
+	}
+}
+
+func TestGithub_checkStatus(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		status     string
+		want       string
+		conclusion string
+	}{
+		{status: "pending", want: "queued", conclusion: ""},
+		{status: "pending approval", want: "queued", conclusion: ""},
+		{status: "running", want: "in_progress", conclusion: ""},
+		{status: "success", want: "completed", conclusion: "success"},
+		{status: "failure", want: "completed", conclusion: "failure"},
+		{status: "canceled", want: "completed", conclusion: "cancelled"},
+		{status: "killed", want: "completed", conclusion: "cancelled"},
+		{status: "skipped", want: "completed", conclusion: "skipped"},
+		{status: "error", want: "completed", conclusion: "failure"},
+	}
+
+	// run tests
+	for _, test := range tests {
+		b := new(library.Build)
+		b.SetStatus(test.status)
+
+		got, conclusion, _ := checkStatus(b)
+
+		if got != test.want {
+			t.Errorf("checkStatus for %s is %v, want %v", test.status, got, test.want)
+		}
+
+		if conclusion != test.conclusion {
+			t.Errorf("checkStatus conclusion for %s is %v, want %v", test.status, conclusion, test.conclusion)
+		}
+	}
+}
+
+func TestGithub_checkAnnotations(t *testing.T) {
+	// setup types
+	step := new(library.Step)
+	step.SetName("lint")
+
+	steps := []*model.CheckStep{
+		{
+			Step: step,
+			Annotations: []*model.CheckAnnotation{
+				{Path: "a.go", StartLine: 1, EndLine: 2, Level: "warning", Message: "foo"},
+				{Path: "b.go", StartLine: 3, EndLine: 3, Level: "failure", Title: "Vet", Message: "bar"},
+			},
+		},
+	}
+
+	want := []*github.CheckRunAnnotation{
+		{
+			Path:            github.String("a.go"),
+			StartLine:       github.Int(1),
+			EndLine:         github.Int(2),
+			AnnotationLevel: github.String("warning"),
+			Message:         github.String("foo"),
+			Title:           github.String("lint"),
+		},
+		{
+			Path:            github.String("b.go"),
+			StartLine:       github.Int(3),
+			EndLine:         github.Int(3),
+			AnnotationLevel: github.String("failure"),
+			Message:         github.String("bar"),
+			Title:           github.String("Vet"),
+		},
+	}
+
+	// run test
+	got := checkAnnotations(steps)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("checkAnnotations is %v, want %v", got, want)
+	}
+}
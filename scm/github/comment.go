@@ -0,0 +1,62 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package github
+
+import (
+	"github.com/go-vela/types/library"
+	"github.com/google/go-github/v42/github"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateComment creates a comment on a pull request for the GitHub repo.
+func (c *client) CreateComment(u *library.User, r *library.Repo, number int, body string) (int64, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("creating comment on pull request %d for repo %s", number, r.GetFullName())
+
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, r.GetOrg(), r.GetName())
+	if err != nil {
+		return 0, err
+	}
+
+	// send API call to create the comment
+	//
+	// https://docs.github.com/en/rest/issues/comments#create-an-issue-comment
+	comment, _, err := client.Issues.CreateComment(ctx, r.GetOrg(), r.GetName(), number, &github.IssueComment{
+		Body: github.String(body),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return comment.GetID(), nil
+}
+
+// UpdateComment updates a comment on a pull request for the GitHub repo.
+func (c *client) UpdateComment(u *library.User, r *library.Repo, number int, id int64, body string) error {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("updating comment %d on pull request %d for repo %s", id, number, r.GetFullName())
+
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, r.GetOrg(), r.GetName())
+	if err != nil {
+		return err
+	}
+
+	// send API call to update the comment
+	//
+	// https://docs.github.com/en/rest/issues/comments#update-an-issue-comment
+	_, _, err = client.Issues.EditComment(ctx, r.GetOrg(), r.GetName(), id, &github.IssueComment{
+		Body: github.String(body),
+	})
+
+	return err
+}
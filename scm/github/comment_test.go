@@ -0,0 +1,124 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/types/library"
+)
+
+func TestGithub_CreateComment(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	body := map[string]string{}
+
+	// setup mock server
+	engine.POST("/api/v3/repos/:org/:repo/issues/:number/comments", func(c *gin.Context) {
+		if c.Param("org") != "foo" || c.Param("repo") != "bar" || c.Param("number") != "1" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		_ = c.BindJSON(&body)
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusCreated)
+		c.File("testdata/comment.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.CreateComment(u, r, 1, "Restarted build #2")
+
+	if err != nil {
+		t.Errorf("CreateComment returned err: %v", err)
+	}
+
+	if got != 1 {
+		t.Errorf("CreateComment is %v, want %v", got, 1)
+	}
+
+	if body["body"] != "Restarted build #2" {
+		t.Errorf("CreateComment sent %v, want %v", body["body"], "Restarted build #2")
+	}
+}
+
+func TestGithub_UpdateComment(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	body := map[string]string{}
+
+	// setup mock server
+	engine.PATCH("/api/v3/repos/:org/:repo/issues/comments/:id", func(c *gin.Context) {
+		if c.Param("org") != "foo" || c.Param("repo") != "bar" || c.Param("id") != "1" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		_ = c.BindJSON(&body)
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/comment.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	err := client.UpdateComment(u, r, 1, 1, "Restarted build #2")
+
+	if err != nil {
+		t.Errorf("UpdateComment returned err: %v", err)
+	}
+
+	if body["body"] != "Restarted build #2" {
+		t.Errorf("UpdateComment sent %v, want %v", body["body"], "Restarted build #2")
+	}
+
+	// run test with an unknown comment
+	err = client.UpdateComment(u, r, 1, 2, "Restarted build #2")
+
+	if err == nil {
+		t.Errorf("UpdateComment should have returned err")
+	}
+}
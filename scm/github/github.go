@@ -6,9 +6,12 @@ package github
 
 import (
 	"context"
+	"crypto/rsa"
 	"fmt"
 	"net/url"
 
+	"github.com/go-vela/server/model"
+
 	"github.com/google/go-github/v42/github"
 	"github.com/sirupsen/logrus"
 
@@ -48,12 +51,20 @@ type config struct {
 	WebUIAddress string
 	// specifies the OAuth scopes to use for the GitHub client
 	Scopes []string
+	// specifies the ID of the GitHub App to use for the GitHub client
+	AppID int64
+	// specifies the private key of the GitHub App to use for the GitHub client
+	AppPrivateKey *rsa.PrivateKey
+	// specifies the mode for reporting builds to use for the GitHub client
+	Reporting string
 }
 
 type client struct {
 	config  *config
 	OAuth   *oauth2.Config
 	AuthReq *github.AuthorizationRequest
+	// cache of the GitHub App installations for repos
+	installations *installationCache
 	// https://pkg.go.dev/github.com/sirupsen/logrus#Entry
 	Logger *logrus.Entry
 }
@@ -67,9 +78,10 @@ func New(opts ...ClientOpt) (*client, error) {
 	c := new(client)
 
 	// create new fields
-	c.config = new(config)
+	c.config = &config{Reporting: model.ReportingStatus}
 	c.OAuth = new(oauth2.Config)
 	c.AuthReq = new(github.AuthorizationRequest)
+	c.installations = newInstallationCache()
 
 	// create new logger for the client
 	//
@@ -89,6 +101,16 @@ func New(opts ...ClientOpt) (*client, error) {
 		}
 	}
 
+	// check if a private key was provided for the GitHub App
+	if c.config.AppID > 0 && c.config.AppPrivateKey == nil {
+		return nil, fmt.Errorf("no GitHub App private key provided")
+	}
+
+	// check if a GitHub App was provided for reporting with check runs
+	if c.config.Reporting == model.ReportingChecks && c.config.AppID == 0 {
+		return nil, fmt.Errorf("no GitHub App provided for %s reporting", model.ReportingChecks)
+	}
+
 	// create the GitHub OAuth config object
 	c.OAuth = &oauth2.Config{
 		ClientID:     c.config.ClientID,
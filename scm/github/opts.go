@@ -7,6 +7,10 @@ package github
 import (
 	"fmt"
 	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/go-vela/server/model"
 )
 
 // ClientOpt represents a configuration option to initialize the scm client for GitHub.
@@ -151,3 +155,60 @@ func WithScopes(scopes []string) ClientOpt {
 		return nil
 	}
 }
+
+// WithAppID sets the ID of the GitHub App in the scm client for GitHub.
+func WithAppID(id int64) ClientOpt {
+	return func(c *client) error {
+		c.Logger.Trace("configuring GitHub App ID in github scm client")
+
+		// set the GitHub App ID in the github client
+		c.config.AppID = id
+
+		return nil
+	}
+}
+
+// WithAppPrivateKey sets the private key of the GitHub App in the scm client for GitHub.
+func WithAppPrivateKey(key string) ClientOpt {
+	return func(c *client) error {
+		c.Logger.Trace("configuring GitHub App private key in github scm client")
+
+		// check if the private key provided is empty
+		if len(key) == 0 {
+			return nil
+		}
+
+		// parse the PEM encoded private key for the GitHub App
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(key))
+		if err != nil {
+			return fmt.Errorf("unable to parse GitHub App private key: %w", err)
+		}
+
+		// set the GitHub App private key in the github client
+		c.config.AppPrivateKey = privateKey
+
+		return nil
+	}
+}
+
+// WithReporting sets the mode for reporting builds in the scm client for GitHub.
+func WithReporting(mode string) ClientOpt {
+	return func(c *client) error {
+		c.Logger.Trace("configuring reporting mode in github scm client")
+
+		// check if the reporting mode provided is empty
+		if len(mode) == 0 {
+			mode = model.ReportingStatus
+		}
+
+		// check if the reporting mode provided is supported
+		if mode != model.ReportingStatus && mode != model.ReportingChecks {
+			return fmt.Errorf("invalid GitHub reporting mode provided: %s", mode)
+		}
+
+		// set the reporting mode in the github client
+		c.config.Reporting = mode
+
+		return nil
+	}
+}
@@ -350,3 +350,153 @@ func TestGithub_ClientOpt_WithScopes(t *testing.T) {
 		}
 	}
 }
+
+func TestGithub_ClientOpt_WithAppID(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		id   int64
+		want int64
+	}{
+		{
+			id:   1,
+			want: 1,
+		},
+		{
+			id:   0,
+			want: 0,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_service, err := New(
+			WithAppID(test.id),
+			WithAppPrivateKey(testAppPrivateKey()),
+		)
+
+		if err != nil {
+			t.Errorf("WithAppID returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_service.config.AppID, test.want) {
+			t.Errorf("WithAppID is %v, want %v", _service.config.AppID, test.want)
+		}
+	}
+}
+
+func TestGithub_ClientOpt_WithAppPrivateKey(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		id      int64
+		key     string
+		want    bool
+	}{
+		{
+			failure: false,
+			id:      1,
+			key:     testAppPrivateKey(),
+			want:    true,
+		},
+		{
+			failure: false,
+			id:      0,
+			key:     "",
+			want:    false,
+		},
+		{
+			failure: true,
+			id:      1,
+			key:     "",
+		},
+		{
+			failure: true,
+			id:      1,
+			key:     "foo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_service, err := New(
+			WithAppID(test.id),
+			WithAppPrivateKey(test.key),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithAppPrivateKey should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithAppPrivateKey returned err: %v", err)
+		}
+
+		if got := _service.config.AppPrivateKey != nil; got != test.want {
+			t.Errorf("WithAppPrivateKey set is %v, want %v", got, test.want)
+		}
+	}
+}
+
+func TestGithub_ClientOpt_WithReporting(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		mode    string
+		want    string
+	}{
+		{
+			failure: false,
+			mode:    "checks",
+			want:    "checks",
+		},
+		{
+			failure: false,
+			mode:    "",
+			want:    "status",
+		},
+		{
+			failure: true,
+			mode:    "foo",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_service, err := New(
+			WithAppID(1),
+			WithAppPrivateKey(testAppPrivateKey()),
+			WithReporting(test.mode),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("WithReporting should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("WithReporting returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(_service.Reporting(), test.want) {
+			t.Errorf("WithReporting is %v, want %v", _service.Reporting(), test.want)
+		}
+	}
+}
+
+func TestGithub_ClientOpt_WithReporting_NoApp(t *testing.T) {
+	// run test
+	_, err := New(
+		WithReporting("checks"),
+	)
+
+	if err == nil {
+		t.Errorf("New should have returned err")
+	}
+}
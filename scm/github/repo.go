@@ -13,6 +13,8 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/go-vela/server/model"
+
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
 	"github.com/google/go-github/v42/github"
@@ -55,8 +57,11 @@ func (c *client) Config(u *library.User, r *library.Repo, ref string) ([]byte, e
 		"user": u.GetName(),
 	}).Tracef("capturing configuration file for %s/commit/%s", r.GetFullName(), ref)
 
-	// create GitHub OAuth client with user's token
-	client := c.newClientToken(*u.Token)
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, r.GetOrg(), r.GetName())
+	if err != nil {
+		return nil, err
+	}
 
 	files := []string{".vela.yml", ".vela.yaml"}
 
@@ -70,26 +75,74 @@ func (c *client) Config(u *library.User, r *library.Repo, ref string) ([]byte, e
 	}
 
 	for _, file := range files {
-		// send API call to capture the .vela.yml pipeline configuration
-		data, _, resp, err := client.Repositories.GetContents(ctx, r.GetOrg(), r.GetName(), file, opts)
+		data, err := c.configFile(client, r, file, opts)
 		if err != nil {
-			if resp.StatusCode != http.StatusNotFound {
-				return nil, err
-			}
+			return nil, err
 		}
 
 		// data is not nil if .vela.yml exists
 		if data != nil {
-			strData, err := data.GetContent()
-			if err != nil {
-				return nil, err
-			}
+			return data, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w (%s)", model.ErrNoPipelineConfig, strings.Join(files, ","))
+}
+
+// ConfigFile gets the pipeline configuration from a path in the GitHub repo.
+func (c *client) ConfigFile(u *library.User, r *library.Repo, ref, path string) ([]byte, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("capturing configuration file %s for %s/commit/%s", path, r.GetFullName(), ref)
+
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, r.GetOrg(), r.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	// set the reference for the options to capture the pipeline configuration
+	opts := &github.RepositoryContentGetOptions{
+		Ref: ref,
+	}
+
+	data, err := c.configFile(client, r, path, opts)
+	if err != nil {
+		return nil, err
+	}
 
-			return []byte(strData), nil
+	if data == nil {
+		return nil, fmt.Errorf("%w (%s)", model.ErrNoPipelineConfig, path)
+	}
+
+	return data, nil
+}
+
+// configFile is a helper function to capture the contents of a
+// pipeline configuration file. No data or error is returned if
+// the file does not exist in the repo.
+// nolint: lll // ignore long line length due to input arguments
+func (c *client) configFile(client *github.Client, r *library.Repo, path string, opts *github.RepositoryContentGetOptions) ([]byte, error) {
+	// send API call to capture the pipeline configuration
+	data, _, resp, err := client.Repositories.GetContents(ctx, r.GetOrg(), r.GetName(), path, opts)
+	if err != nil {
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return nil, err
 		}
 	}
 
-	return nil, fmt.Errorf("no valid pipeline configuration file (%s) found", strings.Join(files, ","))
+	if data == nil {
+		return nil, nil
+	}
+
+	strData, err := data.GetContent()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strData), nil
 }
 
 // Disable deactivates a repo by deleting the webhook.
@@ -190,6 +243,14 @@ func (c *client) Enable(u *library.User, org, name, secret string) (string, erro
 
 // Status sends the commit status for the given SHA from the GitHub repo.
 func (c *client) Status(u *library.User, b *library.Build, org, name string) error {
+	return c.PipelineStatus(u, b, org, name, "")
+}
+
+// PipelineStatus sends the commit status for the given SHA from the GitHub repo
+// with the name of the repo pipeline the build was created for in the context.
+//
+// nolint: lll // ignore long line length due to input arguments
+func (c *client) PipelineStatus(u *library.User, b *library.Build, org, name, pipeline string) error {
 	c.Logger.WithFields(logrus.Fields{
 		"build": b.GetNumber(),
 		"org":   org,
@@ -197,10 +258,13 @@ func (c *client) Status(u *library.User, b *library.Build, org, name string) err
 		"user":  u.GetName(),
 	}).Tracef("setting commit status for %s/%s/%d @ %s", org, name, b.GetNumber(), b.GetCommit())
 
-	// create GitHub OAuth client with user's token
-	client := c.newClientToken(*u.Token)
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, org, name)
+	if err != nil {
+		return err
+	}
 
-	context := fmt.Sprintf("%s/%s", c.config.StatusContext, b.GetEvent())
+	context := c.statusContext(b, pipeline)
 	url := fmt.Sprintf("%s/%s/%s/%d", c.config.WebUIAddress, org, name, b.GetNumber())
 
 	var (
@@ -214,6 +278,9 @@ func (c *client) Status(u *library.User, b *library.Build, org, name string) err
 	case constants.StatusRunning, constants.StatusPending:
 		state = "pending"
 		description = fmt.Sprintf("the build is %s", b.GetStatus())
+	case model.StatusPendingApproval:
+		state = "pending"
+		description = "the build is waiting for approval"
 	case constants.StatusSuccess:
 		state = "success"
 		description = "the build was successful"
@@ -285,11 +352,102 @@ func (c *client) Status(u *library.User, b *library.Build, org, name string) err
 	}
 
 	// send API call to create the status context for the commit
-	_, _, err := client.Repositories.CreateStatus(ctx, org, name, b.GetCommit(), status)
+	_, _, err = client.Repositories.CreateStatus(ctx, org, name, b.GetCommit(), status)
+
+	return err
+}
+
+// StageStatus sends the commit status for the given SHA from the GitHub repo
+// for a stage of the build created for a repo pipeline. The name of the stage
+// is added to the context of the build.
+//
+// nolint: lll // ignore long line length due to input arguments
+func (c *client) StageStatus(u *library.User, b *library.Build, org, name, pipeline, stage, status string) error {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+		"org":   org,
+		"repo":  name,
+		"stage": stage,
+		"user":  u.GetName(),
+	}).Tracef("setting commit status for stage %s of %s/%s/%d @ %s", stage, org, name, b.GetNumber(), b.GetCommit())
+
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, org, name)
+	if err != nil {
+		return err
+	}
+
+	context := fmt.Sprintf("%s/%s", c.statusContext(b, pipeline), stage)
+	url := fmt.Sprintf("%s/%s/%s/%d", c.config.WebUIAddress, org, name, b.GetNumber())
+
+	var state string
+
+	// set the state for the status context
+	// depending on what the status of the stage is
+	switch status {
+	case constants.StatusRunning, constants.StatusPending:
+		state = "pending"
+	case constants.StatusSuccess, constants.StatusSkipped:
+		state = "success"
+	case constants.StatusFailure, constants.StatusCanceled, constants.StatusKilled:
+		state = "failure"
+	default:
+		state = "error"
+	}
+
+	// create the status object to make the API call
+	repoStatus := &github.RepoStatus{
+		Context:     github.String(context),
+		Description: github.String(stageDescription(stage, status)),
+		State:       github.String(state),
+	}
+
+	// provide "Details" link in GitHub UI if server was configured with it
+	if len(c.config.WebUIAddress) > 0 {
+		repoStatus.TargetURL = github.String(url)
+	}
+
+	// send API call to create the status context for the commit
+	_, _, err = client.Repositories.CreateStatus(ctx, org, name, b.GetCommit(), repoStatus)
 
 	return err
 }
 
+// stageDescription is a helper function to create the
+// description for the commit status of a stage.
+func stageDescription(stage, status string) string {
+	switch status {
+	case constants.StatusRunning, constants.StatusPending:
+		return fmt.Sprintf("the %s stage is %s", stage, status)
+	case constants.StatusSuccess:
+		return fmt.Sprintf("the %s stage was successful", stage)
+	case constants.StatusFailure:
+		return fmt.Sprintf("the %s stage has failed", stage)
+	case constants.StatusCanceled:
+		return fmt.Sprintf("the %s stage was canceled", stage)
+	case constants.StatusKilled:
+		return fmt.Sprintf("the %s stage was killed", stage)
+	case constants.StatusSkipped:
+		return fmt.Sprintf("the %s stage was skipped", stage)
+	default:
+		return "there was an error"
+	}
+}
+
+// statusContext is a helper function to create the context
+// for the commit status of a build for a repo pipeline.
+func (c *client) statusContext(b *library.Build, pipeline string) string {
+	context := fmt.Sprintf("%s/%s", c.config.StatusContext, b.GetEvent())
+
+	// add the repo pipeline to the context so every
+	// pipeline of the repo has its own commit status
+	if len(pipeline) > 0 {
+		context = fmt.Sprintf("%s/%s", context, pipeline)
+	}
+
+	return context
+}
+
 // GetRepo gets repo information from Github.
 func (c *client) GetRepo(u *library.User, r *library.Repo) (*library.Repo, error) {
 	c.Logger.WithFields(logrus.Fields{
@@ -372,29 +530,59 @@ func toLibraryRepo(gr github.Repository) *library.Repo {
 }
 
 // GetPullRequest defines a function that retrieves
-// a pull request for a repo.
+// a pull request for a repo and whether the pull
+// request is from a fork of the repo.
 // nolint:lll // function signature is lengthy
-func (c *client) GetPullRequest(u *library.User, r *library.Repo, number int) (string, string, string, string, error) {
+func (c *client) GetPullRequest(u *library.User, r *library.Repo, number int) (string, string, string, string, bool, error) {
 	c.Logger.WithFields(logrus.Fields{
 		"org":  r.GetOrg(),
 		"repo": r.GetName(),
 		"user": u.GetName(),
 	}).Tracef("retrieving pull request %d for repo %s", number, r.GetFullName())
 
-	// create GitHub OAuth client with user's token
-	client := c.newClientToken(u.GetToken())
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, r.GetOrg(), r.GetName())
+	if err != nil {
+		return "", "", "", "", false, err
+	}
 
 	pull, _, err := client.PullRequests.Get(ctx, r.GetOrg(), r.GetName(), number)
 	if err != nil {
-		return "", "", "", "", err
+		return "", "", "", "", false, err
 	}
 
 	commit := pull.GetHead().GetSHA()
 	branch := pull.GetBase().GetRef()
 	baseref := pull.GetBase().GetRef()
 	headref := pull.GetHead().GetRef()
+	fork := pull.GetHead().GetRepo().GetFullName() != pull.GetBase().GetRepo().GetFullName()
+
+	return commit, branch, baseref, headref, fork, nil
+}
+
+// GetRefCommit retrieves the commit SHA a ref of the GitHub repo points to.
+func (c *client) GetRefCommit(u *library.User, r *library.Repo, ref string) (string, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("retrieving commit for ref %s of repo %s", ref, r.GetFullName())
+
+	// create GitHub client for the repo with the app or user's token
+	client, err := c.newClientRepo(u, r.GetOrg(), r.GetName())
+	if err != nil {
+		return "", err
+	}
+
+	// send API call to capture the commit SHA for the ref
+	//
+	// https://docs.github.com/en/rest/commits/commits#get-a-commit
+	sha, _, err := client.Repositories.GetCommitSHA1(ctx, r.GetOrg(), r.GetName(), ref, "")
+	if err != nil {
+		return "", fmt.Errorf("unable to get commit for %s@%s: %w", r.GetFullName(), ref, err)
+	}
 
-	return commit, branch, baseref, headref, nil
+	return sha, nil
 }
 
 // GetHTMLURL retrieves the html_url from repository contents from the GitHub repo.
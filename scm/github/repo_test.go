@@ -5,6 +5,7 @@
 package github
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -14,6 +15,9 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v42/github"
+
+	"github.com/go-vela/server/model"
 
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
@@ -412,8 +416,8 @@ func TestGithub_Config_NotFound(t *testing.T) {
 		t.Errorf("Config returned %v, want %v", resp.Code, http.StatusOK)
 	}
 
-	if err == nil {
-		t.Error("Config should have returned err")
+	if !errors.Is(err, model.ErrNoPipelineConfig) {
+		t.Errorf("Config returned err %v, want %v", err, model.ErrNoPipelineConfig)
 	}
 
 	if got != nil {
@@ -421,6 +425,97 @@ func TestGithub_Config_NotFound(t *testing.T) {
 	}
 }
 
+func TestGithub_ConfigFile(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/contents/ci/vela.yml", func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/yml.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	want, err := ioutil.ReadFile("testdata/pipeline.yml")
+	if err != nil {
+		t.Errorf("ConfigFile reading file returned err: %v", err)
+	}
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.ConfigFile(u, r, "", "ci/vela.yml")
+
+	if resp.Code != http.StatusOK {
+		t.Errorf("ConfigFile returned %v, want %v", resp.Code, http.StatusOK)
+	}
+
+	if err != nil {
+		t.Errorf("ConfigFile returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigFile is %v, want %v", got, want)
+	}
+}
+
+func TestGithub_ConfigFile_NotFound(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	// setup mock server
+	engine.GET("/api/v3/repos/foo/bar/contents/ci/vela.yml", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.ConfigFile(u, r, "", "ci/vela.yml")
+
+	if resp.Code != http.StatusOK {
+		t.Errorf("ConfigFile returned %v, want %v", resp.Code, http.StatusOK)
+	}
+
+	if !errors.Is(err, model.ErrNoPipelineConfig) {
+		t.Errorf("ConfigFile returned err %v, want %v", err, model.ErrNoPipelineConfig)
+	}
+
+	if got != nil {
+		t.Errorf("ConfigFile is %v, want nil", got)
+	}
+}
+
 func TestGithub_Disable(t *testing.T) {
 	// setup context
 	gin.SetMode(gin.TestMode)
@@ -924,6 +1019,119 @@ func TestGithub_Status_Error(t *testing.T) {
 	}
 }
 
+func TestGithub_PipelineStatus(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	var context string
+
+	// setup mock server
+	engine.POST("/api/v3/repos/:org/:repo/statuses/:sha", func(c *gin.Context) {
+		status := new(github.RepoStatus)
+
+		_ = c.Bind(status)
+
+		context = status.GetContext()
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/status.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	b := new(library.Build)
+	b.SetID(1)
+	b.SetRepoID(1)
+	b.SetNumber(1)
+	b.SetEvent(constants.EventPush)
+	b.SetStatus(constants.StatusRunning)
+	b.SetCommit("abcd1234")
+
+	client, _ := NewTest(s.URL)
+
+	want := "continuous-integration/vela/push/api"
+
+	// run test
+	err := client.PipelineStatus(u, b, "foo", "bar", "api")
+
+	if resp.Code != http.StatusOK {
+		t.Errorf("PipelineStatus returned %v, want %v", resp.Code, http.StatusOK)
+	}
+
+	if err != nil {
+		t.Errorf("PipelineStatus returned err: %v", err)
+	}
+
+	if context != want {
+		t.Errorf("PipelineStatus context is %s, want %s", context, want)
+	}
+}
+
+func TestGithub_StageStatus(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	got := new(github.RepoStatus)
+
+	// setup mock server
+	engine.POST("/api/v3/repos/:org/:repo/statuses/:sha", func(c *gin.Context) {
+		_ = c.Bind(got)
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/status.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	b := new(library.Build)
+	b.SetID(1)
+	b.SetRepoID(1)
+	b.SetNumber(1)
+	b.SetEvent(constants.EventPush)
+	b.SetStatus(constants.StatusRunning)
+	b.SetCommit("abcd1234")
+
+	client, _ := NewTest(s.URL)
+
+	want := &github.RepoStatus{
+		Context:     github.String("continuous-integration/vela/push/api/test"),
+		Description: github.String("the test stage has failed"),
+		State:       github.String("failure"),
+		TargetURL:   github.String(s.URL + "/foo/bar/1"),
+	}
+
+	// run test
+	err := client.StageStatus(u, b, "foo", "bar", "api", "test", constants.StatusFailure)
+
+	if err != nil {
+		t.Errorf("StageStatus returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StageStatus sent %v, want %v", got, want)
+	}
+}
+
 func TestGithub_GetRepo(t *testing.T) {
 	// setup context
 	gin.SetMode(gin.TestMode)
@@ -1097,6 +1305,57 @@ func TestGithub_ListUserRepos_Ineligible(t *testing.T) {
 	}
 }
 
+func TestGithub_GetRefCommit(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	_, engine := gin.CreateTestContext(resp)
+
+	// setup mock server
+	engine.GET("/api/v3/repos/:owner/:repo/commits/:ref", func(c *gin.Context) {
+		if c.Param("ref") != "v1.0.0" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		c.String(http.StatusOK, "6dcb09b5b57875f334f61aebed695e2e4193db5e")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("octocat")
+	r.SetName("Hello-World")
+
+	want := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.GetRefCommit(u, r, "v1.0.0")
+
+	if err != nil {
+		t.Errorf("GetRefCommit returned err: %v", err)
+	}
+
+	if got != want {
+		t.Errorf("GetRefCommit is %v, want %v", got, want)
+	}
+
+	_, err = client.GetRefCommit(u, r, "v2.0.0")
+
+	if err == nil {
+		t.Errorf("GetRefCommit should have returned err")
+	}
+}
+
 func TestGithub_GetPullRequest(t *testing.T) {
 	// setup context
 	gin.SetMode(gin.TestMode)
@@ -1131,7 +1390,7 @@ func TestGithub_GetPullRequest(t *testing.T) {
 	client, _ := NewTest(s.URL)
 
 	// run test
-	gotCommit, gotBranch, gotBaseRef, gotHeadRef, err := client.GetPullRequest(u, r, 1)
+	gotCommit, gotBranch, gotBaseRef, gotHeadRef, gotFork, err := client.GetPullRequest(u, r, 1)
 
 	if err != nil {
 		t.Errorf("Status returned err: %v", err)
@@ -1152,4 +1411,8 @@ func TestGithub_GetPullRequest(t *testing.T) {
 	if !strings.EqualFold(gotHeadRef, wantHeadRef) {
 		t.Errorf("HeadRef is %v, want %v", gotHeadRef, wantHeadRef)
 	}
+
+	if gotFork {
+		t.Errorf("Fork is %v, want false", gotFork)
+	}
 }
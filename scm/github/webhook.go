@@ -13,6 +13,8 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/go-vela/server/model"
+
 	"github.com/go-vela/types"
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
@@ -20,7 +22,7 @@ import (
 )
 
 // ProcessWebhook parses the webhook from a repo.
-func (c *client) ProcessWebhook(request *http.Request) (*types.Webhook, error) {
+func (c *client) ProcessWebhook(request *http.Request) (*model.Webhook, error) {
 	c.Logger.Tracef("processing GitHub webhook")
 
 	h := new(library.Hook)
@@ -37,31 +39,42 @@ func (c *client) ProcessWebhook(request *http.Request) (*types.Webhook, error) {
 
 	payload, err := github.ValidatePayload(request, nil)
 	if err != nil {
-		return &types.Webhook{Hook: h}, nil
+		return &model.Webhook{Webhook: &types.Webhook{Hook: h}}, nil
 	}
 
 	// parse the payload from the webhook
 	event, err := github.ParseWebHook(github.WebHookType(request), payload)
 
 	if err != nil {
-		return &types.Webhook{Hook: h}, nil
+		return &model.Webhook{Webhook: &types.Webhook{Hook: h}}, nil
 	}
 
+	var (
+		webhook *types.Webhook
+		pull    *model.BuildPullRequest
+	)
+
 	// process the event from the webhook
 	switch event := event.(type) {
 	case *github.PushEvent:
-		return c.processPushEvent(h, event)
+		webhook, err = c.processPushEvent(h, event)
 	case *github.PullRequestEvent:
-		return c.processPREvent(h, event)
+		webhook, pull, err = c.processPREvent(h, event)
 	case *github.DeploymentEvent:
-		return c.processDeploymentEvent(h, event)
+		webhook, err = c.processDeploymentEvent(h, event)
 	case *github.IssueCommentEvent:
-		return c.processIssueCommentEvent(h, event)
+		webhook, pull, err = c.processIssueCommentEvent(h, event, payload)
 	case *github.RepositoryEvent:
-		return c.processRepositoryEvent(h, event)
+		webhook, err = c.processRepositoryEvent(h, event)
+	case *github.ReleaseEvent:
+		webhook, err = c.processReleaseEvent(h, event)
+	case *github.DeleteEvent:
+		webhook, err = c.processDeleteEvent(h, event)
+	default:
+		webhook = &types.Webhook{Hook: h}
 	}
 
-	return &types.Webhook{Hook: h}, nil
+	return &model.Webhook{Webhook: webhook, PullRequest: pull}, err
 }
 
 // VerifyWebhook verifies the webhook from a repo.
@@ -161,7 +174,7 @@ func (c *client) processPushEvent(h *library.Hook, payload *github.PushEvent) (*
 // processPREvent is a helper function to process the pull_request event.
 //
 // nolint: lll // ignore long line length due to variable names
-func (c *client) processPREvent(h *library.Hook, payload *github.PullRequestEvent) (*types.Webhook, error) {
+func (c *client) processPREvent(h *library.Hook, payload *github.PullRequestEvent) (*types.Webhook, *model.BuildPullRequest, error) {
 	c.Logger.WithFields(logrus.Fields{
 		"org":  payload.GetRepo().GetOwner().GetLogin(),
 		"repo": payload.GetRepo().GetName(),
@@ -174,15 +187,22 @@ func (c *client) processPREvent(h *library.Hook, payload *github.PullRequestEven
 		fmt.Sprintf("https://%s/%s/settings/hooks", h.GetHost(), payload.GetRepo().GetFullName()),
 	)
 
-	// if the pull request state isn't open we ignore it
-	if payload.GetPullRequest().GetState() != "open" {
-		return &types.Webhook{Hook: h}, nil
+	// capture the action of the pull request
+	action := strings.ToLower(payload.GetAction())
+
+	// if the pull request state isn't open we ignore it unless it was closed
+	if payload.GetPullRequest().GetState() != "open" && action != model.ActionClosed {
+		return &types.Webhook{Hook: h}, nil, nil
 	}
 
-	// skip if the pull request action is not opened or synchronize
-	if !strings.EqualFold(payload.GetAction(), "opened") &&
-		!strings.EqualFold(payload.GetAction(), "synchronize") {
-		return &types.Webhook{Hook: h}, nil
+	switch action {
+	case "opened", "synchronize":
+	case model.ActionClosed, model.ActionLabeled, model.ActionReopened:
+		// set the event for the hook with the action of the pull request
+		h.SetEvent(model.EventAction(constants.EventPull, action))
+	default:
+		// skip if the pull request action is not supported
+		return &types.Webhook{Hook: h}, nil, nil
 	}
 
 	// capture the repo from the payload
@@ -234,12 +254,145 @@ func (c *client) processPREvent(h *library.Hook, payload *github.PullRequestEven
 		b.SetEmail(payload.GetPullRequest().GetHead().GetUser().GetEmail())
 	}
 
+	// capture the pull request data matched by rulesets
+	p := new(model.BuildPullRequest)
+	p.Labels = labels(payload.GetPullRequest().Labels)
+	p.Draft = payload.GetPullRequest().GetDraft()
+	p.AuthorAssociation = payload.GetPullRequest().GetAuthorAssociation()
+	p.Fork = payload.GetPullRequest().GetHead().GetRepo().GetFullName() != payload.GetRepo().GetFullName()
+
 	return &types.Webhook{
 		Comment:  "",
 		PRNumber: payload.GetNumber(),
 		Hook:     h,
 		Repo:     r,
 		Build:    b,
+	}, p, nil
+}
+
+// processReleaseEvent is a helper function to process the release event.
+// The payload has no commit so the commit of the tag for the release
+// is captured while creating the build.
+//
+// nolint: lll // ignore long line length due to variable names
+func (c *client) processReleaseEvent(h *library.Hook, payload *github.ReleaseEvent) (*types.Webhook, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  payload.GetRepo().GetOwner().GetLogin(),
+		"repo": payload.GetRepo().GetName(),
+	}).Tracef("processing release GitHub webhook for %s", payload.GetRepo().GetFullName())
+
+	// update the hook object
+	h.SetBranch(payload.GetRelease().GetTargetCommitish())
+	h.SetEvent(model.EventRelease)
+	h.SetLink(
+		fmt.Sprintf("https://%s/%s/settings/hooks", h.GetHost(), payload.GetRepo().GetFullName()),
+	)
+
+	// skip if the release action is not published
+	if !strings.EqualFold(payload.GetAction(), "published") {
+		return &types.Webhook{Hook: h}, nil
+	}
+
+	// capture the repo and release from the payload
+	repo := payload.GetRepo()
+	release := payload.GetRelease()
+
+	// convert payload to library repo
+	r := new(library.Repo)
+	r.SetOrg(repo.GetOwner().GetLogin())
+	r.SetName(repo.GetName())
+	r.SetFullName(repo.GetFullName())
+	r.SetLink(repo.GetHTMLURL())
+	r.SetClone(repo.GetCloneURL())
+	r.SetBranch(repo.GetDefaultBranch())
+	r.SetPrivate(repo.GetPrivate())
+
+	// convert payload to library build
+	b := new(library.Build)
+	b.SetEvent(model.EventRelease)
+	b.SetClone(repo.GetCloneURL())
+	b.SetSource(release.GetHTMLURL())
+	b.SetTitle(fmt.Sprintf("%s received from %s", model.EventRelease, repo.GetHTMLURL()))
+	b.SetMessage(release.GetName())
+	b.SetSender(payload.GetSender().GetLogin())
+	b.SetAuthor(release.GetAuthor().GetLogin())
+	b.SetEmail(release.GetAuthor().GetEmail())
+	b.SetBranch(release.GetTargetCommitish())
+	b.SetRef(fmt.Sprintf("refs/tags/%s", release.GetTagName()))
+
+	// ensure the build message is set
+	if len(b.GetMessage()) == 0 {
+		b.SetMessage(release.GetTagName())
+	}
+
+	// ensure the build branch is set
+	if len(b.GetBranch()) == 0 {
+		b.SetBranch(repo.GetDefaultBranch())
+	}
+
+	return &types.Webhook{
+		Comment: "",
+		Hook:    h,
+		Repo:    r,
+		Build:   b,
+	}, nil
+}
+
+// processDeleteEvent is a helper function to process the delete event.
+// The deleted branch or tag no longer exists so the build is created
+// for the commit of the default branch while the ref of the build is
+// the deleted branch or tag.
+//
+// nolint: lll // ignore long line length due to variable names
+func (c *client) processDeleteEvent(h *library.Hook, payload *github.DeleteEvent) (*types.Webhook, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  payload.GetRepo().GetOwner().GetLogin(),
+		"repo": payload.GetRepo().GetName(),
+	}).Tracef("processing delete GitHub webhook for %s", payload.GetRepo().GetFullName())
+
+	// capture the repo from the payload
+	repo := payload.GetRepo()
+
+	// convert payload to library repo
+	r := new(library.Repo)
+	r.SetOrg(repo.GetOwner().GetLogin())
+	r.SetName(repo.GetName())
+	r.SetFullName(repo.GetFullName())
+	r.SetLink(repo.GetHTMLURL())
+	r.SetClone(repo.GetCloneURL())
+	r.SetBranch(repo.GetDefaultBranch())
+	r.SetPrivate(repo.GetPrivate())
+
+	// convert payload to library build
+	b := new(library.Build)
+	b.SetEvent(model.EventDelete)
+	b.SetClone(repo.GetCloneURL())
+	b.SetSource(repo.GetHTMLURL())
+	b.SetTitle(fmt.Sprintf("%s received from %s", model.EventDelete, repo.GetHTMLURL()))
+	b.SetMessage(fmt.Sprintf("%s %s deleted", payload.GetRefType(), payload.GetRef()))
+	b.SetSender(payload.GetSender().GetLogin())
+	b.SetAuthor(payload.GetSender().GetLogin())
+	b.SetBranch(repo.GetDefaultBranch())
+	b.SetRef(fmt.Sprintf("refs/tags/%s", payload.GetRef()))
+
+	// handle when the deleted ref is a branch
+	if strings.EqualFold(payload.GetRefType(), "branch") {
+		b.SetBranch(payload.GetRef())
+		b.SetRef(fmt.Sprintf("refs/heads/%s", payload.GetRef()))
+	}
+
+	// update the hook object
+	h.SetBranch(b.GetBranch())
+	h.SetEvent(model.EventDelete)
+	h.SetLink(
+		fmt.Sprintf("https://%s/%s/settings/hooks", h.GetHost(), r.GetFullName()),
+	)
+
+	return &types.Webhook{
+		Comment: "",
+		Hook:    h,
+		Repo:    r,
+		Build:   b,
 	}, nil
 }
 
@@ -336,7 +489,7 @@ func (c *client) processDeploymentEvent(h *library.Hook, payload *github.Deploym
 // processIssueCommentEvent is a helper function to process the issue comment event.
 //
 // nolint: lll // ignore long line length due to variable names
-func (c *client) processIssueCommentEvent(h *library.Hook, payload *github.IssueCommentEvent) (*types.Webhook, error) {
+func (c *client) processIssueCommentEvent(h *library.Hook, payload *github.IssueCommentEvent, raw []byte) (*types.Webhook, *model.BuildPullRequest, error) {
 	c.Logger.WithFields(logrus.Fields{
 		"org":  payload.GetRepo().GetOwner().GetLogin(),
 		"repo": payload.GetRepo().GetName(),
@@ -354,7 +507,7 @@ func (c *client) processIssueCommentEvent(h *library.Hook, payload *github.Issue
 		return &types.Webhook{
 			Comment: payload.GetComment().GetBody(),
 			Hook:    h,
-		}, nil
+		}, nil, nil
 	}
 
 	// capture the repo from the payload
@@ -385,11 +538,22 @@ func (c *client) processIssueCommentEvent(h *library.Hook, payload *github.Issue
 	b.SetRef(fmt.Sprintf("refs/heads/%s", r.GetBranch()))
 
 	pr := 0
+
+	var p *model.BuildPullRequest
 	// override ref and pull request number if this is
 	// a comment on a pull request
 	if payload.GetIssue().IsPullRequest() {
 		b.SetRef(fmt.Sprintf("refs/pull/%d/head", payload.GetIssue().GetNumber()))
 		pr = payload.GetIssue().GetNumber()
+
+		// capture the pull request data matched by rulesets
+		p = new(model.BuildPullRequest)
+		p.Labels = labels(payload.GetIssue().Labels)
+		p.Draft = issueDraft(raw)
+		p.AuthorAssociation = payload.GetIssue().GetAuthorAssociation()
+		// capture the action of the comment so only
+		// created comments are handled as commands
+		p.Action = strings.ToLower(payload.GetAction())
 	}
 
 	return &types.Webhook{
@@ -398,7 +562,7 @@ func (c *client) processIssueCommentEvent(h *library.Hook, payload *github.Issue
 		Hook:     h,
 		Repo:     r,
 		Build:    b,
-	}, nil
+	}, p, nil
 }
 
 // processRepositoryEvent is a helper function to process the repository event.
@@ -438,3 +602,33 @@ func (c *client) processRepositoryEvent(h *library.Hook, payload *github.Reposit
 		Repo:    r,
 	}, nil
 }
+
+// labels is a helper function to capture
+// the names of the labels for a pull request.
+func labels(l []*github.Label) model.Labels {
+	names := model.Labels{}
+
+	for _, label := range l {
+		names = append(names, label.GetName())
+	}
+
+	return names
+}
+
+// issueDraft is a helper function to capture the draft state of the
+// pull request for an issue comment. The issue type from the client
+// doesn't include the field so it's read from the raw payload.
+func issueDraft(raw []byte) bool {
+	data := struct {
+		Issue struct {
+			Draft bool `json:"draft"`
+		} `json:"issue"`
+	}{}
+
+	err := json.Unmarshal(raw, &data)
+	if err != nil {
+		return false
+	}
+
+	return data.Issue.Draft
+}
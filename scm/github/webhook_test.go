@@ -16,6 +16,8 @@ import (
 	"github.com/go-vela/types/raw"
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/go-vela/server/model"
+
 	"github.com/go-vela/types"
 	"github.com/go-vela/types/constants"
 	"github.com/go-vela/types/library"
@@ -90,8 +92,8 @@ func TestGithub_ProcessWebhook_Push(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
 	}
 }
 
@@ -166,8 +168,8 @@ func TestGithub_ProcessWebhook_Push_NoSender(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
 	}
 }
 
@@ -238,14 +240,23 @@ func TestGithub_ProcessWebhook_PullRequest(t *testing.T) {
 		Build:    wantBuild,
 	}
 
+	wantPull := &model.BuildPullRequest{
+		Labels:            model.Labels{"bug"},
+		AuthorAssociation: "OWNER",
+	}
+
 	got, err := client.ProcessWebhook(request)
 
 	if err != nil {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
+	}
+
+	if !reflect.DeepEqual(got.PullRequest, wantPull) {
+		t.Errorf("ProcessWebhook pull request is %v, want %v", got.PullRequest, wantPull)
 	}
 }
 
@@ -279,16 +290,41 @@ func TestGithub_ProcessWebhook_PullRequest_ClosedAction(t *testing.T) {
 	wantHook.SetSourceID("7bd477e4-4415-11e9-9359-0d41fdf9567e")
 	wantHook.SetCreated(time.Now().UTC().Unix())
 	wantHook.SetHost("github.com")
-	wantHook.SetEvent("pull_request")
+	wantHook.SetEvent("pull_request:closed")
 	wantHook.SetBranch("master")
 	wantHook.SetStatus(constants.StatusSuccess)
 	wantHook.SetLink("https://github.com/Codertocat/Hello-World/settings/hooks")
 
+	wantRepo := new(library.Repo)
+	wantRepo.SetOrg("Codertocat")
+	wantRepo.SetName("Hello-World")
+	wantRepo.SetFullName("Codertocat/Hello-World")
+	wantRepo.SetLink("https://github.com/Codertocat/Hello-World")
+	wantRepo.SetClone("https://github.com/Codertocat/Hello-World.git")
+	wantRepo.SetBranch("master")
+	wantRepo.SetPrivate(false)
+
+	wantBuild := new(library.Build)
+	wantBuild.SetEvent("pull_request")
+	wantBuild.SetClone("https://github.com/Codertocat/Hello-World.git")
+	wantBuild.SetSource("https://github.com/Codertocat/Hello-World/pull/1")
+	wantBuild.SetTitle("pull_request received from https://github.com/Codertocat/Hello-World")
+	wantBuild.SetMessage("Update the README with new information")
+	wantBuild.SetCommit("34c5c7793cb3b279e22454cb6750c80560547b3a")
+	wantBuild.SetSender("Codertocat")
+	wantBuild.SetAuthor("Codertocat")
+	wantBuild.SetEmail("")
+	wantBuild.SetBranch("master")
+	wantBuild.SetRef("refs/pull/1/head")
+	wantBuild.SetBaseRef("master")
+	wantBuild.SetHeadRef("changes")
+
 	want := &types.Webhook{
-		Comment: "",
-		Hook:    wantHook,
-		Repo:    nil,
-		Build:   nil,
+		Comment:  "",
+		PRNumber: wantHook.GetNumber(),
+		Hook:     wantHook,
+		Repo:     wantRepo,
+		Build:    wantBuild,
 	}
 
 	got, err := client.ProcessWebhook(request)
@@ -297,8 +333,8 @@ func TestGithub_ProcessWebhook_PullRequest_ClosedAction(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
 	}
 }
 
@@ -350,8 +386,155 @@ func TestGithub_ProcessWebhook_PullRequest_ClosedState(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
+	}
+}
+
+func TestGithub_ProcessWebhook_Release(t *testing.T) {
+	// setup router
+	s := httptest.NewServer(http.NotFoundHandler())
+	defer s.Close()
+
+	// setup request
+	body, err := os.Open("testdata/hooks/release.json")
+	if err != nil {
+		t.Errorf("unable to open file: %v", err)
+	}
+
+	defer body.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, "/test", body)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "GitHub-Hookshot/a22606a")
+	request.Header.Set("X-GitHub-Delivery", "7bd477e4-4415-11e9-9359-0d41fdf9567e")
+	request.Header.Set("X-GitHub-Host", "github.com")
+	request.Header.Set("X-GitHub-Version", "2.16.0")
+	request.Header.Set("X-GitHub-Event", "release")
+
+	// setup client
+	client, _ := NewTest(s.URL)
+
+	// run test
+	wantHook := new(library.Hook)
+	wantHook.SetNumber(1)
+	wantHook.SetSourceID("7bd477e4-4415-11e9-9359-0d41fdf9567e")
+	wantHook.SetCreated(time.Now().UTC().Unix())
+	wantHook.SetHost("github.com")
+	wantHook.SetEvent("release")
+	wantHook.SetBranch("master")
+	wantHook.SetStatus(constants.StatusSuccess)
+	wantHook.SetLink("https://github.com/Codertocat/Hello-World/settings/hooks")
+
+	wantRepo := new(library.Repo)
+	wantRepo.SetOrg("Codertocat")
+	wantRepo.SetName("Hello-World")
+	wantRepo.SetFullName("Codertocat/Hello-World")
+	wantRepo.SetLink("https://github.com/Codertocat/Hello-World")
+	wantRepo.SetClone("https://github.com/Codertocat/Hello-World.git")
+	wantRepo.SetBranch("master")
+	wantRepo.SetPrivate(false)
+
+	wantBuild := new(library.Build)
+	wantBuild.SetEvent("release")
+	wantBuild.SetClone("https://github.com/Codertocat/Hello-World.git")
+	wantBuild.SetSource("https://github.com/Codertocat/Hello-World/releases/tag/0.0.1")
+	wantBuild.SetTitle("release received from https://github.com/Codertocat/Hello-World")
+	wantBuild.SetMessage("Release 0.0.1")
+	wantBuild.SetSender("Codertocat")
+	wantBuild.SetAuthor("Codertocat")
+	wantBuild.SetEmail("")
+	wantBuild.SetBranch("master")
+	wantBuild.SetRef("refs/tags/0.0.1")
+
+	want := &types.Webhook{
+		Comment: "",
+		Hook:    wantHook,
+		Repo:    wantRepo,
+		Build:   wantBuild,
+	}
+
+	got, err := client.ProcessWebhook(request)
+
+	if err != nil {
+		t.Errorf("ProcessWebhook returned err: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got.Webhook); diff != "" {
+		t.Errorf("ProcessWebhook() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGithub_ProcessWebhook_Delete(t *testing.T) {
+	// setup router
+	s := httptest.NewServer(http.NotFoundHandler())
+	defer s.Close()
+
+	// setup request
+	body, err := os.Open("testdata/hooks/delete.json")
+	if err != nil {
+		t.Errorf("unable to open file: %v", err)
+	}
+
+	defer body.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, "/test", body)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "GitHub-Hookshot/a22606a")
+	request.Header.Set("X-GitHub-Delivery", "7bd477e4-4415-11e9-9359-0d41fdf9567e")
+	request.Header.Set("X-GitHub-Host", "github.com")
+	request.Header.Set("X-GitHub-Version", "2.16.0")
+	request.Header.Set("X-GitHub-Event", "delete")
+
+	// setup client
+	client, _ := NewTest(s.URL)
+
+	// run test
+	wantHook := new(library.Hook)
+	wantHook.SetNumber(1)
+	wantHook.SetSourceID("7bd477e4-4415-11e9-9359-0d41fdf9567e")
+	wantHook.SetCreated(time.Now().UTC().Unix())
+	wantHook.SetHost("github.com")
+	wantHook.SetEvent("delete")
+	wantHook.SetBranch("feature")
+	wantHook.SetStatus(constants.StatusSuccess)
+	wantHook.SetLink("https://github.com/Codertocat/Hello-World/settings/hooks")
+
+	wantRepo := new(library.Repo)
+	wantRepo.SetOrg("Codertocat")
+	wantRepo.SetName("Hello-World")
+	wantRepo.SetFullName("Codertocat/Hello-World")
+	wantRepo.SetLink("https://github.com/Codertocat/Hello-World")
+	wantRepo.SetClone("https://github.com/Codertocat/Hello-World.git")
+	wantRepo.SetBranch("master")
+	wantRepo.SetPrivate(false)
+
+	wantBuild := new(library.Build)
+	wantBuild.SetEvent("delete")
+	wantBuild.SetClone("https://github.com/Codertocat/Hello-World.git")
+	wantBuild.SetSource("https://github.com/Codertocat/Hello-World")
+	wantBuild.SetTitle("delete received from https://github.com/Codertocat/Hello-World")
+	wantBuild.SetMessage("branch feature deleted")
+	wantBuild.SetSender("Codertocat")
+	wantBuild.SetAuthor("Codertocat")
+	wantBuild.SetBranch("feature")
+	wantBuild.SetRef("refs/heads/feature")
+
+	want := &types.Webhook{
+		Comment: "",
+		Hook:    wantHook,
+		Repo:    wantRepo,
+		Build:   wantBuild,
+	}
+
+	got, err := client.ProcessWebhook(request)
+
+	if err != nil {
+		t.Errorf("ProcessWebhook returned err: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got.Webhook); diff != "" {
+		t.Errorf("ProcessWebhook() mismatch (-want +got):\n%s", diff)
 	}
 }
 
@@ -442,7 +625,7 @@ func TestGithub_ProcessWebhook_Deployment(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(want, got); diff != "" {
+			if diff := cmp.Diff(want, got.Webhook); diff != "" {
 				t.Errorf("ProcessWebhook() mismatch (-want +got):\n%s", diff)
 			}
 		})
@@ -520,8 +703,8 @@ func TestGithub_ProcessWebhook_Deployment_Commit(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
 	}
 }
 
@@ -571,8 +754,8 @@ func TestGithub_ProcessWebhook_BadGithubEvent(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
 	}
 }
 
@@ -622,8 +805,8 @@ func TestGithub_ProcessWebhook_BadContentType(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
 	}
 }
 
@@ -760,14 +943,109 @@ func TestGithub_ProcessWebhook_IssueComment_PR(t *testing.T) {
 		Build:    wantBuild,
 	}
 
+	wantPull := &model.BuildPullRequest{
+		Labels:            model.Labels{"bug"},
+		Draft:             true,
+		AuthorAssociation: "OWNER",
+		Action:            "created",
+	}
+
 	got, err := client.ProcessWebhook(request)
 
 	if err != nil {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
+	}
+
+	if !reflect.DeepEqual(got.PullRequest, wantPull) {
+		t.Errorf("ProcessWebhook pull request is %v, want %v", got.PullRequest, wantPull)
+	}
+}
+
+func TestGithub_ProcessWebhook_IssueComment_PR_Edited(t *testing.T) {
+	// setup router
+	s := httptest.NewServer(http.NotFoundHandler())
+	defer s.Close()
+
+	// setup request
+	body, err := os.Open("testdata/hooks/issue_comment_pr_edited.json")
+	if err != nil {
+		t.Errorf("unable to open file: %v", err)
+	}
+
+	defer body.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, "/test", body)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "GitHub-Hookshot/a22606a")
+	request.Header.Set("X-GitHub-Delivery", "7bd477e4-4415-11e9-9359-0d41fdf9567e")
+	request.Header.Set("X-GitHub-Host", "github.com")
+	request.Header.Set("X-GitHub-Version", "2.16.0")
+	request.Header.Set("X-GitHub-Event", "issue_comment")
+
+	// setup client
+	client, _ := NewTest(s.URL)
+
+	// run test
+	wantHook := new(library.Hook)
+	wantHook.SetNumber(1)
+	wantHook.SetSourceID("7bd477e4-4415-11e9-9359-0d41fdf9567e")
+	wantHook.SetCreated(time.Now().UTC().Unix())
+	wantHook.SetHost("github.com")
+	wantHook.SetEvent("comment")
+	wantHook.SetStatus(constants.StatusSuccess)
+	wantHook.SetLink("https://github.com/Codertocat/Hello-World/settings/hooks")
+
+	wantRepo := new(library.Repo)
+	wantRepo.SetOrg("Codertocat")
+	wantRepo.SetName("Hello-World")
+	wantRepo.SetFullName("Codertocat/Hello-World")
+	wantRepo.SetLink("https://github.com/Codertocat/Hello-World")
+	wantRepo.SetClone("https://github.com/Codertocat/Hello-World.git")
+	wantRepo.SetBranch("master")
+	wantRepo.SetPrivate(false)
+
+	wantBuild := new(library.Build)
+	wantBuild.SetEvent("comment")
+	wantBuild.SetClone("https://github.com/Codertocat/Hello-World.git")
+	wantBuild.SetSource("https://github.com/Codertocat/Hello-World/pull/1")
+	wantBuild.SetTitle("comment received from https://github.com/Codertocat/Hello-World")
+	wantBuild.SetMessage("Update the README with new information")
+	wantBuild.SetSender("Codertocat")
+	wantBuild.SetAuthor("Codertocat")
+	wantBuild.SetEmail("")
+	wantBuild.SetRef("refs/pull/1/head")
+
+	want := &types.Webhook{
+		Comment:  "ok to test",
+		PRNumber: wantHook.GetNumber(),
+		Hook:     wantHook,
+		Repo:     wantRepo,
+		Build:    wantBuild,
+	}
+
+	wantPull := &model.BuildPullRequest{
+		Labels:            model.Labels{"bug"},
+		Draft:             true,
+		AuthorAssociation: "OWNER",
+		Action:            "edited",
+	}
+
+	got, err := client.ProcessWebhook(request)
+
+	if err != nil {
+		t.Errorf("ProcessWebhook returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
+	}
+
+	if !reflect.DeepEqual(got.PullRequest, wantPull) {
+		t.Errorf("ProcessWebhook pull request is %v, want %v", got.PullRequest, wantPull)
 	}
 }
 
@@ -839,8 +1117,8 @@ func TestGithub_ProcessWebhook_IssueComment_Created(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
 	}
 }
 
@@ -892,8 +1170,8 @@ func TestGithub_ProcessWebhook_IssueComment_Deleted(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
 	}
 }
 
@@ -952,8 +1230,8 @@ func TestGitHub_ProcessWebhook_RepositoryRename(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
 	}
 }
 
@@ -1011,7 +1289,7 @@ func TestGitHub_ProcessWebhook_Repository(t *testing.T) {
 		t.Errorf("ProcessWebhook returned err: %v", err)
 	}
 
-	if !reflect.DeepEqual(got, want) {
-		t.Errorf("ProcessWebhook is %v, want %v", got, want)
+	if !reflect.DeepEqual(got.Webhook, want) {
+		t.Errorf("ProcessWebhook is %v, want %v", got.Webhook, want)
 	}
 }
@@ -0,0 +1,203 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/types/library"
+)
+
+// OrgAccess captures the user's access level for an org.
+//
+// An org is a group in GitLab and only owners
+// of the group are given the admin access level.
+func (c *client) OrgAccess(u *library.User, org string) (string, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  org,
+		"user": u.GetName(),
+	}).Tracef("capturing %s access level to org %s", u.GetName(), org)
+
+	// if user is accessing personal org
+	if strings.EqualFold(org, u.GetName()) {
+		// nolint: goconst // ignore making constant
+		return "admin", nil
+	}
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	// send API call to capture the current user
+	current := new(user)
+
+	_, err := client.get("user", nil, current)
+	if err != nil {
+		return "", err
+	}
+
+	// send API call to capture group access level for user
+	m := new(member)
+
+	_, err = client.get(fmt.Sprintf("%s/members/all/%d", groupPath(org), current.ID), nil, m)
+	if err != nil {
+		return "", err
+	}
+
+	// return their access level if they are an active user
+	if m.State == "active" {
+		if m.AccessLevel >= accessOwner {
+			return "admin", nil
+		}
+
+		return "member", nil
+	}
+
+	return "", nil
+}
+
+// RepoAccess captures the user's access level for a repo.
+//
+// A repo is a project in GitLab and the role of the user
+// for the project is mapped to an access level where
+// maintainers and owners are given the admin access level.
+func (c *client) RepoAccess(u *library.User, token, org, repo string) (string, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  org,
+		"repo": repo,
+		"user": u.GetName(),
+	}).Tracef("capturing %s access level to repo %s/%s", u.GetName(), org, repo)
+
+	// create gitlab oauth client with the given token
+	client := c.newClientToken(token)
+
+	// send API call to capture the user by username
+	users := []*user{}
+
+	_, err := client.get("users", url.Values{"username": []string{u.GetName()}}, &users)
+	if err != nil {
+		return "", err
+	}
+
+	if len(users) == 0 {
+		return "", fmt.Errorf("unable to find user %s", u.GetName())
+	}
+
+	// send API call to capture repo access level for user
+	m := new(member)
+
+	_, err = client.get(fmt.Sprintf("%s/members/all/%d", projectPath(org, repo), users[0].ID), nil, m)
+	if err != nil {
+		// a user without a role in the project has no access
+		if isNotFound(err) {
+			return "none", nil
+		}
+
+		return "", err
+	}
+
+	switch {
+	case m.AccessLevel >= accessMaintainer:
+		return "admin", nil
+	case m.AccessLevel >= accessDeveloper:
+		return "write", nil
+	case m.AccessLevel >= accessGuest:
+		return "read", nil
+	default:
+		return "none", nil
+	}
+}
+
+// TeamAccess captures the user's access level for a team.
+//
+// A team is a subgroup of the org in GitLab and all
+// members of the subgroup are given the admin access level.
+func (c *client) TeamAccess(u *library.User, org, team string) (string, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  org,
+		"team": team,
+		"user": u.GetName(),
+	}).Tracef("capturing %s access level to team %s/%s", u.GetName(), org, team)
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	// send API call to capture the current user
+	current := new(user)
+
+	_, err := client.get("user", nil, current)
+	if err != nil {
+		return "", err
+	}
+
+	// send API call to capture subgroup membership for user
+	m := new(member)
+
+	path := fmt.Sprintf("%s/members/all/%d", groupPath(fmt.Sprintf("%s/%s", org, team)), current.ID)
+
+	_, err = client.get(path, nil, m)
+	if err != nil {
+		// a user without a role in the subgroup isn't part of the team
+		if isNotFound(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	// return admin access if the user is a part of that team
+	if m.State == "active" {
+		return "admin", nil
+	}
+
+	return "", nil
+}
+
+// ListUsersTeamsForOrg captures the user's teams for an org.
+func (c *client) ListUsersTeamsForOrg(u *library.User, org string) ([]string, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  org,
+		"user": u.GetName(),
+	}).Tracef("capturing %s team membership for org %s", u.GetName(), org)
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+	groups := []*group{}
+
+	// only capture the groups the user is a member of
+	query := url.Values{"min_access_level": []string{fmt.Sprint(accessGuest)}}
+
+	for page := 1; page > 0; {
+		// send API call to list all groups for the user
+		uGroups := []*group{}
+
+		resp, err := client.get("groups", pageQuery(query, page), &uGroups)
+		if err != nil {
+			return []string{""}, err
+		}
+
+		groups = append(groups, uGroups...)
+
+		// break the loop if there is no more results to page through
+		page = nextPage(resp)
+	}
+
+	var userTeams []string
+
+	// iterate through each element in the groups and filter subgroups for specified org
+	for _, g := range groups {
+		prefix := fmt.Sprintf("%s/", org)
+
+		// capture the team if the group is a subgroup of the org we are checking
+		if len(g.FullPath) > len(prefix) && strings.EqualFold(g.FullPath[:len(prefix)], prefix) {
+			userTeams = append(userTeams, g.FullPath[len(prefix):])
+		}
+	}
+
+	return userTeams, nil
+}
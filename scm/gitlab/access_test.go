@@ -0,0 +1,330 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/types/library"
+)
+
+func TestGitlab_OrgAccess(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		org     string
+		member  string
+		want    string
+		failure bool
+	}{
+		{
+			name:   "owner",
+			org:    "github",
+			member: "testdata/member_owner.json",
+			want:   "admin",
+		},
+		{
+			name:   "maintainer",
+			org:    "github",
+			member: "testdata/member_maintainer.json",
+			want:   "member",
+		},
+		{
+			name: "personal",
+			org:  "foo",
+			want: "admin",
+		},
+		{
+			name:    "not found",
+			org:     "github",
+			failure: true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// setup context
+			gin.SetMode(gin.TestMode)
+
+			_, engine := gin.CreateTestContext(httptest.NewRecorder())
+			engine.UseRawPath = true
+
+			// setup mock server
+			engine.GET("/api/v4/user", func(c *gin.Context) {
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusOK)
+				c.File("testdata/user.json")
+			})
+			engine.GET("/api/v4/groups/:group/members/all/:user", func(c *gin.Context) {
+				if len(test.member) == 0 || c.Param("group") != test.org || c.Param("user") != "1" {
+					c.Status(http.StatusNotFound)
+					return
+				}
+
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusOK)
+				c.File(test.member)
+			})
+
+			s := httptest.NewServer(engine)
+			defer s.Close()
+
+			// setup types
+			u := new(library.User)
+			u.SetName("foo")
+			u.SetToken("bar")
+
+			client, _ := NewTest(s.URL)
+
+			// run test
+			got, err := client.OrgAccess(u, test.org)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("OrgAccess should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("OrgAccess returned err: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("OrgAccess is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGitlab_RepoAccess(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name   string
+		member string
+		want   string
+	}{
+		{
+			name:   "maintainer",
+			member: "testdata/member_maintainer.json",
+			want:   "admin",
+		},
+		{
+			name:   "developer",
+			member: "testdata/member_developer.json",
+			want:   "write",
+		},
+		{
+			name: "not a member",
+			want: "none",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// setup context
+			gin.SetMode(gin.TestMode)
+
+			_, engine := gin.CreateTestContext(httptest.NewRecorder())
+			engine.UseRawPath = true
+
+			// setup mock server
+			engine.GET("/api/v4/users", func(c *gin.Context) {
+				if c.Query("username") != "foo" {
+					c.JSON(http.StatusOK, []string{})
+					return
+				}
+
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusOK)
+				c.File("testdata/users.json")
+			})
+			engine.GET("/api/v4/projects/:project/members/all/:user", func(c *gin.Context) {
+				if len(test.member) == 0 || c.Param("project") != "github/octocat" {
+					c.Status(http.StatusNotFound)
+					return
+				}
+
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusOK)
+				c.File(test.member)
+			})
+
+			s := httptest.NewServer(engine)
+			defer s.Close()
+
+			// setup types
+			u := new(library.User)
+			u.SetName("foo")
+			u.SetToken("bar")
+
+			client, _ := NewTest(s.URL)
+
+			// run test
+			got, err := client.RepoAccess(u, u.GetToken(), "github", "octocat")
+
+			if err != nil {
+				t.Errorf("RepoAccess returned err: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("RepoAccess is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGitlab_RepoAccess_UnknownUser(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	// setup mock server
+	engine.GET("/api/v4/users", func(c *gin.Context) {
+		c.JSON(http.StatusOK, []string{})
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("baz")
+	u.SetToken("bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.RepoAccess(u, u.GetToken(), "github", "octocat")
+
+	if err == nil {
+		t.Errorf("RepoAccess should have returned err")
+	}
+
+	if len(got) > 0 {
+		t.Errorf("RepoAccess is %v, want empty", got)
+	}
+}
+
+func TestGitlab_TeamAccess(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name string
+		team string
+		want string
+	}{
+		{
+			name: "member",
+			team: "octokitty",
+			want: "admin",
+		},
+		{
+			name: "not a member",
+			team: "justice-league",
+			want: "",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// setup context
+			gin.SetMode(gin.TestMode)
+
+			_, engine := gin.CreateTestContext(httptest.NewRecorder())
+			engine.UseRawPath = true
+
+			// setup mock server
+			engine.GET("/api/v4/user", func(c *gin.Context) {
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusOK)
+				c.File("testdata/user.json")
+			})
+			engine.GET("/api/v4/groups/:group/members/all/:user", func(c *gin.Context) {
+				if c.Param("group") != "github/octokitty" {
+					c.Status(http.StatusNotFound)
+					return
+				}
+
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusOK)
+				c.File("testdata/member_developer.json")
+			})
+
+			s := httptest.NewServer(engine)
+			defer s.Close()
+
+			// setup types
+			u := new(library.User)
+			u.SetName("foo")
+			u.SetToken("bar")
+
+			client, _ := NewTest(s.URL)
+
+			// run test
+			got, err := client.TeamAccess(u, "github", test.team)
+
+			if err != nil {
+				t.Errorf("TeamAccess returned err: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("TeamAccess is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGitlab_TeamList(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	// setup mock server
+	engine.GET("/api/v4/groups", func(c *gin.Context) {
+		// return a second empty page to verify pagination
+		if c.Query("page") == "2" {
+			c.JSON(http.StatusOK, []string{})
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.Header("X-Next-Page", "2")
+		c.Status(http.StatusOK)
+		c.File("testdata/groups.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	want := []string{"octokitty", "justice-league"}
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.ListUsersTeamsForOrg(u, "github")
+
+	if err != nil {
+		t.Errorf("ListUsersTeamsForOrg returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListUsersTeamsForOrg is %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// perPage is the max number of results
+// returned for a page from the GitLab API.
+const perPage = 100
+
+// api represents a client for the GitLab REST API
+// authenticated with the token of a user.
+//
+// https://docs.gitlab.com/ee/api/rest/
+type api struct {
+	client  *http.Client
+	baseURL string
+}
+
+// apiError represents an error response from the GitLab API.
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error returns the message for the error response.
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+}
+
+// isNotFound is a helper function to check if
+// the error is a not found response from the API.
+func isNotFound(err error) bool {
+	e, ok := err.(*apiError)
+
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+// projectPath is a helper function to capture the
+// API path for a project from the org and name.
+//
+// https://docs.gitlab.com/ee/api/index.html#namespaced-path-encoding
+func projectPath(org, name string) string {
+	return "projects/" + url.PathEscape(fmt.Sprintf("%s/%s", org, name))
+}
+
+// groupPath is a helper function to capture
+// the API path for a group from the full path.
+func groupPath(group string) string {
+	return "groups/" + url.PathEscape(group)
+}
+
+// get sends a GET request to the API and decodes the response into v.
+func (a *api) get(path string, query url.Values, v interface{}) (*http.Response, error) {
+	return a.do(http.MethodGet, path, query, nil, v)
+}
+
+// post sends a POST request to the API with the body
+// encoded as JSON and decodes the response into v.
+func (a *api) post(path string, body, v interface{}) (*http.Response, error) {
+	return a.do(http.MethodPost, path, nil, body, v)
+}
+
+// put sends a PUT request to the API with the body
+// encoded as JSON and decodes the response into v.
+func (a *api) put(path string, body, v interface{}) (*http.Response, error) {
+	return a.do(http.MethodPut, path, nil, body, v)
+}
+
+// delete sends a DELETE request to the API.
+func (a *api) delete(path string) (*http.Response, error) {
+	return a.do(http.MethodDelete, path, nil, nil, nil)
+}
+
+// raw sends a GET request to the API and returns the
+// response body without decoding it from JSON.
+func (a *api) raw(path string, query url.Values) ([]byte, *http.Response, error) {
+	resp, err := a.send(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+
+	return data, resp, err
+}
+
+// do sends a request to the API and decodes the response into v.
+//
+// nolint: lll // ignore long line length due to parameters
+func (a *api) do(method, path string, query url.Values, body, v interface{}) (*http.Response, error) {
+	resp, err := a.send(method, path, query, body)
+	if err != nil {
+		return resp, err
+	}
+
+	defer resp.Body.Close()
+
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
+	}
+
+	return resp, json.NewDecoder(resp.Body).Decode(v)
+}
+
+// send is a helper function to send a request to the API
+// returning an error for an unsuccessful response.
+//
+// nolint: lll // ignore long line length due to parameters
+func (a *api) send(method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	u := a.baseURL + path
+	if len(query) > 0 {
+		u = fmt.Sprintf("%s?%s", u, query.Encode())
+	}
+
+	var buf bytes.Buffer
+
+	if body != nil {
+		err := json.NewEncoder(&buf).Encode(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint: gomnd // ignore magic number
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+
+		msg := http.StatusText(resp.StatusCode)
+
+		// capture the message from the error response
+		e := struct {
+			Message interface{} `json:"message"`
+			Error   string      `json:"error"`
+		}{}
+
+		if json.NewDecoder(resp.Body).Decode(&e) == nil {
+			switch {
+			case e.Message != nil:
+				msg = fmt.Sprintf("%v", e.Message)
+			case len(e.Error) > 0:
+				msg = e.Error
+			}
+		}
+
+		return resp, &apiError{StatusCode: resp.StatusCode, Message: msg}
+	}
+
+	return resp, nil
+}
+
+// nextPage is a helper function to capture the next
+// page of results from the headers of the response.
+//
+// https://docs.gitlab.com/ee/api/index.html#pagination-link-header
+func nextPage(resp *http.Response) int {
+	page, err := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	if err != nil {
+		return 0
+	}
+
+	return page
+}
+
+// pageQuery is a helper function to capture the
+// query for a page of results from the API.
+func pageQuery(query url.Values, page int) url.Values {
+	q := url.Values{}
+
+	for key, values := range query {
+		q[key] = values
+	}
+
+	q.Set("per_page", strconv.Itoa(perPage))
+	q.Set("page", strconv.Itoa(page))
+
+	return q
+}
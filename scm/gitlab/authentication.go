@@ -0,0 +1,146 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-vela/server/random"
+	"github.com/go-vela/types/library"
+)
+
+// Authorize uses the given access token to authorize the user.
+func (c *client) Authorize(token string) (string, error) {
+	c.Logger.Trace("authorizing user with token")
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(token)
+
+	// send API call to capture the current user making the call
+	u := new(user)
+
+	_, err := client.get("user", nil, u)
+	if err != nil {
+		return "", err
+	}
+
+	return u.Username, nil
+}
+
+// Login begins the authentication workflow for the session.
+func (c *client) Login(w http.ResponseWriter, r *http.Request) (string, error) {
+	c.Logger.Trace("processing login request")
+
+	// generate a random string for creating the OAuth state
+	//
+	// nolint: gomnd // ignore magic number
+	oAuthState, err := random.GenerateRandomString(32)
+	if err != nil {
+		return "", err
+	}
+
+	// pass through the redirect if it exists
+	redirect := r.FormValue("redirect_uri")
+	if len(redirect) > 0 {
+		c.OAuth.RedirectURL = redirect
+	}
+
+	// temporarily redirect request to GitLab to begin workflow
+	http.Redirect(w, r, c.OAuth.AuthCodeURL(oAuthState), http.StatusTemporaryRedirect)
+
+	return oAuthState, nil
+}
+
+// Authenticate completes the authentication workflow for the session
+// and returns the remote user details.
+//
+// nolint: lll // ignore long line length due to variable names
+func (c *client) Authenticate(w http.ResponseWriter, r *http.Request, oAuthState string) (*library.User, error) {
+	c.Logger.Trace("authenticating user")
+
+	// get the OAuth code
+	code := r.FormValue("code")
+	if len(code) == 0 {
+		return nil, nil
+	}
+
+	// verify the OAuth state
+	state := r.FormValue("state")
+	if state != oAuthState {
+		return nil, fmt.Errorf("unexpected oauth state: want %s but got %s", oAuthState, state)
+	}
+
+	// pass through the redirect if it exists
+	redirect := r.FormValue("redirect_uri")
+	if len(redirect) > 0 {
+		c.OAuth.RedirectURL = redirect
+	}
+
+	// exchange OAuth code for token
+	token, err := c.OAuth.Exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	// authorize the user for the token
+	u, err := c.Authorize(token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &library.User{
+		Name:  &u,
+		Token: &token.AccessToken,
+	}, nil
+}
+
+// AuthenticateToken completes the authentication workflow
+// for the session and returns the remote user details.
+func (c *client) AuthenticateToken(r *http.Request) (*library.User, error) {
+	c.Logger.Trace("authenticating user via token")
+
+	token := r.Header.Get("Token")
+	if len(token) == 0 {
+		return nil, errors.New("no token provided")
+	}
+
+	// create GitLab OAuth client with the provided token
+	client := c.newClientToken(token)
+
+	// the token info is served from the GitLab address instead of the API
+	client.baseURL = fmt.Sprintf("%s/", c.config.Address)
+
+	// send API call to capture the OAuth application the token was created by
+	//
+	// https://docs.gitlab.com/ee/api/oauth2.html#retrieve-the-token-information
+	info := new(tokenInfo)
+
+	_, err := client.get("oauth/token/info", nil, info)
+	if err != nil {
+		e, ok := err.(*apiError)
+
+		// 401 or 404 is expected when a personal access token is used
+		if !ok || (e.StatusCode != http.StatusUnauthorized && e.StatusCode != http.StatusNotFound) {
+			return nil, err
+		}
+	}
+
+	// return error if the token was created by Vela
+	if err == nil && info.Application.UID == c.config.ClientID {
+		return nil, errors.New("token must not be created by vela")
+	}
+
+	u, err := c.Authorize(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &library.User{
+		Name:  &u,
+		Token: &token,
+	}, nil
+}
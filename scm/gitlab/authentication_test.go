@@ -0,0 +1,283 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/types/library"
+)
+
+func TestGitlab_Authenticate(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	context, engine := gin.CreateTestContext(resp)
+	context.Request, _ = http.NewRequest(http.MethodGet, "/authenticate?code=foo&state=bar", nil)
+
+	// setup mock server
+	engine.POST("/oauth/token", func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/token.json")
+	})
+	engine.GET("/api/v4/user", func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "Bearer bar" {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/user.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	want := new(library.User)
+	want.SetName("foo")
+	want.SetToken("bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.Authenticate(context.Writer, context.Request, "bar")
+
+	if err != nil {
+		t.Errorf("Authenticate returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Authenticate is %v, want %v", got, want)
+	}
+}
+
+func TestGitlab_Authenticate_NoCode(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(resp)
+	context.Request, _ = http.NewRequest(http.MethodGet, "/login", nil)
+
+	s := httptest.NewServer(http.NotFoundHandler())
+	defer s.Close()
+
+	// setup client
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.Authenticate(context.Writer, context.Request, "bar")
+
+	if err != nil {
+		t.Errorf("Authenticate returned err: %v", err)
+	}
+
+	if got != nil {
+		t.Errorf("Authenticate is %v, want nil", got)
+	}
+}
+
+func TestGitlab_Authenticate_BadState(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(resp)
+	context.Request, _ = http.NewRequest(http.MethodGet, "/login?code=foo&state=baz", nil)
+
+	s := httptest.NewServer(http.NotFoundHandler())
+	defer s.Close()
+
+	// setup client
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.Authenticate(context.Writer, context.Request, "bar")
+
+	if err == nil {
+		t.Errorf("Authenticate should have returned err")
+	}
+
+	if got != nil {
+		t.Errorf("Authenticate is %v, want nil", got)
+	}
+}
+
+func TestGitlab_Authorize_NotFound(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	// setup mock server
+	engine.GET("/api/v4/user", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup client
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.Authorize("foobar")
+
+	if err == nil {
+		t.Errorf("Authorize should have returned err")
+	}
+
+	if len(got) > 0 {
+		t.Errorf("Authorize is %v, want empty", got)
+	}
+}
+
+func TestGitlab_Login(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(resp)
+	context.Request, _ = http.NewRequest(http.MethodGet, "/login", nil)
+
+	// setup client
+	client, _ := NewTest("https://gitlab.example.com")
+
+	// run test
+	state, err := client.Login(context.Writer, context.Request)
+
+	if err != nil {
+		t.Errorf("Login returned err: %v", err)
+	}
+
+	if resp.Code != http.StatusTemporaryRedirect {
+		t.Errorf("Login returned %v, want %v", resp.Code, http.StatusTemporaryRedirect)
+	}
+
+	location := resp.Header().Get("Location")
+
+	if !strings.HasPrefix(location, "https://gitlab.example.com/oauth/authorize?") {
+		t.Errorf("Login redirected to %v, want the GitLab authorize endpoint", location)
+	}
+
+	if !strings.Contains(location, "state="+url.QueryEscape(state)) {
+		t.Errorf("Login redirected to %v, want state %v", location, state)
+	}
+}
+
+func TestGitlab_AuthenticateToken(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		info    int
+		file    string
+		failure bool
+	}{
+		{
+			name: "personal access token",
+			info: http.StatusUnauthorized,
+		},
+		{
+			name: "token from another application",
+			info: http.StatusOK,
+			file: "testdata/token_info_other.json",
+		},
+		{
+			name:    "token created by vela",
+			info:    http.StatusOK,
+			file:    "testdata/token_info.json",
+			failure: true,
+		},
+		{
+			name:    "unexpected error",
+			info:    http.StatusInternalServerError,
+			failure: true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// setup context
+			gin.SetMode(gin.TestMode)
+
+			_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+			// setup mock server
+			engine.GET("/oauth/token/info", func(c *gin.Context) {
+				if len(test.file) == 0 {
+					c.Status(test.info)
+					return
+				}
+
+				c.Header("Content-Type", "application/json")
+				c.Status(test.info)
+				c.File(test.file)
+			})
+			engine.GET("/api/v4/user", func(c *gin.Context) {
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusOK)
+				c.File("testdata/user.json")
+			})
+
+			s := httptest.NewServer(engine)
+			defer s.Close()
+
+			request, _ := http.NewRequest(http.MethodPost, "/authenticate/token", nil)
+			request.Header.Set("Token", "baz")
+
+			want := new(library.User)
+			want.SetName("foo")
+			want.SetToken("baz")
+
+			// setup client
+			client, _ := NewTest(s.URL)
+
+			// run test
+			got, err := client.AuthenticateToken(request)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("AuthenticateToken should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("AuthenticateToken returned err: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AuthenticateToken is %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGitlab_AuthenticateToken_NoToken(t *testing.T) {
+	// setup request
+	request, _ := http.NewRequest(http.MethodPost, "/authenticate/token", nil)
+
+	// setup client
+	client, _ := NewTest("https://gitlab.example.com")
+
+	// run test
+	_, err := client.AuthenticateToken(request)
+
+	if err == nil {
+		t.Errorf("AuthenticateToken should have returned err")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/types/library"
+)
+
+// Changeset captures the list of files changed for a commit.
+func (c *client) Changeset(u *library.User, r *library.Repo, sha string) ([]string, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("capturing commit changeset for %s/commit/%s", r.GetFullName(), sha)
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+	s := []string{}
+	f := []*diff{}
+
+	path := fmt.Sprintf("%s/repository/commits/%s/diff", projectPath(r.GetOrg(), r.GetName()), sha)
+
+	for page := 1; page > 0; {
+		// send API call to capture the files from the commit
+		files := []*diff{}
+
+		resp, err := client.get(path, pageQuery(nil, page), &files)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get diff for commit %s: %v", sha, err)
+		}
+
+		f = append(f, files...)
+
+		// break the loop if there is no more results to page through
+		page = nextPage(resp)
+	}
+
+	// iterate through each file in the commit
+	for _, file := range f {
+		s = append(s, file.NewPath)
+	}
+
+	return s, nil
+}
+
+// ChangesetPR captures the list of files changed for a merge request.
+func (c *client) ChangesetPR(u *library.User, r *library.Repo, number int) ([]string, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("capturing merge request changeset for %s/-/merge_requests/%d", r.GetFullName(), number)
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+	s := []string{}
+
+	path := fmt.Sprintf("%s/merge_requests/%d/changes", projectPath(r.GetOrg(), r.GetName()), number)
+
+	// send API call to capture the files from the merge request
+	mr := new(mergeRequest)
+
+	_, err := client.get(path, nil, mr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get changes for merge request %d: %v", number, err)
+	}
+
+	// iterate through each file in the merge request
+	for _, file := range mr.Changes {
+		s = append(s, file.NewPath)
+	}
+
+	return s, nil
+}
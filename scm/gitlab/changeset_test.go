@@ -0,0 +1,116 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-vela/types/library"
+)
+
+func TestGitlab_Changeset(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	// setup mock server
+	engine.GET("/api/v4/projects/:project/repository/commits/:sha/diff", func(c *gin.Context) {
+		if c.Param("project") != "foo/bar" || c.Param("sha") != "6dcb09b5b57875f334f61aebed695e2e4193db5e" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/commit_diff.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	want := []string{"README.md"}
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.Changeset(u, r, "6dcb09b5b57875f334f61aebed695e2e4193db5e")
+
+	if err != nil {
+		t.Errorf("Changeset returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Changeset is %v, want %v", got, want)
+	}
+}
+
+func TestGitlab_ChangesetPR(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	// setup mock server
+	engine.GET("/api/v4/projects/:project/merge_requests/:iid/changes", func(c *gin.Context) {
+		if c.Param("project") != "foo/bar" || c.Param("iid") != "1" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/merge_request_changes.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	want := []string{"README.md"}
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.ChangesetPR(u, r, 1)
+
+	if err != nil {
+		t.Errorf("ChangesetPR returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ChangesetPR is %v, want %v", got, want)
+	}
+
+	// run test for a merge request that doesn't exist
+	_, err = client.ChangesetPR(u, r, 2)
+
+	if err == nil {
+		t.Errorf("ChangesetPR should have returned err")
+	}
+}
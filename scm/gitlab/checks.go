@@ -0,0 +1,27 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/library"
+)
+
+// Reporting outputs the configured mode for reporting builds.
+//
+// GitLab has no equivalent of check runs so builds
+// are always reported with a commit status.
+func (c *client) Reporting() string {
+	return model.ReportingStatus
+}
+
+// PipelineChecks reports the build for the given SHA from the GitLab repo
+// with a commit status since GitLab has no equivalent of check runs.
+//
+// nolint: lll // ignore long line length due to input arguments
+func (c *client) PipelineChecks(u *library.User, b *library.Build, check *model.BuildCheck, steps []*model.CheckStep, org, name, pipeline string) error {
+	return c.PipelineStatus(u, b, org, name, pipeline)
+}
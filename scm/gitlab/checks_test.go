@@ -0,0 +1,26 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-vela/server/model"
+)
+
+func TestGitlab_Reporting(t *testing.T) {
+	// setup types
+	want := model.ReportingStatus
+
+	client, _ := NewTest("https://gitlab.com")
+
+	// run test
+	got := client.Reporting()
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reporting is %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"fmt"
+
+	"github.com/go-vela/types/library"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateComment creates a note on a merge request for the GitLab repo.
+func (c *client) CreateComment(u *library.User, r *library.Repo, number int, body string) (int64, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("creating note on merge request %d for repo %s", number, r.GetFullName())
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	n := new(note)
+
+	// send API call to create the note
+	//
+	// https://docs.gitlab.com/ee/api/notes.html#create-new-merge-request-note
+	_, err := client.post(
+		fmt.Sprintf("%s/merge_requests/%d/notes", projectPath(r.GetOrg(), r.GetName()), number),
+		map[string]string{"body": body},
+		n,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return n.ID, nil
+}
+
+// UpdateComment updates a note on a merge request for the GitLab repo.
+func (c *client) UpdateComment(u *library.User, r *library.Repo, number int, id int64, body string) error {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("updating note %d on merge request %d for repo %s", id, number, r.GetFullName())
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	// send API call to update the note
+	//
+	// https://docs.gitlab.com/ee/api/notes.html#modify-existing-merge-request-note
+	_, err := client.put(
+		fmt.Sprintf("%s/merge_requests/%d/notes/%d", projectPath(r.GetOrg(), r.GetName()), number, id),
+		map[string]string{"body": body},
+		nil,
+	)
+
+	return err
+}
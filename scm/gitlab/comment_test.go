@@ -0,0 +1,126 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/types/library"
+)
+
+func TestGitlab_CreateComment(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	body := map[string]string{}
+
+	// setup mock server
+	engine.POST("/api/v4/projects/:project/merge_requests/:iid/notes", func(c *gin.Context) {
+		if c.Param("project") != "foo/bar" || c.Param("iid") != "1" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		_ = c.BindJSON(&body)
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusCreated)
+		c.File("testdata/note.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.CreateComment(u, r, 1, "Restarted build #2")
+
+	if err != nil {
+		t.Errorf("CreateComment returned err: %v", err)
+	}
+
+	if got != 301 {
+		t.Errorf("CreateComment is %v, want %v", got, 301)
+	}
+
+	if body["body"] != "Restarted build #2" {
+		t.Errorf("CreateComment sent %v, want %v", body["body"], "Restarted build #2")
+	}
+}
+
+func TestGitlab_UpdateComment(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	body := map[string]string{}
+
+	// setup mock server
+	engine.PUT("/api/v4/projects/:project/merge_requests/:iid/notes/:id", func(c *gin.Context) {
+		if c.Param("project") != "foo/bar" || c.Param("iid") != "1" || c.Param("id") != "301" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		_ = c.BindJSON(&body)
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/note.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	err := client.UpdateComment(u, r, 1, 301, "Restarted build #2")
+
+	if err != nil {
+		t.Errorf("UpdateComment returned err: %v", err)
+	}
+
+	if body["body"] != "Restarted build #2" {
+		t.Errorf("UpdateComment sent %v, want %v", body["body"], "Restarted build #2")
+	}
+
+	// run test with an unknown note
+	err = client.UpdateComment(u, r, 1, 302, "Restarted build #2")
+
+	if err == nil {
+		t.Errorf("UpdateComment should have returned err")
+	}
+}
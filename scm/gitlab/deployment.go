@@ -0,0 +1,66 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/types/library"
+)
+
+// errDeployments defines the error returned for deployments
+// since the GitLab driver doesn't trigger builds from
+// GitLab deployments.
+var errDeployments = errors.New("deployments are not supported by the gitlab scm driver")
+
+// GetDeployment gets a deployment from the GitLab repo.
+//
+// nolint: lll // ignore long line length due to variable names
+func (c *client) GetDeployment(u *library.User, r *library.Repo, id int64) (*library.Deployment, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("capturing deployment %d for repo %s", id, r.GetFullName())
+
+	return nil, errDeployments
+}
+
+// GetDeploymentCount counts a list of deployments from the GitLab repo.
+func (c *client) GetDeploymentCount(u *library.User, r *library.Repo) (int64, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("counting deployments for repo %s", r.GetFullName())
+
+	return 0, errDeployments
+}
+
+// GetDeploymentList gets a list of deployments from the GitLab repo.
+//
+// nolint: lll // ignore long line length due to variable names
+func (c *client) GetDeploymentList(u *library.User, r *library.Repo, page, perPage int) ([]*library.Deployment, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("listing deployments for repo %s", r.GetFullName())
+
+	return nil, errDeployments
+}
+
+// CreateDeployment creates a new deployment for the GitLab repo.
+func (c *client) CreateDeployment(u *library.User, r *library.Repo, d *library.Deployment) error {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("creating deployment for repo %s", r.GetFullName())
+
+	return errDeployments
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+// Package gitlab provides the ability for Vela to
+// integrate with GitLab or a self-managed GitLab as a scm provider.
+//
+// Usage:
+//
+// 	import "github.com/go-vela/server/scm/gitlab"
+package gitlab
@@ -0,0 +1,146 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	defaultURL = "https://gitlab.com" // Default GitLab URL
+
+	// events for project webhooks.
+	eventPush         = "Push Hook"
+	eventTagPush      = "Tag Push Hook"
+	eventMergeRequest = "Merge Request Hook"
+	eventNote         = "Note Hook"
+
+	// access levels for group and project members.
+	//
+	// https://docs.gitlab.com/ee/api/members.html#valid-access-levels
+	accessGuest      = 10
+	accessDeveloper  = 30
+	accessMaintainer = 40
+	accessOwner      = 50
+)
+
+var ctx = context.Background()
+
+type config struct {
+	// specifies the address to use for the GitLab client
+	Address string
+	// specifies the API endpoint to use for the GitLab client
+	API string
+	// specifies the OAuth client ID from GitLab to use for the GitLab client
+	ClientID string
+	// specifies the OAuth client secret from GitLab to use for the GitLab client
+	ClientSecret string
+	// specifies the Vela server address to use for the GitLab client
+	ServerAddress string
+	// specifies the Vela server address that the scm provider should use to send Vela webhooks
+	ServerWebhookAddress string
+	// specifies the context for the commit status to use for the GitLab client
+	StatusContext string
+	// specifies the Vela web UI address to use for the GitLab client
+	WebUIAddress string
+	// specifies the OAuth scopes to use for the GitLab client
+	Scopes []string
+}
+
+type client struct {
+	config *config
+	OAuth  *oauth2.Config
+	// https://pkg.go.dev/github.com/sirupsen/logrus#Entry
+	Logger *logrus.Entry
+}
+
+// New returns a SCM implementation that integrates with
+// a GitLab or a self-managed GitLab instance.
+//
+// nolint: revive // ignore returning unexported client
+func New(opts ...ClientOpt) (*client, error) {
+	// create new GitLab client
+	c := new(client)
+
+	// create new fields
+	c.config = new(config)
+	c.OAuth = new(oauth2.Config)
+
+	// create new logger for the client
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#StandardLogger
+	logger := logrus.StandardLogger()
+
+	// create new logger for the client
+	//
+	// https://pkg.go.dev/github.com/sirupsen/logrus?tab=doc#NewEntry
+	c.Logger = logrus.NewEntry(logger).WithField("scm", c.Driver())
+
+	// apply all provided configuration options
+	for _, opt := range opts {
+		err := opt(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// create the GitLab OAuth config object
+	c.OAuth = &oauth2.Config{
+		ClientID:     c.config.ClientID,
+		ClientSecret: c.config.ClientSecret,
+		Scopes:       c.config.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  fmt.Sprintf("%s/oauth/authorize", c.config.Address),
+			TokenURL: fmt.Sprintf("%s/oauth/token", c.config.Address),
+		},
+	}
+
+	return c, nil
+}
+
+// NewTest returns a SCM implementation that integrates with the provided
+// mock server. Only the url from the mock server is required.
+//
+// This function is intended for running tests only.
+//
+// nolint: revive // ignore returning unexported client
+func NewTest(urls ...string) (*client, error) {
+	address := urls[0]
+	server := address
+
+	// check if multiple URLs were provided
+	if len(urls) > 1 {
+		server = urls[1]
+	}
+
+	return New(
+		WithAddress(address),
+		WithClientID("foo"),
+		WithClientSecret("bar"),
+		WithServerAddress(server),
+		WithServerWebhookAddress(""),
+		WithStatusContext("continuous-integration/vela"),
+		WithWebUIAddress(address),
+	)
+}
+
+// helper function to return the GitLab OAuth client.
+func (c *client) newClientToken(token string) *api {
+	// create the token object for the client
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)
+
+	// create the GitLab client from the OAuth client
+	return &api{
+		client:  oauth2.NewClient(ctx, ts),
+		baseURL: c.config.API,
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"testing"
+)
+
+func TestGitlab_New(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		failure bool
+		id      string
+	}{
+		{
+			failure: false,
+			id:      "foo",
+		},
+		{
+			failure: true,
+			id:      "",
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		_, err := New(
+			WithAddress("https://gitlab.com/"),
+			WithClientID(test.id),
+			WithClientSecret("bar"),
+			WithServerAddress("https://vela-server.example.com"),
+			WithStatusContext("continuous-integration/vela"),
+			WithWebUIAddress("https://vela.example.com"),
+			WithScopes([]string{"api", "read_user"}),
+		)
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("New should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("New returned err: %v", err)
+		}
+	}
+}
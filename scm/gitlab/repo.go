@@ -0,0 +1,567 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+)
+
+// ConfigBackoff is a wrapper for Config that will retry five times if the function
+// fails to retrieve the yaml/yml file.
+// nolint: lll // ignore long line length due to input arguments
+func (c *client) ConfigBackoff(u *library.User, r *library.Repo, ref string) (data []byte, err error) {
+	// number of times to retry
+	retryLimit := 5
+
+	for i := 0; i < retryLimit; i++ {
+		// attempt to fetch the config
+		data, err = c.Config(u, r, ref)
+
+		// return err if the last attempt returns error
+		if err != nil && i == retryLimit-1 {
+			return
+		}
+
+		// if data is valid break the retry loop
+		if data != nil {
+			break
+		}
+
+		// sleep in between retries
+		sleep := time.Duration(i+1) * time.Second
+		time.Sleep(sleep)
+	}
+
+	return
+}
+
+// Config gets the pipeline configuration from the GitLab repo.
+func (c *client) Config(u *library.User, r *library.Repo, ref string) ([]byte, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("capturing configuration file for %s/commit/%s", r.GetFullName(), ref)
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	files := []string{".vela.yml", ".vela.yaml"}
+
+	if strings.EqualFold(r.GetPipelineType(), constants.PipelineTypeStarlark) {
+		files = append(files, ".vela.star", ".vela.py")
+	}
+
+	for _, file := range files {
+		data, err := c.configFile(client, r, file, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		// data is not nil if .vela.yml exists
+		if data != nil {
+			return data, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w (%s)", model.ErrNoPipelineConfig, strings.Join(files, ","))
+}
+
+// ConfigFile gets the pipeline configuration from a path in the GitLab repo.
+func (c *client) ConfigFile(u *library.User, r *library.Repo, ref, path string) ([]byte, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("capturing configuration file %s for %s/commit/%s", path, r.GetFullName(), ref)
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	data, err := c.configFile(client, r, path, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, fmt.Errorf("%w (%s)", model.ErrNoPipelineConfig, path)
+	}
+
+	return data, nil
+}
+
+// configFile is a helper function to capture the contents of a
+// pipeline configuration file. No data or error is returned if
+// the file does not exist in the repo.
+func (c *client) configFile(client *api, r *library.Repo, path, ref string) ([]byte, error) {
+	// set the reference for the query to capture the pipeline configuration
+	query := url.Values{}
+	if len(ref) > 0 {
+		query.Set("ref", ref)
+	}
+
+	// send API call to capture the pipeline configuration
+	//
+	// https://docs.gitlab.com/ee/api/repository_files.html#get-raw-file-from-repository
+	data, _, err := client.raw(
+		fmt.Sprintf("%s/repository/files/%s/raw", projectPath(r.GetOrg(), r.GetName()), url.PathEscape(path)),
+		query,
+	)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Disable deactivates a repo by deleting the webhook.
+func (c *client) Disable(u *library.User, org, name string) error {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  org,
+		"repo": name,
+		"user": u.GetName(),
+	}).Tracef("deleting repository webhook for %s/%s", org, name)
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	// send API call to capture the hooks for the repo
+	ids, err := c.hooks(client, org, name)
+	if err != nil {
+		return err
+	}
+
+	// go through all found hook IDs and delete them
+	for _, id := range ids {
+		// send API call to delete the webhook
+		_, err = client.delete(fmt.Sprintf("%s/hooks/%d", projectPath(org, name), id))
+	}
+
+	return err
+}
+
+// Enable activates a repo by creating the webhook.
+func (c *client) Enable(u *library.User, org, name, secret string) (string, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  org,
+		"repo": name,
+		"user": u.GetName(),
+	}).Tracef("creating repository webhook for %s/%s", org, name)
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	// GitLab allows creating duplicate webhooks so
+	// check for an existing webhook for this vela instance
+	ids, err := c.hooks(client, org, name)
+	if err != nil {
+		if isNotFound(err) {
+			return "", fmt.Errorf("repo not found")
+		}
+
+		return "", err
+	}
+
+	if len(ids) > 0 {
+		return "", fmt.Errorf("repo already enabled")
+	}
+
+	// create the hook object to make the API call
+	//
+	// https://docs.gitlab.com/ee/api/projects.html#add-project-hook
+	hook := map[string]interface{}{
+		"url":                     fmt.Sprintf("%s/webhook", c.config.ServerWebhookAddress),
+		"token":                   secret,
+		"push_events":             true,
+		"tag_push_events":         true,
+		"merge_requests_events":   true,
+		"note_events":             true,
+		"enable_ssl_verification": true,
+	}
+
+	// send API call to create the webhook
+	_, err = client.post(fmt.Sprintf("%s/hooks", projectPath(org, name)), hook, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// create the URL for the repo
+	url := fmt.Sprintf("%s/%s/%s", c.config.Address, org, name)
+
+	return url, nil
+}
+
+// hooks is a helper function to capture the IDs of the
+// webhooks for the repo associated with this vela instance.
+func (c *client) hooks(client *api, org, name string) ([]int64, error) {
+	// accounting for situations in which multiple hooks have been
+	// associated with this vela instance, which causes some
+	// disable, repair, enable operations to act in undesirable ways
+	var ids []int64
+
+	for page := 1; page > 0; {
+		// send API call to capture the hooks for the repo
+		hooks := []*hook{}
+
+		resp, err := client.get(fmt.Sprintf("%s/hooks", projectPath(org, name)), pageQuery(nil, page), &hooks)
+		if err != nil {
+			return nil, err
+		}
+
+		// iterate through each element in the hooks
+		for _, hook := range hooks {
+			// capture hook ID if the hook url matches
+			if hook.URL == fmt.Sprintf("%s/webhook", c.config.ServerWebhookAddress) {
+				ids = append(ids, hook.ID)
+			}
+		}
+
+		// break the loop if there is no more results to page through
+		page = nextPage(resp)
+	}
+
+	return ids, nil
+}
+
+// Status sends the commit status for the given SHA from the GitLab repo.
+func (c *client) Status(u *library.User, b *library.Build, org, name string) error {
+	return c.PipelineStatus(u, b, org, name, "")
+}
+
+// PipelineStatus sends the commit status for the given SHA from the GitLab repo
+// with the name of the repo pipeline the build was created for in the context.
+//
+// nolint: lll // ignore long line length due to input arguments
+func (c *client) PipelineStatus(u *library.User, b *library.Build, org, name, pipeline string) error {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+		"org":   org,
+		"repo":  name,
+		"user":  u.GetName(),
+	}).Tracef("setting commit status for %s/%s/%d @ %s", org, name, b.GetNumber(), b.GetCommit())
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	context := fmt.Sprintf("%s/%s", c.config.StatusContext, b.GetEvent())
+
+	// add the repo pipeline to the context so every
+	// pipeline of the repo has its own commit status
+	if len(pipeline) > 0 {
+		context = fmt.Sprintf("%s/%s", context, pipeline)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%d", c.config.WebUIAddress, org, name, b.GetNumber())
+
+	var (
+		state       string
+		description string
+	)
+
+	// set the state and description for the status context
+	// depending on what the status of the build is
+	//
+	// https://docs.gitlab.com/ee/api/commits.html#post-the-build-status-to-a-commit
+	switch b.GetStatus() {
+	case constants.StatusPending:
+		state = "pending"
+		description = fmt.Sprintf("the build is %s", b.GetStatus())
+	case model.StatusPendingApproval:
+		state = "pending"
+		description = "the build is waiting for approval"
+	case constants.StatusRunning:
+		state = "running"
+		description = fmt.Sprintf("the build is %s", b.GetStatus())
+	case constants.StatusSuccess:
+		state = "success"
+		description = "the build was successful"
+	case constants.StatusFailure:
+		// nolint: goconst // ignore making constant
+		state = "failed"
+		description = "the build has failed"
+	case constants.StatusCanceled:
+		state = "canceled"
+		description = "the build was canceled"
+	case constants.StatusKilled:
+		state = "canceled"
+		description = "the build was killed"
+	case constants.StatusSkipped:
+		state = "success"
+		description = "build was skipped as no steps/stages found"
+	default:
+		state = "failed"
+		description = "there was an error"
+	}
+
+	// create the status object to make the API call
+	status := map[string]string{
+		"state":       state,
+		"name":        context,
+		"description": description,
+	}
+
+	// provide "Details" link in GitLab UI if server was configured with it
+	if len(c.config.WebUIAddress) > 0 && b.GetStatus() != constants.StatusSkipped {
+		status["target_url"] = url
+	}
+
+	// send API call to create the status context for the commit
+	_, err := client.post(fmt.Sprintf("%s/statuses/%s", projectPath(org, name), b.GetCommit()), status, nil)
+
+	return err
+}
+
+// StageStatus sends the commit status for the given SHA from the GitLab repo
+// for a stage of the build created for a repo pipeline. The name of the stage
+// is added to the context of the build.
+//
+// nolint: lll // ignore long line length due to input arguments
+func (c *client) StageStatus(u *library.User, b *library.Build, org, name, pipeline, stage, status string) error {
+	c.Logger.WithFields(logrus.Fields{
+		"build": b.GetNumber(),
+		"org":   org,
+		"repo":  name,
+		"stage": stage,
+		"user":  u.GetName(),
+	}).Tracef("setting commit status for stage %s of %s/%s/%d @ %s", stage, org, name, b.GetNumber(), b.GetCommit())
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	context := fmt.Sprintf("%s/%s", c.config.StatusContext, b.GetEvent())
+
+	// add the repo pipeline to the context before the stage
+	if len(pipeline) > 0 {
+		context = fmt.Sprintf("%s/%s", context, pipeline)
+	}
+
+	context = fmt.Sprintf("%s/%s", context, stage)
+
+	url := fmt.Sprintf("%s/%s/%s/%d", c.config.WebUIAddress, org, name, b.GetNumber())
+
+	var state string
+
+	// set the state for the status context
+	// depending on what the status of the stage is
+	//
+	// https://docs.gitlab.com/ee/api/commits.html#post-the-build-status-to-a-commit
+	switch status {
+	case constants.StatusPending:
+		state = "pending"
+	case constants.StatusRunning:
+		state = "running"
+	case constants.StatusSuccess, constants.StatusSkipped:
+		state = "success"
+	case constants.StatusCanceled, constants.StatusKilled:
+		state = "canceled"
+	default:
+		state = "failed"
+	}
+
+	// create the status object to make the API call
+	commitStatus := map[string]string{
+		"state":       state,
+		"name":        context,
+		"description": stageDescription(stage, status),
+	}
+
+	// provide "Details" link in GitLab UI if server was configured with it
+	if len(c.config.WebUIAddress) > 0 {
+		commitStatus["target_url"] = url
+	}
+
+	// send API call to create the status context for the commit
+	_, err := client.post(fmt.Sprintf("%s/statuses/%s", projectPath(org, name), b.GetCommit()), commitStatus, nil)
+
+	return err
+}
+
+// stageDescription is a helper function to create the
+// description for the commit status of a stage.
+func stageDescription(stage, status string) string {
+	switch status {
+	case constants.StatusRunning, constants.StatusPending:
+		return fmt.Sprintf("the %s stage is %s", stage, status)
+	case constants.StatusSuccess:
+		return fmt.Sprintf("the %s stage was successful", stage)
+	case constants.StatusFailure:
+		return fmt.Sprintf("the %s stage has failed", stage)
+	case constants.StatusCanceled:
+		return fmt.Sprintf("the %s stage was canceled", stage)
+	case constants.StatusKilled:
+		return fmt.Sprintf("the %s stage was killed", stage)
+	case constants.StatusSkipped:
+		return fmt.Sprintf("the %s stage was skipped", stage)
+	default:
+		return "there was an error"
+	}
+}
+
+// GetRepo gets repo information from GitLab.
+func (c *client) GetRepo(u *library.User, r *library.Repo) (*library.Repo, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("retrieving repository information for %s", r.GetFullName())
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	// send an API call to get the repo info
+	p := new(project)
+
+	_, err := client.get(projectPath(r.GetOrg(), r.GetName()), nil, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return toLibraryRepo(p), nil
+}
+
+// ListUserRepos returns a list of all repos the user has access to.
+func (c *client) ListUserRepos(u *library.User) ([]*library.Repo, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"user": u.GetName(),
+	}).Tracef("listing source repositories for %s", u.GetName())
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	p := []*project{}
+	f := []*library.Repo{}
+
+	// only capture the projects the user can maintain
+	query := url.Values{
+		"membership":       []string{"true"},
+		"min_access_level": []string{fmt.Sprint(accessMaintainer)},
+	}
+
+	// loop to capture *ALL* the repos
+	for page := 1; page > 0; {
+		// send API call to capture the user's repos
+		projects := []*project{}
+
+		resp, err := client.get("projects", pageQuery(query, page), &projects)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list user repos: %v", err)
+		}
+
+		p = append(p, projects...)
+
+		// break the loop if there is no more results to page through
+		page = nextPage(resp)
+	}
+
+	// iterate through each repo for the user
+	for _, project := range p {
+		// skip if the repo is archived
+		if project.Archived {
+			continue
+		}
+
+		f = append(f, toLibraryRepo(project))
+	}
+
+	return f, nil
+}
+
+// GetPullRequest defines a function that retrieves
+// a merge request for a repo and whether the merge
+// request is from a fork of the repo.
+// nolint:lll // function signature is lengthy
+func (c *client) GetPullRequest(u *library.User, r *library.Repo, number int) (string, string, string, string, bool, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("retrieving merge request %d for repo %s", number, r.GetFullName())
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	mr := new(mergeRequest)
+
+	_, err := client.get(fmt.Sprintf("%s/merge_requests/%d", projectPath(r.GetOrg(), r.GetName()), number), nil, mr)
+	if err != nil {
+		return "", "", "", "", false, err
+	}
+
+	commit := mr.SHA
+	branch := mr.TargetBranch
+	baseref := mr.TargetBranch
+	headref := mr.SourceBranch
+	fork := mr.SourceProjectID != mr.TargetProjectID
+
+	return commit, branch, baseref, headref, fork, nil
+}
+
+// GetRefCommit retrieves the commit SHA a ref of the GitLab repo points to.
+func (c *client) GetRefCommit(u *library.User, r *library.Repo, ref string) (string, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+		"user": u.GetName(),
+	}).Tracef("retrieving commit for ref %s of repo %s", ref, r.GetFullName())
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	// send API call to capture the commit for the ref
+	//
+	// https://docs.gitlab.com/ee/api/commits.html#get-a-single-commit
+	cmt := new(commit)
+
+	_, err := client.get(
+		fmt.Sprintf("%s/repository/commits/%s", projectPath(r.GetOrg(), r.GetName()), url.PathEscape(ref)), nil, cmt,
+	)
+	if err != nil {
+		return "", fmt.Errorf("unable to get commit for %s@%s: %w", r.GetFullName(), ref, err)
+	}
+
+	return cmt.ID, nil
+}
+
+// GetHTMLURL retrieves the web url for a file in the GitLab repo.
+func (c *client) GetHTMLURL(u *library.User, org, repo, name, ref string) (string, error) {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  org,
+		"repo": repo,
+		"user": u.GetName(),
+	}).Tracef("capturing html_url for %s/%s/%s@%s", org, repo, name, ref)
+
+	// create GitLab OAuth client with user's token
+	client := c.newClientToken(u.GetToken())
+
+	// send API call to verify the file exists for org/repo/name at the ref provided
+	//
+	// https://docs.gitlab.com/ee/api/repository_files.html#get-file-from-repository
+	f := new(file)
+
+	_, err := client.get(
+		fmt.Sprintf("%s/repository/files/%s", projectPath(org, repo), url.PathEscape(name)),
+		url.Values{"ref": []string{ref}},
+		f,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s/%s/-/blob/%s/%s", c.config.Address, org, repo, f.Ref, f.FilePath), nil
+}
@@ -0,0 +1,736 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+)
+
+func TestGitlab_Config(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	// setup mock server
+	engine.GET("/api/v4/projects/:project/repository/files/:file/raw", func(c *gin.Context) {
+		if c.Param("project") != "foo/bar" || c.Param("file") != ".vela.yaml" || c.Query("ref") != "main" {
+			c.JSON(http.StatusNotFound, gin.H{"message": "404 File Not Found"})
+			return
+		}
+
+		c.Status(http.StatusOK)
+		c.File("testdata/pipeline.yml")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	want, err := ioutil.ReadFile("testdata/pipeline.yml")
+	if err != nil {
+		t.Errorf("Config reading file returned err: %v", err)
+	}
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.Config(u, r, "main")
+
+	if err != nil {
+		t.Errorf("Config returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Config is %v, want %v", got, want)
+	}
+}
+
+func TestGitlab_Config_NotFound(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	// setup mock server
+	engine.NoRoute(func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "404 File Not Found"})
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.Config(u, r, "main")
+
+	if !errors.Is(err, model.ErrNoPipelineConfig) {
+		t.Errorf("Config returned err %v, want %v", err, model.ErrNoPipelineConfig)
+	}
+
+	if got != nil {
+		t.Errorf("Config is %v, want nil", got)
+	}
+
+	// run test for a pipeline configuration at a path
+	_, err = client.ConfigFile(u, r, "main", "ci/pipeline.yml")
+
+	if !errors.Is(err, model.ErrNoPipelineConfig) {
+		t.Errorf("ConfigFile returned err %v, want %v", err, model.ErrNoPipelineConfig)
+	}
+}
+
+func TestGitlab_Config_Error(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	// setup mock server
+	engine.NoRoute(func(c *gin.Context) {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "401 Unauthorized"})
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	_, err := client.Config(u, r, "main")
+
+	if err == nil || errors.Is(err, model.ErrNoPipelineConfig) {
+		t.Errorf("Config returned err %v, want unauthorized err", err)
+	}
+}
+
+func TestGitlab_Enable(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		hooks   string
+		found   bool
+		want    string
+		failure bool
+	}{
+		{
+			name:  "enabled",
+			found: true,
+			want:  "foo/bar",
+		},
+		{
+			name:    "already enabled",
+			hooks:   "testdata/hook.json",
+			found:   true,
+			failure: true,
+		},
+		{
+			name:    "repo not found",
+			failure: true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// setup context
+			gin.SetMode(gin.TestMode)
+
+			_, engine := gin.CreateTestContext(httptest.NewRecorder())
+			engine.UseRawPath = true
+
+			created := map[string]interface{}{}
+
+			// setup mock server
+			engine.GET("/api/v4/projects/:project/hooks", func(c *gin.Context) {
+				if !test.found {
+					c.JSON(http.StatusNotFound, gin.H{"message": "404 Project Not Found"})
+					return
+				}
+
+				if len(test.hooks) == 0 {
+					c.JSON(http.StatusOK, []string{})
+					return
+				}
+
+				hook, _ := ioutil.ReadFile(test.hooks)
+
+				c.Data(http.StatusOK, "application/json", []byte("["+string(hook)+"]"))
+			})
+			engine.POST("/api/v4/projects/:project/hooks", func(c *gin.Context) {
+				_ = c.BindJSON(&created)
+
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusCreated)
+				c.File("testdata/hook.json")
+			})
+
+			s := httptest.NewServer(engine)
+			defer s.Close()
+
+			// setup types
+			u := new(library.User)
+			u.SetName("foo")
+			u.SetToken("bar")
+
+			client, _ := NewTest(s.URL, "https://vela-server.example.com")
+
+			// run test
+			got, err := client.Enable(u, "foo", "bar", "secret")
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("Enable should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Enable returned err: %v", err)
+			}
+
+			if got != s.URL+"/"+test.want {
+				t.Errorf("Enable is %v, want %v", got, s.URL+"/"+test.want)
+			}
+
+			if created["url"] != "https://vela-server.example.com/webhook" || created["token"] != "secret" {
+				t.Errorf("Enable created hook %v", created)
+			}
+
+			for _, event := range []string{"push_events", "tag_push_events", "merge_requests_events", "note_events"} {
+				if created[event] != true {
+					t.Errorf("Enable created hook without %s", event)
+				}
+			}
+		})
+	}
+}
+
+func TestGitlab_Disable(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	deleted := []string{}
+
+	// setup mock server
+	engine.GET("/api/v4/projects/:project/hooks", func(c *gin.Context) {
+		c.JSON(http.StatusOK, []gin.H{
+			{"id": 1, "url": "https://vela-server.example.com/webhook"},
+			{"id": 2, "url": "https://ci.example.com/hook"},
+			{"id": 3, "url": "https://vela-server.example.com/webhook"},
+		})
+	})
+	engine.DELETE("/api/v4/projects/:project/hooks/:id", func(c *gin.Context) {
+		deleted = append(deleted, c.Param("id"))
+
+		c.Status(http.StatusNoContent)
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	want := []string{"1", "3"}
+
+	client, _ := NewTest(s.URL, "https://vela-server.example.com")
+
+	// run test
+	err := client.Disable(u, "foo", "bar")
+
+	if err != nil {
+		t.Errorf("Disable returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(deleted, want) {
+		t.Errorf("Disable deleted %v, want %v", deleted, want)
+	}
+}
+
+func TestGitlab_Status(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{status: constants.StatusPending, want: "pending"},
+		{status: model.StatusPendingApproval, want: "pending"},
+		{status: constants.StatusRunning, want: "running"},
+		{status: constants.StatusSuccess, want: "success"},
+		{status: constants.StatusFailure, want: "failed"},
+		{status: constants.StatusCanceled, want: "canceled"},
+		{status: constants.StatusKilled, want: "canceled"},
+		{status: constants.StatusError, want: "failed"},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.status, func(t *testing.T) {
+			// setup context
+			gin.SetMode(gin.TestMode)
+
+			_, engine := gin.CreateTestContext(httptest.NewRecorder())
+			engine.UseRawPath = true
+
+			status := map[string]string{}
+
+			// setup mock server
+			engine.POST("/api/v4/projects/:project/statuses/:sha", func(c *gin.Context) {
+				if c.Param("project") != "foo/bar" || c.Param("sha") != "abc123" {
+					c.Status(http.StatusNotFound)
+					return
+				}
+
+				_ = c.BindJSON(&status)
+
+				c.Header("Content-Type", "application/json")
+				c.Status(http.StatusCreated)
+				c.File("testdata/status.json")
+			})
+
+			s := httptest.NewServer(engine)
+			defer s.Close()
+
+			// setup types
+			u := new(library.User)
+			u.SetName("foo")
+			u.SetToken("bar")
+
+			b := new(library.Build)
+			b.SetNumber(1)
+			b.SetEvent(constants.EventPush)
+			b.SetStatus(test.status)
+			b.SetCommit("abc123")
+
+			client, _ := NewTest(s.URL)
+
+			// run test
+			err := client.Status(u, b, "foo", "bar")
+
+			if err != nil {
+				t.Errorf("Status returned err: %v", err)
+			}
+
+			want := map[string]string{
+				"state":       test.want,
+				"name":        "continuous-integration/vela/push",
+				"description": status["description"],
+				"target_url":  s.URL + "/foo/bar/1",
+			}
+
+			if !reflect.DeepEqual(status, want) {
+				t.Errorf("Status sent %v, want %v", status, want)
+			}
+		})
+	}
+}
+
+func TestGitlab_PipelineStatus(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	status := map[string]string{}
+
+	// setup mock server
+	engine.POST("/api/v4/projects/:project/statuses/:sha", func(c *gin.Context) {
+		_ = c.BindJSON(&status)
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusCreated)
+		c.File("testdata/status.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	b := new(library.Build)
+	b.SetNumber(1)
+	b.SetEvent(constants.EventPull)
+	b.SetStatus(constants.StatusSkipped)
+	b.SetCommit("abc123")
+
+	want := map[string]string{
+		"state":       "success",
+		"name":        "continuous-integration/vela/pull_request/deploy",
+		"description": "build was skipped as no steps/stages found",
+	}
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	err := client.PipelineStatus(u, b, "foo", "bar", "deploy")
+
+	if err != nil {
+		t.Errorf("PipelineStatus returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("PipelineStatus sent %v, want %v", status, want)
+	}
+}
+
+func TestGitlab_StageStatus(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	status := map[string]string{}
+
+	// setup mock server
+	engine.POST("/api/v4/projects/:project/statuses/:sha", func(c *gin.Context) {
+		_ = c.BindJSON(&status)
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusCreated)
+		c.File("testdata/status.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	b := new(library.Build)
+	b.SetNumber(1)
+	b.SetEvent(constants.EventPush)
+	b.SetStatus(constants.StatusRunning)
+	b.SetCommit("abc123")
+
+	want := map[string]string{
+		"state":       "running",
+		"name":        "continuous-integration/vela/push/test",
+		"description": "the test stage is running",
+		"target_url":  s.URL + "/foo/bar/1",
+	}
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	err := client.StageStatus(u, b, "foo", "bar", "", "test", constants.StatusRunning)
+
+	if err != nil {
+		t.Errorf("StageStatus returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("StageStatus sent %v, want %v", status, want)
+	}
+}
+
+func TestGitlab_GetRepo(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	// setup mock server
+	engine.GET("/api/v4/projects/:project", func(c *gin.Context) {
+		if c.Param("project") != "foo/bar" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/project.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	want := new(library.Repo)
+	want.SetOrg("foo")
+	want.SetName("bar")
+	want.SetFullName("foo/bar")
+	want.SetLink("https://gitlab.example.com/foo/bar")
+	want.SetClone("https://gitlab.example.com/foo/bar.git")
+	want.SetBranch("main")
+	want.SetPrivate(true)
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.GetRepo(u, r)
+
+	if err != nil {
+		t.Errorf("GetRepo returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetRepo is %v, want %v", got, want)
+	}
+}
+
+func TestGitlab_ListUserRepos(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+
+	// setup mock server
+	engine.GET("/api/v4/projects", func(c *gin.Context) {
+		if c.Query("membership") != "true" || c.Query("min_access_level") != "40" {
+			c.JSON(http.StatusOK, []string{})
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/projects.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+	r.SetLink("https://gitlab.example.com/foo/bar")
+	r.SetClone("https://gitlab.example.com/foo/bar.git")
+	r.SetBranch("main")
+	r.SetPrivate(true)
+
+	want := []*library.Repo{r}
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.ListUserRepos(u)
+
+	if err != nil {
+		t.Errorf("ListUserRepos returned err: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListUserRepos is %v, want %v", got, want)
+	}
+}
+
+func TestGitlab_GetPullRequest(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	// setup mock server
+	engine.GET("/api/v4/projects/:project/merge_requests/:iid", func(c *gin.Context) {
+		if c.Param("project") != "foo/bar" || c.Param("iid") != "1" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/merge_request.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	commit, branch, baseref, headref, fork, err := client.GetPullRequest(u, r, 1)
+
+	if err != nil {
+		t.Errorf("GetPullRequest returned err: %v", err)
+	}
+
+	if commit != "34c5c7793cb3b279e22454cb6750c80560547b3a" {
+		t.Errorf("GetPullRequest commit is %v", commit)
+	}
+
+	if branch != "main" || baseref != "main" {
+		t.Errorf("GetPullRequest branch is %v and baseref is %v, want main", branch, baseref)
+	}
+
+	if headref != "feature" {
+		t.Errorf("GetPullRequest headref is %v, want feature", headref)
+	}
+
+	if !fork {
+		t.Errorf("GetPullRequest fork is %v, want true", fork)
+	}
+}
+
+func TestGitlab_GetRefCommit(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	// setup mock server
+	engine.GET("/api/v4/projects/:project/repository/commits/:ref", func(c *gin.Context) {
+		if c.Param("project") != "foo/bar" || c.Param("ref") != "feature/foo" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.String(http.StatusOK, `{"id": "34c5c7793cb3b279e22454cb6750c80560547b3a"}`)
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.GetRefCommit(u, r, "feature/foo")
+
+	if err != nil {
+		t.Errorf("GetRefCommit returned err: %v", err)
+	}
+
+	if got != "34c5c7793cb3b279e22454cb6750c80560547b3a" {
+		t.Errorf("GetRefCommit is %v", got)
+	}
+}
+
+func TestGitlab_GetHTMLURL(t *testing.T) {
+	// setup context
+	gin.SetMode(gin.TestMode)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.UseRawPath = true
+
+	// setup mock server
+	engine.GET("/api/v4/projects/:project/repository/files/:file", func(c *gin.Context) {
+		if c.Param("file") != ".vela.yml" || c.Query("ref") != "main" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.File("testdata/file.json")
+	})
+
+	s := httptest.NewServer(engine)
+	defer s.Close()
+
+	// setup types
+	u := new(library.User)
+	u.SetName("foo")
+	u.SetToken("bar")
+
+	want := s.URL + "/foo/bar/-/blob/main/.vela.yml"
+
+	client, _ := NewTest(s.URL)
+
+	// run test
+	got, err := client.GetHTMLURL(u, "foo", "bar", ".vela.yml", "main")
+
+	if err != nil {
+		t.Errorf("GetHTMLURL returned err: %v", err)
+	}
+
+	if got != want {
+		t.Errorf("GetHTMLURL is %v, want %v", got, want)
+	}
+
+	// run test for a file that doesn't exist
+	_, err = client.GetHTMLURL(u, "foo", "bar", ".vela.yaml", "main")
+
+	if err == nil {
+		t.Errorf("GetHTMLURL should have returned err")
+	}
+}
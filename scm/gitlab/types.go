@@ -0,0 +1,123 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"strings"
+
+	"github.com/go-vela/types/library"
+)
+
+// user represents a user from the GitLab API.
+type user struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+}
+
+// member represents a member of a group
+// or project from the GitLab API.
+type member struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	State       string `json:"state"`
+	AccessLevel int    `json:"access_level"`
+}
+
+// group represents a group from the GitLab API.
+type group struct {
+	ID       int64  `json:"id"`
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
+}
+
+// namespace represents the namespace
+// of a project from the GitLab API.
+type namespace struct {
+	ID       int64  `json:"id"`
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
+	Kind     string `json:"kind"`
+}
+
+// project represents a project from the GitLab API.
+type project struct {
+	ID                int64     `json:"id"`
+	Path              string    `json:"path"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	Namespace         namespace `json:"namespace"`
+	WebURL            string    `json:"web_url"`
+	HTTPURLToRepo     string    `json:"http_url_to_repo"`
+	DefaultBranch     string    `json:"default_branch"`
+	Visibility        string    `json:"visibility"`
+	Archived          bool      `json:"archived"`
+}
+
+// hook represents a project webhook from the GitLab API.
+type hook struct {
+	ID  int64  `json:"id"`
+	URL string `json:"url"`
+}
+
+// diff represents a file changed for a commit
+// or merge request from the GitLab API.
+type diff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	DeletedFile bool   `json:"deleted_file"`
+}
+
+// commit represents a commit of a project from the GitLab API.
+type commit struct {
+	ID string `json:"id"`
+}
+
+// mergeRequest represents a merge request from the GitLab API.
+type mergeRequest struct {
+	IID             int    `json:"iid"`
+	Title           string `json:"title"`
+	State           string `json:"state"`
+	SHA             string `json:"sha"`
+	SourceBranch    string `json:"source_branch"`
+	TargetBranch    string `json:"target_branch"`
+	SourceProjectID int64  `json:"source_project_id"`
+	TargetProjectID int64  `json:"target_project_id"`
+	WebURL          string `json:"web_url"`
+	Changes         []diff `json:"changes"`
+}
+
+// note represents a comment on a merge request from the GitLab API.
+type note struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// file represents a file in the repository of a project from the GitLab API.
+type file struct {
+	FilePath string `json:"file_path"`
+	Ref      string `json:"ref"`
+}
+
+// tokenInfo represents the details of an OAuth token from the GitLab API.
+type tokenInfo struct {
+	Application struct {
+		UID string `json:"uid"`
+	} `json:"application"`
+}
+
+// toLibraryRepo does a partial conversion of a gitlab project to a library repo.
+func toLibraryRepo(p *project) *library.Repo {
+	r := new(library.Repo)
+	r.SetOrg(p.Namespace.FullPath)
+	r.SetName(p.Path)
+	r.SetFullName(p.PathWithNamespace)
+	r.SetLink(p.WebURL)
+	r.SetClone(p.HTTPURLToRepo)
+	r.SetBranch(p.DefaultBranch)
+	r.SetPrivate(!strings.EqualFold(p.Visibility, "public"))
+
+	return r
+}
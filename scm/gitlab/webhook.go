@@ -0,0 +1,447 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+)
+
+// hookProject represents the project from a GitLab webhook.
+type hookProject struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+	GitHTTPURL        string `json:"git_http_url"`
+	DefaultBranch     string `json:"default_branch"`
+	VisibilityLevel   int    `json:"visibility_level"`
+}
+
+// hookUser represents the user from a GitLab webhook.
+type hookUser struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// hookCommit represents a commit from a GitLab webhook.
+type hookCommit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	URL     string `json:"url"`
+	Author  struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+}
+
+// hookLabel represents a label from a GitLab webhook.
+type hookLabel struct {
+	Title string `json:"title"`
+}
+
+// hookMergeRequest represents the merge request from a GitLab webhook.
+type hookMergeRequest struct {
+	IID             int         `json:"iid"`
+	Title           string      `json:"title"`
+	State           string      `json:"state"`
+	Action          string      `json:"action"`
+	OldRev          string      `json:"oldrev"`
+	URL             string      `json:"url"`
+	SourceBranch    string      `json:"source_branch"`
+	TargetBranch    string      `json:"target_branch"`
+	LastCommit      hookCommit  `json:"last_commit"`
+	Labels          []hookLabel `json:"labels"`
+	Draft           bool        `json:"draft"`
+	WorkInProgress  bool        `json:"work_in_progress"`
+	SourceProjectID int64       `json:"source_project_id"`
+	TargetProjectID int64       `json:"target_project_id"`
+}
+
+// pushEvent represents the payload for a push or tag push event.
+//
+// https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#push-events
+type pushEvent struct {
+	ObjectKind   string       `json:"object_kind"`
+	Ref          string       `json:"ref"`
+	CheckoutSHA  string       `json:"checkout_sha"`
+	UserName     string       `json:"user_name"`
+	UserUsername string       `json:"user_username"`
+	UserEmail    string       `json:"user_email"`
+	Project      hookProject  `json:"project"`
+	Commits      []hookCommit `json:"commits"`
+}
+
+// mergeRequestEvent represents the payload for a merge request event.
+//
+// https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#merge-request-events
+type mergeRequestEvent struct {
+	ObjectKind       string           `json:"object_kind"`
+	User             hookUser         `json:"user"`
+	Project          hookProject      `json:"project"`
+	ObjectAttributes hookMergeRequest `json:"object_attributes"`
+}
+
+// noteEvent represents the payload for a comment event.
+//
+// https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#comment-events
+type noteEvent struct {
+	ObjectKind       string      `json:"object_kind"`
+	User             hookUser    `json:"user"`
+	Project          hookProject `json:"project"`
+	ObjectAttributes struct {
+		Action       string `json:"action"`
+		Note         string `json:"note"`
+		NoteableType string `json:"noteable_type"`
+		URL          string `json:"url"`
+	} `json:"object_attributes"`
+	MergeRequest *hookMergeRequest `json:"merge_request"`
+	Issue        *struct {
+		IID   int    `json:"iid"`
+		Title string `json:"title"`
+	} `json:"issue"`
+}
+
+// ProcessWebhook parses the webhook from a repo.
+func (c *client) ProcessWebhook(request *http.Request) (*model.Webhook, error) {
+	c.Logger.Tracef("processing GitLab webhook")
+
+	h := new(library.Hook)
+	h.SetNumber(1)
+	h.SetSourceID(request.Header.Get("X-Gitlab-Event-UUID"))
+	h.SetCreated(time.Now().UTC().Unix())
+	h.SetHost("gitlab.com")
+	h.SetEvent(request.Header.Get("X-Gitlab-Event"))
+	h.SetStatus(constants.StatusSuccess)
+
+	// capture the host from the GitLab address
+	if u, err := url.Parse(c.config.Address); err == nil && len(u.Host) > 0 {
+		h.SetHost(u.Host)
+	}
+
+	payload, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return &model.Webhook{Webhook: &types.Webhook{Hook: h}}, nil
+	}
+
+	// process the event from the webhook
+	switch request.Header.Get("X-Gitlab-Event") {
+	case eventPush, eventTagPush:
+		event := new(pushEvent)
+
+		err = json.Unmarshal(payload, event)
+		if err != nil {
+			return &model.Webhook{Webhook: &types.Webhook{Hook: h}}, nil
+		}
+
+		webhook, err := c.processPushEvent(h, event)
+
+		return &model.Webhook{Webhook: webhook}, err
+	case eventMergeRequest:
+		event := new(mergeRequestEvent)
+
+		err = json.Unmarshal(payload, event)
+		if err != nil {
+			return &model.Webhook{Webhook: &types.Webhook{Hook: h}}, nil
+		}
+
+		webhook, pull, err := c.processMergeRequestEvent(h, event)
+
+		return &model.Webhook{Webhook: webhook, PullRequest: pull}, err
+	case eventNote:
+		event := new(noteEvent)
+
+		err = json.Unmarshal(payload, event)
+		if err != nil {
+			return &model.Webhook{Webhook: &types.Webhook{Hook: h}}, nil
+		}
+
+		webhook, pull, err := c.processNoteEvent(h, event)
+
+		return &model.Webhook{Webhook: webhook, PullRequest: pull}, err
+	}
+
+	return &model.Webhook{Webhook: &types.Webhook{Hook: h}}, nil
+}
+
+// VerifyWebhook verifies the webhook from a repo.
+//
+// GitLab sends the secret token configured for the
+// webhook in the request instead of signing the payload.
+func (c *client) VerifyWebhook(request *http.Request, r *library.Repo) error {
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+	}).Tracef("verifying GitLab webhook for %s", r.GetFullName())
+
+	token := request.Header.Get("X-Gitlab-Token")
+	if len(token) == 0 {
+		return errors.New("missing X-Gitlab-Token header")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(r.GetHash())) != 1 {
+		return errors.New("invalid X-Gitlab-Token header")
+	}
+
+	return nil
+}
+
+// processPushEvent is a helper function to process the push and tag push events.
+func (c *client) processPushEvent(h *library.Hook, payload *pushEvent) (*types.Webhook, error) {
+	// convert payload to library repo
+	r := hookRepo(&payload.Project)
+
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+	}).Tracef("processing push GitLab webhook for %s", r.GetFullName())
+
+	// capture the head commit from the payload
+	head := hookCommit{ID: payload.CheckoutSHA}
+
+	for _, commit := range payload.Commits {
+		if commit.ID == payload.CheckoutSHA {
+			head = commit
+		}
+	}
+
+	// convert payload to library build
+	b := new(library.Build)
+	b.SetEvent(constants.EventPush)
+	b.SetClone(r.GetClone())
+	b.SetSource(head.URL)
+	b.SetTitle(fmt.Sprintf("%s received from %s", constants.EventPush, r.GetLink()))
+	b.SetMessage(head.Message)
+	b.SetCommit(payload.CheckoutSHA)
+	b.SetSender(payload.UserUsername)
+	b.SetAuthor(head.Author.Name)
+	b.SetEmail(head.Author.Email)
+	b.SetBranch(strings.TrimPrefix(payload.Ref, "refs/heads/"))
+	b.SetRef(payload.Ref)
+
+	// update the hook object
+	h.SetBranch(b.GetBranch())
+	h.SetEvent(constants.EventPush)
+	h.SetLink(hookLink(r))
+
+	// skip if the push deleted the branch or tag
+	if len(payload.CheckoutSHA) == 0 {
+		return &types.Webhook{Hook: h}, nil
+	}
+
+	// ensure the build author is set
+	if len(b.GetAuthor()) == 0 {
+		b.SetAuthor(payload.UserUsername)
+	}
+
+	// ensure the build email is set
+	if len(b.GetEmail()) == 0 {
+		b.SetEmail(payload.UserEmail)
+	}
+
+	// handle when push event is a tag
+	if strings.HasPrefix(b.GetRef(), "refs/tags/") {
+		// set the proper event for the hook
+		h.SetEvent(constants.EventTag)
+		// set the proper event for the build
+		b.SetEvent(constants.EventTag)
+		// set the proper title for the build
+		b.SetTitle(fmt.Sprintf("%s received from %s", constants.EventTag, r.GetLink()))
+		// set the proper branch for the build
+		b.SetBranch(r.GetBranch())
+	}
+
+	return &types.Webhook{
+		Comment: "",
+		Hook:    h,
+		Repo:    r,
+		Build:   b,
+	}, nil
+}
+
+// processMergeRequestEvent is a helper function to process the merge request event.
+//
+// nolint: lll // ignore long line length due to variable names
+func (c *client) processMergeRequestEvent(h *library.Hook, payload *mergeRequestEvent) (*types.Webhook, *model.BuildPullRequest, error) {
+	// convert payload to library repo
+	r := hookRepo(&payload.Project)
+
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+	}).Tracef("processing merge request GitLab webhook for %s", r.GetFullName())
+	mr := payload.ObjectAttributes
+
+	// update the hook object
+	h.SetBranch(mr.TargetBranch)
+	h.SetEvent(constants.EventPull)
+	h.SetLink(hookLink(r))
+
+	// if the merge request state isn't open we ignore it
+	if mr.State != "opened" {
+		return &types.Webhook{Hook: h}, nil, nil
+	}
+
+	// skip if the merge request wasn't opened or updated with new commits
+	if !strings.EqualFold(mr.Action, "open") &&
+		!(strings.EqualFold(mr.Action, "update") && len(mr.OldRev) > 0) {
+		return &types.Webhook{Hook: h}, nil, nil
+	}
+
+	// convert payload to library build
+	b := new(library.Build)
+	b.SetEvent(constants.EventPull)
+	b.SetClone(r.GetClone())
+	b.SetSource(mr.URL)
+	b.SetTitle(fmt.Sprintf("%s received from %s", constants.EventPull, r.GetLink()))
+	b.SetMessage(mr.Title)
+	b.SetCommit(mr.LastCommit.ID)
+	b.SetSender(payload.User.Username)
+	b.SetAuthor(mr.LastCommit.Author.Name)
+	b.SetEmail(mr.LastCommit.Author.Email)
+	b.SetBranch(mr.TargetBranch)
+	b.SetRef(fmt.Sprintf("refs/merge-requests/%d/head", mr.IID))
+	b.SetBaseRef(mr.TargetBranch)
+	b.SetHeadRef(mr.SourceBranch)
+
+	// ensure the build author is set
+	if len(b.GetAuthor()) == 0 {
+		b.SetAuthor(payload.User.Username)
+	}
+
+	// ensure the build email is set
+	if len(b.GetEmail()) == 0 {
+		b.SetEmail(payload.User.Email)
+	}
+
+	return &types.Webhook{
+		Comment:  "",
+		PRNumber: mr.IID,
+		Hook:     h,
+		Repo:     r,
+		Build:    b,
+	}, hookPullRequest(&mr), nil
+}
+
+// processNoteEvent is a helper function to process the comment event.
+func (c *client) processNoteEvent(h *library.Hook, payload *noteEvent) (*types.Webhook, *model.BuildPullRequest, error) {
+	// convert payload to library repo
+	r := hookRepo(&payload.Project)
+
+	c.Logger.WithFields(logrus.Fields{
+		"org":  r.GetOrg(),
+		"repo": r.GetName(),
+	}).Tracef("processing comment GitLab webhook for %s", r.GetFullName())
+
+	// update the hook object
+	h.SetEvent(constants.EventComment)
+	h.SetLink(hookLink(r))
+
+	// convert payload to library build
+	b := new(library.Build)
+	b.SetEvent(constants.EventComment)
+	b.SetClone(r.GetClone())
+	b.SetSource(payload.ObjectAttributes.URL)
+	b.SetTitle(fmt.Sprintf("%s received from %s", constants.EventComment, r.GetLink()))
+	b.SetSender(payload.User.Username)
+	b.SetAuthor(payload.User.Username)
+	b.SetEmail(payload.User.Email)
+	// treat as non-merge-request comment by default and
+	// set ref to default branch for the repo
+	b.SetRef(fmt.Sprintf("refs/heads/%s", r.GetBranch()))
+
+	pr := 0
+
+	var p *model.BuildPullRequest
+
+	switch {
+	// override ref and merge request number if this is
+	// a comment on a merge request
+	case payload.MergeRequest != nil:
+		b.SetMessage(payload.MergeRequest.Title)
+		b.SetRef(fmt.Sprintf("refs/merge-requests/%d/head", payload.MergeRequest.IID))
+		pr = payload.MergeRequest.IID
+		p = hookPullRequest(payload.MergeRequest)
+
+		// capture the action of the comment so only created
+		// comments are handled as commands, older versions
+		// of GitLab only send the event for created comments
+		p.Action = model.ActionCreated
+		if len(payload.ObjectAttributes.Action) > 0 && payload.ObjectAttributes.Action != "create" {
+			p.Action = payload.ObjectAttributes.Action
+		}
+	case payload.Issue != nil:
+		b.SetMessage(payload.Issue.Title)
+	}
+
+	return &types.Webhook{
+		Comment:  payload.ObjectAttributes.Note,
+		PRNumber: pr,
+		Hook:     h,
+		Repo:     r,
+		Build:    b,
+	}, p, nil
+}
+
+// hookPullRequest is a helper function to capture the pull request
+// data matched by rulesets from the merge request of a webhook.
+// GitLab has no author association for merge requests.
+func hookPullRequest(mr *hookMergeRequest) *model.BuildPullRequest {
+	p := new(model.BuildPullRequest)
+	p.Labels = model.Labels{}
+	p.Draft = mr.Draft || mr.WorkInProgress
+	p.Fork = mr.SourceProjectID != mr.TargetProjectID
+
+	for _, label := range mr.Labels {
+		p.Labels = append(p.Labels, label.Title)
+	}
+
+	return p
+}
+
+// hookRepo is a helper function to convert
+// the project from a webhook to a library repo.
+func hookRepo(p *hookProject) *library.Repo {
+	org, name := "", p.PathWithNamespace
+
+	// the org is the full path of the namespace for the project
+	if i := strings.LastIndex(p.PathWithNamespace, "/"); i >= 0 {
+		org, name = p.PathWithNamespace[:i], p.PathWithNamespace[i+1:]
+	}
+
+	r := new(library.Repo)
+	r.SetOrg(org)
+	r.SetName(name)
+	r.SetFullName(p.PathWithNamespace)
+	r.SetLink(p.WebURL)
+	r.SetClone(p.GitHTTPURL)
+	r.SetBranch(p.DefaultBranch)
+	// nolint: gomnd // ignore magic number
+	r.SetPrivate(p.VisibilityLevel != 20)
+
+	return r
+}
+
+// hookLink is a helper function to capture
+// the link to the webhooks for a repo.
+func hookLink(r *library.Repo) string {
+	return fmt.Sprintf("%s/-/hooks", r.GetLink())
+}
@@ -0,0 +1,417 @@
+// Copyright (c) 2022 Target Brands, Inc. All rights reserved.
+//
+// Use of this source code is governed by the LICENSE file in this repository.
+
+package gitlab
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-vela/server/model"
+
+	"github.com/go-vela/types"
+	"github.com/go-vela/types/constants"
+	"github.com/go-vela/types/library"
+)
+
+// webhookRequest is a helper function to create
+// a GitLab webhook request for the event.
+func webhookRequest(t *testing.T, file, event string) *http.Request {
+	body, err := os.Open(file)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+
+	t.Cleanup(func() { body.Close() })
+
+	request, _ := http.NewRequest(http.MethodPost, "/webhook", body)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Gitlab-Event", event)
+	request.Header.Set("X-Gitlab-Event-UUID", "7bd477e4-4415-11e9-9359-0d41fdf9567e")
+	request.Header.Set("X-Gitlab-Token", "secret")
+
+	return request
+}
+
+// wantHook is a helper function to create
+// the hook expected from a GitLab webhook.
+func wantHook(event, branch string) *library.Hook {
+	h := new(library.Hook)
+	h.SetNumber(1)
+	h.SetSourceID("7bd477e4-4415-11e9-9359-0d41fdf9567e")
+	h.SetCreated(time.Now().UTC().Unix())
+	h.SetHost("gitlab.example.com")
+	h.SetEvent(event)
+	h.SetStatus(constants.StatusSuccess)
+	h.SetLink("https://gitlab.example.com/foo/bar/-/hooks")
+
+	if len(branch) > 0 {
+		h.SetBranch(branch)
+	}
+
+	return h
+}
+
+// wantRepo is a helper function to create
+// the repo expected from a GitLab webhook.
+func wantRepo() *library.Repo {
+	r := new(library.Repo)
+	r.SetOrg("foo")
+	r.SetName("bar")
+	r.SetFullName("foo/bar")
+	r.SetLink("https://gitlab.example.com/foo/bar")
+	r.SetClone("https://gitlab.example.com/foo/bar.git")
+	r.SetBranch("main")
+	r.SetPrivate(true)
+
+	return r
+}
+
+func TestGitlab_ProcessWebhook_Push(t *testing.T) {
+	// setup types
+	wantBuild := new(library.Build)
+	wantBuild.SetEvent(constants.EventPush)
+	wantBuild.SetClone("https://gitlab.example.com/foo/bar.git")
+	wantBuild.SetSource("https://gitlab.example.com/foo/bar/-/commit/da1560886d4f094c3e6c9ef40349f7d38b5d27d7")
+	wantBuild.SetTitle("push received from https://gitlab.example.com/foo/bar")
+	wantBuild.SetMessage("fixed readme")
+	wantBuild.SetCommit("da1560886d4f094c3e6c9ef40349f7d38b5d27d7")
+	wantBuild.SetSender("foo")
+	wantBuild.SetAuthor("GitLab dev user")
+	wantBuild.SetEmail("gitlabdev@dv6700.(none)")
+	wantBuild.SetBranch("main")
+	wantBuild.SetRef("refs/heads/main")
+
+	want := &types.Webhook{
+		Comment: "",
+		Hook:    wantHook(constants.EventPush, "main"),
+		Repo:    wantRepo(),
+		Build:   wantBuild,
+	}
+
+	client, _ := NewTest("https://gitlab.example.com")
+
+	// run test
+	got, err := client.ProcessWebhook(webhookRequest(t, "testdata/hooks/push.json", eventPush))
+
+	if err != nil {
+		t.Errorf("ProcessWebhook returned err: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got.Webhook); diff != "" {
+		t.Errorf("ProcessWebhook mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGitlab_ProcessWebhook_Push_Deleted(t *testing.T) {
+	// setup types
+	want := &types.Webhook{
+		Hook: wantHook(constants.EventPush, "feature"),
+	}
+
+	client, _ := NewTest("https://gitlab.example.com")
+
+	// run test
+	got, err := client.ProcessWebhook(webhookRequest(t, "testdata/hooks/push_deleted.json", eventPush))
+
+	if err != nil {
+		t.Errorf("ProcessWebhook returned err: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got.Webhook); diff != "" {
+		t.Errorf("ProcessWebhook mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGitlab_ProcessWebhook_TagPush(t *testing.T) {
+	// setup types
+	wantBuild := new(library.Build)
+	wantBuild.SetEvent(constants.EventTag)
+	wantBuild.SetClone("https://gitlab.example.com/foo/bar.git")
+	wantBuild.SetSource("")
+	wantBuild.SetTitle("tag received from https://gitlab.example.com/foo/bar")
+	wantBuild.SetMessage("")
+	wantBuild.SetCommit("82b3d5ae55f7080f1e6022629cdb57bfae7cccc7")
+	wantBuild.SetSender("foo")
+	wantBuild.SetAuthor("foo")
+	wantBuild.SetEmail("foo@example.com")
+	wantBuild.SetBranch("main")
+	wantBuild.SetRef("refs/tags/v1.0.0")
+
+	want := &types.Webhook{
+		Comment: "",
+		Hook:    wantHook(constants.EventTag, "refs/tags/v1.0.0"),
+		Repo:    wantRepo(),
+		Build:   wantBuild,
+	}
+
+	client, _ := NewTest("https://gitlab.example.com")
+
+	// run test
+	got, err := client.ProcessWebhook(webhookRequest(t, "testdata/hooks/tag_push.json", eventTagPush))
+
+	if err != nil {
+		t.Errorf("ProcessWebhook returned err: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got.Webhook); diff != "" {
+		t.Errorf("ProcessWebhook mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGitlab_ProcessWebhook_MergeRequest(t *testing.T) {
+	// setup types
+	wantBuild := new(library.Build)
+	wantBuild.SetEvent(constants.EventPull)
+	wantBuild.SetClone("https://gitlab.example.com/foo/bar.git")
+	wantBuild.SetSource("https://gitlab.example.com/foo/bar/-/merge_requests/1")
+	wantBuild.SetTitle("pull_request received from https://gitlab.example.com/foo/bar")
+	wantBuild.SetMessage("Update README.md")
+	wantBuild.SetCommit("da1560886d4f094c3e6c9ef40349f7d38b5d27d7")
+	wantBuild.SetSender("foo")
+	wantBuild.SetAuthor("GitLab dev user")
+	wantBuild.SetEmail("gitlabdev@dv6700.(none)")
+	wantBuild.SetBranch("main")
+	wantBuild.SetRef("refs/merge-requests/1/head")
+	wantBuild.SetBaseRef("main")
+	wantBuild.SetHeadRef("feature")
+
+	// setup tests
+	tests := []struct {
+		name string
+		file string
+		want *model.Webhook
+	}{
+		{
+			name: "opened",
+			file: "testdata/hooks/merge_request.json",
+			want: &model.Webhook{
+				Webhook: &types.Webhook{
+					Comment:  "",
+					PRNumber: 1,
+					Hook:     wantHook(constants.EventPull, "main"),
+					Repo:     wantRepo(),
+					Build:    wantBuild,
+				},
+				PullRequest: &model.BuildPullRequest{
+					Labels: model.Labels{"API"},
+					Draft:  true,
+					Fork:   true,
+				},
+			},
+		},
+		{
+			name: "updated with new commits",
+			file: "testdata/hooks/merge_request_update.json",
+			want: &model.Webhook{
+				Webhook: &types.Webhook{
+					Comment:  "",
+					PRNumber: 1,
+					Hook:     wantHook(constants.EventPull, "main"),
+					Repo:     wantRepo(),
+					Build:    wantBuild,
+				},
+				PullRequest: &model.BuildPullRequest{Labels: model.Labels{}},
+			},
+		},
+		{
+			name: "updated without new commits",
+			file: "testdata/hooks/merge_request_edited.json",
+			want: &model.Webhook{Webhook: &types.Webhook{Hook: wantHook(constants.EventPull, "main")}},
+		},
+		{
+			name: "closed",
+			file: "testdata/hooks/merge_request_closed.json",
+			want: &model.Webhook{Webhook: &types.Webhook{Hook: wantHook(constants.EventPull, "main")}},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, _ := NewTest("https://gitlab.example.com")
+
+			got, err := client.ProcessWebhook(webhookRequest(t, test.file, eventMergeRequest))
+
+			if err != nil {
+				t.Errorf("ProcessWebhook returned err: %v", err)
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ProcessWebhook mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGitlab_ProcessWebhook_Note(t *testing.T) {
+	// setup types
+	wantBuild := new(library.Build)
+	wantBuild.SetEvent(constants.EventComment)
+	wantBuild.SetClone("https://gitlab.example.com/foo/bar.git")
+	wantBuild.SetSource("https://gitlab.example.com/foo/bar/-/merge_requests/1#note_1244")
+	wantBuild.SetTitle("comment received from https://gitlab.example.com/foo/bar")
+	wantBuild.SetMessage("Update README.md")
+	wantBuild.SetSender("foo")
+	wantBuild.SetAuthor("foo")
+	wantBuild.SetEmail("foo@example.com")
+	wantBuild.SetRef("refs/merge-requests/1/head")
+
+	wantIssueBuild := new(library.Build)
+	wantIssueBuild.SetEvent(constants.EventComment)
+	wantIssueBuild.SetClone("https://gitlab.example.com/foo/bar.git")
+	wantIssueBuild.SetSource("https://gitlab.example.com/foo/bar/-/issues/17#note_1241")
+	wantIssueBuild.SetTitle("comment received from https://gitlab.example.com/foo/bar")
+	wantIssueBuild.SetMessage("test")
+	wantIssueBuild.SetSender("foo")
+	wantIssueBuild.SetAuthor("foo")
+	wantIssueBuild.SetEmail("foo@example.com")
+	wantIssueBuild.SetRef("refs/heads/main")
+
+	// setup tests
+	tests := []struct {
+		name string
+		file string
+		want *model.Webhook
+	}{
+		{
+			name: "merge request",
+			file: "testdata/hooks/note_merge_request.json",
+			want: &model.Webhook{
+				Webhook: &types.Webhook{
+					Comment:  "ok to test",
+					PRNumber: 1,
+					Hook:     wantHook(constants.EventComment, ""),
+					Repo:     wantRepo(),
+					Build:    wantBuild,
+				},
+				PullRequest: &model.BuildPullRequest{Labels: model.Labels{}, Action: model.ActionCreated},
+			},
+		},
+		{
+			name: "updated merge request comment",
+			file: "testdata/hooks/note_merge_request_update.json",
+			want: &model.Webhook{
+				Webhook: &types.Webhook{
+					Comment:  "ok to test",
+					PRNumber: 1,
+					Hook:     wantHook(constants.EventComment, ""),
+					Repo:     wantRepo(),
+					Build:    wantBuild,
+				},
+				PullRequest: &model.BuildPullRequest{Labels: model.Labels{}, Action: "update"},
+			},
+		},
+		{
+			name: "issue",
+			file: "testdata/hooks/note_issue.json",
+			want: &model.Webhook{
+				Webhook: &types.Webhook{
+					Comment: "Hello world",
+					Hook:    wantHook(constants.EventComment, ""),
+					Repo:    wantRepo(),
+					Build:   wantIssueBuild,
+				},
+			},
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, _ := NewTest("https://gitlab.example.com")
+
+			got, err := client.ProcessWebhook(webhookRequest(t, test.file, eventNote))
+
+			if err != nil {
+				t.Errorf("ProcessWebhook returned err: %v", err)
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ProcessWebhook mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGitlab_ProcessWebhook_UnsupportedEvent(t *testing.T) {
+	// setup types
+	want := wantHook("Pipeline Hook", "")
+	want.Link = nil
+
+	client, _ := NewTest("https://gitlab.example.com")
+
+	// run test
+	got, err := client.ProcessWebhook(webhookRequest(t, "testdata/hooks/push.json", "Pipeline Hook"))
+
+	if err != nil {
+		t.Errorf("ProcessWebhook returned err: %v", err)
+	}
+
+	if got.Build != nil {
+		t.Errorf("ProcessWebhook build is %v, want nil", got.Build)
+	}
+
+	if diff := cmp.Diff(want, got.Hook); diff != "" {
+		t.Errorf("ProcessWebhook mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGitlab_VerifyWebhook(t *testing.T) {
+	// setup tests
+	tests := []struct {
+		name    string
+		token   string
+		failure bool
+	}{
+		{
+			name:  "valid token",
+			token: "secret",
+		},
+		{
+			name:    "invalid token",
+			token:   "foobar",
+			failure: true,
+		},
+		{
+			name:    "missing token",
+			token:   "",
+			failure: true,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodPost, "/webhook", strings.NewReader("{}"))
+			request.Header.Set("X-Gitlab-Event", eventPush)
+			request.Header.Set("X-Gitlab-Token", test.token)
+
+			r := wantRepo()
+			r.SetHash("secret")
+
+			client, _ := NewTest("https://gitlab.example.com")
+
+			// run test
+			err := client.VerifyWebhook(request, r)
+
+			if test.failure {
+				if err == nil {
+					t.Errorf("VerifyWebhook should have returned err")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("VerifyWebhook returned err: %v", err)
+			}
+		})
+	}
+}
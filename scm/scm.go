@@ -20,6 +20,7 @@ import (
 // Currently the following scm providers are supported:
 //
 // * Github
+// * Gitlab
 func New(s *Setup) (Service, error) {
 	// validate the setup being provided
 	//
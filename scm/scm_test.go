@@ -29,7 +29,7 @@ func TestSCM_New(t *testing.T) {
 			},
 		},
 		{
-			failure: true,
+			failure: false,
 			setup: &Setup{
 				Driver:               "gitlab",
 				Address:              "https://gitlab.com",
@@ -39,7 +39,7 @@ func TestSCM_New(t *testing.T) {
 				ServerWebhookAddress: "",
 				StatusContext:        "continuous-integration/vela",
 				WebUIAddress:         "https://vela.example.com",
-				Scopes:               []string{"repo", "repo:status", "user:email", "read:user", "read:org"},
+				Scopes:               []string{"api", "read_user"},
 			},
 		},
 		{
@@ -7,7 +7,8 @@ package scm
 import (
 	"net/http"
 
-	"github.com/go-vela/types"
+	"github.com/go-vela/server/model"
+
 	"github.com/go-vela/types/library"
 )
 
@@ -19,6 +20,9 @@ type Service interface {
 	// Driver defines a function that outputs
 	// the configured scm driver.
 	Driver() string
+	// Reporting defines a function that outputs
+	// the configured mode for reporting builds.
+	Reporting() string
 
 	// Authentication SCM Interface Functions
 
@@ -68,6 +72,15 @@ type Service interface {
 	// https://en.wikipedia.org/wiki/Changeset.
 	ChangesetPR(*library.User, *library.Repo, int) ([]string, error)
 
+	// Comment SCM Interface Functions
+
+	// CreateComment defines a function that creates a
+	// comment on a pull request and returns its ID.
+	CreateComment(*library.User, *library.Repo, int, string) (int64, error)
+	// UpdateComment defines a function that
+	// updates a comment on a pull request by ID.
+	UpdateComment(*library.User, *library.Repo, int, int64, string) error
+
 	// Deployment SCM Interface Functions
 
 	// GetDeployment defines a function that
@@ -92,6 +105,9 @@ type Service interface {
 	// Retry again in five seconds if Config fails to retrieve yaml/yml file.
 	// Will return an error after five failed attempts.
 	ConfigBackoff(*library.User, *library.Repo, string) ([]byte, error)
+	// ConfigFile defines a function that captures the
+	// pipeline configuration from a path in a repo.
+	ConfigFile(*library.User, *library.Repo, string, string) ([]byte, error)
 	// Disable defines a function that deactivates
 	// a repo by destroying the webhook.
 	Disable(*library.User, string, string) error
@@ -101,12 +117,28 @@ type Service interface {
 	// Status defines a function that sends the
 	// commit status for the given SHA from a repo.
 	Status(*library.User, *library.Build, string, string) error
+	// PipelineStatus defines a function that sends the commit
+	// status for the given SHA from a repo for a repo pipeline.
+	PipelineStatus(*library.User, *library.Build, string, string, string) error
+	// PipelineChecks defines a function that reports the build
+	// for the given SHA from a repo with the results of the
+	// steps as a check run for a repo pipeline and captures
+	// the ID of the check run created for the build.
+	PipelineChecks(*library.User, *library.Build, *model.BuildCheck, []*model.CheckStep, string, string, string) error
+	// StageStatus defines a function that sends the commit
+	// status for the given SHA from a repo for a stage of
+	// the build with the status of the stage.
+	StageStatus(*library.User, *library.Build, string, string, string, string, string) error
 	// ListUserRepos defines a function that retrieves
 	// all repos with admin rights for the user.
 	ListUserRepos(*library.User) ([]*library.Repo, error)
 	// GetPullRequest defines a function that retrieves
-	// a pull request for a repo.
-	GetPullRequest(*library.User, *library.Repo, int) (string, string, string, string, error)
+	// a pull request for a repo and whether the pull
+	// request is from a fork of the repo.
+	GetPullRequest(*library.User, *library.Repo, int) (string, string, string, string, bool, error)
+	// GetRefCommit defines a function that retrieves
+	// the commit SHA a ref of a repo points to.
+	GetRefCommit(*library.User, *library.Repo, string) (string, error)
 	// GetRepo defines a function that retrieves
 	// details for a repo.
 	GetRepo(*library.User, *library.Repo) (*library.Repo, error)
@@ -118,7 +150,7 @@ type Service interface {
 
 	// ProcessWebhook defines a function that
 	// parses the webhook from a repo.
-	ProcessWebhook(*http.Request) (*types.Webhook, error)
+	ProcessWebhook(*http.Request) (*model.Webhook, error)
 	// VerifyWebhook defines a function that
 	// verifies the webhook from a repo.
 	VerifyWebhook(*http.Request, *library.Repo) error
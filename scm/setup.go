@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/go-vela/server/model"
 	"github.com/go-vela/server/scm/github"
+	"github.com/go-vela/server/scm/gitlab"
+
 	"github.com/go-vela/types/constants"
 
 	"github.com/sirupsen/logrus"
@@ -38,6 +41,12 @@ type Setup struct {
 	WebUIAddress string
 	// specifies the OAuth scopes to use for the scm client
 	Scopes []string
+	// specifies the ID of the GitHub App to use for the scm client
+	AppID int64
+	// specifies the PEM encoded private key of the GitHub App to use for the scm client
+	AppPrivateKey string
+	// specifies the mode for reporting builds to use for the scm client
+	Reporting string
 }
 
 // Github creates and returns a Vela service capable of
@@ -57,6 +66,9 @@ func (s *Setup) Github() (Service, error) {
 		github.WithStatusContext(s.StatusContext),
 		github.WithWebUIAddress(s.WebUIAddress),
 		github.WithScopes(s.Scopes),
+		github.WithAppID(s.AppID),
+		github.WithAppPrivateKey(s.AppPrivateKey),
+		github.WithReporting(s.Reporting),
 	)
 }
 
@@ -65,7 +77,19 @@ func (s *Setup) Github() (Service, error) {
 func (s *Setup) Gitlab() (Service, error) {
 	logrus.Trace("creating gitlab scm client from setup")
 
-	return nil, fmt.Errorf("unsupported scm driver: %s", constants.DriverGitlab)
+	// create new Gitlab scm service
+	//
+	// https://pkg.go.dev/github.com/go-vela/server/scm/gitlab?tab=doc#New
+	return gitlab.New(
+		gitlab.WithAddress(s.Address),
+		gitlab.WithClientID(s.ClientID),
+		gitlab.WithClientSecret(s.ClientSecret),
+		gitlab.WithServerAddress(s.ServerAddress),
+		gitlab.WithServerWebhookAddress(s.ServerWebhookAddress),
+		gitlab.WithStatusContext(s.StatusContext),
+		gitlab.WithWebUIAddress(s.WebUIAddress),
+		gitlab.WithScopes(s.Scopes),
+	)
 }
 
 // Validate verifies the necessary fields for the
@@ -112,6 +136,34 @@ func (s *Setup) Validate() error {
 		return fmt.Errorf("no scm scopes provided")
 	}
 
+	// verify a scm app private key was provided with the app ID
+	if s.AppID > 0 && len(s.AppPrivateKey) == 0 {
+		return fmt.Errorf("no scm app private key provided")
+	}
+
+	// verify a scm app ID was provided with the app private key
+	if len(s.AppPrivateKey) > 0 && s.AppID == 0 {
+		return fmt.Errorf("no scm app id provided")
+	}
+
+	// verify a supported scm reporting mode was provided
+	if len(s.Reporting) > 0 && s.Reporting != model.ReportingStatus && s.Reporting != model.ReportingChecks {
+		return fmt.Errorf("invalid scm reporting mode provided: %s", s.Reporting)
+	}
+
+	// check if the scm reporting mode provided is checks
+	if s.Reporting == model.ReportingChecks {
+		// verify the scm driver supports reporting with check runs
+		if s.Driver != constants.DriverGithub {
+			return fmt.Errorf("scm reporting mode %s is not supported for driver %s", s.Reporting, s.Driver)
+		}
+
+		// verify a scm app was provided for reporting with check runs
+		if s.AppID == 0 {
+			return fmt.Errorf("no scm app id provided for reporting mode %s", s.Reporting)
+		}
+	}
+
 	// setup is valid
 	return nil
 }
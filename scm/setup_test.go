@@ -79,16 +79,51 @@ func TestSCM_Setup_Gitlab(t *testing.T) {
 		ServerWebhookAddress: "",
 		StatusContext:        "continuous-integration/vela",
 		WebUIAddress:         "https://vela.example.com",
+		Scopes:               []string{"api", "read_user"},
 	}
 
-	// run test
-	got, err := _setup.Gitlab()
-	if err == nil {
-		t.Errorf("Gitlab should have returned err")
+	_gitlab, err := _setup.Gitlab()
+	if err != nil {
+		t.Errorf("unable to setup scm: %v", err)
 	}
 
-	if got != nil {
-		t.Errorf("Gitlab is %v, want nil", got)
+	// setup tests
+	tests := []struct {
+		failure bool
+		setup   *Setup
+		want    Service
+	}{
+		{
+			failure: false,
+			setup:   _setup,
+			want:    _gitlab,
+		},
+		{
+			failure: true,
+			setup:   &Setup{Driver: "gitlab"},
+			want:    nil,
+		},
+	}
+
+	// run tests
+	for _, test := range tests {
+		got, err := test.setup.Gitlab()
+
+		if test.failure {
+			if err == nil {
+				t.Errorf("Gitlab should have returned err")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Gitlab returned err: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Gitlab is %v, want %v", got, test.want)
+		}
 	}
 }
 
@@ -238,6 +273,100 @@ func TestSCM_Setup_Validate(t *testing.T) {
 				Scopes:               []string{},
 			},
 		},
+		{
+			failure: true,
+			setup: &Setup{
+				Driver:               "github",
+				Address:              "https://github.com",
+				ClientID:             "foo",
+				ClientSecret:         "bar",
+				ServerAddress:        "https://vela-server.example.com",
+				ServerWebhookAddress: "",
+				StatusContext:        "continuous-integration/vela",
+				WebUIAddress:         "https://vela.example.com",
+				Scopes:               []string{"repo", "repo:status", "user:email", "read:user", "read:org"},
+				AppID:                1,
+			},
+		},
+		{
+			failure: true,
+			setup: &Setup{
+				Driver:               "github",
+				Address:              "https://github.com",
+				ClientID:             "foo",
+				ClientSecret:         "bar",
+				ServerAddress:        "https://vela-server.example.com",
+				ServerWebhookAddress: "",
+				StatusContext:        "continuous-integration/vela",
+				WebUIAddress:         "https://vela.example.com",
+				Scopes:               []string{"repo", "repo:status", "user:email", "read:user", "read:org"},
+				AppPrivateKey:        "foo",
+			},
+		},
+		{
+			failure: false,
+			setup: &Setup{
+				Driver:               "github",
+				Address:              "https://github.com",
+				ClientID:             "foo",
+				ClientSecret:         "bar",
+				ServerAddress:        "https://vela-server.example.com",
+				ServerWebhookAddress: "",
+				StatusContext:        "continuous-integration/vela",
+				WebUIAddress:         "https://vela.example.com",
+				Scopes:               []string{"repo", "repo:status", "user:email", "read:user", "read:org"},
+				AppID:                1,
+				AppPrivateKey:        "foo",
+				Reporting:            "checks",
+			},
+		},
+		{
+			failure: true,
+			setup: &Setup{
+				Driver:               "github",
+				Address:              "https://github.com",
+				ClientID:             "foo",
+				ClientSecret:         "bar",
+				ServerAddress:        "https://vela-server.example.com",
+				ServerWebhookAddress: "",
+				StatusContext:        "continuous-integration/vela",
+				WebUIAddress:         "https://vela.example.com",
+				Scopes:               []string{"repo", "repo:status", "user:email", "read:user", "read:org"},
+				Reporting:            "checks",
+			},
+		},
+		{
+			failure: true,
+			setup: &Setup{
+				Driver:               "gitlab",
+				Address:              "https://gitlab.com",
+				ClientID:             "foo",
+				ClientSecret:         "bar",
+				ServerAddress:        "https://vela-server.example.com",
+				ServerWebhookAddress: "",
+				StatusContext:        "continuous-integration/vela",
+				WebUIAddress:         "https://vela.example.com",
+				Scopes:               []string{"repo", "repo:status", "user:email", "read:user", "read:org"},
+				AppID:                1,
+				AppPrivateKey:        "foo",
+				Reporting:            "checks",
+			},
+		},
+		{
+			failure: true,
+			setup: &Setup{
+				Driver:               "github",
+				Address:              "https://github.com",
+				ClientID:             "foo",
+				ClientSecret:         "bar",
+				ServerAddress:        "https://vela-server.example.com",
+				ServerWebhookAddress: "",
+				StatusContext:        "continuous-integration/vela",
+				WebUIAddress:         "https://vela.example.com",
+				Scopes:               []string{"repo", "repo:status", "user:email", "read:user", "read:org"},
+				Reporting:            "foo",
+			},
+		},
 	}
 
 	// run tests